@@ -23,6 +23,7 @@ import (
 	timestamp "github.com/golang/protobuf/ptypes/timestamp"
 	_ "google.golang.org/genproto/googleapis/api/annotations"
 	math "math"
+	metadata_store_go_proto "ml_metadata/proto/metadata_store_go_proto"
 )
 
 // Reference imports to suppress errors if they are not otherwise used.
@@ -102,10 +103,12 @@ func (Execution_State) EnumDescriptor() ([]byte, []int) {
 }
 
 type Workspace struct {
-	Name                 string   `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
-	XXX_NoUnkeyedLiteral struct{} `json:"-"`
-	XXX_unrecognized     []byte   `json:"-"`
-	XXX_sizecache        int32    `json:"-"`
+	Name                 string                                    `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Type                 string                                    `protobuf:"bytes,2,opt,name=type,proto3" json:"type,omitempty"`
+	Properties           map[string]*metadata_store_go_proto.Value `protobuf:"bytes,3,rep,name=properties,proto3" json:"properties,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	XXX_NoUnkeyedLiteral struct{}                                  `json:"-"`
+	XXX_unrecognized     []byte                                    `json:"-"`
+	XXX_sizecache        int32                                     `json:"-"`
 }
 
 func (m *Workspace) Reset()         { *m = Workspace{} }
@@ -140,6 +143,20 @@ func (m *Workspace) GetName() string {
 	return ""
 }
 
+func (m *Workspace) GetType() string {
+	if m != nil {
+		return m.Type
+	}
+	return ""
+}
+
+func (m *Workspace) GetProperties() map[string]*metadata_store_go_proto.Value {
+	if m != nil {
+		return m.Properties
+	}
+	return nil
+}
+
 type ArtifactType struct {
 	Id                   int64                   `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
 	Name                 string                  `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
@@ -621,6 +638,101 @@ func (m *DoubleType) GetValidator() string {
 	return ""
 }
 
+type PropertyValidator struct {
+	// Types that are valid to be assigned to Type:
+	//	*PropertyValidator_StringType
+	//	*PropertyValidator_IntType
+	//	*PropertyValidator_DoubleType
+	Type                 isPropertyValidator_Type `protobuf_oneof:"type"`
+	XXX_NoUnkeyedLiteral struct{}                 `json:"-"`
+	XXX_unrecognized     []byte                   `json:"-"`
+	XXX_sizecache        int32                    `json:"-"`
+}
+
+func (m *PropertyValidator) Reset()         { *m = PropertyValidator{} }
+func (m *PropertyValidator) String() string { return proto.CompactTextString(m) }
+func (*PropertyValidator) ProtoMessage()    {}
+func (*PropertyValidator) Descriptor() ([]byte, []int) {
+	return fileDescriptor_1b40cafcd4234784, []int{8}
+}
+
+func (m *PropertyValidator) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_PropertyValidator.Unmarshal(m, b)
+}
+func (m *PropertyValidator) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_PropertyValidator.Marshal(b, m, deterministic)
+}
+func (m *PropertyValidator) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_PropertyValidator.Merge(m, src)
+}
+func (m *PropertyValidator) XXX_Size() int {
+	return xxx_messageInfo_PropertyValidator.Size(m)
+}
+func (m *PropertyValidator) XXX_DiscardUnknown() {
+	xxx_messageInfo_PropertyValidator.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_PropertyValidator proto.InternalMessageInfo
+
+type isPropertyValidator_Type interface {
+	isPropertyValidator_Type()
+}
+
+type PropertyValidator_StringType struct {
+	StringType *StringType `protobuf:"bytes,1,opt,name=string_type,json=stringType,proto3,oneof"`
+}
+
+type PropertyValidator_IntType struct {
+	IntType *IntType `protobuf:"bytes,2,opt,name=int_type,json=intType,proto3,oneof"`
+}
+
+type PropertyValidator_DoubleType struct {
+	DoubleType *DoubleType `protobuf:"bytes,3,opt,name=double_type,json=doubleType,proto3,oneof"`
+}
+
+func (*PropertyValidator_StringType) isPropertyValidator_Type() {}
+
+func (*PropertyValidator_IntType) isPropertyValidator_Type() {}
+
+func (*PropertyValidator_DoubleType) isPropertyValidator_Type() {}
+
+func (m *PropertyValidator) GetType() isPropertyValidator_Type {
+	if m != nil {
+		return m.Type
+	}
+	return nil
+}
+
+func (m *PropertyValidator) GetStringType() *StringType {
+	if x, ok := m.GetType().(*PropertyValidator_StringType); ok {
+		return x.StringType
+	}
+	return nil
+}
+
+func (m *PropertyValidator) GetIntType() *IntType {
+	if x, ok := m.GetType().(*PropertyValidator_IntType); ok {
+		return x.IntType
+	}
+	return nil
+}
+
+func (m *PropertyValidator) GetDoubleType() *DoubleType {
+	if x, ok := m.GetType().(*PropertyValidator_DoubleType); ok {
+		return x.DoubleType
+	}
+	return nil
+}
+
+// XXX_OneofWrappers is for the internal use of the proto package.
+func (*PropertyValidator) XXX_OneofWrappers() []interface{} {
+	return []interface{}{
+		(*PropertyValidator_StringType)(nil),
+		(*PropertyValidator_IntType)(nil),
+		(*PropertyValidator_DoubleType)(nil),
+	}
+}
+
 type StringValue struct {
 	Value                string   `protobuf:"bytes,1,opt,name=value,proto3" json:"value,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
@@ -632,7 +744,7 @@ func (m *StringValue) Reset()         { *m = StringValue{} }
 func (m *StringValue) String() string { return proto.CompactTextString(m) }
 func (*StringValue) ProtoMessage()    {}
 func (*StringValue) Descriptor() ([]byte, []int) {
-	return fileDescriptor_1b40cafcd4234784, []int{8}
+	return fileDescriptor_1b40cafcd4234784, []int{9}
 }
 
 func (m *StringValue) XXX_Unmarshal(b []byte) error {
@@ -671,7 +783,7 @@ func (m *IntValue) Reset()         { *m = IntValue{} }
 func (m *IntValue) String() string { return proto.CompactTextString(m) }
 func (*IntValue) ProtoMessage()    {}
 func (*IntValue) Descriptor() ([]byte, []int) {
-	return fileDescriptor_1b40cafcd4234784, []int{9}
+	return fileDescriptor_1b40cafcd4234784, []int{10}
 }
 
 func (m *IntValue) XXX_Unmarshal(b []byte) error {
@@ -710,7 +822,7 @@ func (m *DoubleValue) Reset()         { *m = DoubleValue{} }
 func (m *DoubleValue) String() string { return proto.CompactTextString(m) }
 func (*DoubleValue) ProtoMessage()    {}
 func (*DoubleValue) Descriptor() ([]byte, []int) {
-	return fileDescriptor_1b40cafcd4234784, []int{10}
+	return fileDescriptor_1b40cafcd4234784, []int{11}
 }
 
 func (m *DoubleValue) XXX_Unmarshal(b []byte) error {
@@ -753,7 +865,7 @@ func (m *Value) Reset()         { *m = Value{} }
 func (m *Value) String() string { return proto.CompactTextString(m) }
 func (*Value) ProtoMessage()    {}
 func (*Value) Descriptor() ([]byte, []int) {
-	return fileDescriptor_1b40cafcd4234784, []int{11}
+	return fileDescriptor_1b40cafcd4234784, []int{12}
 }
 
 func (m *Value) XXX_Unmarshal(b []byte) error {
@@ -837,6 +949,7 @@ func init() {
 	proto.RegisterEnum("api.PropertyType", PropertyType_name, PropertyType_value)
 	proto.RegisterEnum("api.Execution_State", Execution_State_name, Execution_State_value)
 	proto.RegisterType((*Workspace)(nil), "api.Workspace")
+	proto.RegisterMapType((map[string]*metadata_store_go_proto.Value)(nil), "api.Workspace.PropertiesEntry")
 	proto.RegisterType((*ArtifactType)(nil), "api.ArtifactType")
 	proto.RegisterMapType((map[string]PropertyType)(nil), "api.ArtifactType.PropertiesEntry")
 	proto.RegisterType((*ExecutionType)(nil), "api.ExecutionType")
@@ -852,6 +965,7 @@ func init() {
 	proto.RegisterType((*StringType)(nil), "api.StringType")
 	proto.RegisterType((*IntType)(nil), "api.IntType")
 	proto.RegisterType((*DoubleType)(nil), "api.DoubleType")
+	proto.RegisterType((*PropertyValidator)(nil), "api.PropertyValidator")
 	proto.RegisterType((*StringValue)(nil), "api.StringValue")
 	proto.RegisterType((*IntValue)(nil), "api.IntValue")
 	proto.RegisterType((*DoubleValue)(nil), "api.DoubleValue")
@@ -861,59 +975,66 @@ func init() {
 func init() { proto.RegisterFile("api/api.proto", fileDescriptor_1b40cafcd4234784) }
 
 var fileDescriptor_1b40cafcd4234784 = []byte{
-	// 849 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xdc, 0x56, 0xdd, 0x8e, 0xdb, 0x44,
-	0x14, 0x5e, 0xc7, 0x49, 0x6c, 0x1f, 0xa7, 0xc1, 0x1d, 0x15, 0x11, 0xa2, 0x96, 0x06, 0x07, 0xa9,
-	0xab, 0x15, 0x72, 0x44, 0x10, 0x12, 0xe5, 0xa7, 0xd2, 0xa6, 0x09, 0x34, 0x6d, 0x70, 0x82, 0x37,
-	0xcb, 0x4a, 0xdc, 0x44, 0x13, 0x7b, 0x36, 0x8c, 0xe2, 0xd8, 0x96, 0x3d, 0xee, 0x92, 0x17, 0xe0,
-	0x29, 0x78, 0x41, 0x9e, 0x80, 0x5b, 0x34, 0xe3, 0xfc, 0x38, 0x69, 0xd8, 0x0d, 0x5c, 0xac, 0x10,
-	0x77, 0xf6, 0x99, 0xef, 0x7c, 0xf3, 0x9d, 0x73, 0xbe, 0x23, 0x1b, 0x1e, 0xe0, 0x88, 0xb6, 0x70,
-	0x44, 0xad, 0x28, 0x0e, 0x59, 0x88, 0x64, 0x1c, 0xd1, 0xfa, 0xe3, 0x59, 0x18, 0xce, 0x7c, 0xd2,
-	0x12, 0x47, 0x41, 0x10, 0x32, 0xcc, 0x68, 0x18, 0x24, 0x19, 0xa4, 0xfe, 0x74, 0x75, 0x2a, 0xde,
-	0xa6, 0xe9, 0x75, 0x8b, 0xd1, 0x05, 0x49, 0x18, 0x5e, 0x44, 0x19, 0xc0, 0x7c, 0x0a, 0xda, 0x55,
-	0x18, 0xcf, 0x93, 0x08, 0xbb, 0x04, 0x21, 0x28, 0x06, 0x78, 0x41, 0x6a, 0x52, 0x43, 0x3a, 0xd5,
-	0x1c, 0xf1, 0x6c, 0xfe, 0x21, 0x41, 0xe5, 0x3c, 0x66, 0xf4, 0x1a, 0xbb, 0x6c, 0xbc, 0x8c, 0x08,
-	0xaa, 0x42, 0x81, 0x7a, 0x02, 0x22, 0x3b, 0x05, 0xea, 0x6d, 0x92, 0x0a, 0xdb, 0x24, 0x74, 0x0e,
-	0x10, 0xc5, 0x61, 0x44, 0x62, 0x46, 0x49, 0x52, 0x93, 0x1b, 0xf2, 0xa9, 0xde, 0xfe, 0xd8, 0xe2,
-	0xca, 0xf3, 0x54, 0xd6, 0x68, 0x83, 0xe9, 0x05, 0x2c, 0x5e, 0x3a, 0xb9, 0x24, 0xd4, 0x00, 0xdd,
-	0x23, 0x89, 0x1b, 0xd3, 0x88, 0xd7, 0x53, 0x2b, 0x0a, 0xf6, 0x7c, 0xa8, 0x3e, 0x82, 0xf7, 0xf6,
-	0x08, 0x90, 0x01, 0xf2, 0x9c, 0x2c, 0x57, 0xfa, 0xf9, 0x23, 0x7a, 0x06, 0xa5, 0xb7, 0xd8, 0x4f,
-	0x33, 0x79, 0xd5, 0xf6, 0x43, 0x21, 0x62, 0x95, 0xb6, 0xe4, 0x22, 0x9c, 0xec, 0xfc, 0xab, 0xc2,
-	0x97, 0x92, 0xf9, 0xa7, 0x04, 0x0f, 0x7a, 0xbf, 0x12, 0x37, 0xe5, 0xfc, 0x47, 0x17, 0xdb, 0x39,
-	0x50, 0xac, 0x29, 0xee, 0xd9, 0xe1, 0xfa, 0x27, 0xd5, 0x96, 0xef, 0xa1, 0xda, 0xd7, 0x45, 0xb5,
-	0x68, 0x94, 0x5e, 0x17, 0xd5, 0x92, 0x51, 0x36, 0x7f, 0x2f, 0x81, 0xba, 0x1e, 0xcd, 0x3b, 0x45,
-	0x7f, 0x00, 0x0a, 0x5b, 0x46, 0x64, 0x42, 0x3d, 0xc1, 0x2b, 0x3b, 0x65, 0xfe, 0xda, 0xf7, 0xb8,
-	0x80, 0x34, 0xa6, 0x35, 0x39, 0x13, 0x90, 0xc6, 0x14, 0x7d, 0xbb, 0xd3, 0x8b, 0xa2, 0xe8, 0xc5,
-	0x93, 0x9d, 0xc1, 0xdf, 0xda, 0x86, 0x11, 0x3c, 0x74, 0xd3, 0x84, 0x85, 0x8b, 0x49, 0x8e, 0xa5,
-	0x24, 0x58, 0x9a, 0xbb, 0x2c, 0x2f, 0x05, 0x6c, 0x9f, 0xcb, 0x70, 0xf7, 0xc2, 0x9b, 0x81, 0x95,
-	0x73, 0x03, 0xfb, 0x14, 0xb4, 0x9b, 0xb5, 0xe7, 0x6b, 0x4a, 0x43, 0x3a, 0xd5, 0xdb, 0x55, 0xc1,
-	0xbe, 0xd9, 0x04, 0x67, 0x0b, 0x40, 0x9f, 0x41, 0xd9, 0xc7, 0x53, 0xe2, 0x27, 0x35, 0x55, 0x08,
-	0xf9, 0x70, 0x57, 0xc8, 0x40, 0x9c, 0x65, 0xd7, 0xaf, 0x80, 0xe8, 0x6b, 0xd0, 0xdd, 0x98, 0x60,
-	0x46, 0x26, 0x7c, 0xdd, 0x6a, 0x9a, 0xb8, 0xa2, 0x6e, 0x65, 0xbb, 0x68, 0xad, 0x77, 0xd1, 0x1a,
-	0xaf, 0x77, 0xd1, 0x81, 0x0c, 0xce, 0x03, 0x3c, 0x39, 0x8d, 0xbc, 0x4d, 0x32, 0xdc, 0x9d, 0x9c,
-	0xc1, 0x79, 0xa0, 0xde, 0x3f, 0xc6, 0x25, 0x8d, 0xbc, 0x4b, 0xf4, 0x36, 0x88, 0x82, 0x7e, 0xe2,
-	0x91, 0x9c, 0x3d, 0xea, 0x43, 0x78, 0xff, 0x60, 0x93, 0xff, 0x35, 0xe1, 0x73, 0xd0, 0x73, 0xcd,
-	0x3a, 0x40, 0xf3, 0x28, 0x4f, 0xa3, 0xe5, 0x17, 0xf3, 0x37, 0x05, 0xb4, 0xcd, 0x32, 0x1d, 0xef,
-	0xcf, 0x17, 0x60, 0xf8, 0x38, 0x61, 0x93, 0x79, 0x10, 0xde, 0x04, 0x93, 0x84, 0x61, 0x46, 0x84,
-	0x59, 0xab, 0xed, 0x47, 0xbb, 0xfb, 0x69, 0x5d, 0xf0, 0x33, 0xa7, 0xca, 0xd1, 0x6f, 0x38, 0x58,
-	0xbc, 0xa3, 0x17, 0x07, 0xdc, 0xfc, 0xd1, 0x5e, 0xe6, 0x6d, 0x76, 0xfe, 0xf1, 0xef, 0xed, 0xfc,
-	0xc9, 0x1e, 0xcd, 0xfd, 0xf9, 0xb9, 0xbd, 0xe7, 0xe7, 0xfa, 0x9e, 0x92, 0xff, 0x94, 0xa1, 0xd1,
-	0x73, 0x80, 0x84, 0xe1, 0x98, 0x65, 0xb9, 0xfa, 0x9d, 0xb9, 0x9a, 0x40, 0x8b, 0xd4, 0x2f, 0x40,
-	0x25, 0x81, 0x97, 0x25, 0x56, 0xee, 0x4c, 0x54, 0x48, 0xe0, 0xfd, 0x9f, 0x57, 0xa8, 0x0b, 0xa5,
-	0xcc, 0xd4, 0x3a, 0x28, 0x97, 0xf6, 0x1b, 0x7b, 0x78, 0x65, 0x1b, 0x27, 0x48, 0x01, 0xd9, 0xee,
-	0x5d, 0x19, 0x12, 0x8f, 0x3a, 0x97, 0xb6, 0xdd, 0xb7, 0xbf, 0x37, 0x0a, 0xa8, 0x02, 0xea, 0xcb,
-	0xe1, 0x0f, 0xa3, 0x41, 0x6f, 0xdc, 0x33, 0x64, 0x04, 0x50, 0xfe, 0xee, 0xbc, 0x3f, 0xe8, 0x75,
-	0x8d, 0xa2, 0x79, 0x06, 0x70, 0xc1, 0x62, 0x1a, 0xcc, 0xc4, 0xd7, 0xf1, 0x31, 0x68, 0x6f, 0xb1,
-	0x4f, 0x3d, 0xcc, 0xc2, 0x78, 0xa5, 0x62, 0x1b, 0x30, 0x9f, 0x81, 0xd2, 0x0f, 0xd8, 0x11, 0xc0,
-	0x33, 0x80, 0x6e, 0x98, 0x4e, 0x7d, 0x72, 0x04, 0xb6, 0x09, 0x7a, 0x26, 0x40, 0xf4, 0x66, 0x5b,
-	0xaf, 0x94, 0xab, 0xd7, 0x6c, 0x80, 0xda, 0x0f, 0xd8, 0x01, 0x84, 0xbc, 0x46, 0x34, 0x41, 0xcf,
-	0xae, 0xbc, 0x0d, 0xb4, 0x84, 0x52, 0x76, 0xfc, 0x04, 0x34, 0x1a, 0xb0, 0x49, 0x0e, 0xf2, 0xea,
-	0xc4, 0x51, 0xe9, 0xfa, 0x8a, 0x26, 0x54, 0x3c, 0x41, 0x36, 0xd9, 0xf6, 0x5e, 0x7a, 0x75, 0xe2,
-	0xe8, 0x5e, 0xee, 0x8a, 0x26, 0x54, 0x12, 0x21, 0x7c, 0x05, 0x12, 0x1f, 0x4d, 0x0e, 0x4a, 0xb6,
-	0xe5, 0x74, 0x94, 0x95, 0x8e, 0xb3, 0x6f, 0xa0, 0x92, 0xff, 0x6c, 0xbf, 0x33, 0xb4, 0xbe, 0x3d,
-	0x36, 0x24, 0x3e, 0x99, 0xee, 0xf0, 0xb2, 0x33, 0xe8, 0x19, 0x05, 0xfe, 0x7c, 0x31, 0x76, 0xf8,
-	0xfc, 0xe4, 0x8e, 0xf9, 0x73, 0x63, 0x46, 0xd9, 0x2f, 0xe9, 0xd4, 0x72, 0xc3, 0x45, 0x6b, 0x9e,
-	0x4e, 0xc9, 0xb5, 0x1f, 0xde, 0xb4, 0x16, 0x84, 0x61, 0x0f, 0x33, 0xcc, 0xff, 0x15, 0xa7, 0x65,
-	0xb1, 0x03, 0x9f, 0xff, 0x15, 0x00, 0x00, 0xff, 0xff, 0xd5, 0xd7, 0x50, 0x7d, 0x54, 0x0a, 0x00,
-	0x00,
+	// 966 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xdc, 0x56, 0xdd, 0x6e, 0xdb, 0x36,
+	0x14, 0x8e, 0x2c, 0xdb, 0xb2, 0x8e, 0x5c, 0x57, 0x21, 0x3a, 0xcc, 0x33, 0xda, 0xce, 0x53, 0x86,
+	0x35, 0x0b, 0x06, 0x19, 0xf3, 0x30, 0x60, 0xdd, 0x4f, 0x80, 0xb8, 0xf1, 0x16, 0xb7, 0x99, 0x93,
+	0x2a, 0x4e, 0x03, 0xec, 0x46, 0xa0, 0x2d, 0x26, 0x23, 0x22, 0x4b, 0x82, 0x44, 0x35, 0xf3, 0x0b,
+	0xec, 0x29, 0xf6, 0x0c, 0x7b, 0x8c, 0xbd, 0xcb, 0x9e, 0x60, 0xb7, 0x03, 0x49, 0x59, 0x96, 0x5d,
+	0x2f, 0xf1, 0x76, 0x51, 0x0c, 0xbd, 0x13, 0x0f, 0xbf, 0xf3, 0xf1, 0xfb, 0xc8, 0x73, 0x28, 0xc2,
+	0x3d, 0x1c, 0xd1, 0x0e, 0x8e, 0xa8, 0x1d, 0xc5, 0x21, 0x0b, 0x91, 0x8a, 0x23, 0xda, 0x7a, 0x78,
+	0x15, 0x86, 0x57, 0x3e, 0xe9, 0x88, 0xa9, 0x20, 0x08, 0x19, 0x66, 0x34, 0x0c, 0x12, 0x09, 0x69,
+	0x7d, 0x98, 0xcd, 0x8a, 0xd1, 0x38, 0xbd, 0xec, 0x30, 0x3a, 0x25, 0x09, 0xc3, 0xd3, 0x28, 0x03,
+	0x7c, 0x32, 0xf5, 0xdd, 0x29, 0x61, 0xd8, 0xc3, 0x0c, 0x4b, 0x54, 0x67, 0x3e, 0x74, 0x13, 0x16,
+	0xc6, 0x44, 0xe2, 0xac, 0x3f, 0x14, 0xd0, 0x2f, 0xc2, 0xf8, 0x3a, 0x89, 0xf0, 0x84, 0x20, 0x04,
+	0xe5, 0x00, 0x4f, 0x49, 0x53, 0x69, 0x2b, 0xbb, 0xba, 0x23, 0xbe, 0x79, 0x8c, 0xcd, 0x22, 0xd2,
+	0x2c, 0xc9, 0x18, 0xff, 0x46, 0xfb, 0x00, 0x51, 0x1c, 0x46, 0x24, 0x66, 0x94, 0x24, 0x4d, 0xb5,
+	0xad, 0xee, 0x1a, 0xdd, 0xc7, 0x36, 0x77, 0x90, 0x73, 0xd9, 0xa7, 0x39, 0xa0, 0x1f, 0xb0, 0x78,
+	0xe6, 0x14, 0x32, 0x5a, 0x2f, 0xe1, 0xfe, 0xca, 0x34, 0x32, 0x41, 0xbd, 0x26, 0xb3, 0x6c, 0x65,
+	0xfe, 0x89, 0x76, 0xa1, 0xf2, 0x1a, 0xfb, 0xa9, 0x5c, 0xd9, 0xe8, 0x22, 0xbb, 0x60, 0xc9, 0x7e,
+	0xc5, 0x67, 0x1c, 0x09, 0xf8, 0xba, 0xf4, 0x95, 0x62, 0xfd, 0xa9, 0x40, 0xfd, 0x20, 0x66, 0xf4,
+	0x12, 0x4f, 0xd8, 0x88, 0x6b, 0x6c, 0x40, 0x89, 0x7a, 0x82, 0x4f, 0x75, 0x4a, 0xd4, 0xcb, 0xbd,
+	0x95, 0x0a, 0xde, 0x0e, 0xd6, 0xf8, 0xf8, 0x48, 0xf8, 0x28, 0x52, 0xdd, 0x66, 0x05, 0xb5, 0xc1,
+	0xf0, 0x48, 0x32, 0x89, 0x69, 0xc4, 0xcf, 0xa7, 0x59, 0x16, 0xec, 0xc5, 0x50, 0xeb, 0x74, 0x13,
+	0xb3, 0x4f, 0x8a, 0x66, 0x1b, 0xdd, 0x6d, 0x21, 0x22, 0x4b, 0x9b, 0x71, 0x11, 0x45, 0xaf, 0x7f,
+	0x29, 0x70, 0xaf, 0xff, 0x0b, 0x99, 0xa4, 0x9c, 0x7f, 0x63, 0xb3, 0xbd, 0x35, 0x66, 0x2d, 0xb1,
+	0xce, 0x12, 0xd7, 0xbf, 0x71, 0x5b, 0x7d, 0x0b, 0x6e, 0x9f, 0x97, 0x6b, 0x65, 0xb3, 0xf2, 0xbc,
+	0x5c, 0xab, 0x98, 0x55, 0xeb, 0xb7, 0x0a, 0xd4, 0xe6, 0x47, 0xf3, 0x86, 0xe9, 0xf7, 0x41, 0xe3,
+	0xd5, 0xe9, 0x52, 0x4f, 0xf0, 0xaa, 0x4e, 0x95, 0x0f, 0x07, 0x1e, 0x17, 0x90, 0xc6, 0xb4, 0xa9,
+	0x4a, 0x01, 0x69, 0x4c, 0xd1, 0x77, 0x4b, 0x7b, 0x51, 0x16, 0x7b, 0xf1, 0x68, 0xe9, 0xe0, 0x6f,
+	0xdd, 0x86, 0x53, 0xd8, 0x9e, 0xa4, 0x09, 0x0b, 0xa7, 0x6e, 0x81, 0xa5, 0x22, 0x58, 0x76, 0x96,
+	0x59, 0x9e, 0x09, 0xd8, 0x2a, 0x97, 0x39, 0x59, 0x09, 0xe7, 0x07, 0x56, 0x2d, 0x1c, 0xd8, 0x67,
+	0xa0, 0xdf, 0xcc, 0xdb, 0xa9, 0xa9, 0x89, 0x26, 0x68, 0x2c, 0x37, 0x99, 0xb3, 0x00, 0xa0, 0xcf,
+	0xa1, 0xea, 0xe3, 0x31, 0xf1, 0x93, 0x66, 0x4d, 0x08, 0xf9, 0x60, 0x59, 0xc8, 0xb1, 0x98, 0x93,
+	0xcb, 0x67, 0x40, 0xf4, 0x0d, 0x18, 0x93, 0x98, 0x60, 0x46, 0x5c, 0x7e, 0x7d, 0x34, 0x75, 0xb1,
+	0x44, 0xcb, 0x96, 0x77, 0x8b, 0x3d, 0xbf, 0x5b, 0xec, 0xd1, 0xfc, 0x6e, 0x71, 0x40, 0xc2, 0x79,
+	0x80, 0x27, 0xa7, 0x91, 0x97, 0x27, 0xc3, 0xdd, 0xc9, 0x12, 0xce, 0x03, 0xad, 0xc1, 0x26, 0x55,
+	0xd2, 0x5e, 0xbe, 0x00, 0x40, 0x18, 0x5a, 0x6d, 0xfc, 0xd6, 0x09, 0xbc, 0xb7, 0x76, 0x93, 0xff,
+	0x33, 0xe1, 0x53, 0x30, 0x0a, 0x9b, 0xb5, 0x86, 0xe6, 0x41, 0x91, 0x46, 0x2f, 0x36, 0xe6, 0xaf,
+	0x1a, 0xe8, 0x79, 0x33, 0x6d, 0x5e, 0x9f, 0xfb, 0x60, 0xfa, 0x38, 0x61, 0xee, 0x75, 0x10, 0xde,
+	0x04, 0x6e, 0xc2, 0x30, 0x23, 0xa2, 0x58, 0x1b, 0xdd, 0x07, 0xcb, 0xfd, 0x69, 0x9f, 0xf1, 0x39,
+	0xa7, 0xc1, 0xd1, 0x2f, 0x38, 0x58, 0x8c, 0xd1, 0xfe, 0x9a, 0x6a, 0x7e, 0xbc, 0x92, 0x79, 0x5b,
+	0x39, 0xbf, 0xfc, 0xe7, 0x72, 0xfe, 0x78, 0x85, 0xe6, 0xed, 0xd5, 0x73, 0x77, 0xa5, 0x9e, 0x5b,
+	0x2b, 0x4a, 0xfe, 0x57, 0x05, 0x8d, 0x9e, 0x02, 0x24, 0x0c, 0xc7, 0x4c, 0xe6, 0x1a, 0x77, 0xe6,
+	0xea, 0x02, 0x2d, 0x52, 0xbf, 0x84, 0x1a, 0x09, 0x3c, 0x99, 0x58, 0xbf, 0x33, 0x51, 0x23, 0x81,
+	0xf7, 0x2e, 0xb7, 0xd0, 0x21, 0x54, 0x64, 0x51, 0x1b, 0xa0, 0x9d, 0x0f, 0x5f, 0x0c, 0x4f, 0x2e,
+	0x86, 0xe6, 0x16, 0xd2, 0x40, 0x1d, 0xf6, 0x2f, 0x4c, 0x85, 0x47, 0x9d, 0xf3, 0xe1, 0x70, 0x30,
+	0xfc, 0xc1, 0x2c, 0xa1, 0x3a, 0xd4, 0x9e, 0x9d, 0xfc, 0x78, 0x7a, 0xdc, 0x1f, 0xf5, 0x4d, 0x15,
+	0x01, 0x54, 0xbf, 0x3f, 0x18, 0x1c, 0xf7, 0x0f, 0xcd, 0xb2, 0xb5, 0x07, 0x70, 0xc6, 0x62, 0x1a,
+	0x5c, 0x89, 0xbf, 0xe3, 0x43, 0xd0, 0x5f, 0x63, 0x9f, 0x7a, 0x98, 0x85, 0x71, 0xa6, 0x62, 0x11,
+	0xb0, 0x9e, 0x80, 0x36, 0x08, 0xd8, 0x06, 0xc0, 0x3d, 0x80, 0xc3, 0x30, 0x1d, 0xfb, 0x64, 0x03,
+	0xec, 0xef, 0x0a, 0x6c, 0xcf, 0x7f, 0x68, 0xaf, 0xe6, 0x51, 0xd4, 0x05, 0x23, 0x11, 0xb2, 0x5c,
+	0xf1, 0xa4, 0x52, 0xc4, 0x1e, 0xde, 0x17, 0x7b, 0xb8, 0x90, 0x7b, 0xb4, 0xe5, 0x40, 0xb2, 0x10,
+	0xff, 0x29, 0xd4, 0x68, 0xc0, 0xdc, 0xfc, 0x0d, 0x66, 0x74, 0xeb, 0x22, 0x21, 0xd3, 0x7c, 0xb4,
+	0xe5, 0x68, 0x34, 0x93, 0xdf, 0x05, 0xc3, 0x13, 0x02, 0x25, 0x5a, 0x2d, 0xd0, 0x2f, 0x84, 0x73,
+	0x7a, 0x2f, 0x1f, 0xf5, 0xaa, 0xf2, 0x79, 0x67, 0xed, 0x80, 0x21, 0x25, 0x88, 0xc3, 0x5c, 0x1c,
+	0x90, 0x52, 0x38, 0x20, 0xab, 0x0d, 0xb5, 0x41, 0xc0, 0xd6, 0x20, 0xd4, 0x39, 0x62, 0x07, 0x0c,
+	0xb9, 0xd4, 0x6d, 0xa0, 0x19, 0x54, 0xe4, 0xf4, 0x23, 0xd0, 0xb9, 0xb7, 0x02, 0xe4, 0x68, 0xcb,
+	0xe1, 0x76, 0xe5, 0xf4, 0x0e, 0xd4, 0x33, 0x3f, 0x8b, 0x62, 0x51, 0x8e, 0xb6, 0x9c, 0xcc, 0x65,
+	0x0e, 0xca, 0xf6, 0x54, 0x82, 0xc4, 0x5f, 0x9e, 0x83, 0x92, 0x85, 0x9d, 0x9e, 0x96, 0xe9, 0xd8,
+	0xfb, 0x16, 0xea, 0xc5, 0x77, 0xc6, 0x1b, 0x55, 0x36, 0x18, 0x8e, 0x4c, 0x85, 0x97, 0xd2, 0xe1,
+	0xc9, 0x79, 0xef, 0xb8, 0x6f, 0x96, 0xf8, 0xf7, 0xd9, 0xc8, 0xe1, 0x05, 0xa7, 0xf6, 0xac, 0x9f,
+	0xda, 0x57, 0x94, 0xfd, 0x9c, 0x8e, 0xed, 0x49, 0x38, 0xed, 0x5c, 0xa7, 0x63, 0x72, 0xe9, 0x87,
+	0x37, 0xf9, 0xcb, 0x9a, 0x3f, 0xd6, 0xc7, 0x55, 0xd1, 0xb4, 0x5f, 0xfc, 0x3d, 0x00, 0x94, 0xd3,
+	0x79, 0x56, 0xd5, 0x0b, 0x00, 0x00,
 }
@@ -24,6 +24,7 @@ import (
 	empty "github.com/golang/protobuf/ptypes/empty"
 	_ "github.com/golang/protobuf/ptypes/timestamp"
 	_ "google.golang.org/genproto/googleapis/api/annotations"
+	field_mask "google.golang.org/genproto/protobuf/field_mask"
 	grpc "google.golang.org/grpc"
 	math "math"
 	metadata_store_go_proto "ml_metadata/proto/metadata_store_go_proto"
@@ -40,6 +41,34 @@ var _ = math.Inf
 // proto package needs to be updated.
 const _ = proto.ProtoPackageIsVersion3 // please upgrade the proto package
 
+type GetLineageRequest_Direction int32
+
+const (
+	GetLineageRequest_BOTH       GetLineageRequest_Direction = 0
+	GetLineageRequest_UPSTREAM   GetLineageRequest_Direction = 1
+	GetLineageRequest_DOWNSTREAM GetLineageRequest_Direction = 2
+)
+
+var GetLineageRequest_Direction_name = map[int32]string{
+	0: "BOTH",
+	1: "UPSTREAM",
+	2: "DOWNSTREAM",
+}
+
+var GetLineageRequest_Direction_value = map[string]int32{
+	"BOTH":       0,
+	"UPSTREAM":   1,
+	"DOWNSTREAM": 2,
+}
+
+func (x GetLineageRequest_Direction) String() string {
+	return proto.EnumName(GetLineageRequest_Direction_name, int32(x))
+}
+
+func (GetLineageRequest_Direction) EnumDescriptor() ([]byte, []int) {
+	return fileDescriptor_42c32aec9010f89c, []int{53, 0}
+}
+
 type CreateArtifactTypeRequest struct {
 	ArtifactType         *metadata_store_go_proto.ArtifactType `protobuf:"bytes,1,opt,name=artifact_type,json=artifactType,proto3" json:"artifact_type,omitempty"`
 	XXX_NoUnkeyedLiteral struct{}                              `json:"-"`
@@ -237,6 +266,7 @@ func (m *GetArtifactTypeRequest) GetName() string {
 
 type GetArtifactTypeResponse struct {
 	ArtifactType         *metadata_store_go_proto.ArtifactType `protobuf:"bytes,1,opt,name=artifact_type,json=artifactType,proto3" json:"artifact_type,omitempty"`
+	PropertyValidators   map[string]*PropertyValidator         `protobuf:"bytes,2,rep,name=property_validators,json=propertyValidators,proto3" json:"property_validators,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
 	XXX_NoUnkeyedLiteral struct{}                              `json:"-"`
 	XXX_unrecognized     []byte                                `json:"-"`
 	XXX_sizecache        int32                                 `json:"-"`
@@ -274,6 +304,13 @@ func (m *GetArtifactTypeResponse) GetArtifactType() *metadata_store_go_proto.Art
 	return nil
 }
 
+func (m *GetArtifactTypeResponse) GetPropertyValidators() map[string]*PropertyValidator {
+	if m != nil {
+		return m.PropertyValidators
+	}
+	return nil
+}
+
 type ListArtifactTypesRequest struct {
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
@@ -346,6 +383,7 @@ func (m *ListArtifactTypesResponse) GetArtifactTypes() []*metadata_store_go_prot
 
 type DeleteArtifactTypeRequest struct {
 	Name                 string   `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Force                bool     `protobuf:"varint,2,opt,name=force,proto3" json:"force,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
@@ -383,9 +421,17 @@ func (m *DeleteArtifactTypeRequest) GetName() string {
 	return ""
 }
 
+func (m *DeleteArtifactTypeRequest) GetForce() bool {
+	if m != nil {
+		return m.Force
+	}
+	return false
+}
+
 type CreateArtifactRequest struct {
 	Parent               string                            `protobuf:"bytes,1,opt,name=parent,proto3" json:"parent,omitempty"`
 	Artifact             *metadata_store_go_proto.Artifact `protobuf:"bytes,2,opt,name=artifact,proto3" json:"artifact,omitempty"`
+	Workspace            string                            `protobuf:"bytes,3,opt,name=workspace,proto3" json:"workspace,omitempty"`
 	XXX_NoUnkeyedLiteral struct{}                          `json:"-"`
 	XXX_unrecognized     []byte                            `json:"-"`
 	XXX_sizecache        int32                             `json:"-"`
@@ -430,6 +476,13 @@ func (m *CreateArtifactRequest) GetArtifact() *metadata_store_go_proto.Artifact
 	return nil
 }
 
+func (m *CreateArtifactRequest) GetWorkspace() string {
+	if m != nil {
+		return m.Workspace
+	}
+	return ""
+}
+
 type CreateArtifactResponse struct {
 	Artifact             *metadata_store_go_proto.Artifact `protobuf:"bytes,1,opt,name=artifact,proto3" json:"artifact,omitempty"`
 	XXX_NoUnkeyedLiteral struct{}                          `json:"-"`
@@ -469,6 +522,84 @@ func (m *CreateArtifactResponse) GetArtifact() *metadata_store_go_proto.Artifact
 	return nil
 }
 
+type BatchCreateArtifactsRequest struct {
+	Requests             []*CreateArtifactRequest `protobuf:"bytes,1,rep,name=requests,proto3" json:"requests,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}                 `json:"-"`
+	XXX_unrecognized     []byte                   `json:"-"`
+	XXX_sizecache        int32                    `json:"-"`
+}
+
+func (m *BatchCreateArtifactsRequest) Reset()         { *m = BatchCreateArtifactsRequest{} }
+func (m *BatchCreateArtifactsRequest) String() string { return proto.CompactTextString(m) }
+func (*BatchCreateArtifactsRequest) ProtoMessage()    {}
+func (*BatchCreateArtifactsRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_42c32aec9010f89c, []int{11}
+}
+
+func (m *BatchCreateArtifactsRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_BatchCreateArtifactsRequest.Unmarshal(m, b)
+}
+func (m *BatchCreateArtifactsRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_BatchCreateArtifactsRequest.Marshal(b, m, deterministic)
+}
+func (m *BatchCreateArtifactsRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_BatchCreateArtifactsRequest.Merge(m, src)
+}
+func (m *BatchCreateArtifactsRequest) XXX_Size() int {
+	return xxx_messageInfo_BatchCreateArtifactsRequest.Size(m)
+}
+func (m *BatchCreateArtifactsRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_BatchCreateArtifactsRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_BatchCreateArtifactsRequest proto.InternalMessageInfo
+
+func (m *BatchCreateArtifactsRequest) GetRequests() []*CreateArtifactRequest {
+	if m != nil {
+		return m.Requests
+	}
+	return nil
+}
+
+type BatchCreateArtifactsResponse struct {
+	Artifacts            []*metadata_store_go_proto.Artifact `protobuf:"bytes,1,rep,name=artifacts,proto3" json:"artifacts,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}                            `json:"-"`
+	XXX_unrecognized     []byte                              `json:"-"`
+	XXX_sizecache        int32                               `json:"-"`
+}
+
+func (m *BatchCreateArtifactsResponse) Reset()         { *m = BatchCreateArtifactsResponse{} }
+func (m *BatchCreateArtifactsResponse) String() string { return proto.CompactTextString(m) }
+func (*BatchCreateArtifactsResponse) ProtoMessage()    {}
+func (*BatchCreateArtifactsResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_42c32aec9010f89c, []int{12}
+}
+
+func (m *BatchCreateArtifactsResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_BatchCreateArtifactsResponse.Unmarshal(m, b)
+}
+func (m *BatchCreateArtifactsResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_BatchCreateArtifactsResponse.Marshal(b, m, deterministic)
+}
+func (m *BatchCreateArtifactsResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_BatchCreateArtifactsResponse.Merge(m, src)
+}
+func (m *BatchCreateArtifactsResponse) XXX_Size() int {
+	return xxx_messageInfo_BatchCreateArtifactsResponse.Size(m)
+}
+func (m *BatchCreateArtifactsResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_BatchCreateArtifactsResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_BatchCreateArtifactsResponse proto.InternalMessageInfo
+
+func (m *BatchCreateArtifactsResponse) GetArtifacts() []*metadata_store_go_proto.Artifact {
+	if m != nil {
+		return m.Artifacts
+	}
+	return nil
+}
+
 type GetArtifactRequest struct {
 	Name                 string   `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
@@ -480,7 +611,7 @@ func (m *GetArtifactRequest) Reset()         { *m = GetArtifactRequest{} }
 func (m *GetArtifactRequest) String() string { return proto.CompactTextString(m) }
 func (*GetArtifactRequest) ProtoMessage()    {}
 func (*GetArtifactRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_42c32aec9010f89c, []int{11}
+	return fileDescriptor_42c32aec9010f89c, []int{13}
 }
 
 func (m *GetArtifactRequest) XXX_Unmarshal(b []byte) error {
@@ -519,7 +650,7 @@ func (m *GetArtifactResponse) Reset()         { *m = GetArtifactResponse{} }
 func (m *GetArtifactResponse) String() string { return proto.CompactTextString(m) }
 func (*GetArtifactResponse) ProtoMessage()    {}
 func (*GetArtifactResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_42c32aec9010f89c, []int{12}
+	return fileDescriptor_42c32aec9010f89c, []int{14}
 }
 
 func (m *GetArtifactResponse) XXX_Unmarshal(b []byte) error {
@@ -549,6 +680,10 @@ func (m *GetArtifactResponse) GetArtifact() *metadata_store_go_proto.Artifact {
 
 type ListArtifactsRequest struct {
 	Name                 string   `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	PageSize             int32    `protobuf:"varint,2,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`
+	PageToken            string   `protobuf:"bytes,3,opt,name=page_token,json=pageToken,proto3" json:"page_token,omitempty"`
+	Filter               string   `protobuf:"bytes,4,opt,name=filter,proto3" json:"filter,omitempty"`
+	Workspace            string   `protobuf:"bytes,5,opt,name=workspace,proto3" json:"workspace,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
@@ -558,7 +693,7 @@ func (m *ListArtifactsRequest) Reset()         { *m = ListArtifactsRequest{} }
 func (m *ListArtifactsRequest) String() string { return proto.CompactTextString(m) }
 func (*ListArtifactsRequest) ProtoMessage()    {}
 func (*ListArtifactsRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_42c32aec9010f89c, []int{13}
+	return fileDescriptor_42c32aec9010f89c, []int{15}
 }
 
 func (m *ListArtifactsRequest) XXX_Unmarshal(b []byte) error {
@@ -586,8 +721,37 @@ func (m *ListArtifactsRequest) GetName() string {
 	return ""
 }
 
+func (m *ListArtifactsRequest) GetPageSize() int32 {
+	if m != nil {
+		return m.PageSize
+	}
+	return 0
+}
+
+func (m *ListArtifactsRequest) GetPageToken() string {
+	if m != nil {
+		return m.PageToken
+	}
+	return ""
+}
+
+func (m *ListArtifactsRequest) GetFilter() string {
+	if m != nil {
+		return m.Filter
+	}
+	return ""
+}
+
+func (m *ListArtifactsRequest) GetWorkspace() string {
+	if m != nil {
+		return m.Workspace
+	}
+	return ""
+}
+
 type ListArtifactsResponse struct {
 	Artifacts            []*metadata_store_go_proto.Artifact `protobuf:"bytes,1,rep,name=artifacts,proto3" json:"artifacts,omitempty"`
+	NextPageToken        string                              `protobuf:"bytes,2,opt,name=next_page_token,json=nextPageToken,proto3" json:"next_page_token,omitempty"`
 	XXX_NoUnkeyedLiteral struct{}                            `json:"-"`
 	XXX_unrecognized     []byte                              `json:"-"`
 	XXX_sizecache        int32                               `json:"-"`
@@ -597,7 +761,7 @@ func (m *ListArtifactsResponse) Reset()         { *m = ListArtifactsResponse{} }
 func (m *ListArtifactsResponse) String() string { return proto.CompactTextString(m) }
 func (*ListArtifactsResponse) ProtoMessage()    {}
 func (*ListArtifactsResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_42c32aec9010f89c, []int{14}
+	return fileDescriptor_42c32aec9010f89c, []int{16}
 }
 
 func (m *ListArtifactsResponse) XXX_Unmarshal(b []byte) error {
@@ -625,8 +789,110 @@ func (m *ListArtifactsResponse) GetArtifacts() []*metadata_store_go_proto.Artifa
 	return nil
 }
 
+func (m *ListArtifactsResponse) GetNextPageToken() string {
+	if m != nil {
+		return m.NextPageToken
+	}
+	return ""
+}
+
+type UpdateArtifactRequest struct {
+	Name                 string                            `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Artifact             *metadata_store_go_proto.Artifact `protobuf:"bytes,2,opt,name=artifact,proto3" json:"artifact,omitempty"`
+	UpdateMask           *field_mask.FieldMask             `protobuf:"bytes,3,opt,name=update_mask,json=updateMask,proto3" json:"update_mask,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}                          `json:"-"`
+	XXX_unrecognized     []byte                            `json:"-"`
+	XXX_sizecache        int32                             `json:"-"`
+}
+
+func (m *UpdateArtifactRequest) Reset()         { *m = UpdateArtifactRequest{} }
+func (m *UpdateArtifactRequest) String() string { return proto.CompactTextString(m) }
+func (*UpdateArtifactRequest) ProtoMessage()    {}
+func (*UpdateArtifactRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_42c32aec9010f89c, []int{17}
+}
+
+func (m *UpdateArtifactRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_UpdateArtifactRequest.Unmarshal(m, b)
+}
+func (m *UpdateArtifactRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_UpdateArtifactRequest.Marshal(b, m, deterministic)
+}
+func (m *UpdateArtifactRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_UpdateArtifactRequest.Merge(m, src)
+}
+func (m *UpdateArtifactRequest) XXX_Size() int {
+	return xxx_messageInfo_UpdateArtifactRequest.Size(m)
+}
+func (m *UpdateArtifactRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_UpdateArtifactRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_UpdateArtifactRequest proto.InternalMessageInfo
+
+func (m *UpdateArtifactRequest) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+func (m *UpdateArtifactRequest) GetArtifact() *metadata_store_go_proto.Artifact {
+	if m != nil {
+		return m.Artifact
+	}
+	return nil
+}
+
+func (m *UpdateArtifactRequest) GetUpdateMask() *field_mask.FieldMask {
+	if m != nil {
+		return m.UpdateMask
+	}
+	return nil
+}
+
+type UpdateArtifactResponse struct {
+	Artifact             *metadata_store_go_proto.Artifact `protobuf:"bytes,1,opt,name=artifact,proto3" json:"artifact,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}                          `json:"-"`
+	XXX_unrecognized     []byte                            `json:"-"`
+	XXX_sizecache        int32                             `json:"-"`
+}
+
+func (m *UpdateArtifactResponse) Reset()         { *m = UpdateArtifactResponse{} }
+func (m *UpdateArtifactResponse) String() string { return proto.CompactTextString(m) }
+func (*UpdateArtifactResponse) ProtoMessage()    {}
+func (*UpdateArtifactResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_42c32aec9010f89c, []int{18}
+}
+
+func (m *UpdateArtifactResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_UpdateArtifactResponse.Unmarshal(m, b)
+}
+func (m *UpdateArtifactResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_UpdateArtifactResponse.Marshal(b, m, deterministic)
+}
+func (m *UpdateArtifactResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_UpdateArtifactResponse.Merge(m, src)
+}
+func (m *UpdateArtifactResponse) XXX_Size() int {
+	return xxx_messageInfo_UpdateArtifactResponse.Size(m)
+}
+func (m *UpdateArtifactResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_UpdateArtifactResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_UpdateArtifactResponse proto.InternalMessageInfo
+
+func (m *UpdateArtifactResponse) GetArtifact() *metadata_store_go_proto.Artifact {
+	if m != nil {
+		return m.Artifact
+	}
+	return nil
+}
+
 type DeleteArtifactRequest struct {
 	Name                 string   `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Cascade              bool     `protobuf:"varint,2,opt,name=cascade,proto3" json:"cascade,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
@@ -636,7 +902,7 @@ func (m *DeleteArtifactRequest) Reset()         { *m = DeleteArtifactRequest{} }
 func (m *DeleteArtifactRequest) String() string { return proto.CompactTextString(m) }
 func (*DeleteArtifactRequest) ProtoMessage()    {}
 func (*DeleteArtifactRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_42c32aec9010f89c, []int{15}
+	return fileDescriptor_42c32aec9010f89c, []int{19}
 }
 
 func (m *DeleteArtifactRequest) XXX_Unmarshal(b []byte) error {
@@ -664,6 +930,13 @@ func (m *DeleteArtifactRequest) GetName() string {
 	return ""
 }
 
+func (m *DeleteArtifactRequest) GetCascade() bool {
+	if m != nil {
+		return m.Cascade
+	}
+	return false
+}
+
 type CreateExecutionTypeRequest struct {
 	ExecutionType        *metadata_store_go_proto.ExecutionType `protobuf:"bytes,1,opt,name=execution_type,json=executionType,proto3" json:"execution_type,omitempty"`
 	XXX_NoUnkeyedLiteral struct{}                               `json:"-"`
@@ -675,7 +948,7 @@ func (m *CreateExecutionTypeRequest) Reset()         { *m = CreateExecutionTypeR
 func (m *CreateExecutionTypeRequest) String() string { return proto.CompactTextString(m) }
 func (*CreateExecutionTypeRequest) ProtoMessage()    {}
 func (*CreateExecutionTypeRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_42c32aec9010f89c, []int{16}
+	return fileDescriptor_42c32aec9010f89c, []int{20}
 }
 
 func (m *CreateExecutionTypeRequest) XXX_Unmarshal(b []byte) error {
@@ -714,7 +987,7 @@ func (m *CreateExecutionTypeResponse) Reset()         { *m = CreateExecutionType
 func (m *CreateExecutionTypeResponse) String() string { return proto.CompactTextString(m) }
 func (*CreateExecutionTypeResponse) ProtoMessage()    {}
 func (*CreateExecutionTypeResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_42c32aec9010f89c, []int{17}
+	return fileDescriptor_42c32aec9010f89c, []int{21}
 }
 
 func (m *CreateExecutionTypeResponse) XXX_Unmarshal(b []byte) error {
@@ -753,7 +1026,7 @@ func (m *UpdateExecutionTypeRequest) Reset()         { *m = UpdateExecutionTypeR
 func (m *UpdateExecutionTypeRequest) String() string { return proto.CompactTextString(m) }
 func (*UpdateExecutionTypeRequest) ProtoMessage()    {}
 func (*UpdateExecutionTypeRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_42c32aec9010f89c, []int{18}
+	return fileDescriptor_42c32aec9010f89c, []int{22}
 }
 
 func (m *UpdateExecutionTypeRequest) XXX_Unmarshal(b []byte) error {
@@ -792,7 +1065,7 @@ func (m *UpdateExecutionTypeResponse) Reset()         { *m = UpdateExecutionType
 func (m *UpdateExecutionTypeResponse) String() string { return proto.CompactTextString(m) }
 func (*UpdateExecutionTypeResponse) ProtoMessage()    {}
 func (*UpdateExecutionTypeResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_42c32aec9010f89c, []int{19}
+	return fileDescriptor_42c32aec9010f89c, []int{23}
 }
 
 func (m *UpdateExecutionTypeResponse) XXX_Unmarshal(b []byte) error {
@@ -831,7 +1104,7 @@ func (m *GetExecutionTypeRequest) Reset()         { *m = GetExecutionTypeRequest
 func (m *GetExecutionTypeRequest) String() string { return proto.CompactTextString(m) }
 func (*GetExecutionTypeRequest) ProtoMessage()    {}
 func (*GetExecutionTypeRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_42c32aec9010f89c, []int{20}
+	return fileDescriptor_42c32aec9010f89c, []int{24}
 }
 
 func (m *GetExecutionTypeRequest) XXX_Unmarshal(b []byte) error {
@@ -861,6 +1134,7 @@ func (m *GetExecutionTypeRequest) GetName() string {
 
 type GetExecutionTypeResponse struct {
 	ExecutionType        *metadata_store_go_proto.ExecutionType `protobuf:"bytes,1,opt,name=execution_type,json=executionType,proto3" json:"execution_type,omitempty"`
+	PropertyValidators   map[string]*PropertyValidator          `protobuf:"bytes,2,rep,name=property_validators,json=propertyValidators,proto3" json:"property_validators,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
 	XXX_NoUnkeyedLiteral struct{}                               `json:"-"`
 	XXX_unrecognized     []byte                                 `json:"-"`
 	XXX_sizecache        int32                                  `json:"-"`
@@ -870,7 +1144,7 @@ func (m *GetExecutionTypeResponse) Reset()         { *m = GetExecutionTypeRespon
 func (m *GetExecutionTypeResponse) String() string { return proto.CompactTextString(m) }
 func (*GetExecutionTypeResponse) ProtoMessage()    {}
 func (*GetExecutionTypeResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_42c32aec9010f89c, []int{21}
+	return fileDescriptor_42c32aec9010f89c, []int{25}
 }
 
 func (m *GetExecutionTypeResponse) XXX_Unmarshal(b []byte) error {
@@ -898,6 +1172,13 @@ func (m *GetExecutionTypeResponse) GetExecutionType() *metadata_store_go_proto.E
 	return nil
 }
 
+func (m *GetExecutionTypeResponse) GetPropertyValidators() map[string]*PropertyValidator {
+	if m != nil {
+		return m.PropertyValidators
+	}
+	return nil
+}
+
 type ListExecutionTypesRequest struct {
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
@@ -908,7 +1189,7 @@ func (m *ListExecutionTypesRequest) Reset()         { *m = ListExecutionTypesReq
 func (m *ListExecutionTypesRequest) String() string { return proto.CompactTextString(m) }
 func (*ListExecutionTypesRequest) ProtoMessage()    {}
 func (*ListExecutionTypesRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_42c32aec9010f89c, []int{22}
+	return fileDescriptor_42c32aec9010f89c, []int{26}
 }
 
 func (m *ListExecutionTypesRequest) XXX_Unmarshal(b []byte) error {
@@ -940,7 +1221,7 @@ func (m *ListExecutionTypesResponse) Reset()         { *m = ListExecutionTypesRe
 func (m *ListExecutionTypesResponse) String() string { return proto.CompactTextString(m) }
 func (*ListExecutionTypesResponse) ProtoMessage()    {}
 func (*ListExecutionTypesResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_42c32aec9010f89c, []int{23}
+	return fileDescriptor_42c32aec9010f89c, []int{27}
 }
 
 func (m *ListExecutionTypesResponse) XXX_Unmarshal(b []byte) error {
@@ -970,6 +1251,7 @@ func (m *ListExecutionTypesResponse) GetExecutionTypes() []*metadata_store_go_pr
 
 type DeleteExecutionTypeRequest struct {
 	Name                 string   `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Force                bool     `protobuf:"varint,2,opt,name=force,proto3" json:"force,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
@@ -979,7 +1261,7 @@ func (m *DeleteExecutionTypeRequest) Reset()         { *m = DeleteExecutionTypeR
 func (m *DeleteExecutionTypeRequest) String() string { return proto.CompactTextString(m) }
 func (*DeleteExecutionTypeRequest) ProtoMessage()    {}
 func (*DeleteExecutionTypeRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_42c32aec9010f89c, []int{24}
+	return fileDescriptor_42c32aec9010f89c, []int{28}
 }
 
 func (m *DeleteExecutionTypeRequest) XXX_Unmarshal(b []byte) error {
@@ -1007,9 +1289,17 @@ func (m *DeleteExecutionTypeRequest) GetName() string {
 	return ""
 }
 
+func (m *DeleteExecutionTypeRequest) GetForce() bool {
+	if m != nil {
+		return m.Force
+	}
+	return false
+}
+
 type CreateExecutionRequest struct {
 	Parent               string                             `protobuf:"bytes,1,opt,name=parent,proto3" json:"parent,omitempty"`
 	Execution            *metadata_store_go_proto.Execution `protobuf:"bytes,2,opt,name=execution,proto3" json:"execution,omitempty"`
+	Workspace            string                             `protobuf:"bytes,3,opt,name=workspace,proto3" json:"workspace,omitempty"`
 	XXX_NoUnkeyedLiteral struct{}                           `json:"-"`
 	XXX_unrecognized     []byte                             `json:"-"`
 	XXX_sizecache        int32                              `json:"-"`
@@ -1019,7 +1309,7 @@ func (m *CreateExecutionRequest) Reset()         { *m = CreateExecutionRequest{}
 func (m *CreateExecutionRequest) String() string { return proto.CompactTextString(m) }
 func (*CreateExecutionRequest) ProtoMessage()    {}
 func (*CreateExecutionRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_42c32aec9010f89c, []int{25}
+	return fileDescriptor_42c32aec9010f89c, []int{29}
 }
 
 func (m *CreateExecutionRequest) XXX_Unmarshal(b []byte) error {
@@ -1054,6 +1344,13 @@ func (m *CreateExecutionRequest) GetExecution() *metadata_store_go_proto.Executi
 	return nil
 }
 
+func (m *CreateExecutionRequest) GetWorkspace() string {
+	if m != nil {
+		return m.Workspace
+	}
+	return ""
+}
+
 type CreateExecutionResponse struct {
 	Execution            *metadata_store_go_proto.Execution `protobuf:"bytes,1,opt,name=execution,proto3" json:"execution,omitempty"`
 	XXX_NoUnkeyedLiteral struct{}                           `json:"-"`
@@ -1065,7 +1362,7 @@ func (m *CreateExecutionResponse) Reset()         { *m = CreateExecutionResponse
 func (m *CreateExecutionResponse) String() string { return proto.CompactTextString(m) }
 func (*CreateExecutionResponse) ProtoMessage()    {}
 func (*CreateExecutionResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_42c32aec9010f89c, []int{26}
+	return fileDescriptor_42c32aec9010f89c, []int{30}
 }
 
 func (m *CreateExecutionResponse) XXX_Unmarshal(b []byte) error {
@@ -1093,6 +1390,84 @@ func (m *CreateExecutionResponse) GetExecution() *metadata_store_go_proto.Execut
 	return nil
 }
 
+type BatchCreateExecutionsRequest struct {
+	Requests             []*CreateExecutionRequest `protobuf:"bytes,1,rep,name=requests,proto3" json:"requests,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}                  `json:"-"`
+	XXX_unrecognized     []byte                    `json:"-"`
+	XXX_sizecache        int32                     `json:"-"`
+}
+
+func (m *BatchCreateExecutionsRequest) Reset()         { *m = BatchCreateExecutionsRequest{} }
+func (m *BatchCreateExecutionsRequest) String() string { return proto.CompactTextString(m) }
+func (*BatchCreateExecutionsRequest) ProtoMessage()    {}
+func (*BatchCreateExecutionsRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_42c32aec9010f89c, []int{31}
+}
+
+func (m *BatchCreateExecutionsRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_BatchCreateExecutionsRequest.Unmarshal(m, b)
+}
+func (m *BatchCreateExecutionsRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_BatchCreateExecutionsRequest.Marshal(b, m, deterministic)
+}
+func (m *BatchCreateExecutionsRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_BatchCreateExecutionsRequest.Merge(m, src)
+}
+func (m *BatchCreateExecutionsRequest) XXX_Size() int {
+	return xxx_messageInfo_BatchCreateExecutionsRequest.Size(m)
+}
+func (m *BatchCreateExecutionsRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_BatchCreateExecutionsRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_BatchCreateExecutionsRequest proto.InternalMessageInfo
+
+func (m *BatchCreateExecutionsRequest) GetRequests() []*CreateExecutionRequest {
+	if m != nil {
+		return m.Requests
+	}
+	return nil
+}
+
+type BatchCreateExecutionsResponse struct {
+	Executions           []*metadata_store_go_proto.Execution `protobuf:"bytes,1,rep,name=executions,proto3" json:"executions,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}                             `json:"-"`
+	XXX_unrecognized     []byte                               `json:"-"`
+	XXX_sizecache        int32                                `json:"-"`
+}
+
+func (m *BatchCreateExecutionsResponse) Reset()         { *m = BatchCreateExecutionsResponse{} }
+func (m *BatchCreateExecutionsResponse) String() string { return proto.CompactTextString(m) }
+func (*BatchCreateExecutionsResponse) ProtoMessage()    {}
+func (*BatchCreateExecutionsResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_42c32aec9010f89c, []int{32}
+}
+
+func (m *BatchCreateExecutionsResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_BatchCreateExecutionsResponse.Unmarshal(m, b)
+}
+func (m *BatchCreateExecutionsResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_BatchCreateExecutionsResponse.Marshal(b, m, deterministic)
+}
+func (m *BatchCreateExecutionsResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_BatchCreateExecutionsResponse.Merge(m, src)
+}
+func (m *BatchCreateExecutionsResponse) XXX_Size() int {
+	return xxx_messageInfo_BatchCreateExecutionsResponse.Size(m)
+}
+func (m *BatchCreateExecutionsResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_BatchCreateExecutionsResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_BatchCreateExecutionsResponse proto.InternalMessageInfo
+
+func (m *BatchCreateExecutionsResponse) GetExecutions() []*metadata_store_go_proto.Execution {
+	if m != nil {
+		return m.Executions
+	}
+	return nil
+}
+
 type GetExecutionRequest struct {
 	Name                 string   `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
@@ -1104,7 +1479,7 @@ func (m *GetExecutionRequest) Reset()         { *m = GetExecutionRequest{} }
 func (m *GetExecutionRequest) String() string { return proto.CompactTextString(m) }
 func (*GetExecutionRequest) ProtoMessage()    {}
 func (*GetExecutionRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_42c32aec9010f89c, []int{27}
+	return fileDescriptor_42c32aec9010f89c, []int{33}
 }
 
 func (m *GetExecutionRequest) XXX_Unmarshal(b []byte) error {
@@ -1143,7 +1518,7 @@ func (m *GetExecutionResponse) Reset()         { *m = GetExecutionResponse{} }
 func (m *GetExecutionResponse) String() string { return proto.CompactTextString(m) }
 func (*GetExecutionResponse) ProtoMessage()    {}
 func (*GetExecutionResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_42c32aec9010f89c, []int{28}
+	return fileDescriptor_42c32aec9010f89c, []int{34}
 }
 
 func (m *GetExecutionResponse) XXX_Unmarshal(b []byte) error {
@@ -1173,6 +1548,10 @@ func (m *GetExecutionResponse) GetExecution() *metadata_store_go_proto.Execution
 
 type ListExecutionsRequest struct {
 	Name                 string   `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	PageSize             int32    `protobuf:"varint,2,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`
+	PageToken            string   `protobuf:"bytes,3,opt,name=page_token,json=pageToken,proto3" json:"page_token,omitempty"`
+	Filter               string   `protobuf:"bytes,4,opt,name=filter,proto3" json:"filter,omitempty"`
+	Workspace            string   `protobuf:"bytes,5,opt,name=workspace,proto3" json:"workspace,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
@@ -1182,7 +1561,7 @@ func (m *ListExecutionsRequest) Reset()         { *m = ListExecutionsRequest{} }
 func (m *ListExecutionsRequest) String() string { return proto.CompactTextString(m) }
 func (*ListExecutionsRequest) ProtoMessage()    {}
 func (*ListExecutionsRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_42c32aec9010f89c, []int{29}
+	return fileDescriptor_42c32aec9010f89c, []int{35}
 }
 
 func (m *ListExecutionsRequest) XXX_Unmarshal(b []byte) error {
@@ -1210,8 +1589,37 @@ func (m *ListExecutionsRequest) GetName() string {
 	return ""
 }
 
+func (m *ListExecutionsRequest) GetPageSize() int32 {
+	if m != nil {
+		return m.PageSize
+	}
+	return 0
+}
+
+func (m *ListExecutionsRequest) GetPageToken() string {
+	if m != nil {
+		return m.PageToken
+	}
+	return ""
+}
+
+func (m *ListExecutionsRequest) GetFilter() string {
+	if m != nil {
+		return m.Filter
+	}
+	return ""
+}
+
+func (m *ListExecutionsRequest) GetWorkspace() string {
+	if m != nil {
+		return m.Workspace
+	}
+	return ""
+}
+
 type ListExecutionsResponse struct {
 	Executions           []*metadata_store_go_proto.Execution `protobuf:"bytes,1,rep,name=executions,proto3" json:"executions,omitempty"`
+	NextPageToken        string                               `protobuf:"bytes,2,opt,name=next_page_token,json=nextPageToken,proto3" json:"next_page_token,omitempty"`
 	XXX_NoUnkeyedLiteral struct{}                             `json:"-"`
 	XXX_unrecognized     []byte                               `json:"-"`
 	XXX_sizecache        int32                                `json:"-"`
@@ -1221,222 +1629,1150 @@ func (m *ListExecutionsResponse) Reset()         { *m = ListExecutionsResponse{}
 func (m *ListExecutionsResponse) String() string { return proto.CompactTextString(m) }
 func (*ListExecutionsResponse) ProtoMessage()    {}
 func (*ListExecutionsResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_42c32aec9010f89c, []int{30}
+	return fileDescriptor_42c32aec9010f89c, []int{36}
+}
+
+func (m *ListExecutionsResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ListExecutionsResponse.Unmarshal(m, b)
+}
+func (m *ListExecutionsResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ListExecutionsResponse.Marshal(b, m, deterministic)
+}
+func (m *ListExecutionsResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ListExecutionsResponse.Merge(m, src)
+}
+func (m *ListExecutionsResponse) XXX_Size() int {
+	return xxx_messageInfo_ListExecutionsResponse.Size(m)
+}
+func (m *ListExecutionsResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_ListExecutionsResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ListExecutionsResponse proto.InternalMessageInfo
+
+func (m *ListExecutionsResponse) GetExecutions() []*metadata_store_go_proto.Execution {
+	if m != nil {
+		return m.Executions
+	}
+	return nil
+}
+
+func (m *ListExecutionsResponse) GetNextPageToken() string {
+	if m != nil {
+		return m.NextPageToken
+	}
+	return ""
+}
+
+type UpdateExecutionRequest struct {
+	Name                 string                             `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Execution            *metadata_store_go_proto.Execution `protobuf:"bytes,2,opt,name=execution,proto3" json:"execution,omitempty"`
+	UpdateMask           *field_mask.FieldMask              `protobuf:"bytes,3,opt,name=update_mask,json=updateMask,proto3" json:"update_mask,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}                           `json:"-"`
+	XXX_unrecognized     []byte                             `json:"-"`
+	XXX_sizecache        int32                              `json:"-"`
+}
+
+func (m *UpdateExecutionRequest) Reset()         { *m = UpdateExecutionRequest{} }
+func (m *UpdateExecutionRequest) String() string { return proto.CompactTextString(m) }
+func (*UpdateExecutionRequest) ProtoMessage()    {}
+func (*UpdateExecutionRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_42c32aec9010f89c, []int{37}
+}
+
+func (m *UpdateExecutionRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_UpdateExecutionRequest.Unmarshal(m, b)
+}
+func (m *UpdateExecutionRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_UpdateExecutionRequest.Marshal(b, m, deterministic)
+}
+func (m *UpdateExecutionRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_UpdateExecutionRequest.Merge(m, src)
+}
+func (m *UpdateExecutionRequest) XXX_Size() int {
+	return xxx_messageInfo_UpdateExecutionRequest.Size(m)
+}
+func (m *UpdateExecutionRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_UpdateExecutionRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_UpdateExecutionRequest proto.InternalMessageInfo
+
+func (m *UpdateExecutionRequest) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+func (m *UpdateExecutionRequest) GetExecution() *metadata_store_go_proto.Execution {
+	if m != nil {
+		return m.Execution
+	}
+	return nil
+}
+
+func (m *UpdateExecutionRequest) GetUpdateMask() *field_mask.FieldMask {
+	if m != nil {
+		return m.UpdateMask
+	}
+	return nil
+}
+
+type UpdateExecutionResponse struct {
+	Execution            *metadata_store_go_proto.Execution `protobuf:"bytes,1,opt,name=execution,proto3" json:"execution,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}                           `json:"-"`
+	XXX_unrecognized     []byte                             `json:"-"`
+	XXX_sizecache        int32                              `json:"-"`
+}
+
+func (m *UpdateExecutionResponse) Reset()         { *m = UpdateExecutionResponse{} }
+func (m *UpdateExecutionResponse) String() string { return proto.CompactTextString(m) }
+func (*UpdateExecutionResponse) ProtoMessage()    {}
+func (*UpdateExecutionResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_42c32aec9010f89c, []int{38}
+}
+
+func (m *UpdateExecutionResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_UpdateExecutionResponse.Unmarshal(m, b)
+}
+func (m *UpdateExecutionResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_UpdateExecutionResponse.Marshal(b, m, deterministic)
+}
+func (m *UpdateExecutionResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_UpdateExecutionResponse.Merge(m, src)
+}
+func (m *UpdateExecutionResponse) XXX_Size() int {
+	return xxx_messageInfo_UpdateExecutionResponse.Size(m)
+}
+func (m *UpdateExecutionResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_UpdateExecutionResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_UpdateExecutionResponse proto.InternalMessageInfo
+
+func (m *UpdateExecutionResponse) GetExecution() *metadata_store_go_proto.Execution {
+	if m != nil {
+		return m.Execution
+	}
+	return nil
+}
+
+type DeleteExecutionRequest struct {
+	Name                 string   `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Cascade              bool     `protobuf:"varint,2,opt,name=cascade,proto3" json:"cascade,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *DeleteExecutionRequest) Reset()         { *m = DeleteExecutionRequest{} }
+func (m *DeleteExecutionRequest) String() string { return proto.CompactTextString(m) }
+func (*DeleteExecutionRequest) ProtoMessage()    {}
+func (*DeleteExecutionRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_42c32aec9010f89c, []int{39}
+}
+
+func (m *DeleteExecutionRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_DeleteExecutionRequest.Unmarshal(m, b)
+}
+func (m *DeleteExecutionRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_DeleteExecutionRequest.Marshal(b, m, deterministic)
+}
+func (m *DeleteExecutionRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_DeleteExecutionRequest.Merge(m, src)
+}
+func (m *DeleteExecutionRequest) XXX_Size() int {
+	return xxx_messageInfo_DeleteExecutionRequest.Size(m)
+}
+func (m *DeleteExecutionRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_DeleteExecutionRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_DeleteExecutionRequest proto.InternalMessageInfo
+
+func (m *DeleteExecutionRequest) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+func (m *DeleteExecutionRequest) GetCascade() bool {
+	if m != nil {
+		return m.Cascade
+	}
+	return false
+}
+
+type RecordExecutionRequest struct {
+	Execution            *CreateExecutionRequest  `protobuf:"bytes,1,opt,name=execution,proto3" json:"execution,omitempty"`
+	InputArtifacts       []string                 `protobuf:"bytes,2,rep,name=input_artifacts,json=inputArtifacts,proto3" json:"input_artifacts,omitempty"`
+	OutputArtifacts      []*CreateArtifactRequest `protobuf:"bytes,3,rep,name=output_artifacts,json=outputArtifacts,proto3" json:"output_artifacts,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}                 `json:"-"`
+	XXX_unrecognized     []byte                   `json:"-"`
+	XXX_sizecache        int32                    `json:"-"`
+}
+
+func (m *RecordExecutionRequest) Reset()         { *m = RecordExecutionRequest{} }
+func (m *RecordExecutionRequest) String() string { return proto.CompactTextString(m) }
+func (*RecordExecutionRequest) ProtoMessage()    {}
+func (*RecordExecutionRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_42c32aec9010f89c, []int{40}
+}
+
+func (m *RecordExecutionRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_RecordExecutionRequest.Unmarshal(m, b)
+}
+func (m *RecordExecutionRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_RecordExecutionRequest.Marshal(b, m, deterministic)
+}
+func (m *RecordExecutionRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_RecordExecutionRequest.Merge(m, src)
+}
+func (m *RecordExecutionRequest) XXX_Size() int {
+	return xxx_messageInfo_RecordExecutionRequest.Size(m)
+}
+func (m *RecordExecutionRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_RecordExecutionRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_RecordExecutionRequest proto.InternalMessageInfo
+
+func (m *RecordExecutionRequest) GetExecution() *CreateExecutionRequest {
+	if m != nil {
+		return m.Execution
+	}
+	return nil
+}
+
+func (m *RecordExecutionRequest) GetInputArtifacts() []string {
+	if m != nil {
+		return m.InputArtifacts
+	}
+	return nil
+}
+
+func (m *RecordExecutionRequest) GetOutputArtifacts() []*CreateArtifactRequest {
+	if m != nil {
+		return m.OutputArtifacts
+	}
+	return nil
+}
+
+type RecordExecutionResponse struct {
+	Execution            *metadata_store_go_proto.Execution  `protobuf:"bytes,1,opt,name=execution,proto3" json:"execution,omitempty"`
+	OutputArtifacts      []*metadata_store_go_proto.Artifact `protobuf:"bytes,2,rep,name=output_artifacts,json=outputArtifacts,proto3" json:"output_artifacts,omitempty"`
+	Events               []*metadata_store_go_proto.Event    `protobuf:"bytes,3,rep,name=events,proto3" json:"events,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}                            `json:"-"`
+	XXX_unrecognized     []byte                              `json:"-"`
+	XXX_sizecache        int32                               `json:"-"`
+}
+
+func (m *RecordExecutionResponse) Reset()         { *m = RecordExecutionResponse{} }
+func (m *RecordExecutionResponse) String() string { return proto.CompactTextString(m) }
+func (*RecordExecutionResponse) ProtoMessage()    {}
+func (*RecordExecutionResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_42c32aec9010f89c, []int{41}
+}
+
+func (m *RecordExecutionResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_RecordExecutionResponse.Unmarshal(m, b)
+}
+func (m *RecordExecutionResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_RecordExecutionResponse.Marshal(b, m, deterministic)
+}
+func (m *RecordExecutionResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_RecordExecutionResponse.Merge(m, src)
+}
+func (m *RecordExecutionResponse) XXX_Size() int {
+	return xxx_messageInfo_RecordExecutionResponse.Size(m)
+}
+func (m *RecordExecutionResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_RecordExecutionResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_RecordExecutionResponse proto.InternalMessageInfo
+
+func (m *RecordExecutionResponse) GetExecution() *metadata_store_go_proto.Execution {
+	if m != nil {
+		return m.Execution
+	}
+	return nil
+}
+
+func (m *RecordExecutionResponse) GetOutputArtifacts() []*metadata_store_go_proto.Artifact {
+	if m != nil {
+		return m.OutputArtifacts
+	}
+	return nil
+}
+
+func (m *RecordExecutionResponse) GetEvents() []*metadata_store_go_proto.Event {
+	if m != nil {
+		return m.Events
+	}
+	return nil
+}
+
+type CreateEventRequest struct {
+	Event                *metadata_store_go_proto.Event `protobuf:"bytes,1,opt,name=event,proto3" json:"event,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}                       `json:"-"`
+	XXX_unrecognized     []byte                         `json:"-"`
+	XXX_sizecache        int32                          `json:"-"`
+}
+
+func (m *CreateEventRequest) Reset()         { *m = CreateEventRequest{} }
+func (m *CreateEventRequest) String() string { return proto.CompactTextString(m) }
+func (*CreateEventRequest) ProtoMessage()    {}
+func (*CreateEventRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_42c32aec9010f89c, []int{42}
+}
+
+func (m *CreateEventRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_CreateEventRequest.Unmarshal(m, b)
+}
+func (m *CreateEventRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_CreateEventRequest.Marshal(b, m, deterministic)
+}
+func (m *CreateEventRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CreateEventRequest.Merge(m, src)
+}
+func (m *CreateEventRequest) XXX_Size() int {
+	return xxx_messageInfo_CreateEventRequest.Size(m)
+}
+func (m *CreateEventRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_CreateEventRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_CreateEventRequest proto.InternalMessageInfo
+
+func (m *CreateEventRequest) GetEvent() *metadata_store_go_proto.Event {
+	if m != nil {
+		return m.Event
+	}
+	return nil
+}
+
+type BatchCreateEventsRequest struct {
+	Events               []*metadata_store_go_proto.Event `protobuf:"bytes,1,rep,name=events,proto3" json:"events,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}                         `json:"-"`
+	XXX_unrecognized     []byte                           `json:"-"`
+	XXX_sizecache        int32                            `json:"-"`
+}
+
+func (m *BatchCreateEventsRequest) Reset()         { *m = BatchCreateEventsRequest{} }
+func (m *BatchCreateEventsRequest) String() string { return proto.CompactTextString(m) }
+func (*BatchCreateEventsRequest) ProtoMessage()    {}
+func (*BatchCreateEventsRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_42c32aec9010f89c, []int{43}
+}
+
+func (m *BatchCreateEventsRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_BatchCreateEventsRequest.Unmarshal(m, b)
+}
+func (m *BatchCreateEventsRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_BatchCreateEventsRequest.Marshal(b, m, deterministic)
+}
+func (m *BatchCreateEventsRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_BatchCreateEventsRequest.Merge(m, src)
+}
+func (m *BatchCreateEventsRequest) XXX_Size() int {
+	return xxx_messageInfo_BatchCreateEventsRequest.Size(m)
+}
+func (m *BatchCreateEventsRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_BatchCreateEventsRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_BatchCreateEventsRequest proto.InternalMessageInfo
+
+func (m *BatchCreateEventsRequest) GetEvents() []*metadata_store_go_proto.Event {
+	if m != nil {
+		return m.Events
+	}
+	return nil
+}
+
+type ListEventsRequest struct {
+	Name                 string   `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ListEventsRequest) Reset()         { *m = ListEventsRequest{} }
+func (m *ListEventsRequest) String() string { return proto.CompactTextString(m) }
+func (*ListEventsRequest) ProtoMessage()    {}
+func (*ListEventsRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_42c32aec9010f89c, []int{44}
+}
+
+func (m *ListEventsRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ListEventsRequest.Unmarshal(m, b)
+}
+func (m *ListEventsRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ListEventsRequest.Marshal(b, m, deterministic)
+}
+func (m *ListEventsRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ListEventsRequest.Merge(m, src)
+}
+func (m *ListEventsRequest) XXX_Size() int {
+	return xxx_messageInfo_ListEventsRequest.Size(m)
+}
+func (m *ListEventsRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_ListEventsRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ListEventsRequest proto.InternalMessageInfo
+
+func (m *ListEventsRequest) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+type ListEventsResponse struct {
+	Events               []*metadata_store_go_proto.Event             `protobuf:"bytes,1,rep,name=events,proto3" json:"events,omitempty"`
+	Artifacts            map[int64]*metadata_store_go_proto.Artifact  `protobuf:"bytes,2,rep,name=artifacts,proto3" json:"artifacts,omitempty" protobuf_key:"varint,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	Executions           map[int64]*metadata_store_go_proto.Execution `protobuf:"bytes,3,rep,name=executions,proto3" json:"executions,omitempty" protobuf_key:"varint,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	XXX_NoUnkeyedLiteral struct{}                                     `json:"-"`
+	XXX_unrecognized     []byte                                       `json:"-"`
+	XXX_sizecache        int32                                        `json:"-"`
+}
+
+func (m *ListEventsResponse) Reset()         { *m = ListEventsResponse{} }
+func (m *ListEventsResponse) String() string { return proto.CompactTextString(m) }
+func (*ListEventsResponse) ProtoMessage()    {}
+func (*ListEventsResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_42c32aec9010f89c, []int{45}
+}
+
+func (m *ListEventsResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ListEventsResponse.Unmarshal(m, b)
+}
+func (m *ListEventsResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ListEventsResponse.Marshal(b, m, deterministic)
+}
+func (m *ListEventsResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ListEventsResponse.Merge(m, src)
+}
+func (m *ListEventsResponse) XXX_Size() int {
+	return xxx_messageInfo_ListEventsResponse.Size(m)
+}
+func (m *ListEventsResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_ListEventsResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ListEventsResponse proto.InternalMessageInfo
+
+func (m *ListEventsResponse) GetEvents() []*metadata_store_go_proto.Event {
+	if m != nil {
+		return m.Events
+	}
+	return nil
+}
+
+func (m *ListEventsResponse) GetArtifacts() map[int64]*metadata_store_go_proto.Artifact {
+	if m != nil {
+		return m.Artifacts
+	}
+	return nil
+}
+
+func (m *ListEventsResponse) GetExecutions() map[int64]*metadata_store_go_proto.Execution {
+	if m != nil {
+		return m.Executions
+	}
+	return nil
+}
+
+type CreateWorkspaceRequest struct {
+	Workspace            *Workspace `protobuf:"bytes,1,opt,name=workspace,proto3" json:"workspace,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}   `json:"-"`
+	XXX_unrecognized     []byte     `json:"-"`
+	XXX_sizecache        int32      `json:"-"`
+}
+
+func (m *CreateWorkspaceRequest) Reset()         { *m = CreateWorkspaceRequest{} }
+func (m *CreateWorkspaceRequest) String() string { return proto.CompactTextString(m) }
+func (*CreateWorkspaceRequest) ProtoMessage()    {}
+func (*CreateWorkspaceRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_42c32aec9010f89c, []int{46}
+}
+
+func (m *CreateWorkspaceRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_CreateWorkspaceRequest.Unmarshal(m, b)
+}
+func (m *CreateWorkspaceRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_CreateWorkspaceRequest.Marshal(b, m, deterministic)
+}
+func (m *CreateWorkspaceRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CreateWorkspaceRequest.Merge(m, src)
+}
+func (m *CreateWorkspaceRequest) XXX_Size() int {
+	return xxx_messageInfo_CreateWorkspaceRequest.Size(m)
+}
+func (m *CreateWorkspaceRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_CreateWorkspaceRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_CreateWorkspaceRequest proto.InternalMessageInfo
+
+func (m *CreateWorkspaceRequest) GetWorkspace() *Workspace {
+	if m != nil {
+		return m.Workspace
+	}
+	return nil
+}
+
+type CreateWorkspaceResponse struct {
+	Workspace            *Workspace `protobuf:"bytes,1,opt,name=workspace,proto3" json:"workspace,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}   `json:"-"`
+	XXX_unrecognized     []byte     `json:"-"`
+	XXX_sizecache        int32      `json:"-"`
+}
+
+func (m *CreateWorkspaceResponse) Reset()         { *m = CreateWorkspaceResponse{} }
+func (m *CreateWorkspaceResponse) String() string { return proto.CompactTextString(m) }
+func (*CreateWorkspaceResponse) ProtoMessage()    {}
+func (*CreateWorkspaceResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_42c32aec9010f89c, []int{47}
+}
+
+func (m *CreateWorkspaceResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_CreateWorkspaceResponse.Unmarshal(m, b)
+}
+func (m *CreateWorkspaceResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_CreateWorkspaceResponse.Marshal(b, m, deterministic)
+}
+func (m *CreateWorkspaceResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CreateWorkspaceResponse.Merge(m, src)
+}
+func (m *CreateWorkspaceResponse) XXX_Size() int {
+	return xxx_messageInfo_CreateWorkspaceResponse.Size(m)
+}
+func (m *CreateWorkspaceResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_CreateWorkspaceResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_CreateWorkspaceResponse proto.InternalMessageInfo
+
+func (m *CreateWorkspaceResponse) GetWorkspace() *Workspace {
+	if m != nil {
+		return m.Workspace
+	}
+	return nil
+}
+
+type GetWorkspaceRequest struct {
+	Name                 string   `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *GetWorkspaceRequest) Reset()         { *m = GetWorkspaceRequest{} }
+func (m *GetWorkspaceRequest) String() string { return proto.CompactTextString(m) }
+func (*GetWorkspaceRequest) ProtoMessage()    {}
+func (*GetWorkspaceRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_42c32aec9010f89c, []int{48}
+}
+
+func (m *GetWorkspaceRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetWorkspaceRequest.Unmarshal(m, b)
+}
+func (m *GetWorkspaceRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetWorkspaceRequest.Marshal(b, m, deterministic)
+}
+func (m *GetWorkspaceRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetWorkspaceRequest.Merge(m, src)
+}
+func (m *GetWorkspaceRequest) XXX_Size() int {
+	return xxx_messageInfo_GetWorkspaceRequest.Size(m)
+}
+func (m *GetWorkspaceRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetWorkspaceRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetWorkspaceRequest proto.InternalMessageInfo
+
+func (m *GetWorkspaceRequest) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+type GetWorkspaceResponse struct {
+	Workspace            *Workspace `protobuf:"bytes,1,opt,name=workspace,proto3" json:"workspace,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}   `json:"-"`
+	XXX_unrecognized     []byte     `json:"-"`
+	XXX_sizecache        int32      `json:"-"`
+}
+
+func (m *GetWorkspaceResponse) Reset()         { *m = GetWorkspaceResponse{} }
+func (m *GetWorkspaceResponse) String() string { return proto.CompactTextString(m) }
+func (*GetWorkspaceResponse) ProtoMessage()    {}
+func (*GetWorkspaceResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_42c32aec9010f89c, []int{49}
+}
+
+func (m *GetWorkspaceResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetWorkspaceResponse.Unmarshal(m, b)
+}
+func (m *GetWorkspaceResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetWorkspaceResponse.Marshal(b, m, deterministic)
+}
+func (m *GetWorkspaceResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetWorkspaceResponse.Merge(m, src)
+}
+func (m *GetWorkspaceResponse) XXX_Size() int {
+	return xxx_messageInfo_GetWorkspaceResponse.Size(m)
+}
+func (m *GetWorkspaceResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetWorkspaceResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetWorkspaceResponse proto.InternalMessageInfo
+
+func (m *GetWorkspaceResponse) GetWorkspace() *Workspace {
+	if m != nil {
+		return m.Workspace
+	}
+	return nil
+}
+
+type ListWorkspacesRequest struct {
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ListWorkspacesRequest) Reset()         { *m = ListWorkspacesRequest{} }
+func (m *ListWorkspacesRequest) String() string { return proto.CompactTextString(m) }
+func (*ListWorkspacesRequest) ProtoMessage()    {}
+func (*ListWorkspacesRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_42c32aec9010f89c, []int{50}
+}
+
+func (m *ListWorkspacesRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ListWorkspacesRequest.Unmarshal(m, b)
+}
+func (m *ListWorkspacesRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ListWorkspacesRequest.Marshal(b, m, deterministic)
+}
+func (m *ListWorkspacesRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ListWorkspacesRequest.Merge(m, src)
+}
+func (m *ListWorkspacesRequest) XXX_Size() int {
+	return xxx_messageInfo_ListWorkspacesRequest.Size(m)
+}
+func (m *ListWorkspacesRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_ListWorkspacesRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ListWorkspacesRequest proto.InternalMessageInfo
+
+type ListWorkspacesResponse struct {
+	Workspaces           []*Workspace `protobuf:"bytes,1,rep,name=workspaces,proto3" json:"workspaces,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}     `json:"-"`
+	XXX_unrecognized     []byte       `json:"-"`
+	XXX_sizecache        int32        `json:"-"`
+}
+
+func (m *ListWorkspacesResponse) Reset()         { *m = ListWorkspacesResponse{} }
+func (m *ListWorkspacesResponse) String() string { return proto.CompactTextString(m) }
+func (*ListWorkspacesResponse) ProtoMessage()    {}
+func (*ListWorkspacesResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_42c32aec9010f89c, []int{51}
+}
+
+func (m *ListWorkspacesResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ListWorkspacesResponse.Unmarshal(m, b)
+}
+func (m *ListWorkspacesResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ListWorkspacesResponse.Marshal(b, m, deterministic)
+}
+func (m *ListWorkspacesResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ListWorkspacesResponse.Merge(m, src)
+}
+func (m *ListWorkspacesResponse) XXX_Size() int {
+	return xxx_messageInfo_ListWorkspacesResponse.Size(m)
+}
+func (m *ListWorkspacesResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_ListWorkspacesResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ListWorkspacesResponse proto.InternalMessageInfo
+
+func (m *ListWorkspacesResponse) GetWorkspaces() []*Workspace {
+	if m != nil {
+		return m.Workspaces
+	}
+	return nil
+}
+
+type DeleteWorkspaceRequest struct {
+	Name                 string   `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Force                bool     `protobuf:"varint,2,opt,name=force,proto3" json:"force,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *DeleteWorkspaceRequest) Reset()         { *m = DeleteWorkspaceRequest{} }
+func (m *DeleteWorkspaceRequest) String() string { return proto.CompactTextString(m) }
+func (*DeleteWorkspaceRequest) ProtoMessage()    {}
+func (*DeleteWorkspaceRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_42c32aec9010f89c, []int{52}
+}
+
+func (m *DeleteWorkspaceRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_DeleteWorkspaceRequest.Unmarshal(m, b)
+}
+func (m *DeleteWorkspaceRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_DeleteWorkspaceRequest.Marshal(b, m, deterministic)
+}
+func (m *DeleteWorkspaceRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_DeleteWorkspaceRequest.Merge(m, src)
+}
+func (m *DeleteWorkspaceRequest) XXX_Size() int {
+	return xxx_messageInfo_DeleteWorkspaceRequest.Size(m)
+}
+func (m *DeleteWorkspaceRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_DeleteWorkspaceRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_DeleteWorkspaceRequest proto.InternalMessageInfo
+
+func (m *DeleteWorkspaceRequest) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+func (m *DeleteWorkspaceRequest) GetForce() bool {
+	if m != nil {
+		return m.Force
+	}
+	return false
+}
+
+type GetLineageRequest struct {
+	Name                 string                      `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Direction            GetLineageRequest_Direction `protobuf:"varint,2,opt,name=direction,proto3,enum=api.GetLineageRequest_Direction" json:"direction,omitempty"`
+	MaxDepth             int32                       `protobuf:"varint,3,opt,name=max_depth,json=maxDepth,proto3" json:"max_depth,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}                    `json:"-"`
+	XXX_unrecognized     []byte                      `json:"-"`
+	XXX_sizecache        int32                       `json:"-"`
+}
+
+func (m *GetLineageRequest) Reset()         { *m = GetLineageRequest{} }
+func (m *GetLineageRequest) String() string { return proto.CompactTextString(m) }
+func (*GetLineageRequest) ProtoMessage()    {}
+func (*GetLineageRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_42c32aec9010f89c, []int{53}
+}
+
+func (m *GetLineageRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetLineageRequest.Unmarshal(m, b)
+}
+func (m *GetLineageRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetLineageRequest.Marshal(b, m, deterministic)
+}
+func (m *GetLineageRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetLineageRequest.Merge(m, src)
+}
+func (m *GetLineageRequest) XXX_Size() int {
+	return xxx_messageInfo_GetLineageRequest.Size(m)
+}
+func (m *GetLineageRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetLineageRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetLineageRequest proto.InternalMessageInfo
+
+func (m *GetLineageRequest) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+func (m *GetLineageRequest) GetDirection() GetLineageRequest_Direction {
+	if m != nil {
+		return m.Direction
+	}
+	return GetLineageRequest_BOTH
+}
+
+func (m *GetLineageRequest) GetMaxDepth() int32 {
+	if m != nil {
+		return m.MaxDepth
+	}
+	return 0
+}
+
+type GetLineageResponse struct {
+	Events               []*metadata_store_go_proto.Event             `protobuf:"bytes,1,rep,name=events,proto3" json:"events,omitempty"`
+	Artifacts            map[int64]*metadata_store_go_proto.Artifact  `protobuf:"bytes,2,rep,name=artifacts,proto3" json:"artifacts,omitempty" protobuf_key:"varint,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	Executions           map[int64]*metadata_store_go_proto.Execution `protobuf:"bytes,3,rep,name=executions,proto3" json:"executions,omitempty" protobuf_key:"varint,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	XXX_NoUnkeyedLiteral struct{}                                     `json:"-"`
+	XXX_unrecognized     []byte                                       `json:"-"`
+	XXX_sizecache        int32                                        `json:"-"`
+}
+
+func (m *GetLineageResponse) Reset()         { *m = GetLineageResponse{} }
+func (m *GetLineageResponse) String() string { return proto.CompactTextString(m) }
+func (*GetLineageResponse) ProtoMessage()    {}
+func (*GetLineageResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_42c32aec9010f89c, []int{54}
+}
+
+func (m *GetLineageResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetLineageResponse.Unmarshal(m, b)
+}
+func (m *GetLineageResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetLineageResponse.Marshal(b, m, deterministic)
+}
+func (m *GetLineageResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetLineageResponse.Merge(m, src)
+}
+func (m *GetLineageResponse) XXX_Size() int {
+	return xxx_messageInfo_GetLineageResponse.Size(m)
+}
+func (m *GetLineageResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetLineageResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetLineageResponse proto.InternalMessageInfo
+
+func (m *GetLineageResponse) GetEvents() []*metadata_store_go_proto.Event {
+	if m != nil {
+		return m.Events
+	}
+	return nil
+}
+
+func (m *GetLineageResponse) GetArtifacts() map[int64]*metadata_store_go_proto.Artifact {
+	if m != nil {
+		return m.Artifacts
+	}
+	return nil
+}
+
+func (m *GetLineageResponse) GetExecutions() map[int64]*metadata_store_go_proto.Execution {
+	if m != nil {
+		return m.Executions
+	}
+	return nil
+}
+
+type Schema struct {
+	Name                 string   `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Id                   string   `protobuf:"bytes,2,opt,name=id,proto3" json:"id,omitempty"`
+	JsonSchema           string   `protobuf:"bytes,3,opt,name=json_schema,json=jsonSchema,proto3" json:"json_schema,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
 }
 
-func (m *ListExecutionsResponse) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_ListExecutionsResponse.Unmarshal(m, b)
+func (m *Schema) Reset()         { *m = Schema{} }
+func (m *Schema) String() string { return proto.CompactTextString(m) }
+func (*Schema) ProtoMessage()    {}
+func (*Schema) Descriptor() ([]byte, []int) {
+	return fileDescriptor_42c32aec9010f89c, []int{55}
 }
-func (m *ListExecutionsResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_ListExecutionsResponse.Marshal(b, m, deterministic)
+
+func (m *Schema) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Schema.Unmarshal(m, b)
 }
-func (m *ListExecutionsResponse) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_ListExecutionsResponse.Merge(m, src)
+func (m *Schema) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Schema.Marshal(b, m, deterministic)
 }
-func (m *ListExecutionsResponse) XXX_Size() int {
-	return xxx_messageInfo_ListExecutionsResponse.Size(m)
+func (m *Schema) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Schema.Merge(m, src)
 }
-func (m *ListExecutionsResponse) XXX_DiscardUnknown() {
-	xxx_messageInfo_ListExecutionsResponse.DiscardUnknown(m)
+func (m *Schema) XXX_Size() int {
+	return xxx_messageInfo_Schema.Size(m)
+}
+func (m *Schema) XXX_DiscardUnknown() {
+	xxx_messageInfo_Schema.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_ListExecutionsResponse proto.InternalMessageInfo
+var xxx_messageInfo_Schema proto.InternalMessageInfo
 
-func (m *ListExecutionsResponse) GetExecutions() []*metadata_store_go_proto.Execution {
+func (m *Schema) GetName() string {
 	if m != nil {
-		return m.Executions
+		return m.Name
 	}
-	return nil
+	return ""
 }
 
-type DeleteExecutionRequest struct {
-	Name                 string   `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+func (m *Schema) GetId() string {
+	if m != nil {
+		return m.Id
+	}
+	return ""
+}
+
+func (m *Schema) GetJsonSchema() string {
+	if m != nil {
+		return m.JsonSchema
+	}
+	return ""
+}
+
+type RegisterSchemaRequest struct {
+	JsonSchema           string   `protobuf:"bytes,1,opt,name=json_schema,json=jsonSchema,proto3" json:"json_schema,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
 }
 
-func (m *DeleteExecutionRequest) Reset()         { *m = DeleteExecutionRequest{} }
-func (m *DeleteExecutionRequest) String() string { return proto.CompactTextString(m) }
-func (*DeleteExecutionRequest) ProtoMessage()    {}
-func (*DeleteExecutionRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_42c32aec9010f89c, []int{31}
+func (m *RegisterSchemaRequest) Reset()         { *m = RegisterSchemaRequest{} }
+func (m *RegisterSchemaRequest) String() string { return proto.CompactTextString(m) }
+func (*RegisterSchemaRequest) ProtoMessage()    {}
+func (*RegisterSchemaRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_42c32aec9010f89c, []int{56}
 }
 
-func (m *DeleteExecutionRequest) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_DeleteExecutionRequest.Unmarshal(m, b)
+func (m *RegisterSchemaRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_RegisterSchemaRequest.Unmarshal(m, b)
 }
-func (m *DeleteExecutionRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_DeleteExecutionRequest.Marshal(b, m, deterministic)
+func (m *RegisterSchemaRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_RegisterSchemaRequest.Marshal(b, m, deterministic)
 }
-func (m *DeleteExecutionRequest) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_DeleteExecutionRequest.Merge(m, src)
+func (m *RegisterSchemaRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_RegisterSchemaRequest.Merge(m, src)
 }
-func (m *DeleteExecutionRequest) XXX_Size() int {
-	return xxx_messageInfo_DeleteExecutionRequest.Size(m)
+func (m *RegisterSchemaRequest) XXX_Size() int {
+	return xxx_messageInfo_RegisterSchemaRequest.Size(m)
 }
-func (m *DeleteExecutionRequest) XXX_DiscardUnknown() {
-	xxx_messageInfo_DeleteExecutionRequest.DiscardUnknown(m)
+func (m *RegisterSchemaRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_RegisterSchemaRequest.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_DeleteExecutionRequest proto.InternalMessageInfo
+var xxx_messageInfo_RegisterSchemaRequest proto.InternalMessageInfo
 
-func (m *DeleteExecutionRequest) GetName() string {
+func (m *RegisterSchemaRequest) GetJsonSchema() string {
 	if m != nil {
-		return m.Name
+		return m.JsonSchema
 	}
 	return ""
 }
 
-type CreateEventRequest struct {
-	Event                *metadata_store_go_proto.Event `protobuf:"bytes,1,opt,name=event,proto3" json:"event,omitempty"`
-	XXX_NoUnkeyedLiteral struct{}                       `json:"-"`
-	XXX_unrecognized     []byte                         `json:"-"`
-	XXX_sizecache        int32                          `json:"-"`
+type RegisterSchemaResponse struct {
+	Schema               *Schema  `protobuf:"bytes,1,opt,name=schema,proto3" json:"schema,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
 }
 
-func (m *CreateEventRequest) Reset()         { *m = CreateEventRequest{} }
-func (m *CreateEventRequest) String() string { return proto.CompactTextString(m) }
-func (*CreateEventRequest) ProtoMessage()    {}
-func (*CreateEventRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_42c32aec9010f89c, []int{32}
+func (m *RegisterSchemaResponse) Reset()         { *m = RegisterSchemaResponse{} }
+func (m *RegisterSchemaResponse) String() string { return proto.CompactTextString(m) }
+func (*RegisterSchemaResponse) ProtoMessage()    {}
+func (*RegisterSchemaResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_42c32aec9010f89c, []int{57}
 }
 
-func (m *CreateEventRequest) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_CreateEventRequest.Unmarshal(m, b)
+func (m *RegisterSchemaResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_RegisterSchemaResponse.Unmarshal(m, b)
 }
-func (m *CreateEventRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_CreateEventRequest.Marshal(b, m, deterministic)
+func (m *RegisterSchemaResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_RegisterSchemaResponse.Marshal(b, m, deterministic)
 }
-func (m *CreateEventRequest) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_CreateEventRequest.Merge(m, src)
+func (m *RegisterSchemaResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_RegisterSchemaResponse.Merge(m, src)
 }
-func (m *CreateEventRequest) XXX_Size() int {
-	return xxx_messageInfo_CreateEventRequest.Size(m)
+func (m *RegisterSchemaResponse) XXX_Size() int {
+	return xxx_messageInfo_RegisterSchemaResponse.Size(m)
 }
-func (m *CreateEventRequest) XXX_DiscardUnknown() {
-	xxx_messageInfo_CreateEventRequest.DiscardUnknown(m)
+func (m *RegisterSchemaResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_RegisterSchemaResponse.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_CreateEventRequest proto.InternalMessageInfo
+var xxx_messageInfo_RegisterSchemaResponse proto.InternalMessageInfo
 
-func (m *CreateEventRequest) GetEvent() *metadata_store_go_proto.Event {
+func (m *RegisterSchemaResponse) GetSchema() *Schema {
 	if m != nil {
-		return m.Event
+		return m.Schema
 	}
 	return nil
 }
 
-type ListEventsRequest struct {
+type GetSchemaRequest struct {
 	Name                 string   `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
 }
 
-func (m *ListEventsRequest) Reset()         { *m = ListEventsRequest{} }
-func (m *ListEventsRequest) String() string { return proto.CompactTextString(m) }
-func (*ListEventsRequest) ProtoMessage()    {}
-func (*ListEventsRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_42c32aec9010f89c, []int{33}
+func (m *GetSchemaRequest) Reset()         { *m = GetSchemaRequest{} }
+func (m *GetSchemaRequest) String() string { return proto.CompactTextString(m) }
+func (*GetSchemaRequest) ProtoMessage()    {}
+func (*GetSchemaRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_42c32aec9010f89c, []int{58}
 }
 
-func (m *ListEventsRequest) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_ListEventsRequest.Unmarshal(m, b)
+func (m *GetSchemaRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetSchemaRequest.Unmarshal(m, b)
 }
-func (m *ListEventsRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_ListEventsRequest.Marshal(b, m, deterministic)
+func (m *GetSchemaRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetSchemaRequest.Marshal(b, m, deterministic)
 }
-func (m *ListEventsRequest) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_ListEventsRequest.Merge(m, src)
+func (m *GetSchemaRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetSchemaRequest.Merge(m, src)
 }
-func (m *ListEventsRequest) XXX_Size() int {
-	return xxx_messageInfo_ListEventsRequest.Size(m)
+func (m *GetSchemaRequest) XXX_Size() int {
+	return xxx_messageInfo_GetSchemaRequest.Size(m)
 }
-func (m *ListEventsRequest) XXX_DiscardUnknown() {
-	xxx_messageInfo_ListEventsRequest.DiscardUnknown(m)
+func (m *GetSchemaRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetSchemaRequest.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_ListEventsRequest proto.InternalMessageInfo
+var xxx_messageInfo_GetSchemaRequest proto.InternalMessageInfo
 
-func (m *ListEventsRequest) GetName() string {
+func (m *GetSchemaRequest) GetName() string {
 	if m != nil {
 		return m.Name
 	}
 	return ""
 }
 
-type ListEventsResponse struct {
-	Events               []*metadata_store_go_proto.Event             `protobuf:"bytes,1,rep,name=events,proto3" json:"events,omitempty"`
-	Artifacts            map[int64]*metadata_store_go_proto.Artifact  `protobuf:"bytes,2,rep,name=artifacts,proto3" json:"artifacts,omitempty" protobuf_key:"varint,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
-	Executions           map[int64]*metadata_store_go_proto.Execution `protobuf:"bytes,3,rep,name=executions,proto3" json:"executions,omitempty" protobuf_key:"varint,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
-	XXX_NoUnkeyedLiteral struct{}                                     `json:"-"`
-	XXX_unrecognized     []byte                                       `json:"-"`
-	XXX_sizecache        int32                                        `json:"-"`
+type GetSchemaResponse struct {
+	Schema               *Schema  `protobuf:"bytes,1,opt,name=schema,proto3" json:"schema,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
 }
 
-func (m *ListEventsResponse) Reset()         { *m = ListEventsResponse{} }
-func (m *ListEventsResponse) String() string { return proto.CompactTextString(m) }
-func (*ListEventsResponse) ProtoMessage()    {}
-func (*ListEventsResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_42c32aec9010f89c, []int{34}
+func (m *GetSchemaResponse) Reset()         { *m = GetSchemaResponse{} }
+func (m *GetSchemaResponse) String() string { return proto.CompactTextString(m) }
+func (*GetSchemaResponse) ProtoMessage()    {}
+func (*GetSchemaResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_42c32aec9010f89c, []int{59}
 }
 
-func (m *ListEventsResponse) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_ListEventsResponse.Unmarshal(m, b)
+func (m *GetSchemaResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetSchemaResponse.Unmarshal(m, b)
 }
-func (m *ListEventsResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_ListEventsResponse.Marshal(b, m, deterministic)
+func (m *GetSchemaResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetSchemaResponse.Marshal(b, m, deterministic)
 }
-func (m *ListEventsResponse) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_ListEventsResponse.Merge(m, src)
+func (m *GetSchemaResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetSchemaResponse.Merge(m, src)
 }
-func (m *ListEventsResponse) XXX_Size() int {
-	return xxx_messageInfo_ListEventsResponse.Size(m)
+func (m *GetSchemaResponse) XXX_Size() int {
+	return xxx_messageInfo_GetSchemaResponse.Size(m)
 }
-func (m *ListEventsResponse) XXX_DiscardUnknown() {
-	xxx_messageInfo_ListEventsResponse.DiscardUnknown(m)
+func (m *GetSchemaResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetSchemaResponse.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_ListEventsResponse proto.InternalMessageInfo
+var xxx_messageInfo_GetSchemaResponse proto.InternalMessageInfo
 
-func (m *ListEventsResponse) GetEvents() []*metadata_store_go_proto.Event {
+func (m *GetSchemaResponse) GetSchema() *Schema {
 	if m != nil {
-		return m.Events
+		return m.Schema
 	}
 	return nil
 }
 
-func (m *ListEventsResponse) GetArtifacts() map[int64]*metadata_store_go_proto.Artifact {
-	if m != nil {
-		return m.Artifacts
-	}
-	return nil
+type ListSchemasRequest struct {
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
 }
 
-func (m *ListEventsResponse) GetExecutions() map[int64]*metadata_store_go_proto.Execution {
+func (m *ListSchemasRequest) Reset()         { *m = ListSchemasRequest{} }
+func (m *ListSchemasRequest) String() string { return proto.CompactTextString(m) }
+func (*ListSchemasRequest) ProtoMessage()    {}
+func (*ListSchemasRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_42c32aec9010f89c, []int{60}
+}
+
+func (m *ListSchemasRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ListSchemasRequest.Unmarshal(m, b)
+}
+func (m *ListSchemasRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ListSchemasRequest.Marshal(b, m, deterministic)
+}
+func (m *ListSchemasRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ListSchemasRequest.Merge(m, src)
+}
+func (m *ListSchemasRequest) XXX_Size() int {
+	return xxx_messageInfo_ListSchemasRequest.Size(m)
+}
+func (m *ListSchemasRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_ListSchemasRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ListSchemasRequest proto.InternalMessageInfo
+
+type ListSchemasResponse struct {
+	Schemas              []*Schema `protobuf:"bytes,1,rep,name=schemas,proto3" json:"schemas,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}  `json:"-"`
+	XXX_unrecognized     []byte    `json:"-"`
+	XXX_sizecache        int32     `json:"-"`
+}
+
+func (m *ListSchemasResponse) Reset()         { *m = ListSchemasResponse{} }
+func (m *ListSchemasResponse) String() string { return proto.CompactTextString(m) }
+func (*ListSchemasResponse) ProtoMessage()    {}
+func (*ListSchemasResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_42c32aec9010f89c, []int{61}
+}
+
+func (m *ListSchemasResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ListSchemasResponse.Unmarshal(m, b)
+}
+func (m *ListSchemasResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ListSchemasResponse.Marshal(b, m, deterministic)
+}
+func (m *ListSchemasResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ListSchemasResponse.Merge(m, src)
+}
+func (m *ListSchemasResponse) XXX_Size() int {
+	return xxx_messageInfo_ListSchemasResponse.Size(m)
+}
+func (m *ListSchemasResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_ListSchemasResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ListSchemasResponse proto.InternalMessageInfo
+
+func (m *ListSchemasResponse) GetSchemas() []*Schema {
 	if m != nil {
-		return m.Executions
+		return m.Schemas
 	}
 	return nil
 }
 
 func init() {
+	proto.RegisterEnum("api.GetLineageRequest_Direction", GetLineageRequest_Direction_name, GetLineageRequest_Direction_value)
 	proto.RegisterType((*CreateArtifactTypeRequest)(nil), "api.CreateArtifactTypeRequest")
 	proto.RegisterType((*CreateArtifactTypeResponse)(nil), "api.CreateArtifactTypeResponse")
 	proto.RegisterType((*UpdateArtifactTypeRequest)(nil), "api.UpdateArtifactTypeRequest")
 	proto.RegisterType((*UpdateArtifactTypeResponse)(nil), "api.UpdateArtifactTypeResponse")
 	proto.RegisterType((*GetArtifactTypeRequest)(nil), "api.GetArtifactTypeRequest")
 	proto.RegisterType((*GetArtifactTypeResponse)(nil), "api.GetArtifactTypeResponse")
+	proto.RegisterMapType((map[string]*PropertyValidator)(nil), "api.GetArtifactTypeResponse.PropertyValidatorsEntry")
 	proto.RegisterType((*ListArtifactTypesRequest)(nil), "api.ListArtifactTypesRequest")
 	proto.RegisterType((*ListArtifactTypesResponse)(nil), "api.ListArtifactTypesResponse")
 	proto.RegisterType((*DeleteArtifactTypeRequest)(nil), "api.DeleteArtifactTypeRequest")
 	proto.RegisterType((*CreateArtifactRequest)(nil), "api.CreateArtifactRequest")
 	proto.RegisterType((*CreateArtifactResponse)(nil), "api.CreateArtifactResponse")
+	proto.RegisterType((*BatchCreateArtifactsRequest)(nil), "api.BatchCreateArtifactsRequest")
+	proto.RegisterType((*BatchCreateArtifactsResponse)(nil), "api.BatchCreateArtifactsResponse")
 	proto.RegisterType((*GetArtifactRequest)(nil), "api.GetArtifactRequest")
 	proto.RegisterType((*GetArtifactResponse)(nil), "api.GetArtifactResponse")
 	proto.RegisterType((*ListArtifactsRequest)(nil), "api.ListArtifactsRequest")
 	proto.RegisterType((*ListArtifactsResponse)(nil), "api.ListArtifactsResponse")
+	proto.RegisterType((*UpdateArtifactRequest)(nil), "api.UpdateArtifactRequest")
+	proto.RegisterType((*UpdateArtifactResponse)(nil), "api.UpdateArtifactResponse")
 	proto.RegisterType((*DeleteArtifactRequest)(nil), "api.DeleteArtifactRequest")
 	proto.RegisterType((*CreateExecutionTypeRequest)(nil), "api.CreateExecutionTypeRequest")
 	proto.RegisterType((*CreateExecutionTypeResponse)(nil), "api.CreateExecutionTypeResponse")
@@ -1444,111 +2780,207 @@ func init() {
 	proto.RegisterType((*UpdateExecutionTypeResponse)(nil), "api.UpdateExecutionTypeResponse")
 	proto.RegisterType((*GetExecutionTypeRequest)(nil), "api.GetExecutionTypeRequest")
 	proto.RegisterType((*GetExecutionTypeResponse)(nil), "api.GetExecutionTypeResponse")
+	proto.RegisterMapType((map[string]*PropertyValidator)(nil), "api.GetExecutionTypeResponse.PropertyValidatorsEntry")
 	proto.RegisterType((*ListExecutionTypesRequest)(nil), "api.ListExecutionTypesRequest")
 	proto.RegisterType((*ListExecutionTypesResponse)(nil), "api.ListExecutionTypesResponse")
 	proto.RegisterType((*DeleteExecutionTypeRequest)(nil), "api.DeleteExecutionTypeRequest")
 	proto.RegisterType((*CreateExecutionRequest)(nil), "api.CreateExecutionRequest")
 	proto.RegisterType((*CreateExecutionResponse)(nil), "api.CreateExecutionResponse")
+	proto.RegisterType((*BatchCreateExecutionsRequest)(nil), "api.BatchCreateExecutionsRequest")
+	proto.RegisterType((*BatchCreateExecutionsResponse)(nil), "api.BatchCreateExecutionsResponse")
 	proto.RegisterType((*GetExecutionRequest)(nil), "api.GetExecutionRequest")
 	proto.RegisterType((*GetExecutionResponse)(nil), "api.GetExecutionResponse")
 	proto.RegisterType((*ListExecutionsRequest)(nil), "api.ListExecutionsRequest")
 	proto.RegisterType((*ListExecutionsResponse)(nil), "api.ListExecutionsResponse")
+	proto.RegisterType((*UpdateExecutionRequest)(nil), "api.UpdateExecutionRequest")
+	proto.RegisterType((*UpdateExecutionResponse)(nil), "api.UpdateExecutionResponse")
 	proto.RegisterType((*DeleteExecutionRequest)(nil), "api.DeleteExecutionRequest")
+	proto.RegisterType((*RecordExecutionRequest)(nil), "api.RecordExecutionRequest")
+	proto.RegisterType((*RecordExecutionResponse)(nil), "api.RecordExecutionResponse")
 	proto.RegisterType((*CreateEventRequest)(nil), "api.CreateEventRequest")
+	proto.RegisterType((*BatchCreateEventsRequest)(nil), "api.BatchCreateEventsRequest")
 	proto.RegisterType((*ListEventsRequest)(nil), "api.ListEventsRequest")
 	proto.RegisterType((*ListEventsResponse)(nil), "api.ListEventsResponse")
 	proto.RegisterMapType((map[int64]*metadata_store_go_proto.Artifact)(nil), "api.ListEventsResponse.ArtifactsEntry")
 	proto.RegisterMapType((map[int64]*metadata_store_go_proto.Execution)(nil), "api.ListEventsResponse.ExecutionsEntry")
+	proto.RegisterType((*CreateWorkspaceRequest)(nil), "api.CreateWorkspaceRequest")
+	proto.RegisterType((*CreateWorkspaceResponse)(nil), "api.CreateWorkspaceResponse")
+	proto.RegisterType((*GetWorkspaceRequest)(nil), "api.GetWorkspaceRequest")
+	proto.RegisterType((*GetWorkspaceResponse)(nil), "api.GetWorkspaceResponse")
+	proto.RegisterType((*ListWorkspacesRequest)(nil), "api.ListWorkspacesRequest")
+	proto.RegisterType((*ListWorkspacesResponse)(nil), "api.ListWorkspacesResponse")
+	proto.RegisterType((*DeleteWorkspaceRequest)(nil), "api.DeleteWorkspaceRequest")
+	proto.RegisterType((*GetLineageRequest)(nil), "api.GetLineageRequest")
+	proto.RegisterType((*GetLineageResponse)(nil), "api.GetLineageResponse")
+	proto.RegisterMapType((map[int64]*metadata_store_go_proto.Artifact)(nil), "api.GetLineageResponse.ArtifactsEntry")
+	proto.RegisterMapType((map[int64]*metadata_store_go_proto.Execution)(nil), "api.GetLineageResponse.ExecutionsEntry")
+	proto.RegisterType((*Schema)(nil), "api.Schema")
+	proto.RegisterType((*RegisterSchemaRequest)(nil), "api.RegisterSchemaRequest")
+	proto.RegisterType((*RegisterSchemaResponse)(nil), "api.RegisterSchemaResponse")
+	proto.RegisterType((*GetSchemaRequest)(nil), "api.GetSchemaRequest")
+	proto.RegisterType((*GetSchemaResponse)(nil), "api.GetSchemaResponse")
+	proto.RegisterType((*ListSchemasRequest)(nil), "api.ListSchemasRequest")
+	proto.RegisterType((*ListSchemasResponse)(nil), "api.ListSchemasResponse")
 }
 
 func init() { proto.RegisterFile("api/service.proto", fileDescriptor_42c32aec9010f89c) }
 
 var fileDescriptor_42c32aec9010f89c = []byte{
-	// 1335 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xb4, 0x58, 0x5d, 0x4f, 0xdc, 0x56,
-	0x13, 0x96, 0xe1, 0x0d, 0x7a, 0x19, 0xc2, 0x6e, 0x32, 0xc0, 0xb2, 0x6b, 0x3e, 0xe5, 0xb6, 0x40,
-	0x96, 0x64, 0x1d, 0x16, 0x1a, 0x55, 0xab, 0x16, 0x95, 0x06, 0x44, 0xa5, 0x26, 0x6a, 0x04, 0xa5,
-	0x52, 0xa1, 0x11, 0x35, 0xe4, 0x40, 0x56, 0xd9, 0x0f, 0x77, 0xed, 0xa5, 0x41, 0x25, 0xea, 0x87,
-	0x7a, 0xd5, 0x9b, 0x5c, 0x54, 0xbd, 0x69, 0xaf, 0xfa, 0x53, 0xfa, 0x1b, 0xfa, 0x17, 0xfa, 0x2f,
-	0x7a, 0x53, 0xf9, 0x78, 0xec, 0x3d, 0xc7, 0x3e, 0xc7, 0xdd, 0x10, 0x72, 0x87, 0x77, 0xc6, 0xf3,
-	0x3c, 0x67, 0x66, 0xfc, 0x9c, 0x19, 0xe0, 0xa6, 0xe3, 0xd6, 0x6d, 0x8f, 0x75, 0xce, 0xea, 0xc7,
-	0xac, 0xe2, 0x76, 0xda, 0x7e, 0x1b, 0x07, 0x1d, 0xb7, 0x6e, 0x8e, 0x06, 0xbf, 0x3b, 0x6e, 0x3d,
-	0xfc, 0xcd, 0x9c, 0x3e, 0x6d, 0xb7, 0x4f, 0x1b, 0xcc, 0xe6, 0xbf, 0xb6, 0x5a, 0x6d, 0xdf, 0xf1,
-	0xeb, 0xed, 0x96, 0x47, 0xd6, 0x29, 0xb2, 0xf2, 0xa7, 0xa3, 0xee, 0x89, 0xcd, 0x9a, 0xae, 0x7f,
-	0x4e, 0xc6, 0xb9, 0xa4, 0xd1, 0xaf, 0x37, 0x99, 0xe7, 0x3b, 0x4d, 0x97, 0x1c, 0x16, 0x9a, 0x8d,
-	0xc3, 0x26, 0xf3, 0x9d, 0x27, 0x8e, 0xef, 0x84, 0x5e, 0x76, 0xf4, 0x78, 0xe8, 0xf9, 0xed, 0x0e,
-	0xf1, 0xb2, 0x0e, 0xa0, 0x74, 0xbf, 0xc3, 0x1c, 0x9f, 0x6d, 0x74, 0xfc, 0xfa, 0x89, 0x73, 0xec,
-	0x7f, 0x76, 0xee, 0xb2, 0x1d, 0xf6, 0x75, 0x97, 0x79, 0x3e, 0xae, 0xc3, 0xa8, 0x43, 0x3f, 0x1f,
-	0xfa, 0xe7, 0x2e, 0x2b, 0x1a, 0xf3, 0xc6, 0xd2, 0x48, 0xb5, 0x54, 0x11, 0x82, 0x57, 0xa4, 0x17,
-	0xaf, 0x3b, 0xc2, 0x93, 0xf5, 0x25, 0x98, 0xaa, 0xe0, 0x9e, 0xdb, 0x6e, 0x79, 0xec, 0xb5, 0xa3,
-	0x1f, 0x40, 0x69, 0xcf, 0x7d, 0xf2, 0xe6, 0xa8, 0xab, 0x82, 0x5f, 0x11, 0xf5, 0xdb, 0x50, 0xd8,
-	0x66, 0xbe, 0x8a, 0x37, 0xc2, 0xff, 0x5a, 0x4e, 0x33, 0x0c, 0x38, 0xbc, 0xc3, 0xff, 0xb6, 0xbe,
-	0x80, 0xc9, 0x94, 0xf7, 0x15, 0x11, 0x31, 0xa1, 0xf8, 0xa0, 0xee, 0x49, 0xb1, 0x3d, 0xa2, 0x62,
-	0x3d, 0x86, 0x92, 0xc2, 0x46, 0xc0, 0x1f, 0x42, 0x4e, 0x02, 0xf6, 0x8a, 0xc6, 0xfc, 0x60, 0x36,
-	0xf2, 0xa8, 0x88, 0xec, 0x59, 0x36, 0x94, 0x36, 0x59, 0x83, 0xa9, 0xcb, 0xa7, 0x4a, 0xc3, 0x11,
-	0x4c, 0xc8, 0xdd, 0x14, 0x39, 0x17, 0x60, 0xc8, 0x75, 0x3a, 0xac, 0xe5, 0x93, 0x3b, 0x3d, 0xe1,
-	0x0a, 0xfc, 0x3f, 0x82, 0x2c, 0x0e, 0xf0, 0xbc, 0x4c, 0x28, 0xd9, 0xed, 0xc4, 0x6e, 0xd6, 0x27,
-	0x50, 0x48, 0x62, 0xd0, 0x81, 0xc5, 0x60, 0x46, 0x7f, 0xc1, 0x96, 0x00, 0x85, 0xba, 0x65, 0x1d,
-	0xed, 0x63, 0x18, 0x93, 0x3c, 0x2f, 0x8f, 0x59, 0x86, 0x71, 0xb1, 0x68, 0x5e, 0x16, 0xea, 0x03,
-	0x98, 0x48, 0xf8, 0x12, 0xee, 0x2a, 0x0c, 0x47, 0x01, 0xa3, 0xba, 0x6a, 0x80, 0x7b, 0x7e, 0xd6,
-	0x32, 0x4c, 0xc8, 0xf5, 0xcc, 0x82, 0x3e, 0x8c, 0x94, 0x61, 0xeb, 0x39, 0x3b, 0xee, 0x06, 0xb2,
-	0x27, 0x56, 0x7f, 0x03, 0x72, 0x2c, 0xfa, 0x5d, 0x6c, 0x6b, 0x53, 0x22, 0x21, 0xbf, 0x3a, 0xca,
-	0xc4, 0x47, 0xeb, 0x2b, 0x98, 0x52, 0x02, 0xd0, 0x09, 0xaf, 0x00, 0xe1, 0x30, 0x52, 0x88, 0x37,
-	0x78, 0x04, 0x25, 0xc0, 0xd5, 0x1d, 0xe1, 0x0e, 0x17, 0x16, 0x25, 0x7f, 0x55, 0xd1, 0x1e, 0x43,
-	0x31, 0xed, 0x7e, 0x75, 0x6c, 0xa6, 0x42, 0xbd, 0x91, 0x7c, 0x62, 0x31, 0x72, 0xc0, 0x54, 0x19,
-	0x09, 0xfd, 0x3e, 0xe4, 0x65, 0xf4, 0xa8, 0x6d, 0xb3, 0xe0, 0x73, 0x12, 0xbc, 0x67, 0xdd, 0x05,
-	0x33, 0x6c, 0xe0, 0xbe, 0x13, 0x72, 0x12, 0xa9, 0x45, 0xfc, 0xc6, 0x7f, 0x49, 0xd2, 0x1a, 0x0c,
-	0xc7, 0xa8, 0xa4, 0x49, 0x05, 0x35, 0xc5, 0x9d, 0x9e, 0xa3, 0xf5, 0x29, 0x4c, 0xa6, 0x70, 0xe8,
-	0xe4, 0x52, 0x40, 0xa3, 0xdf, 0x80, 0xb7, 0xb8, 0xde, 0xa4, 0x58, 0xab, 0x45, 0x62, 0x5c, 0x76,
-	0x7d, 0x2d, 0xe0, 0xe5, 0x50, 0x72, 0x62, 0x5b, 0xa6, 0x3e, 0x3d, 0x82, 0x42, 0xd2, 0x99, 0xc0,
-	0xef, 0x01, 0xc4, 0x31, 0xa3, 0x52, 0xeb, 0xd0, 0x05, 0xcf, 0xe0, 0xde, 0x4d, 0x94, 0x38, 0x0b,
-	0x7f, 0x1d, 0x90, 0xd2, 0x7e, 0xc6, 0x5a, 0xb1, 0x9c, 0x2d, 0xc1, 0x35, 0x76, 0x16, 0x55, 0x76,
-	0xa4, 0x8a, 0x32, 0x2c, 0xf7, 0x0c, 0x1d, 0xac, 0x45, 0xb8, 0xc9, 0xf9, 0x07, 0x0f, 0x99, 0x07,
-	0xfd, 0x67, 0x00, 0x50, 0xf4, 0xa4, 0x53, 0x96, 0x61, 0x88, 0x07, 0x8a, 0x4e, 0xa8, 0x82, 0x22,
-	0x0f, 0xdc, 0x14, 0x25, 0x7b, 0x80, 0xbb, 0x2f, 0x54, 0x82, 0x51, 0x33, 0x1d, 0x37, 0x56, 0x6f,
-	0x6f, 0xab, 0xe5, 0x77, 0xce, 0x05, 0x0d, 0xc7, 0x6d, 0x29, 0xaf, 0x83, 0x3c, 0xcc, 0xa2, 0x2e,
-	0x4c, 0xaf, 0x2e, 0x61, 0x1c, 0xe1, 0x55, 0x73, 0x17, 0x72, 0x32, 0x0a, 0xde, 0x80, 0xc1, 0x67,
-	0xec, 0x9c, 0x1f, 0x7b, 0x70, 0x27, 0xf8, 0x13, 0x97, 0xe1, 0xda, 0x99, 0xd3, 0xe8, 0xb2, 0xec,
-	0xbb, 0x39, 0xf4, 0xa9, 0x0d, 0xbc, 0x67, 0x98, 0x7b, 0x90, 0x4f, 0x60, 0x2a, 0xa2, 0xde, 0x96,
-	0xa3, 0xea, 0xba, 0xa2, 0x17, 0xb6, 0xfa, 0xe7, 0x04, 0xe4, 0x1f, 0x92, 0xc7, 0x6e, 0x38, 0xb4,
-	0xe3, 0x4b, 0x03, 0x72, 0xf2, 0x20, 0x80, 0x26, 0xcf, 0x83, 0x72, 0x02, 0x31, 0xa7, 0x94, 0xb6,
-	0x30, 0x4f, 0xd6, 0xe6, 0x8f, 0x7f, 0xfd, 0xfd, 0xcb, 0xc0, 0xba, 0x55, 0xe5, 0x83, 0xfe, 0xd9,
-	0x8a, 0xd3, 0x70, 0x9f, 0x3a, 0x2b, 0xf6, 0xb7, 0xa1, 0x24, 0x7c, 0x20, 0x8f, 0x51, 0x76, 0xb9,
-	0xfc, 0xc2, 0x8e, 0x0b, 0x52, 0x8b, 0x2f, 0x76, 0xbc, 0x80, 0x11, 0x61, 0x44, 0xc0, 0x49, 0x8e,
-	0x98, 0x1e, 0x2f, 0xcc, 0x62, 0xda, 0x40, 0x3c, 0x6a, 0x9c, 0xc7, 0x1a, 0x26, 0x79, 0x04, 0x2d,
-	0x98, 0x66, 0xd1, 0x23, 0x61, 0x97, 0x5f, 0xe0, 0xef, 0x06, 0x8c, 0x4a, 0xb3, 0x02, 0x96, 0xe2,
-	0xb6, 0x48, 0xce, 0x1a, 0xa6, 0xa9, 0x32, 0x11, 0x89, 0x5d, 0x4e, 0xe2, 0x21, 0xde, 0xed, 0x8b,
-	0x84, 0x90, 0x8a, 0xfd, 0x12, 0x4e, 0xca, 0xef, 0xf4, 0xda, 0xf6, 0x7b, 0x03, 0x72, 0xf2, 0xec,
-	0x41, 0xd5, 0x52, 0x0e, 0x24, 0x66, 0xa1, 0x12, 0x6e, 0x4f, 0x95, 0x68, 0x7b, 0xaa, 0x6c, 0x05,
-	0xab, 0x55, 0x94, 0xa0, 0xf2, 0x65, 0x12, 0xf4, 0xab, 0x01, 0xf9, 0x84, 0x46, 0xa3, 0xd8, 0x15,
-	0x49, 0xc1, 0x31, 0xa7, 0xd5, 0x46, 0x4a, 0xd3, 0x36, 0xa7, 0xb2, 0x61, 0xad, 0xa9, 0x7b, 0x26,
-	0x71, 0xd9, 0xf1, 0x4c, 0xf5, 0xbe, 0xbe, 0x5a, 0x4f, 0x70, 0xf1, 0x07, 0x03, 0xae, 0x8b, 0xfa,
-	0x8d, 0x71, 0x7f, 0xa4, 0x18, 0x95, 0x14, 0x16, 0xa2, 0xf3, 0x3e, 0xa7, 0x73, 0x0f, 0xd7, 0x54,
-	0x99, 0x49, 0x93, 0x11, 0xb8, 0x04, 0xb9, 0xf9, 0xc3, 0x80, 0x9c, 0x2c, 0xe4, 0xd8, 0x6b, 0x91,
-	0xd4, 0x55, 0x40, 0x1f, 0x93, 0x5a, 0xf9, 0xad, 0xcf, 0x39, 0x93, 0x47, 0xea, 0x26, 0xce, 0x4e,
-	0xcb, 0xbe, 0x89, 0x45, 0xf9, 0xad, 0x9e, 0x0d, 0x7f, 0x32, 0x20, 0x9f, 0xb8, 0x1a, 0xa8, 0x7e,
-	0xea, 0x0b, 0x43, 0xdb, 0x44, 0x94, 0xaa, 0xf2, 0xe5, 0x52, 0xf5, 0xb3, 0x01, 0x98, 0xde, 0x3b,
-	0x71, 0x96, 0x33, 0xd1, 0x6e, 0xbb, 0xe6, 0x9c, 0xd6, 0x4e, 0x69, 0x5b, 0xe5, 0xac, 0xee, 0x54,
-	0xa7, 0xd5, 0x9f, 0x50, 0x48, 0xa7, 0x26, 0xef, 0x92, 0x9c, 0x4c, 0x7a, 0x7f, 0x27, 0x32, 0xda,
-	0xff, 0x1a, 0x10, 0x19, 0xfd, 0xe2, 0x1f, 0x91, 0xb1, 0x5e, 0x89, 0xcc, 0xf3, 0xf0, 0x32, 0x95,
-	0xb6, 0x51, 0x9c, 0x49, 0x29, 0x8d, 0x38, 0x34, 0x9a, 0xb3, 0x3a, 0x33, 0x11, 0x79, 0x9b, 0x13,
-	0x99, 0xc5, 0x4c, 0x22, 0x78, 0x01, 0xf9, 0xc4, 0xfa, 0x4d, 0x9d, 0xa1, 0x5e, 0xe1, 0xe9, 0xcb,
-	0xd6, 0x6c, 0xec, 0x56, 0x85, 0x63, 0x2e, 0xe1, 0x42, 0x7f, 0x02, 0x88, 0x17, 0x80, 0xe9, 0x35,
-	0x99, 0x6a, 0xa0, 0xdd, 0x9f, 0xb5, 0xdd, 0x49, 0xe8, 0xe5, 0x7e, 0xd1, 0x5f, 0x1a, 0x30, 0xa6,
-	0x58, 0x42, 0x50, 0x6c, 0x38, 0xd5, 0xb8, 0x6c, 0xce, 0xeb, 0x1d, 0x28, 0x11, 0xef, 0x72, 0x2a,
-	0x76, 0x75, 0x46, 0xf3, 0x4d, 0x52, 0x1b, 0x24, 0xd6, 0x0a, 0xce, 0x48, 0xb1, 0xd9, 0xe1, 0x9c,
-	0x4a, 0x4f, 0xd3, 0x8c, 0x32, 0x96, 0xc2, 0x88, 0x91, 0xf5, 0x8a, 0x8c, 0x2e, 0x68, 0x78, 0x93,
-	0xb6, 0x09, 0x9c, 0x4d, 0xab, 0x98, 0xd4, 0x9b, 0x73, 0x5a, 0x3b, 0xb1, 0x79, 0x87, 0xb3, 0x99,
-	0xc3, 0x6c, 0x36, 0xc1, 0xdd, 0x77, 0x23, 0xb9, 0x95, 0xe1, 0x74, 0x4a, 0xca, 0xc5, 0x4c, 0xcc,
-	0x68, 0xac, 0x04, 0x6c, 0x73, 0xe0, 0x5b, 0xb8, 0xd8, 0xa7, 0xc4, 0xe2, 0x77, 0x30, 0xa6, 0x58,
-	0x9c, 0xa8, 0x22, 0xfa, 0x95, 0x4a, 0xdb, 0xa4, 0x44, 0xa0, 0xdc, 0x37, 0x81, 0x63, 0x18, 0x11,
-	0x06, 0x75, 0x9a, 0x8d, 0xd2, 0xa3, 0xbb, 0x16, 0xf0, 0x2d, 0x0e, 0x38, 0x63, 0x8d, 0x27, 0x52,
-	0xcd, 0xe7, 0xdd, 0x5a, 0x38, 0xcd, 0xe3, 0x6f, 0x06, 0x40, 0x6f, 0x0a, 0xc6, 0x42, 0x6a, 0x2c,
-	0x0e, 0x31, 0x26, 0x35, 0xe3, 0xb2, 0x75, 0xc0, 0x41, 0xf6, 0x70, 0x49, 0x05, 0x92, 0x3c, 0x5c,
-	0x70, 0x19, 0xec, 0xa7, 0x4a, 0x20, 0xf9, 0x8a, 0xe3, 0xc7, 0x47, 0xd6, 0xfe, 0xfc, 0x69, 0xdd,
-	0x7f, 0xda, 0x3d, 0xaa, 0x1c, 0xb7, 0x9b, 0xf6, 0xb3, 0xee, 0x11, 0x3b, 0x69, 0xb4, 0xbf, 0x89,
-	0xff, 0xe5, 0x1b, 0x84, 0x39, 0x1a, 0xe2, 0xa7, 0x5e, 0xfd, 0x37, 0x00, 0x00, 0xff, 0xff, 0xf9,
-	0x55, 0x4d, 0x40, 0x9e, 0x16, 0x00, 0x00,
+	// 2455 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xdc, 0x5a, 0x5f, 0x6f, 0x1b, 0xc7,
+	0x11, 0xef, 0x51, 0x91, 0x23, 0x8e, 0x2c, 0x52, 0x5e, 0x49, 0x24, 0x75, 0x94, 0x4c, 0xf9, 0x64,
+	0x4b, 0x32, 0xe3, 0x90, 0x35, 0xad, 0xb8, 0xae, 0x9a, 0x18, 0xb1, 0xa3, 0x3f, 0x06, 0x6a, 0xd5,
+	0x06, 0x65, 0x45, 0x80, 0x5d, 0x83, 0x3d, 0x91, 0x2b, 0xe9, 0x2a, 0xfe, 0x0b, 0xef, 0x24, 0x4b,
+	0xa9, 0x83, 0xa6, 0x45, 0x9e, 0xda, 0x07, 0x3f, 0x14, 0x7d, 0xa9, 0x53, 0x04, 0x05, 0xfa, 0x25,
+	0xfa, 0xd0, 0xa7, 0x7e, 0x84, 0x7e, 0x85, 0x7e, 0x8b, 0xbe, 0x14, 0xb7, 0xb7, 0x7b, 0xb7, 0x7b,
+	0xbb, 0x77, 0xa6, 0x28, 0x05, 0x08, 0xfa, 0xc6, 0xdb, 0x99, 0x9d, 0xdf, 0xef, 0x66, 0x67, 0x77,
+	0xe6, 0x66, 0x09, 0x57, 0xcc, 0xae, 0x55, 0xb6, 0x71, 0xef, 0xd8, 0xaa, 0xe3, 0x52, 0xb7, 0xd7,
+	0x71, 0x3a, 0x68, 0xc8, 0xec, 0x5a, 0xfa, 0x98, 0x3b, 0x6e, 0x76, 0x2d, 0x6f, 0x4c, 0x9f, 0xd9,
+	0xef, 0x74, 0xf6, 0x9b, 0xb8, 0x4c, 0x46, 0xdb, 0xed, 0x8e, 0x63, 0x3a, 0x56, 0xa7, 0x6d, 0x53,
+	0x69, 0x9e, 0x4a, 0xc9, 0xd3, 0xee, 0xd1, 0x5e, 0x19, 0xb7, 0xba, 0xce, 0x29, 0x15, 0xce, 0x85,
+	0x85, 0x7b, 0x16, 0x6e, 0x36, 0x6a, 0x2d, 0xd3, 0x3e, 0xa4, 0x1a, 0x85, 0xb0, 0x86, 0x63, 0xb5,
+	0xb0, 0xed, 0x98, 0xad, 0x2e, 0x55, 0x58, 0x68, 0x35, 0x6b, 0x2d, 0xec, 0x98, 0x0d, 0xd3, 0x31,
+	0x3d, 0xad, 0x32, 0x7b, 0xac, 0xd9, 0x4e, 0xa7, 0x47, 0x99, 0x1b, 0x2f, 0x60, 0xfa, 0xb3, 0x1e,
+	0x36, 0x1d, 0xfc, 0xa0, 0xe7, 0x58, 0x7b, 0x66, 0xdd, 0x79, 0x76, 0xda, 0xc5, 0x55, 0xfc, 0xc5,
+	0x11, 0xb6, 0x1d, 0x74, 0x1f, 0xc6, 0x4c, 0x3a, 0x5c, 0x73, 0x4e, 0xbb, 0x38, 0xa7, 0xcd, 0x69,
+	0x4b, 0xa3, 0x95, 0xe9, 0x12, 0x67, 0xbc, 0x24, 0x4c, 0xbc, 0x6c, 0x72, 0x4f, 0xc6, 0x2f, 0x41,
+	0x57, 0x19, 0xb7, 0xbb, 0x9d, 0xb6, 0x8d, 0xcf, 0x6d, 0xfd, 0x05, 0x4c, 0x6f, 0x77, 0x1b, 0xdf,
+	0x1f, 0x75, 0x95, 0xf1, 0x0b, 0xa2, 0x7e, 0x0b, 0x32, 0x1b, 0xd8, 0x51, 0xf1, 0x46, 0xf0, 0x5e,
+	0xdb, 0x6c, 0x79, 0x06, 0x93, 0x55, 0xf2, 0xdb, 0xf8, 0x2e, 0x01, 0x59, 0x49, 0xfd, 0x62, 0x98,
+	0x20, 0x0c, 0x13, 0xdd, 0x5e, 0xa7, 0x8b, 0x7b, 0xce, 0x69, 0xed, 0xd8, 0x6c, 0x5a, 0x0d, 0xd3,
+	0xe9, 0xf4, 0xec, 0x5c, 0x62, 0x6e, 0x68, 0x69, 0xb4, 0xb2, 0x5c, 0x72, 0xc3, 0x39, 0x02, 0xba,
+	0xf4, 0x94, 0xce, 0xfb, 0xdc, 0x9f, 0xb6, 0xd6, 0x76, 0x7a, 0xa7, 0x55, 0xd4, 0x95, 0x04, 0xfa,
+	0x4b, 0xc8, 0x46, 0xa8, 0xa3, 0x71, 0x18, 0x3a, 0xc4, 0xa7, 0xf4, 0x85, 0xdd, 0x9f, 0xe8, 0x16,
+	0x0c, 0x1f, 0x9b, 0xcd, 0x23, 0x9c, 0x4b, 0x90, 0x77, 0xc9, 0x10, 0x16, 0xd2, 0xf4, 0xaa, 0xa7,
+	0xb4, 0x92, 0xb8, 0xa7, 0x19, 0x3a, 0xe4, 0x1e, 0x5b, 0xb6, 0x40, 0xd3, 0xa6, 0x1e, 0x35, 0x5e,
+	0xc2, 0xb4, 0x42, 0x46, 0xdd, 0xf7, 0x29, 0xa4, 0x04, 0xf7, 0xd9, 0x39, 0x6d, 0x6e, 0x28, 0xde,
+	0x7f, 0x63, 0xbc, 0xff, 0x6c, 0x63, 0x0d, 0xa6, 0x57, 0x71, 0x13, 0x3b, 0xb8, 0xcf, 0xd5, 0x44,
+	0x93, 0x30, 0xbc, 0xd7, 0xe9, 0xd5, 0xbd, 0xb7, 0x1b, 0xa9, 0x7a, 0x0f, 0xc6, 0xd7, 0x1a, 0x4c,
+	0x89, 0x7b, 0x85, 0xd9, 0xc8, 0xc0, 0xa5, 0xae, 0xd9, 0xc3, 0x6d, 0x87, 0x5a, 0xa1, 0x4f, 0xe8,
+	0x36, 0x8c, 0x30, 0x26, 0xd4, 0x51, 0x53, 0x4a, 0xd2, 0x55, 0x5f, 0x0d, 0xcd, 0x40, 0xf2, 0x55,
+	0xa7, 0x77, 0x68, 0x77, 0xcd, 0x3a, 0xce, 0x0d, 0x11, 0x6b, 0xc1, 0x80, 0xf1, 0x73, 0xc8, 0x84,
+	0x19, 0x50, 0x2f, 0xf1, 0x50, 0x5a, 0x5f, 0x50, 0xc6, 0x36, 0xe4, 0x1f, 0x9a, 0x4e, 0xfd, 0x40,
+	0xb4, 0xc8, 0x16, 0x05, 0xdd, 0x85, 0x91, 0x9e, 0xf7, 0x93, 0x79, 0x5c, 0x27, 0xab, 0xac, 0x74,
+	0x41, 0xd5, 0xd7, 0x35, 0xb6, 0x60, 0x46, 0x6d, 0x96, 0x32, 0xbd, 0x03, 0x49, 0x46, 0x81, 0x19,
+	0x8e, 0xa0, 0x1a, 0xe8, 0x19, 0x4b, 0x80, 0xb8, 0x18, 0x8f, 0xdb, 0x89, 0x8f, 0x60, 0x42, 0xd0,
+	0x1c, 0xdc, 0x3f, 0xdf, 0x6a, 0x30, 0xc9, 0x87, 0xa5, 0x1d, 0x17, 0x32, 0x79, 0x48, 0x76, 0xcd,
+	0x7d, 0x5c, 0xb3, 0xad, 0x2f, 0xbd, 0xb0, 0x19, 0xae, 0x8e, 0xb8, 0x03, 0x5b, 0xd6, 0x97, 0x18,
+	0xcd, 0x02, 0x10, 0xa1, 0xd3, 0x39, 0xc4, 0x6d, 0xb6, 0xaa, 0xee, 0xc8, 0x33, 0x77, 0xc0, 0x0d,
+	0x9f, 0x3d, 0xab, 0xe9, 0xe0, 0x5e, 0xee, 0x3d, 0x2f, 0x7c, 0xbc, 0x27, 0x31, 0x16, 0x86, 0xc3,
+	0xb1, 0xe0, 0xc0, 0x54, 0x88, 0xdd, 0x39, 0x1c, 0x8c, 0x16, 0x20, 0xdd, 0xc6, 0x27, 0x4e, 0x8d,
+	0xe3, 0x99, 0x20, 0x88, 0x63, 0xee, 0xf0, 0x53, 0xc6, 0xd5, 0x78, 0xab, 0xc1, 0x94, 0x78, 0xea,
+	0xc6, 0x79, 0x65, 0x80, 0x0d, 0xf0, 0x33, 0x18, 0x3d, 0x22, 0xf6, 0x49, 0x2e, 0x25, 0xce, 0x72,
+	0x23, 0xcf, 0x4b, 0xa6, 0x25, 0x96, 0x4c, 0x4b, 0xeb, 0x6e, 0xba, 0xdd, 0x34, 0xed, 0xc3, 0x2a,
+	0x78, 0xea, 0xee, 0x6f, 0x77, 0x7f, 0x84, 0xc9, 0x0d, 0xbe, 0xfe, 0x6b, 0x30, 0x25, 0x1e, 0x1b,
+	0x71, 0x6f, 0x9a, 0x83, 0xf7, 0xeb, 0xa6, 0x5d, 0x37, 0x1b, 0xec, 0xd0, 0x60, 0x8f, 0x46, 0x8d,
+	0x65, 0xd8, 0xb5, 0x13, 0x5c, 0x3f, 0x72, 0x0b, 0x0c, 0xfe, 0xf8, 0x79, 0x00, 0x29, 0xcc, 0xc6,
+	0xf9, 0xec, 0xa0, 0x0b, 0xec, 0xc4, 0xa9, 0x63, 0x98, 0x7f, 0x34, 0x7e, 0x05, 0x79, 0x25, 0x00,
+	0x7d, 0xf3, 0x0b, 0x40, 0xa8, 0xb1, 0x4c, 0xfb, 0x3d, 0xbe, 0x82, 0x12, 0xe0, 0xe2, 0x5e, 0xe1,
+	0x43, 0x92, 0x9f, 0x95, 0xfc, 0x55, 0xa7, 0xc8, 0xdf, 0x13, 0x90, 0x93, 0xf5, 0x2f, 0x8c, 0x0e,
+	0xda, 0x8b, 0xcb, 0xe9, 0x1f, 0xb1, 0x9c, 0xae, 0x84, 0xff, 0x21, 0x25, 0xf5, 0xbc, 0x97, 0xb8,
+	0x05, 0x9e, 0x7e, 0x56, 0x37, 0x41, 0x57, 0x09, 0xa9, 0x13, 0x3f, 0x83, 0xb4, 0xe8, 0xc4, 0x20,
+	0xcb, 0x44, 0x7b, 0x31, 0x25, 0x78, 0xd1, 0x36, 0xd6, 0x41, 0xf7, 0xb6, 0x68, 0xbf, 0x0b, 0x1b,
+	0x91, 0xda, 0xbf, 0xd1, 0x58, 0x62, 0xf5, 0x0d, 0xbd, 0x2b, 0xb7, 0x2f, 0x43, 0xd2, 0x27, 0xe3,
+	0x3b, 0x4c, 0xc9, 0xbc, 0x1a, 0x28, 0xbe, 0x23, 0xbd, 0x3f, 0x81, 0xac, 0xc4, 0x82, 0xba, 0x4b,
+	0x80, 0xd3, 0xfa, 0x84, 0x33, 0x76, 0x84, 0x5c, 0xec, 0xab, 0xf8, 0x99, 0xec, 0x27, 0x52, 0x8e,
+	0xcf, 0x73, 0x39, 0x3e, 0xec, 0x0b, 0x2e, 0xc9, 0xef, 0xc0, 0x6c, 0x84, 0x61, 0xca, 0xf7, 0x2e,
+	0x80, 0x4f, 0x83, 0xd9, 0x8e, 0x22, 0xcc, 0x69, 0x1a, 0x37, 0x49, 0xfa, 0x96, 0x56, 0x41, 0xb5,
+	0x47, 0x1f, 0xc3, 0xa4, 0xa8, 0x7a, 0x2e, 0x57, 0xfd, 0x55, 0xf3, 0xf2, 0xa9, 0xec, 0xa4, 0x1f,
+	0x46, 0xba, 0x3f, 0x81, 0x4c, 0x98, 0xde, 0xf9, 0x5c, 0xdd, 0x77, 0xca, 0xff, 0x4e, 0x63, 0x59,
+	0xb5, 0x9f, 0x65, 0x19, 0x70, 0x63, 0x9c, 0x2b, 0xed, 0x3f, 0x81, 0xac, 0x44, 0xf0, 0x5c, 0xc1,
+	0xb0, 0x0e, 0x99, 0xd0, 0xb9, 0x32, 0x58, 0xee, 0xff, 0x97, 0x06, 0x99, 0x2a, 0xae, 0x77, 0x7a,
+	0x0d, 0xc9, 0xd0, 0x4f, 0x65, 0x62, 0xb1, 0x7b, 0x8f, 0xf3, 0xd5, 0x22, 0xa4, 0xad, 0x76, 0xf7,
+	0xc8, 0xa9, 0x05, 0x65, 0x9e, 0x9b, 0x38, 0x92, 0xd5, 0x14, 0x19, 0xf6, 0x2b, 0x42, 0xb4, 0x06,
+	0xe3, 0x9d, 0x23, 0x47, 0xd4, 0x1c, 0x7a, 0x67, 0x29, 0x9f, 0xf6, 0xe6, 0xf8, 0x66, 0x8c, 0x7f,
+	0x6a, 0x90, 0x95, 0xde, 0xe2, 0x3c, 0xfe, 0x45, 0x9f, 0x2a, 0x88, 0x25, 0xe2, 0x2a, 0xd5, 0x30,
+	0x27, 0x54, 0x84, 0x4b, 0xf8, 0x18, 0xb7, 0xfd, 0x17, 0x42, 0x22, 0xa8, 0x2b, 0xaa, 0x52, 0x0d,
+	0xe3, 0x3e, 0x20, 0xea, 0x54, 0x32, 0x4c, 0x17, 0x60, 0x09, 0x86, 0x89, 0x9c, 0xb2, 0x56, 0x19,
+	0xf0, 0x14, 0x8c, 0x75, 0xc8, 0xf1, 0x87, 0x1d, 0x31, 0xca, 0xac, 0x04, 0x3c, 0xb4, 0x77, 0xf2,
+	0x58, 0x84, 0x2b, 0x64, 0x0b, 0x0b, 0x06, 0x54, 0x27, 0xdb, 0x7f, 0x13, 0x80, 0x78, 0x4d, 0xea,
+	0xeb, 0x33, 0x60, 0xa1, 0x55, 0x48, 0x86, 0x5d, 0xbb, 0x40, 0xd6, 0x5c, 0xb6, 0xeb, 0x7b, 0x99,
+	0xd6, 0x11, 0xc1, 0x44, 0xb4, 0x21, 0x1c, 0x2d, 0x9e, 0xa7, 0x17, 0xa3, 0xcc, 0x04, 0x47, 0x93,
+	0x67, 0x87, 0x9b, 0xaa, 0x6f, 0x41, 0x4a, 0x44, 0xe1, 0xcb, 0x8f, 0x21, 0xaf, 0xfc, 0xf8, 0x40,
+	0x2c, 0x3f, 0x22, 0x22, 0x21, 0xa8, 0x3e, 0xf4, 0x6d, 0x48, 0x87, 0x30, 0x15, 0x56, 0xa5, 0xa2,
+	0x46, 0x1d, 0x9c, 0x5c, 0x51, 0xb3, 0xce, 0x6a, 0x81, 0x1d, 0x76, 0xf8, 0xb2, 0xb5, 0xba, 0xc5,
+	0x9f, 0xd0, 0x5e, 0xd8, 0xa4, 0x88, 0x37, 0x02, 0x4d, 0xee, 0xc4, 0xde, 0x60, 0xd9, 0x3c, 0x90,
+	0xb2, 0x95, 0x3c, 0x9b, 0x21, 0x2f, 0x27, 0x4a, 0x6c, 0x54, 0x91, 0xb3, 0x0a, 0x93, 0xa2, 0xea,
+	0x40, 0x80, 0x59, 0x2f, 0x15, 0xfa, 0x32, 0xbf, 0xa4, 0x7b, 0x04, 0x99, 0xb0, 0x80, 0x02, 0x94,
+	0x00, 0xfc, 0xf9, 0x2c, 0x3e, 0xc3, 0x08, 0x9c, 0x86, 0xf1, 0x90, 0x9d, 0xb0, 0xfd, 0xbc, 0x56,
+	0x44, 0xd5, 0xf6, 0x0f, 0x0d, 0xae, 0x6c, 0x60, 0xe7, 0xb1, 0xd5, 0xc6, 0xe6, 0x7e, 0xec, 0xfc,
+	0xfb, 0x90, 0x6c, 0x58, 0x3d, 0x5c, 0xf7, 0x73, 0x52, 0xaa, 0x32, 0xc7, 0x8a, 0x6c, 0x71, 0x7a,
+	0x69, 0x95, 0xe9, 0x55, 0x83, 0x29, 0x6e, 0xba, 0x6f, 0x99, 0x27, 0xb5, 0x06, 0xee, 0x3a, 0x07,
+	0x24, 0x37, 0x0d, 0x57, 0x47, 0x5a, 0xe6, 0xc9, 0xaa, 0xfb, 0x6c, 0xdc, 0x81, 0xa4, 0x3f, 0x09,
+	0x8d, 0xc0, 0x7b, 0x0f, 0x9f, 0x3c, 0x7b, 0x34, 0xfe, 0x23, 0x74, 0x19, 0x46, 0xb6, 0x9f, 0x6e,
+	0x3d, 0xab, 0xae, 0x3d, 0xd8, 0x1c, 0xd7, 0x50, 0x0a, 0x60, 0xf5, 0xc9, 0xce, 0x2f, 0xe8, 0x73,
+	0x82, 0x6c, 0x71, 0x1e, 0xfc, 0x22, 0xb7, 0xb8, 0x6c, 0x77, 0xa0, 0x2d, 0xae, 0x30, 0xf3, 0xff,
+	0xb2, 0xc5, 0x37, 0xe1, 0xd2, 0x56, 0xfd, 0x00, 0xb7, 0x4c, 0x65, 0xb4, 0xa4, 0x20, 0x61, 0x35,
+	0x68, 0x2d, 0x94, 0xb0, 0x1a, 0xa8, 0x00, 0xa3, 0xbf, 0xb6, 0x3b, 0xed, 0x9a, 0x4d, 0xa6, 0xd0,
+	0x82, 0x0e, 0xdc, 0x21, 0xcf, 0x88, 0x71, 0x0f, 0xa6, 0xaa, 0x78, 0xdf, 0xb2, 0x1d, 0xdc, 0xf3,
+	0x46, 0x58, 0x2c, 0x86, 0x66, 0x6a, 0xd2, 0xcc, 0x4f, 0x20, 0x13, 0x9e, 0x49, 0x23, 0x61, 0x1e,
+	0x2e, 0x71, 0xb3, 0x46, 0x2b, 0xa3, 0x64, 0x4d, 0xa8, 0x12, 0x15, 0x19, 0x0b, 0x30, 0xbe, 0x81,
+	0x1d, 0x11, 0x53, 0x75, 0x2c, 0xdc, 0x83, 0x2b, 0x9c, 0xde, 0x59, 0x10, 0x26, 0xbd, 0x4c, 0xe4,
+	0x8d, 0xfa, 0xe7, 0xc0, 0xc7, 0x30, 0x21, 0x8c, 0x52, 0x8b, 0x37, 0xe0, 0x7d, 0x6f, 0x1a, 0x0b,
+	0x5f, 0xc1, 0x24, 0x93, 0x55, 0xde, 0xce, 0x43, 0x7a, 0x93, 0xae, 0xcf, 0x96, 0x77, 0x49, 0x83,
+	0xde, 0x68, 0x90, 0x12, 0xcb, 0x11, 0x14, 0x53, 0xa3, 0xe8, 0x79, 0xa5, 0xcc, 0xa3, 0x61, 0xac,
+	0xfe, 0xfe, 0xdf, 0xff, 0xf9, 0x53, 0xe2, 0xbe, 0x51, 0x21, 0x17, 0x3b, 0xc7, 0xb7, 0xcd, 0x66,
+	0xf7, 0xc0, 0xbc, 0x5d, 0xfe, 0x8d, 0xf7, 0xe5, 0xf6, 0x89, 0xd8, 0x4d, 0x2e, 0x17, 0x8b, 0x5f,
+	0x95, 0xd9, 0x90, 0xbd, 0x12, 0x34, 0xa2, 0xfe, 0xa8, 0xc1, 0xa4, 0xaa, 0x91, 0x89, 0xbc, 0x93,
+	0x23, 0xa6, 0x75, 0xaa, 0x5f, 0x8b, 0xd1, 0xa0, 0x1c, 0x4b, 0x84, 0xe3, 0x92, 0x31, 0x2f, 0x72,
+	0x0c, 0x88, 0xec, 0x06, 0xb3, 0x57, 0xb4, 0x22, 0x7a, 0x0d, 0xa3, 0x5c, 0x5b, 0x13, 0x65, 0xc3,
+	0x6d, 0x7f, 0x06, 0x9d, 0x93, 0x05, 0x14, 0x71, 0x85, 0x20, 0x2e, 0xa3, 0xb0, 0x57, 0xdc, 0x00,
+	0x91, 0x7d, 0x12, 0x30, 0x29, 0x17, 0xbf, 0x42, 0x6f, 0x35, 0x18, 0x13, 0x9a, 0x8d, 0x68, 0xda,
+	0xaf, 0x02, 0xa4, 0xb7, 0xd7, 0x55, 0x22, 0x4a, 0x62, 0x8b, 0x90, 0xd8, 0x44, 0x3f, 0xee, 0x8b,
+	0x04, 0xb7, 0x30, 0xcf, 0xa7, 0x51, 0x36, 0xc2, 0x55, 0x24, 0x76, 0xc4, 0xb6, 0x1f, 0x8d, 0x1d,
+	0x65, 0xa3, 0x52, 0xcf, 0x2b, 0x65, 0x62, 0xec, 0x54, 0x06, 0xf0, 0x12, 0x17, 0x3b, 0x5f, 0x6b,
+	0x90, 0x12, 0x7b, 0x87, 0x94, 0x91, 0xb2, 0xa1, 0xa8, 0x67, 0xa4, 0xef, 0x9c, 0x35, 0xf7, 0xaa,
+	0x91, 0x2d, 0x59, 0x71, 0x90, 0x25, 0xfb, 0xb3, 0x06, 0xe9, 0xd0, 0xa7, 0x04, 0x8a, 0xfb, 0xc0,
+	0xd0, 0x67, 0xd4, 0x42, 0xea, 0x97, 0x0d, 0x42, 0xe5, 0x81, 0xb1, 0xac, 0xde, 0x53, 0xa1, 0x56,
+	0x0e, 0x59, 0x3b, 0x7f, 0xcc, 0x5e, 0xe1, 0x4a, 0xff, 0x37, 0x1a, 0x4c, 0x29, 0x5b, 0x07, 0x48,
+	0xda, 0x35, 0xd2, 0xa7, 0xb8, 0x6e, 0xc4, 0xa9, 0x50, 0xa6, 0x65, 0xc2, 0xf4, 0xa6, 0x71, 0x5d,
+	0x64, 0xca, 0xb1, 0x09, 0x6d, 0xad, 0x13, 0x48, 0x87, 0xbe, 0x6e, 0xa8, 0xa3, 0xd4, 0x5f, 0x6e,
+	0xfa, 0x8c, 0x5a, 0x48, 0xe1, 0x8b, 0x04, 0xfe, 0xba, 0x51, 0x88, 0x84, 0xef, 0x91, 0x99, 0x2e,
+	0xf2, 0xef, 0x34, 0xb8, 0xcc, 0xb7, 0x30, 0x50, 0x4e, 0xea, 0xfc, 0x31, 0xd0, 0x69, 0x85, 0x84,
+	0x22, 0x7e, 0x4c, 0x10, 0xef, 0xa2, 0x65, 0x55, 0x94, 0xc8, 0x0b, 0xc3, 0x51, 0x71, 0xe3, 0xe4,
+	0x6f, 0x1a, 0xa4, 0xc4, 0xc6, 0x02, 0x0a, 0x36, 0xb0, 0xbc, 0x02, 0x79, 0xa5, 0x8c, 0x32, 0xf9,
+	0x9c, 0x30, 0x79, 0xaa, 0x3e, 0x62, 0xe2, 0x43, 0xe4, 0xb9, 0x8e, 0x72, 0x51, 0x1e, 0x23, 0xb1,
+	0x1c, 0xfa, 0xc0, 0x47, 0xfc, 0x2e, 0x8e, 0x58, 0xa2, 0x88, 0x9e, 0x00, 0x8b, 0xe5, 0xca, 0x40,
+	0x0e, 0xe3, 0x63, 0xf9, 0x1b, 0x0d, 0xd2, 0xa1, 0x3e, 0x01, 0xe5, 0xa5, 0xee, 0x1e, 0x44, 0x6e,
+	0x74, 0xba, 0x84, 0xc5, 0xc1, 0x96, 0xf0, 0x0f, 0x1a, 0x20, 0xf9, 0x26, 0x1c, 0x5d, 0x55, 0x9c,
+	0x73, 0x5c, 0x7b, 0x54, 0x2f, 0x44, 0xca, 0xa9, 0x9f, 0xee, 0x10, 0x56, 0x1f, 0x56, 0x66, 0xd4,
+	0x07, 0xaf, 0x47, 0x67, 0x45, 0xbc, 0xdc, 0x26, 0x64, 0xe4, 0x7f, 0x14, 0x50, 0x32, 0x91, 0xff,
+	0x63, 0xd0, 0x0b, 0x91, 0x72, 0x91, 0x8c, 0x71, 0x26, 0x32, 0x27, 0xde, 0x17, 0x37, 0x6f, 0xd0,
+	0x46, 0xb3, 0x52, 0x7e, 0xe2, 0xdb, 0xd6, 0xfa, 0xd5, 0x28, 0x31, 0x25, 0x72, 0x9d, 0x10, 0xb9,
+	0x8a, 0x62, 0x89, 0xa0, 0xd7, 0x90, 0x0e, 0x5d, 0xca, 0xd3, 0xc8, 0x50, 0xff, 0xa9, 0x40, 0x9f,
+	0x51, 0x0b, 0xc5, 0x6a, 0x01, 0x2d, 0xf4, 0x97, 0x36, 0xd1, 0x6b, 0x40, 0xf2, 0x8d, 0x37, 0x5d,
+	0x83, 0xc8, 0xab, 0xf0, 0xc8, 0xe8, 0xa4, 0xe8, 0xc5, 0x7e, 0xd1, 0xdf, 0x68, 0x30, 0xa1, 0xb8,
+	0xce, 0x41, 0x05, 0xd5, 0xae, 0xe4, 0x09, 0xcc, 0x45, 0x2b, 0x50, 0x47, 0x7c, 0x44, 0xa8, 0x94,
+	0x2b, 0xb3, 0x11, 0x67, 0x05, 0x0d, 0x83, 0xd0, 0xfd, 0x0c, 0x61, 0xa4, 0xb8, 0x23, 0x43, 0x05,
+	0x55, 0xce, 0x93, 0x19, 0xc5, 0x5c, 0xaf, 0x31, 0x46, 0xc6, 0x19, 0x19, 0xbd, 0xa6, 0x1d, 0x1e,
+	0xde, 0xa6, 0x8d, 0xae, 0xca, 0xa7, 0xab, 0x10, 0x9b, 0x85, 0x48, 0x39, 0x65, 0x73, 0x83, 0xb0,
+	0x29, 0xa0, 0x78, 0x36, 0x6e, 0x7d, 0x32, 0x1e, 0xbe, 0x5f, 0x42, 0x33, 0x11, 0xd7, 0x4e, 0x1e,
+	0xf4, 0x6c, 0xec, 0xa5, 0x14, 0xcb, 0xba, 0x68, 0xb1, 0xcf, 0xa3, 0x1f, 0xfd, 0x16, 0x26, 0x14,
+	0x57, 0x37, 0x74, 0x45, 0xa2, 0x2f, 0x75, 0x22, 0x83, 0x94, 0x12, 0x28, 0xf6, 0x4d, 0xa0, 0x0e,
+	0xa3, 0x5c, 0x43, 0x8f, 0x56, 0xd4, 0x72, 0x9f, 0x30, 0x12, 0x70, 0x9e, 0x00, 0xce, 0x1a, 0x93,
+	0x21, 0x57, 0xbb, 0x73, 0xed, 0x15, 0xaf, 0x75, 0x88, 0x5e, 0xc1, 0x15, 0xa9, 0x75, 0x48, 0x0f,
+	0xa0, 0xa8, 0x96, 0x62, 0x24, 0xe0, 0x07, 0x04, 0xf0, 0x86, 0x31, 0xa7, 0x04, 0x0c, 0x15, 0x35,
+	0x7f, 0xd1, 0x00, 0x82, 0x1e, 0x1d, 0xca, 0x48, 0x4d, 0x3b, 0x0f, 0x2b, 0x1b, 0xd1, 0xcc, 0x33,
+	0x5e, 0x10, 0xb0, 0x6d, 0xb4, 0xa4, 0x02, 0x0b, 0x7b, 0xd5, 0xcd, 0x42, 0xcf, 0xa5, 0xb5, 0x17,
+	0x74, 0x85, 0xda, 0xf4, 0x94, 0x95, 0xa6, 0x7e, 0xf3, 0x47, 0x28, 0x4d, 0xc3, 0x2d, 0x21, 0x7d,
+	0x46, 0x2d, 0xa4, 0x54, 0x99, 0x5f, 0x42, 0xf5, 0x43, 0xd0, 0x6c, 0x5a, 0x09, 0x5a, 0x5b, 0xa8,
+	0x4d, 0x2a, 0xae, 0x00, 0xd7, 0xaf, 0xb8, 0x24, 0xd0, 0x69, 0x85, 0x84, 0x22, 0xde, 0x24, 0x88,
+	0xf3, 0xe8, 0x9a, 0x2a, 0xd6, 0x02, 0x5c, 0xf7, 0x55, 0x0f, 0xbd, 0xea, 0xca, 0xb7, 0xc1, 0x57,
+	0x57, 0x52, 0x7f, 0x4d, 0xcf, 0x2b, 0x65, 0x14, 0x75, 0x8e, 0xa0, 0x4a, 0x75, 0x52, 0x80, 0x87,
+	0xbe, 0x60, 0xe5, 0x48, 0xd8, 0xaf, 0xea, 0x56, 0x5b, 0x64, 0xa4, 0xd1, 0xf7, 0x2b, 0xf6, 0xf1,
+	0x7e, 0xdf, 0x6a, 0x00, 0x41, 0xa3, 0x88, 0xc6, 0x99, 0xd4, 0x55, 0xd3, 0xb3, 0x11, 0x1d, 0x25,
+	0xe3, 0x25, 0x81, 0xda, 0x41, 0x37, 0x45, 0xa8, 0xa6, 0xa7, 0xa6, 0x0c, 0xb4, 0x22, 0x5a, 0x8a,
+	0x53, 0x16, 0x22, 0xed, 0x10, 0x52, 0x62, 0x7f, 0x85, 0xba, 0x5f, 0xd9, 0xae, 0xd1, 0xf3, 0x4a,
+	0x99, 0xe8, 0x7e, 0x63, 0x4a, 0x04, 0xa7, 0x4d, 0x0d, 0x77, 0xcf, 0xd5, 0x60, 0x94, 0xeb, 0x8a,
+	0xa0, 0x60, 0x6f, 0x89, 0xdd, 0x13, 0x3d, 0x27, 0x0b, 0x28, 0xc6, 0x2c, 0xc1, 0xc8, 0x22, 0x35,
+	0x06, 0xaa, 0x43, 0xd2, 0x6f, 0xe3, 0xa0, 0x29, 0xe6, 0x52, 0xf1, 0x1d, 0x32, 0xe1, 0x61, 0x6a,
+	0x7a, 0x91, 0x98, 0xbe, 0x86, 0x0a, 0xaa, 0x35, 0xa5, 0x00, 0xee, 0xb9, 0xf8, 0xd0, 0x78, 0x3e,
+	0xb7, 0x6f, 0x39, 0x07, 0x47, 0xbb, 0xa5, 0x7a, 0xa7, 0x55, 0x3e, 0x3c, 0xda, 0xc5, 0x7b, 0xcd,
+	0xce, 0x2b, 0xff, 0xaf, 0xa9, 0xee, 0xf4, 0xdd, 0x4b, 0x24, 0x60, 0xee, 0xfc, 0x6f, 0x00, 0x57,
+	0x67, 0xc2, 0x4f, 0x68, 0x2b, 0x00, 0x00,
 }
 
 // Reference imports to suppress errors if they are not otherwise used.
@@ -1564,12 +2996,17 @@ const _ = grpc.SupportPackageIsVersion4
 // For semantics around ctx use and closing/ending streaming RPCs, please refer to https://godoc.org/google.golang.org/grpc#ClientConn.NewStream.
 type MetadataServiceClient interface {
 	CreateArtifact(ctx context.Context, in *CreateArtifactRequest, opts ...grpc.CallOption) (*CreateArtifactResponse, error)
+	BatchCreateArtifacts(ctx context.Context, in *BatchCreateArtifactsRequest, opts ...grpc.CallOption) (*BatchCreateArtifactsResponse, error)
 	GetArtifact(ctx context.Context, in *GetArtifactRequest, opts ...grpc.CallOption) (*GetArtifactResponse, error)
 	ListArtifacts(ctx context.Context, in *ListArtifactsRequest, opts ...grpc.CallOption) (*ListArtifactsResponse, error)
+	UpdateArtifact(ctx context.Context, in *UpdateArtifactRequest, opts ...grpc.CallOption) (*UpdateArtifactResponse, error)
 	DeleteArtifact(ctx context.Context, in *DeleteArtifactRequest, opts ...grpc.CallOption) (*empty.Empty, error)
 	CreateExecution(ctx context.Context, in *CreateExecutionRequest, opts ...grpc.CallOption) (*CreateExecutionResponse, error)
+	BatchCreateExecutions(ctx context.Context, in *BatchCreateExecutionsRequest, opts ...grpc.CallOption) (*BatchCreateExecutionsResponse, error)
+	RecordExecution(ctx context.Context, in *RecordExecutionRequest, opts ...grpc.CallOption) (*RecordExecutionResponse, error)
 	GetExecution(ctx context.Context, in *GetExecutionRequest, opts ...grpc.CallOption) (*GetExecutionResponse, error)
 	ListExecutions(ctx context.Context, in *ListExecutionsRequest, opts ...grpc.CallOption) (*ListExecutionsResponse, error)
+	UpdateExecution(ctx context.Context, in *UpdateExecutionRequest, opts ...grpc.CallOption) (*UpdateExecutionResponse, error)
 	DeleteExecution(ctx context.Context, in *DeleteExecutionRequest, opts ...grpc.CallOption) (*empty.Empty, error)
 	UpdateArtifactType(ctx context.Context, in *UpdateArtifactTypeRequest, opts ...grpc.CallOption) (*UpdateArtifactTypeResponse, error)
 	CreateArtifactType(ctx context.Context, in *CreateArtifactTypeRequest, opts ...grpc.CallOption) (*CreateArtifactTypeResponse, error)
@@ -1582,7 +3019,16 @@ type MetadataServiceClient interface {
 	GetExecutionType(ctx context.Context, in *GetExecutionTypeRequest, opts ...grpc.CallOption) (*GetExecutionTypeResponse, error)
 	DeleteExecutionType(ctx context.Context, in *DeleteExecutionTypeRequest, opts ...grpc.CallOption) (*empty.Empty, error)
 	CreateEvent(ctx context.Context, in *CreateEventRequest, opts ...grpc.CallOption) (*empty.Empty, error)
+	BatchCreateEvents(ctx context.Context, in *BatchCreateEventsRequest, opts ...grpc.CallOption) (*empty.Empty, error)
 	ListEvents(ctx context.Context, in *ListEventsRequest, opts ...grpc.CallOption) (*ListEventsResponse, error)
+	CreateWorkspace(ctx context.Context, in *CreateWorkspaceRequest, opts ...grpc.CallOption) (*CreateWorkspaceResponse, error)
+	GetWorkspace(ctx context.Context, in *GetWorkspaceRequest, opts ...grpc.CallOption) (*GetWorkspaceResponse, error)
+	ListWorkspaces(ctx context.Context, in *ListWorkspacesRequest, opts ...grpc.CallOption) (*ListWorkspacesResponse, error)
+	DeleteWorkspace(ctx context.Context, in *DeleteWorkspaceRequest, opts ...grpc.CallOption) (*empty.Empty, error)
+	GetLineage(ctx context.Context, in *GetLineageRequest, opts ...grpc.CallOption) (*GetLineageResponse, error)
+	RegisterSchema(ctx context.Context, in *RegisterSchemaRequest, opts ...grpc.CallOption) (*RegisterSchemaResponse, error)
+	ListSchemas(ctx context.Context, in *ListSchemasRequest, opts ...grpc.CallOption) (*ListSchemasResponse, error)
+	GetSchema(ctx context.Context, in *GetSchemaRequest, opts ...grpc.CallOption) (*GetSchemaResponse, error)
 }
 
 type metadataServiceClient struct {
@@ -1602,6 +3048,15 @@ func (c *metadataServiceClient) CreateArtifact(ctx context.Context, in *CreateAr
 	return out, nil
 }
 
+func (c *metadataServiceClient) BatchCreateArtifacts(ctx context.Context, in *BatchCreateArtifactsRequest, opts ...grpc.CallOption) (*BatchCreateArtifactsResponse, error) {
+	out := new(BatchCreateArtifactsResponse)
+	err := c.cc.Invoke(ctx, "/api.MetadataService/BatchCreateArtifacts", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *metadataServiceClient) GetArtifact(ctx context.Context, in *GetArtifactRequest, opts ...grpc.CallOption) (*GetArtifactResponse, error) {
 	out := new(GetArtifactResponse)
 	err := c.cc.Invoke(ctx, "/api.MetadataService/GetArtifact", in, out, opts...)
@@ -1620,6 +3075,15 @@ func (c *metadataServiceClient) ListArtifacts(ctx context.Context, in *ListArtif
 	return out, nil
 }
 
+func (c *metadataServiceClient) UpdateArtifact(ctx context.Context, in *UpdateArtifactRequest, opts ...grpc.CallOption) (*UpdateArtifactResponse, error) {
+	out := new(UpdateArtifactResponse)
+	err := c.cc.Invoke(ctx, "/api.MetadataService/UpdateArtifact", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *metadataServiceClient) DeleteArtifact(ctx context.Context, in *DeleteArtifactRequest, opts ...grpc.CallOption) (*empty.Empty, error) {
 	out := new(empty.Empty)
 	err := c.cc.Invoke(ctx, "/api.MetadataService/DeleteArtifact", in, out, opts...)
@@ -1638,6 +3102,24 @@ func (c *metadataServiceClient) CreateExecution(ctx context.Context, in *CreateE
 	return out, nil
 }
 
+func (c *metadataServiceClient) BatchCreateExecutions(ctx context.Context, in *BatchCreateExecutionsRequest, opts ...grpc.CallOption) (*BatchCreateExecutionsResponse, error) {
+	out := new(BatchCreateExecutionsResponse)
+	err := c.cc.Invoke(ctx, "/api.MetadataService/BatchCreateExecutions", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *metadataServiceClient) RecordExecution(ctx context.Context, in *RecordExecutionRequest, opts ...grpc.CallOption) (*RecordExecutionResponse, error) {
+	out := new(RecordExecutionResponse)
+	err := c.cc.Invoke(ctx, "/api.MetadataService/RecordExecution", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *metadataServiceClient) GetExecution(ctx context.Context, in *GetExecutionRequest, opts ...grpc.CallOption) (*GetExecutionResponse, error) {
 	out := new(GetExecutionResponse)
 	err := c.cc.Invoke(ctx, "/api.MetadataService/GetExecution", in, out, opts...)
@@ -1656,6 +3138,15 @@ func (c *metadataServiceClient) ListExecutions(ctx context.Context, in *ListExec
 	return out, nil
 }
 
+func (c *metadataServiceClient) UpdateExecution(ctx context.Context, in *UpdateExecutionRequest, opts ...grpc.CallOption) (*UpdateExecutionResponse, error) {
+	out := new(UpdateExecutionResponse)
+	err := c.cc.Invoke(ctx, "/api.MetadataService/UpdateExecution", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *metadataServiceClient) DeleteExecution(ctx context.Context, in *DeleteExecutionRequest, opts ...grpc.CallOption) (*empty.Empty, error) {
 	out := new(empty.Empty)
 	err := c.cc.Invoke(ctx, "/api.MetadataService/DeleteExecution", in, out, opts...)
@@ -1674,99 +3165,180 @@ func (c *metadataServiceClient) UpdateArtifactType(ctx context.Context, in *Upda
 	return out, nil
 }
 
-func (c *metadataServiceClient) CreateArtifactType(ctx context.Context, in *CreateArtifactTypeRequest, opts ...grpc.CallOption) (*CreateArtifactTypeResponse, error) {
-	out := new(CreateArtifactTypeResponse)
-	err := c.cc.Invoke(ctx, "/api.MetadataService/CreateArtifactType", in, out, opts...)
+func (c *metadataServiceClient) CreateArtifactType(ctx context.Context, in *CreateArtifactTypeRequest, opts ...grpc.CallOption) (*CreateArtifactTypeResponse, error) {
+	out := new(CreateArtifactTypeResponse)
+	err := c.cc.Invoke(ctx, "/api.MetadataService/CreateArtifactType", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *metadataServiceClient) ListArtifactTypes(ctx context.Context, in *ListArtifactTypesRequest, opts ...grpc.CallOption) (*ListArtifactTypesResponse, error) {
+	out := new(ListArtifactTypesResponse)
+	err := c.cc.Invoke(ctx, "/api.MetadataService/ListArtifactTypes", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *metadataServiceClient) GetArtifactType(ctx context.Context, in *GetArtifactTypeRequest, opts ...grpc.CallOption) (*GetArtifactTypeResponse, error) {
+	out := new(GetArtifactTypeResponse)
+	err := c.cc.Invoke(ctx, "/api.MetadataService/GetArtifactType", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *metadataServiceClient) DeleteArtifactType(ctx context.Context, in *DeleteArtifactTypeRequest, opts ...grpc.CallOption) (*empty.Empty, error) {
+	out := new(empty.Empty)
+	err := c.cc.Invoke(ctx, "/api.MetadataService/DeleteArtifactType", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *metadataServiceClient) UpdateExecutionType(ctx context.Context, in *UpdateExecutionTypeRequest, opts ...grpc.CallOption) (*UpdateExecutionTypeResponse, error) {
+	out := new(UpdateExecutionTypeResponse)
+	err := c.cc.Invoke(ctx, "/api.MetadataService/UpdateExecutionType", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *metadataServiceClient) CreateExecutionType(ctx context.Context, in *CreateExecutionTypeRequest, opts ...grpc.CallOption) (*CreateExecutionTypeResponse, error) {
+	out := new(CreateExecutionTypeResponse)
+	err := c.cc.Invoke(ctx, "/api.MetadataService/CreateExecutionType", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *metadataServiceClient) ListExecutionTypes(ctx context.Context, in *ListExecutionTypesRequest, opts ...grpc.CallOption) (*ListExecutionTypesResponse, error) {
+	out := new(ListExecutionTypesResponse)
+	err := c.cc.Invoke(ctx, "/api.MetadataService/ListExecutionTypes", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *metadataServiceClient) GetExecutionType(ctx context.Context, in *GetExecutionTypeRequest, opts ...grpc.CallOption) (*GetExecutionTypeResponse, error) {
+	out := new(GetExecutionTypeResponse)
+	err := c.cc.Invoke(ctx, "/api.MetadataService/GetExecutionType", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *metadataServiceClient) DeleteExecutionType(ctx context.Context, in *DeleteExecutionTypeRequest, opts ...grpc.CallOption) (*empty.Empty, error) {
+	out := new(empty.Empty)
+	err := c.cc.Invoke(ctx, "/api.MetadataService/DeleteExecutionType", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *metadataServiceClient) CreateEvent(ctx context.Context, in *CreateEventRequest, opts ...grpc.CallOption) (*empty.Empty, error) {
+	out := new(empty.Empty)
+	err := c.cc.Invoke(ctx, "/api.MetadataService/CreateEvent", in, out, opts...)
 	if err != nil {
 		return nil, err
 	}
 	return out, nil
 }
 
-func (c *metadataServiceClient) ListArtifactTypes(ctx context.Context, in *ListArtifactTypesRequest, opts ...grpc.CallOption) (*ListArtifactTypesResponse, error) {
-	out := new(ListArtifactTypesResponse)
-	err := c.cc.Invoke(ctx, "/api.MetadataService/ListArtifactTypes", in, out, opts...)
+func (c *metadataServiceClient) BatchCreateEvents(ctx context.Context, in *BatchCreateEventsRequest, opts ...grpc.CallOption) (*empty.Empty, error) {
+	out := new(empty.Empty)
+	err := c.cc.Invoke(ctx, "/api.MetadataService/BatchCreateEvents", in, out, opts...)
 	if err != nil {
 		return nil, err
 	}
 	return out, nil
 }
 
-func (c *metadataServiceClient) GetArtifactType(ctx context.Context, in *GetArtifactTypeRequest, opts ...grpc.CallOption) (*GetArtifactTypeResponse, error) {
-	out := new(GetArtifactTypeResponse)
-	err := c.cc.Invoke(ctx, "/api.MetadataService/GetArtifactType", in, out, opts...)
+func (c *metadataServiceClient) ListEvents(ctx context.Context, in *ListEventsRequest, opts ...grpc.CallOption) (*ListEventsResponse, error) {
+	out := new(ListEventsResponse)
+	err := c.cc.Invoke(ctx, "/api.MetadataService/ListEvents", in, out, opts...)
 	if err != nil {
 		return nil, err
 	}
 	return out, nil
 }
 
-func (c *metadataServiceClient) DeleteArtifactType(ctx context.Context, in *DeleteArtifactTypeRequest, opts ...grpc.CallOption) (*empty.Empty, error) {
-	out := new(empty.Empty)
-	err := c.cc.Invoke(ctx, "/api.MetadataService/DeleteArtifactType", in, out, opts...)
+func (c *metadataServiceClient) CreateWorkspace(ctx context.Context, in *CreateWorkspaceRequest, opts ...grpc.CallOption) (*CreateWorkspaceResponse, error) {
+	out := new(CreateWorkspaceResponse)
+	err := c.cc.Invoke(ctx, "/api.MetadataService/CreateWorkspace", in, out, opts...)
 	if err != nil {
 		return nil, err
 	}
 	return out, nil
 }
 
-func (c *metadataServiceClient) UpdateExecutionType(ctx context.Context, in *UpdateExecutionTypeRequest, opts ...grpc.CallOption) (*UpdateExecutionTypeResponse, error) {
-	out := new(UpdateExecutionTypeResponse)
-	err := c.cc.Invoke(ctx, "/api.MetadataService/UpdateExecutionType", in, out, opts...)
+func (c *metadataServiceClient) GetWorkspace(ctx context.Context, in *GetWorkspaceRequest, opts ...grpc.CallOption) (*GetWorkspaceResponse, error) {
+	out := new(GetWorkspaceResponse)
+	err := c.cc.Invoke(ctx, "/api.MetadataService/GetWorkspace", in, out, opts...)
 	if err != nil {
 		return nil, err
 	}
 	return out, nil
 }
 
-func (c *metadataServiceClient) CreateExecutionType(ctx context.Context, in *CreateExecutionTypeRequest, opts ...grpc.CallOption) (*CreateExecutionTypeResponse, error) {
-	out := new(CreateExecutionTypeResponse)
-	err := c.cc.Invoke(ctx, "/api.MetadataService/CreateExecutionType", in, out, opts...)
+func (c *metadataServiceClient) ListWorkspaces(ctx context.Context, in *ListWorkspacesRequest, opts ...grpc.CallOption) (*ListWorkspacesResponse, error) {
+	out := new(ListWorkspacesResponse)
+	err := c.cc.Invoke(ctx, "/api.MetadataService/ListWorkspaces", in, out, opts...)
 	if err != nil {
 		return nil, err
 	}
 	return out, nil
 }
 
-func (c *metadataServiceClient) ListExecutionTypes(ctx context.Context, in *ListExecutionTypesRequest, opts ...grpc.CallOption) (*ListExecutionTypesResponse, error) {
-	out := new(ListExecutionTypesResponse)
-	err := c.cc.Invoke(ctx, "/api.MetadataService/ListExecutionTypes", in, out, opts...)
+func (c *metadataServiceClient) DeleteWorkspace(ctx context.Context, in *DeleteWorkspaceRequest, opts ...grpc.CallOption) (*empty.Empty, error) {
+	out := new(empty.Empty)
+	err := c.cc.Invoke(ctx, "/api.MetadataService/DeleteWorkspace", in, out, opts...)
 	if err != nil {
 		return nil, err
 	}
 	return out, nil
 }
 
-func (c *metadataServiceClient) GetExecutionType(ctx context.Context, in *GetExecutionTypeRequest, opts ...grpc.CallOption) (*GetExecutionTypeResponse, error) {
-	out := new(GetExecutionTypeResponse)
-	err := c.cc.Invoke(ctx, "/api.MetadataService/GetExecutionType", in, out, opts...)
+func (c *metadataServiceClient) GetLineage(ctx context.Context, in *GetLineageRequest, opts ...grpc.CallOption) (*GetLineageResponse, error) {
+	out := new(GetLineageResponse)
+	err := c.cc.Invoke(ctx, "/api.MetadataService/GetLineage", in, out, opts...)
 	if err != nil {
 		return nil, err
 	}
 	return out, nil
 }
 
-func (c *metadataServiceClient) DeleteExecutionType(ctx context.Context, in *DeleteExecutionTypeRequest, opts ...grpc.CallOption) (*empty.Empty, error) {
-	out := new(empty.Empty)
-	err := c.cc.Invoke(ctx, "/api.MetadataService/DeleteExecutionType", in, out, opts...)
+func (c *metadataServiceClient) RegisterSchema(ctx context.Context, in *RegisterSchemaRequest, opts ...grpc.CallOption) (*RegisterSchemaResponse, error) {
+	out := new(RegisterSchemaResponse)
+	err := c.cc.Invoke(ctx, "/api.MetadataService/RegisterSchema", in, out, opts...)
 	if err != nil {
 		return nil, err
 	}
 	return out, nil
 }
 
-func (c *metadataServiceClient) CreateEvent(ctx context.Context, in *CreateEventRequest, opts ...grpc.CallOption) (*empty.Empty, error) {
-	out := new(empty.Empty)
-	err := c.cc.Invoke(ctx, "/api.MetadataService/CreateEvent", in, out, opts...)
+func (c *metadataServiceClient) ListSchemas(ctx context.Context, in *ListSchemasRequest, opts ...grpc.CallOption) (*ListSchemasResponse, error) {
+	out := new(ListSchemasResponse)
+	err := c.cc.Invoke(ctx, "/api.MetadataService/ListSchemas", in, out, opts...)
 	if err != nil {
 		return nil, err
 	}
 	return out, nil
 }
 
-func (c *metadataServiceClient) ListEvents(ctx context.Context, in *ListEventsRequest, opts ...grpc.CallOption) (*ListEventsResponse, error) {
-	out := new(ListEventsResponse)
-	err := c.cc.Invoke(ctx, "/api.MetadataService/ListEvents", in, out, opts...)
+func (c *metadataServiceClient) GetSchema(ctx context.Context, in *GetSchemaRequest, opts ...grpc.CallOption) (*GetSchemaResponse, error) {
+	out := new(GetSchemaResponse)
+	err := c.cc.Invoke(ctx, "/api.MetadataService/GetSchema", in, out, opts...)
 	if err != nil {
 		return nil, err
 	}
@@ -1776,12 +3348,17 @@ func (c *metadataServiceClient) ListEvents(ctx context.Context, in *ListEventsRe
 // MetadataServiceServer is the server API for MetadataService service.
 type MetadataServiceServer interface {
 	CreateArtifact(context.Context, *CreateArtifactRequest) (*CreateArtifactResponse, error)
+	BatchCreateArtifacts(context.Context, *BatchCreateArtifactsRequest) (*BatchCreateArtifactsResponse, error)
 	GetArtifact(context.Context, *GetArtifactRequest) (*GetArtifactResponse, error)
 	ListArtifacts(context.Context, *ListArtifactsRequest) (*ListArtifactsResponse, error)
+	UpdateArtifact(context.Context, *UpdateArtifactRequest) (*UpdateArtifactResponse, error)
 	DeleteArtifact(context.Context, *DeleteArtifactRequest) (*empty.Empty, error)
 	CreateExecution(context.Context, *CreateExecutionRequest) (*CreateExecutionResponse, error)
+	BatchCreateExecutions(context.Context, *BatchCreateExecutionsRequest) (*BatchCreateExecutionsResponse, error)
+	RecordExecution(context.Context, *RecordExecutionRequest) (*RecordExecutionResponse, error)
 	GetExecution(context.Context, *GetExecutionRequest) (*GetExecutionResponse, error)
 	ListExecutions(context.Context, *ListExecutionsRequest) (*ListExecutionsResponse, error)
+	UpdateExecution(context.Context, *UpdateExecutionRequest) (*UpdateExecutionResponse, error)
 	DeleteExecution(context.Context, *DeleteExecutionRequest) (*empty.Empty, error)
 	UpdateArtifactType(context.Context, *UpdateArtifactTypeRequest) (*UpdateArtifactTypeResponse, error)
 	CreateArtifactType(context.Context, *CreateArtifactTypeRequest) (*CreateArtifactTypeResponse, error)
@@ -1794,7 +3371,16 @@ type MetadataServiceServer interface {
 	GetExecutionType(context.Context, *GetExecutionTypeRequest) (*GetExecutionTypeResponse, error)
 	DeleteExecutionType(context.Context, *DeleteExecutionTypeRequest) (*empty.Empty, error)
 	CreateEvent(context.Context, *CreateEventRequest) (*empty.Empty, error)
+	BatchCreateEvents(context.Context, *BatchCreateEventsRequest) (*empty.Empty, error)
 	ListEvents(context.Context, *ListEventsRequest) (*ListEventsResponse, error)
+	CreateWorkspace(context.Context, *CreateWorkspaceRequest) (*CreateWorkspaceResponse, error)
+	GetWorkspace(context.Context, *GetWorkspaceRequest) (*GetWorkspaceResponse, error)
+	ListWorkspaces(context.Context, *ListWorkspacesRequest) (*ListWorkspacesResponse, error)
+	DeleteWorkspace(context.Context, *DeleteWorkspaceRequest) (*empty.Empty, error)
+	GetLineage(context.Context, *GetLineageRequest) (*GetLineageResponse, error)
+	RegisterSchema(context.Context, *RegisterSchemaRequest) (*RegisterSchemaResponse, error)
+	ListSchemas(context.Context, *ListSchemasRequest) (*ListSchemasResponse, error)
+	GetSchema(context.Context, *GetSchemaRequest) (*GetSchemaResponse, error)
 }
 
 func RegisterMetadataServiceServer(s *grpc.Server, srv MetadataServiceServer) {
@@ -1819,6 +3405,24 @@ func _MetadataService_CreateArtifact_Handler(srv interface{}, ctx context.Contex
 	return interceptor(ctx, in, info, handler)
 }
 
+func _MetadataService_BatchCreateArtifacts_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(BatchCreateArtifactsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MetadataServiceServer).BatchCreateArtifacts(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/api.MetadataService/BatchCreateArtifacts",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MetadataServiceServer).BatchCreateArtifacts(ctx, req.(*BatchCreateArtifactsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _MetadataService_GetArtifact_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(GetArtifactRequest)
 	if err := dec(in); err != nil {
@@ -1855,6 +3459,24 @@ func _MetadataService_ListArtifacts_Handler(srv interface{}, ctx context.Context
 	return interceptor(ctx, in, info, handler)
 }
 
+func _MetadataService_UpdateArtifact_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateArtifactRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MetadataServiceServer).UpdateArtifact(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/api.MetadataService/UpdateArtifact",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MetadataServiceServer).UpdateArtifact(ctx, req.(*UpdateArtifactRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _MetadataService_DeleteArtifact_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(DeleteArtifactRequest)
 	if err := dec(in); err != nil {
@@ -1891,6 +3513,42 @@ func _MetadataService_CreateExecution_Handler(srv interface{}, ctx context.Conte
 	return interceptor(ctx, in, info, handler)
 }
 
+func _MetadataService_BatchCreateExecutions_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(BatchCreateExecutionsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MetadataServiceServer).BatchCreateExecutions(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/api.MetadataService/BatchCreateExecutions",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MetadataServiceServer).BatchCreateExecutions(ctx, req.(*BatchCreateExecutionsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _MetadataService_RecordExecution_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RecordExecutionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MetadataServiceServer).RecordExecution(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/api.MetadataService/RecordExecution",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MetadataServiceServer).RecordExecution(ctx, req.(*RecordExecutionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _MetadataService_GetExecution_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(GetExecutionRequest)
 	if err := dec(in); err != nil {
@@ -1927,6 +3585,24 @@ func _MetadataService_ListExecutions_Handler(srv interface{}, ctx context.Contex
 	return interceptor(ctx, in, info, handler)
 }
 
+func _MetadataService_UpdateExecution_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateExecutionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MetadataServiceServer).UpdateExecution(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/api.MetadataService/UpdateExecution",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MetadataServiceServer).UpdateExecution(ctx, req.(*UpdateExecutionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _MetadataService_DeleteExecution_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(DeleteExecutionRequest)
 	if err := dec(in); err != nil {
@@ -2143,6 +3819,24 @@ func _MetadataService_CreateEvent_Handler(srv interface{}, ctx context.Context,
 	return interceptor(ctx, in, info, handler)
 }
 
+func _MetadataService_BatchCreateEvents_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(BatchCreateEventsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MetadataServiceServer).BatchCreateEvents(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/api.MetadataService/BatchCreateEvents",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MetadataServiceServer).BatchCreateEvents(ctx, req.(*BatchCreateEventsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _MetadataService_ListEvents_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(ListEventsRequest)
 	if err := dec(in); err != nil {
@@ -2161,6 +3855,150 @@ func _MetadataService_ListEvents_Handler(srv interface{}, ctx context.Context, d
 	return interceptor(ctx, in, info, handler)
 }
 
+func _MetadataService_CreateWorkspace_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateWorkspaceRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MetadataServiceServer).CreateWorkspace(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/api.MetadataService/CreateWorkspace",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MetadataServiceServer).CreateWorkspace(ctx, req.(*CreateWorkspaceRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _MetadataService_GetWorkspace_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetWorkspaceRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MetadataServiceServer).GetWorkspace(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/api.MetadataService/GetWorkspace",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MetadataServiceServer).GetWorkspace(ctx, req.(*GetWorkspaceRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _MetadataService_ListWorkspaces_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListWorkspacesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MetadataServiceServer).ListWorkspaces(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/api.MetadataService/ListWorkspaces",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MetadataServiceServer).ListWorkspaces(ctx, req.(*ListWorkspacesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _MetadataService_DeleteWorkspace_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteWorkspaceRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MetadataServiceServer).DeleteWorkspace(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/api.MetadataService/DeleteWorkspace",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MetadataServiceServer).DeleteWorkspace(ctx, req.(*DeleteWorkspaceRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _MetadataService_GetLineage_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetLineageRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MetadataServiceServer).GetLineage(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/api.MetadataService/GetLineage",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MetadataServiceServer).GetLineage(ctx, req.(*GetLineageRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _MetadataService_RegisterSchema_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RegisterSchemaRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MetadataServiceServer).RegisterSchema(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/api.MetadataService/RegisterSchema",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MetadataServiceServer).RegisterSchema(ctx, req.(*RegisterSchemaRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _MetadataService_ListSchemas_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListSchemasRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MetadataServiceServer).ListSchemas(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/api.MetadataService/ListSchemas",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MetadataServiceServer).ListSchemas(ctx, req.(*ListSchemasRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _MetadataService_GetSchema_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetSchemaRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MetadataServiceServer).GetSchema(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/api.MetadataService/GetSchema",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MetadataServiceServer).GetSchema(ctx, req.(*GetSchemaRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 var _MetadataService_serviceDesc = grpc.ServiceDesc{
 	ServiceName: "api.MetadataService",
 	HandlerType: (*MetadataServiceServer)(nil),
@@ -2169,6 +4007,10 @@ var _MetadataService_serviceDesc = grpc.ServiceDesc{
 			MethodName: "CreateArtifact",
 			Handler:    _MetadataService_CreateArtifact_Handler,
 		},
+		{
+			MethodName: "BatchCreateArtifacts",
+			Handler:    _MetadataService_BatchCreateArtifacts_Handler,
+		},
 		{
 			MethodName: "GetArtifact",
 			Handler:    _MetadataService_GetArtifact_Handler,
@@ -2177,6 +4019,10 @@ var _MetadataService_serviceDesc = grpc.ServiceDesc{
 			MethodName: "ListArtifacts",
 			Handler:    _MetadataService_ListArtifacts_Handler,
 		},
+		{
+			MethodName: "UpdateArtifact",
+			Handler:    _MetadataService_UpdateArtifact_Handler,
+		},
 		{
 			MethodName: "DeleteArtifact",
 			Handler:    _MetadataService_DeleteArtifact_Handler,
@@ -2185,6 +4031,14 @@ var _MetadataService_serviceDesc = grpc.ServiceDesc{
 			MethodName: "CreateExecution",
 			Handler:    _MetadataService_CreateExecution_Handler,
 		},
+		{
+			MethodName: "BatchCreateExecutions",
+			Handler:    _MetadataService_BatchCreateExecutions_Handler,
+		},
+		{
+			MethodName: "RecordExecution",
+			Handler:    _MetadataService_RecordExecution_Handler,
+		},
 		{
 			MethodName: "GetExecution",
 			Handler:    _MetadataService_GetExecution_Handler,
@@ -2193,6 +4047,10 @@ var _MetadataService_serviceDesc = grpc.ServiceDesc{
 			MethodName: "ListExecutions",
 			Handler:    _MetadataService_ListExecutions_Handler,
 		},
+		{
+			MethodName: "UpdateExecution",
+			Handler:    _MetadataService_UpdateExecution_Handler,
+		},
 		{
 			MethodName: "DeleteExecution",
 			Handler:    _MetadataService_DeleteExecution_Handler,
@@ -2241,10 +4099,46 @@ var _MetadataService_serviceDesc = grpc.ServiceDesc{
 			MethodName: "CreateEvent",
 			Handler:    _MetadataService_CreateEvent_Handler,
 		},
+		{
+			MethodName: "BatchCreateEvents",
+			Handler:    _MetadataService_BatchCreateEvents_Handler,
+		},
 		{
 			MethodName: "ListEvents",
 			Handler:    _MetadataService_ListEvents_Handler,
 		},
+		{
+			MethodName: "CreateWorkspace",
+			Handler:    _MetadataService_CreateWorkspace_Handler,
+		},
+		{
+			MethodName: "GetWorkspace",
+			Handler:    _MetadataService_GetWorkspace_Handler,
+		},
+		{
+			MethodName: "ListWorkspaces",
+			Handler:    _MetadataService_ListWorkspaces_Handler,
+		},
+		{
+			MethodName: "DeleteWorkspace",
+			Handler:    _MetadataService_DeleteWorkspace_Handler,
+		},
+		{
+			MethodName: "GetLineage",
+			Handler:    _MetadataService_GetLineage_Handler,
+		},
+		{
+			MethodName: "RegisterSchema",
+			Handler:    _MetadataService_RegisterSchema_Handler,
+		},
+		{
+			MethodName: "ListSchemas",
+			Handler:    _MetadataService_ListSchemas_Handler,
+		},
+		{
+			MethodName: "GetSchema",
+			Handler:    _MetadataService_GetSchema_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "api/service.proto",
@@ -0,0 +1,110 @@
+// Copyright 2019 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package auth authenticates the callers of the API server, by bearer tokens,
+// JWTs or the headers of an authenticating proxy, and authorizes their
+// requests with a policy granting them access to workspaces and type
+// namespaces.
+package auth
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// Principal is an authenticated caller.
+type Principal struct {
+	User   string
+	Groups []string
+}
+
+func (p *Principal) String() string {
+	return fmt.Sprintf("user %q", p.User)
+}
+
+// System is the principal of the calls the server makes itself, e.g. to load
+// and reload schemas. Policies grant it all access. Authenticators never
+// return it, so it is told apart from callers by identity, not by name.
+var System = &Principal{User: "system:server"}
+
+type principalKey struct{}
+
+// NewContext returns a copy of ctx carrying p.
+func NewContext(ctx context.Context, p *Principal) context.Context {
+	return context.WithValue(ctx, principalKey{}, p)
+}
+
+// FromContext returns the principal carried by ctx, if any.
+func FromContext(ctx context.Context) (*Principal, bool) {
+	p, ok := ctx.Value(principalKey{}).(*Principal)
+	return p, ok
+}
+
+// Authenticator authenticates the caller of a gRPC request.
+type Authenticator interface {
+	// Authenticate returns the principal of the request of ctx, or nil if
+	// the request has none of the credentials it checks. It fails if the
+	// request has credentials it cannot verify.
+	Authenticate(ctx context.Context) (*Principal, error)
+}
+
+// UnaryServerInterceptor returns a gRPC unary server interceptor passing the
+// principal of each request to its handler, in its context. The principal is
+// the one of the first of authenticators to verify the credentials of the
+// request. Requests no authenticator verifies fail with codes.Unauthenticated.
+func UnaryServerInterceptor(authenticators ...Authenticator) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		p, err := authenticate(ctx, authenticators)
+		if err != nil {
+			return nil, err
+		}
+		return handler(NewContext(ctx, p), req)
+	}
+}
+
+func authenticate(ctx context.Context, authenticators []Authenticator) (*Principal, error) {
+	var failures []string
+	for _, a := range authenticators {
+		p, err := a.Authenticate(ctx)
+		if err != nil {
+			failures = append(failures, err.Error())
+			continue
+		}
+		if p != nil {
+			return p, nil
+		}
+	}
+	if len(failures) > 0 {
+		return nil, status.Errorf(codes.Unauthenticated, "invalid credentials: %s", strings.Join(failures, "; "))
+	}
+	return nil, status.Error(codes.Unauthenticated, "missing credentials")
+}
+
+// bearerToken returns the bearer token in the authorization metadata of the
+// request of ctx, if any.
+func bearerToken(ctx context.Context) string {
+	md, _ := metadata.FromIncomingContext(ctx)
+	for _, v := range md.Get("authorization") {
+		if len(v) > len("bearer ") && strings.EqualFold(v[:len("bearer ")], "bearer ") {
+			return strings.TrimSpace(v[len("bearer "):])
+		}
+	}
+	return ""
+}
@@ -0,0 +1,115 @@
+// Copyright 2019 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package auth
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// fakeAuthenticator returns its principal and error.
+type fakeAuthenticator struct {
+	principal *Principal
+	err       error
+}
+
+func (a *fakeAuthenticator) Authenticate(ctx context.Context) (*Principal, error) {
+	return a.principal, a.err
+}
+
+// withAuthorization returns a context of an incoming request with an
+// authorization header.
+func withAuthorization(value string) context.Context {
+	return metadata.NewIncomingContext(context.Background(), metadata.Pairs("authorization", value))
+}
+
+func TestUnaryServerInterceptor(t *testing.T) {
+	alice := &Principal{User: "alice"}
+	bob := &Principal{User: "bob"}
+	none := &fakeAuthenticator{}
+	invalid := &fakeAuthenticator{err: errors.New("bad token")}
+	tests := []struct {
+		name           string
+		authenticators []Authenticator
+		want           *Principal
+		wantErr        string
+	}{
+		{"first verifying one wins", []Authenticator{none, &fakeAuthenticator{principal: alice}, &fakeAuthenticator{principal: bob}}, alice, ""},
+		{"failures of others are ignored", []Authenticator{invalid, &fakeAuthenticator{principal: bob}}, bob, ""},
+		{"no credentials", []Authenticator{none, none}, nil, "missing credentials"},
+		{"invalid credentials", []Authenticator{none, invalid}, nil, "invalid credentials: bad token"},
+	}
+	for _, test := range tests {
+		var got *Principal
+		handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+			got, _ = FromContext(ctx)
+			return "response", nil
+		}
+		resp, err := UnaryServerInterceptor(test.authenticators...)(context.Background(), "request", &grpc.UnaryServerInfo{}, handler)
+		if test.wantErr != "" {
+			if status.Code(err) != codes.Unauthenticated || !strings.Contains(err.Error(), test.wantErr) {
+				t.Errorf("%s: got error %v; want Unauthenticated error containing %q", test.name, err, test.wantErr)
+			}
+			if got != nil {
+				t.Errorf("%s: handler called with principal %v; want no call", test.name, got)
+			}
+			continue
+		}
+		if err != nil || resp != "response" {
+			t.Errorf("%s: got %v, %v; want response, nil", test.name, resp, err)
+		}
+		if got != test.want {
+			t.Errorf("%s: handler got principal %v; want %v", test.name, got, test.want)
+		}
+	}
+}
+
+func TestBearerToken(t *testing.T) {
+	tests := []struct {
+		ctx  context.Context
+		want string
+	}{
+		{context.Background(), ""},
+		{withAuthorization("Bearer abc"), "abc"},
+		{withAuthorization("bearer  abc "), "abc"},
+		{withAuthorization("Basic YWxpY2U6cGFzcw=="), ""},
+		{withAuthorization("Bearer "), ""},
+	}
+	for _, test := range tests {
+		md, _ := metadata.FromIncomingContext(test.ctx)
+		if got := bearerToken(test.ctx); got != test.want {
+			t.Errorf("bearerToken(%v) = %q; want %q", md, got, test.want)
+		}
+	}
+}
+
+func TestContext(t *testing.T) {
+	if p, ok := FromContext(context.Background()); ok {
+		t.Errorf("FromContext(context.Background()) = %v, true; want nil, false", p)
+	}
+	want := &Principal{User: "alice", Groups: []string{"ml-team"}}
+	got, ok := FromContext(NewContext(context.Background(), want))
+	if !ok || !reflect.DeepEqual(got, want) {
+		t.Errorf("FromContext(NewContext(ctx, %v)) = %v, %t; want %v, true", want, got, ok, want)
+	}
+}
@@ -0,0 +1,134 @@
+// Copyright 2019 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package auth
+
+import (
+	"context"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/textproto"
+	"strings"
+
+	"github.com/grpc-ecosystem/grpc-gateway/runtime"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+)
+
+// HeaderAuthenticator authenticates requests by the user and group headers
+// set by an authenticating proxy, in the manner of the request header
+// authentication of Kubernetes. The proxy is trusted by the common name of
+// the client certificate it connects with, so client certificates must be
+// verified by the server.
+type HeaderAuthenticator struct {
+	userHeader  string
+	groupHeader string
+	proxyNames  map[string]bool
+}
+
+// NewHeaderAuthenticator returns a HeaderAuthenticator trusting the user
+// header, e.g. X-Remote-User, and the optional group header, e.g.
+// X-Remote-Group, of the peers whose client certificates have one of
+// proxyNames as their common name.
+func NewHeaderAuthenticator(userHeader, groupHeader string, proxyNames []string) (*HeaderAuthenticator, error) {
+	if userHeader == "" {
+		return nil, errors.New("no user header")
+	}
+	if len(proxyNames) == 0 {
+		return nil, errors.New("no trusted proxy name")
+	}
+	a := &HeaderAuthenticator{
+		userHeader:  textproto.CanonicalMIMEHeaderKey(userHeader),
+		groupHeader: textproto.CanonicalMIMEHeaderKey(groupHeader),
+		proxyNames:  make(map[string]bool),
+	}
+	for _, name := range proxyNames {
+		a.proxyNames[name] = true
+	}
+	return a, nil
+}
+
+// trusted reports whether certs, the verified certificate chain of a peer,
+// is the one of a trusted proxy.
+func (a *HeaderAuthenticator) trusted(certs []*x509.Certificate) bool {
+	return len(certs) > 0 && a.proxyNames[certs[0].Subject.CommonName]
+}
+
+// Authenticate returns the principal of the user header of the request, if
+// any, failing unless the request comes from a trusted proxy.
+func (a *HeaderAuthenticator) Authenticate(ctx context.Context) (*Principal, error) {
+	md, _ := metadata.FromIncomingContext(ctx)
+	users := md.Get(a.userHeader)
+	if len(users) == 0 {
+		return nil, nil
+	}
+	var certs []*x509.Certificate
+	if p, ok := peer.FromContext(ctx); ok {
+		if info, ok := p.AuthInfo.(credentials.TLSInfo); ok {
+			certs = info.State.PeerCertificates
+		}
+	}
+	if !a.trusted(certs) {
+		return nil, fmt.Errorf("%s header from an untrusted peer", a.userHeader)
+	}
+	if len(users) != 1 || users[0] == "" {
+		return nil, fmt.Errorf("expecting a single %s header", a.userHeader)
+	}
+
+	p := &Principal{User: users[0]}
+	if a.groupHeader != "" {
+		for _, v := range md.Get(a.groupHeader) {
+			for _, g := range strings.Split(v, ",") {
+				if g = strings.TrimSpace(g); g != "" {
+					p.Groups = append(p.Groups, g)
+				}
+			}
+		}
+	}
+	return p, nil
+}
+
+// HeaderMatcher is a grpc-gateway header matcher forwarding the user and
+// group headers to the gRPC endpoint, which trusts them if the gateway is a
+// trusted proxy. Other headers are matched by runtime.DefaultHeaderMatcher.
+func (a *HeaderAuthenticator) HeaderMatcher(key string) (string, bool) {
+	key = textproto.CanonicalMIMEHeaderKey(key)
+	if key == a.userHeader || (a.groupHeader != "" && key == a.groupHeader) {
+		return key, true
+	}
+	return runtime.DefaultHeaderMatcher(key)
+}
+
+// HTTPHandler returns a handler removing the user and group headers of the
+// requests that do not come from a trusted proxy before passing them to h, so
+// that a gateway trusted as a proxy only forwards the headers of trusted
+// proxies.
+func (a *HeaderAuthenticator) HTTPHandler(h http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.TLS == nil || !a.trusted(r.TLS.PeerCertificates) {
+			for _, header := range []string{a.userHeader, a.groupHeader} {
+				if header != "" {
+					r.Header.Del(header)
+					// The gateway forwards the headers with this
+					// prefix as metadata too.
+					r.Header.Del(runtime.MetadataHeaderPrefix + header)
+				}
+			}
+		}
+		h.ServeHTTP(w, r)
+	})
+}
@@ -0,0 +1,145 @@
+// Copyright 2019 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package auth
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+)
+
+// fromPeer returns a context of an incoming request with metadata kv from a
+// peer authenticated by a client certificate of commonName, if any.
+func fromPeer(commonName string, kv ...string) context.Context {
+	ctx := metadata.NewIncomingContext(context.Background(), metadata.Pairs(kv...))
+	p := &peer.Peer{}
+	if commonName != "" {
+		p.AuthInfo = credentials.TLSInfo{State: tls.ConnectionState{
+			PeerCertificates: []*x509.Certificate{{Subject: pkix.Name{CommonName: commonName}}},
+		}}
+	}
+	return peer.NewContext(ctx, p)
+}
+
+func TestHeaderAuthenticator(t *testing.T) {
+	a, err := NewHeaderAuthenticator("x-remote-user", "x-remote-group", []string{"proxy"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	tests := []struct {
+		name    string
+		ctx     context.Context
+		want    *Principal
+		wantErr bool
+	}{
+		{"trusted proxy", fromPeer("proxy", "x-remote-user", "alice"), &Principal{User: "alice"}, false},
+		{"groups", fromPeer("proxy", "x-remote-user", "alice", "x-remote-group", "ml-team, admins", "x-remote-group", "dev"), &Principal{User: "alice", Groups: []string{"ml-team", "admins", "dev"}}, false},
+		{"no header", fromPeer("client"), nil, false},
+		{"untrusted peer", fromPeer("client", "x-remote-user", "alice"), nil, true},
+		{"no client certificate", fromPeer("", "x-remote-user", "alice"), nil, true},
+		{"several users", fromPeer("proxy", "x-remote-user", "alice", "x-remote-user", "bob"), nil, true},
+		{"empty user", fromPeer("proxy", "x-remote-user", ""), nil, true},
+	}
+	for _, test := range tests {
+		got, err := a.Authenticate(test.ctx)
+		if gotErr := err != nil; gotErr != test.wantErr || !reflect.DeepEqual(got, test.want) {
+			t.Errorf("%s: Authenticate() = %v, %v; want %v, error: %t", test.name, got, err, test.want, test.wantErr)
+		}
+	}
+
+	if _, err := NewHeaderAuthenticator("", "", []string{"proxy"}); err == nil {
+		t.Error("NewHeaderAuthenticator without a user header succeeded; want error")
+	}
+	if _, err := NewHeaderAuthenticator("X-Remote-User", "", nil); err == nil {
+		t.Error("NewHeaderAuthenticator without proxy names succeeded; want error")
+	}
+}
+
+func TestHeaderMatcher(t *testing.T) {
+	a, err := NewHeaderAuthenticator("X-Remote-User", "X-Remote-Group", []string{"proxy"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	tests := []struct {
+		key    string
+		want   string
+		wantOK bool
+	}{
+		{"x-remote-user", "X-Remote-User", true},
+		{"X-Remote-Group", "X-Remote-Group", true},
+		{"Authorization", "grpcgateway-Authorization", true},
+		{"Grpc-Metadata-Foo", "Foo", true},
+		{"X-Other", "", false},
+	}
+	for _, test := range tests {
+		if got, ok := a.HeaderMatcher(test.key); got != test.want || ok != test.wantOK {
+			t.Errorf("HeaderMatcher(%q) = %q, %t; want %q, %t", test.key, got, ok, test.want, test.wantOK)
+		}
+	}
+}
+
+func TestHTTPHandler(t *testing.T) {
+	a, err := NewHeaderAuthenticator("X-Remote-User", "X-Remote-Group", []string{"proxy"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	var got http.Header
+	h := a.HTTPHandler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		got = r.Header
+	}))
+	tests := []struct {
+		name       string
+		commonName string
+		want       http.Header
+	}{
+		{"trusted proxy", "proxy", http.Header{
+			"X-Remote-User":                {"alice"},
+			"X-Remote-Group":               {"admins"},
+			"Grpc-Metadata-X-Remote-User":  {"bob"},
+			"Grpc-Metadata-X-Remote-Group": {"dev"},
+			"X-Other":                      {"other"},
+		}},
+		{"untrusted client", "client", http.Header{"X-Other": {"other"}}},
+		{"no client certificate", "", http.Header{"X-Other": {"other"}}},
+	}
+	for _, test := range tests {
+		r := httptest.NewRequest("GET", "/", nil)
+		r.Header = http.Header{
+			"X-Remote-User":                {"alice"},
+			"X-Remote-Group":               {"admins"},
+			"Grpc-Metadata-X-Remote-User":  {"bob"},
+			"Grpc-Metadata-X-Remote-Group": {"dev"},
+			"X-Other":                      {"other"},
+		}
+		if test.commonName != "" {
+			r.TLS = &tls.ConnectionState{
+				PeerCertificates: []*x509.Certificate{{Subject: pkix.Name{CommonName: test.commonName}}},
+			}
+		}
+		h.ServeHTTP(httptest.NewRecorder(), r)
+		if !reflect.DeepEqual(got, test.want) {
+			t.Errorf("%s: handler got headers %v; want %v", test.name, got, test.want)
+		}
+	}
+}
@@ -0,0 +1,320 @@
+// Copyright 2019 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package auth
+
+import (
+	"bytes"
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rsa"
+	_ "crypto/sha256" // Registers the hashes of the RS256, PS256 and ES256 algorithms.
+	_ "crypto/sha512" // Registers the hashes of the 384 and 512 variants.
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"math/big"
+	"time"
+)
+
+// JWTConfig configures the verification of JWTs.
+type JWTConfig struct {
+	// JWKSFile holds the JSON Web Key Set of the keys signing the tokens.
+	JWKSFile string
+	// Issuer and Audience, if set, must match the iss and aud claims of
+	// tokens.
+	Issuer   string
+	Audience string
+	// UserClaim names the claim holding the user name, sub if empty.
+	UserClaim string
+	// GroupsClaim names the claim holding the groups of the user, a string
+	// or a list of strings, if any.
+	GroupsClaim string
+}
+
+// JWTAuthenticator authenticates requests by JWT bearer tokens, signed by
+// one of the keys of a JSON Web Key Set with RS256, RS384, RS512, PS256,
+// PS384, PS512, ES256, ES384 or ES512. Tokens must carry an exp claim.
+type JWTAuthenticator struct {
+	config JWTConfig
+	keys   []*jsonWebKey
+	now    func() time.Time
+}
+
+// jwtLeeway is the tolerated clock skew between the server and token issuers.
+const jwtLeeway = time.Minute
+
+// jwtHashes holds the hash of each supported algorithm.
+var jwtHashes = map[string]crypto.Hash{
+	"RS256": crypto.SHA256, "RS384": crypto.SHA384, "RS512": crypto.SHA512,
+	"PS256": crypto.SHA256, "PS384": crypto.SHA384, "PS512": crypto.SHA512,
+	"ES256": crypto.SHA256, "ES384": crypto.SHA384, "ES512": crypto.SHA512,
+}
+
+// esAlgorithms holds the ES algorithm of each curve.
+var esAlgorithms = map[string]string{"P-256": "ES256", "P-384": "ES384", "P-521": "ES512"}
+
+type jsonWebKey struct {
+	kid string
+	alg string
+	key crypto.PublicKey
+}
+
+// NewJWTAuthenticator returns a JWTAuthenticator verifying tokens with the
+// keys of config.JWKSFile.
+func NewJWTAuthenticator(config JWTConfig) (*JWTAuthenticator, error) {
+	data, err := ioutil.ReadFile(config.JWKSFile)
+	if err != nil {
+		return nil, err
+	}
+	keys, err := parseJWKS(data)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse JWKS file %s: %v", config.JWKSFile, err)
+	}
+	if config.UserClaim == "" {
+		config.UserClaim = "sub"
+	}
+	return &JWTAuthenticator{config: config, keys: keys, now: time.Now}, nil
+}
+
+// parseJWKS returns the RSA and EC signing keys of a JSON Web Key Set.
+func parseJWKS(data []byte) ([]*jsonWebKey, error) {
+	var jwks struct {
+		Keys []struct {
+			Kty string `json:"kty"`
+			Kid string `json:"kid"`
+			Alg string `json:"alg"`
+			Use string `json:"use"`
+			N   string `json:"n"`
+			E   string `json:"e"`
+			Crv string `json:"crv"`
+			X   string `json:"x"`
+			Y   string `json:"y"`
+		} `json:"keys"`
+	}
+	if err := json.Unmarshal(data, &jwks); err != nil {
+		return nil, err
+	}
+	var keys []*jsonWebKey
+	for i, k := range jwks.Keys {
+		if k.Use != "" && k.Use != "sig" {
+			continue
+		}
+		key := &jsonWebKey{kid: k.Kid, alg: k.Alg}
+		switch k.Kty {
+		case "RSA":
+			n, err := base64BigInt(k.N)
+			if err != nil {
+				return nil, fmt.Errorf("key %d: invalid n: %v", i, err)
+			}
+			e, err := base64BigInt(k.E)
+			if err != nil || !e.IsInt64() || e.Int64() > 1<<31-1 {
+				return nil, fmt.Errorf("key %d: invalid e", i)
+			}
+			key.key = &rsa.PublicKey{N: n, E: int(e.Int64())}
+		case "EC":
+			var curve elliptic.Curve
+			switch k.Crv {
+			case "P-256":
+				curve = elliptic.P256()
+			case "P-384":
+				curve = elliptic.P384()
+			case "P-521":
+				curve = elliptic.P521()
+			default:
+				return nil, fmt.Errorf("key %d: unsupported curve %q", i, k.Crv)
+			}
+			x, err := base64BigInt(k.X)
+			if err != nil {
+				return nil, fmt.Errorf("key %d: invalid x: %v", i, err)
+			}
+			y, err := base64BigInt(k.Y)
+			if err != nil {
+				return nil, fmt.Errorf("key %d: invalid y: %v", i, err)
+			}
+			if !curve.IsOnCurve(x, y) {
+				return nil, fmt.Errorf("key %d: point not on curve %s", i, k.Crv)
+			}
+			key.key = &ecdsa.PublicKey{Curve: curve, X: x, Y: y}
+		default:
+			// Other keys, e.g. symmetric ones, cannot verify tokens
+			// signed by their issuer only.
+			continue
+		}
+		keys = append(keys, key)
+	}
+	if len(keys) == 0 {
+		return nil, errors.New("no RSA or EC signing key")
+	}
+	return keys, nil
+}
+
+func base64BigInt(s string) (*big.Int, error) {
+	b, err := base64.RawURLEncoding.DecodeString(s)
+	if err != nil {
+		return nil, err
+	}
+	if len(b) == 0 {
+		return nil, errors.New("empty value")
+	}
+	return new(big.Int).SetBytes(b), nil
+}
+
+// Authenticate returns the principal of the JWT bearer token of the request,
+// if any.
+func (a *JWTAuthenticator) Authenticate(ctx context.Context) (*Principal, error) {
+	token := bearerToken(ctx)
+	if token == "" {
+		return nil, nil
+	}
+	claims, err := a.verify(token)
+	if err != nil {
+		return nil, err
+	}
+
+	user, _ := claims[a.config.UserClaim].(string)
+	if user == "" {
+		return nil, fmt.Errorf("JWT has no %s claim", a.config.UserClaim)
+	}
+	p := &Principal{User: user}
+	if a.config.GroupsClaim != "" {
+		switch groups := claims[a.config.GroupsClaim].(type) {
+		case string:
+			p.Groups = []string{groups}
+		case []interface{}:
+			for _, g := range groups {
+				if g, ok := g.(string); ok {
+					p.Groups = append(p.Groups, g)
+				}
+			}
+		}
+	}
+	return p, nil
+}
+
+// verify returns the claims of token once its signature and time, issuer and
+// audience claims are verified.
+func (a *JWTAuthenticator) verify(token string) (map[string]interface{}, error) {
+	parts := bytes.Split([]byte(token), []byte("."))
+	if len(parts) != 3 {
+		return nil, errors.New("malformed JWT")
+	}
+	var header struct {
+		Alg string `json:"alg"`
+		Kid string `json:"kid"`
+	}
+	if err := decodeSegment(parts[0], &header); err != nil {
+		return nil, fmt.Errorf("malformed JWT header: %v", err)
+	}
+	hash, ok := jwtHashes[header.Alg]
+	if !ok {
+		return nil, fmt.Errorf("unsupported JWT algorithm %q", header.Alg)
+	}
+	signature, err := base64.RawURLEncoding.DecodeString(string(parts[2]))
+	if err != nil {
+		return nil, fmt.Errorf("malformed JWT signature: %v", err)
+	}
+	h := hash.New()
+	h.Write(parts[0])
+	h.Write([]byte("."))
+	h.Write(parts[1])
+	digest := h.Sum(nil)
+	verified := false
+	for _, k := range a.keys {
+		if (header.Kid != "" && k.kid != header.Kid) || (k.alg != "" && k.alg != header.Alg) {
+			continue
+		}
+		if verifySignature(header.Alg, hash, k.key, digest, signature) {
+			verified = true
+			break
+		}
+	}
+	if !verified {
+		return nil, errors.New("invalid JWT signature")
+	}
+
+	var claims map[string]interface{}
+	if err := decodeSegment(parts[1], &claims); err != nil {
+		return nil, fmt.Errorf("malformed JWT claims: %v", err)
+	}
+	now := a.now()
+	exp, ok := claims["exp"].(float64)
+	if !ok {
+		return nil, errors.New("JWT has no exp claim")
+	}
+	if now.After(time.Unix(int64(exp), 0).Add(jwtLeeway)) {
+		return nil, errors.New("JWT has expired")
+	}
+	if nbf, ok := claims["nbf"].(float64); ok && now.Add(jwtLeeway).Before(time.Unix(int64(nbf), 0)) {
+		return nil, errors.New("JWT is not valid yet")
+	}
+	if a.config.Issuer != "" && claims["iss"] != a.config.Issuer {
+		return nil, fmt.Errorf("JWT issuer %v is not %q", claims["iss"], a.config.Issuer)
+	}
+	if a.config.Audience != "" && !hasAudience(claims["aud"], a.config.Audience) {
+		return nil, fmt.Errorf("JWT audience %v does not include %q", claims["aud"], a.config.Audience)
+	}
+	return claims, nil
+}
+
+func decodeSegment(segment []byte, v interface{}) error {
+	b, err := base64.RawURLEncoding.DecodeString(string(segment))
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(b, v)
+}
+
+// verifySignature reports whether signature is a valid signature of digest by
+// key with algorithm alg.
+func verifySignature(alg string, hash crypto.Hash, key crypto.PublicKey, digest, signature []byte) bool {
+	switch key := key.(type) {
+	case *rsa.PublicKey:
+		switch alg[:2] {
+		case "RS":
+			return rsa.VerifyPKCS1v15(key, hash, digest, signature) == nil
+		case "PS":
+			return rsa.VerifyPSS(key, hash, digest, signature, &rsa.PSSOptions{SaltLength: rsa.PSSSaltLengthEqualsHash}) == nil
+		}
+	case *ecdsa.PublicKey:
+		size := (key.Curve.Params().BitSize + 7) / 8
+		if alg != esAlgorithms[key.Curve.Params().Name] || len(signature) != 2*size {
+			return false
+		}
+		r := new(big.Int).SetBytes(signature[:size])
+		s := new(big.Int).SetBytes(signature[size:])
+		return ecdsa.Verify(key, digest, r, s)
+	}
+	return false
+}
+
+// hasAudience reports whether the aud claim, a string or a list of strings,
+// includes audience.
+func hasAudience(aud interface{}, audience string) bool {
+	switch aud := aud.(type) {
+	case string:
+		return aud == audience
+	case []interface{}:
+		for _, a := range aud {
+			if a == audience {
+				return true
+			}
+		}
+	}
+	return false
+}
@@ -0,0 +1,208 @@
+// Copyright 2019 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package auth
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"io/ioutil"
+	"math/big"
+	"os"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+var testNow = time.Unix(1500000000, 0)
+
+func encodeSegment(t *testing.T, v interface{}) string {
+	t.Helper()
+	b, err := json.Marshal(v)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return base64.RawURLEncoding.EncodeToString(b)
+}
+
+// signJWT returns a token of header and claims signed by key with the
+// algorithm of header.
+func signJWT(t *testing.T, key crypto.Signer, header, claims map[string]interface{}) string {
+	t.Helper()
+	signed := encodeSegment(t, header) + "." + encodeSegment(t, claims)
+	alg, _ := header["alg"].(string)
+	hash, ok := jwtHashes[alg]
+	if !ok {
+		return signed + ".c2lnbmF0dXJl"
+	}
+	h := hash.New()
+	h.Write([]byte(signed))
+	digest := h.Sum(nil)
+	var signature []byte
+	var err error
+	switch k := key.(type) {
+	case *rsa.PrivateKey:
+		if alg[:2] == "PS" {
+			signature, err = rsa.SignPSS(rand.Reader, k, hash, digest, &rsa.PSSOptions{SaltLength: rsa.PSSSaltLengthEqualsHash})
+		} else {
+			signature, err = rsa.SignPKCS1v15(rand.Reader, k, hash, digest)
+		}
+	case *ecdsa.PrivateKey:
+		var r, s *big.Int
+		r, s, err = ecdsa.Sign(rand.Reader, k, digest)
+		size := (k.Curve.Params().BitSize + 7) / 8
+		signature = make([]byte, 2*size)
+		rb, sb := r.Bytes(), s.Bytes()
+		copy(signature[size-len(rb):size], rb)
+		copy(signature[2*size-len(sb):], sb)
+	}
+	if err != nil {
+		t.Fatal(err)
+	}
+	return signed + "." + base64.RawURLEncoding.EncodeToString(signature)
+}
+
+func base64Int(i *big.Int) string {
+	return base64.RawURLEncoding.EncodeToString(i.Bytes())
+}
+
+func TestJWTAuthenticator(t *testing.T) {
+	rsaKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ecKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	otherKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+	jwks := map[string]interface{}{"keys": []map[string]string{
+		{"kty": "RSA", "kid": "rsa", "n": base64Int(rsaKey.N), "e": base64Int(big.NewInt(int64(rsaKey.E)))},
+		{"kty": "EC", "kid": "ec", "crv": "P-256", "x": base64Int(ecKey.X), "y": base64Int(ecKey.Y)},
+		{"kty": "oct", "kid": "hmac", "k": "c2VjcmV0"},
+	}}
+	jwksJSON, err := json.Marshal(jwks)
+	if err != nil {
+		t.Fatal(err)
+	}
+	dir, err := ioutil.TempDir("", "auth")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	a, err := NewJWTAuthenticator(JWTConfig{
+		JWKSFile:    writeTestFile(t, dir, "jwks.json", string(jwksJSON)),
+		Issuer:      "https://issuer.example.com",
+		Audience:    "metadata",
+		GroupsClaim: "groups",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	a.now = func() time.Time { return testNow }
+
+	claims := func(changes map[string]interface{}) map[string]interface{} {
+		c := map[string]interface{}{
+			"iss":    "https://issuer.example.com",
+			"aud":    "metadata",
+			"sub":    "alice",
+			"exp":    testNow.Add(time.Hour).Unix(),
+			"groups": []string{"ml-team", "admins"},
+		}
+		for k, v := range changes {
+			if v == nil {
+				delete(c, k)
+			} else {
+				c[k] = v
+			}
+		}
+		return c
+	}
+	alice := &Principal{User: "alice", Groups: []string{"ml-team", "admins"}}
+	rs256 := map[string]interface{}{"alg": "RS256", "kid": "rsa"}
+
+	tests := []struct {
+		name  string
+		token string
+		want  *Principal
+	}{
+		{"RS256", signJWT(t, rsaKey, rs256, claims(nil)), alice},
+		{"PS384", signJWT(t, rsaKey, map[string]interface{}{"alg": "PS384"}, claims(nil)), alice},
+		{"ES256", signJWT(t, ecKey, map[string]interface{}{"alg": "ES256", "kid": "ec"}, claims(nil)), alice},
+		{"audience list", signJWT(t, rsaKey, rs256, claims(map[string]interface{}{"aud": []string{"other", "metadata"}})), alice},
+		{"single group", signJWT(t, rsaKey, rs256, claims(map[string]interface{}{"groups": "ml-team"})), &Principal{User: "alice", Groups: []string{"ml-team"}}},
+		{"expired within leeway", signJWT(t, rsaKey, rs256, claims(map[string]interface{}{"exp": testNow.Add(-30 * time.Second).Unix()})), alice},
+		{"expired", signJWT(t, rsaKey, rs256, claims(map[string]interface{}{"exp": testNow.Add(-time.Hour).Unix()})), nil},
+		{"no exp", signJWT(t, rsaKey, rs256, claims(map[string]interface{}{"exp": nil})), nil},
+		{"not valid yet", signJWT(t, rsaKey, rs256, claims(map[string]interface{}{"nbf": testNow.Add(time.Hour).Unix()})), nil},
+		{"other issuer", signJWT(t, rsaKey, rs256, claims(map[string]interface{}{"iss": "https://evil.example.com"})), nil},
+		{"other audience", signJWT(t, rsaKey, rs256, claims(map[string]interface{}{"aud": "other"})), nil},
+		{"no subject", signJWT(t, rsaKey, rs256, claims(map[string]interface{}{"sub": nil})), nil},
+		{"unknown key", signJWT(t, otherKey, map[string]interface{}{"alg": "RS256"}, claims(nil)), nil},
+		{"key of another id", signJWT(t, rsaKey, map[string]interface{}{"alg": "RS256", "kid": "ec"}, claims(nil)), nil},
+		{"curve of another algorithm", signJWT(t, ecKey, map[string]interface{}{"alg": "ES384", "kid": "ec"}, claims(nil)), nil},
+		{"none algorithm", signJWT(t, rsaKey, map[string]interface{}{"alg": "none"}, claims(nil)), nil},
+		{"symmetric algorithm", signJWT(t, rsaKey, map[string]interface{}{"alg": "HS256", "kid": "hmac"}, claims(nil)), nil},
+		{"not a JWT", "alice-token", nil},
+	}
+	for _, test := range tests {
+		got, err := a.Authenticate(withAuthorization("Bearer " + test.token))
+		if test.want == nil {
+			if err == nil {
+				t.Errorf("%s: Authenticate() = %v, nil; want error", test.name, got)
+			}
+			continue
+		}
+		if err != nil || !reflect.DeepEqual(got, test.want) {
+			t.Errorf("%s: Authenticate() = %v, %v; want %v, nil", test.name, got, err, test.want)
+		}
+	}
+
+	// A token whose claims were changed after signing is rejected.
+	token := signJWT(t, rsaKey, rs256, claims(nil))
+	header := encodeSegment(t, rs256)
+	forged := header + "." + encodeSegment(t, claims(map[string]interface{}{"sub": "admin"})) + token[strings.LastIndex(token, "."):]
+	if got, err := a.Authenticate(withAuthorization("Bearer " + forged)); err == nil {
+		t.Errorf("Authenticate() of a forged token = %v, nil; want error", got)
+	}
+}
+
+func TestNewJWTAuthenticatorErrors(t *testing.T) {
+	dir, err := ioutil.TempDir("", "auth")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	for _, jwks := range []string{
+		`not json`,
+		`{"keys": []}`,
+		`{"keys": [{"kty": "oct", "k": "c2VjcmV0"}]}`,
+		`{"keys": [{"kty": "RSA", "n": "", "e": "AQAB"}]}`,
+		`{"keys": [{"kty": "EC", "crv": "P-256", "x": "AQ", "y": "AQ"}]}`,
+		`{"keys": [{"kty": "EC", "crv": "secp256k1", "x": "AQ", "y": "AQ"}]}`,
+	} {
+		if _, err := NewJWTAuthenticator(JWTConfig{JWKSFile: writeTestFile(t, dir, "jwks.json", jwks)}); err == nil {
+			t.Errorf("NewJWTAuthenticator of %s succeeded; want error", jwks)
+		}
+	}
+}
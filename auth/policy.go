@@ -0,0 +1,164 @@
+// Copyright 2019 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package auth
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"strings"
+
+	"github.com/kubeflow/metadata/service"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// Binding grants a role to principals on workspaces and type namespaces.
+type Binding struct {
+	// Principals are `user:{name}`, `group:{name}` or `*` for any
+	// authenticated principal.
+	Principals []string `json:"principals"`
+	// Role is read, write or admin, see service.Access.
+	Role string `json:"role"`
+	// Workspaces and Namespaces are names, or prefixes of names followed by
+	// `*`. Records without a workspace belong to the default workspace
+	// `__kf_default_workspace`.
+	Workspaces []string `json:"workspaces"`
+	Namespaces []string `json:"namespaces"`
+}
+
+// Policy grants principals access to workspaces and type namespaces by its
+// bindings. A principal has the highest access granted by the bindings
+// matching it and the workspace or namespace of a request. Policy implements
+// service.Authorizer.
+type Policy struct {
+	bindings []binding
+}
+
+type binding struct {
+	Binding
+	access service.Access
+}
+
+var roles = map[string]service.Access{
+	"read":  service.ReadAccess,
+	"write": service.WriteAccess,
+	"admin": service.AdminAccess,
+}
+
+// NewPolicy returns the policy of bindings.
+func NewPolicy(bindings []Binding) (*Policy, error) {
+	p := &Policy{}
+	for i, b := range bindings {
+		access, ok := roles[b.Role]
+		if !ok {
+			return nil, fmt.Errorf("binding %d: unknown role %q: please choose from [read, write, admin]", i, b.Role)
+		}
+		for _, principal := range b.Principals {
+			if principal != "*" && !strings.HasPrefix(principal, "user:") && !strings.HasPrefix(principal, "group:") {
+				return nil, fmt.Errorf("binding %d: malformed principal %q: must be user:{name}, group:{name} or *", i, principal)
+			}
+		}
+		p.bindings = append(p.bindings, binding{Binding: b, access: access})
+	}
+	return p, nil
+}
+
+// LoadPolicy returns the policy of a JSON file holding its bindings, e.g.
+//
+//	{"bindings": [{
+//	  "principals": ["group:ml-team"],
+//	  "role": "write",
+//	  "workspaces": ["ml-team-*"],
+//	  "namespaces": ["kubeflow.org/alpha"]
+//	}]}
+func LoadPolicy(file string) (*Policy, error) {
+	data, err := ioutil.ReadFile(file)
+	if err != nil {
+		return nil, err
+	}
+	var policy struct {
+		Bindings []Binding `json:"bindings"`
+	}
+	if err := json.Unmarshal(data, &policy); err != nil {
+		return nil, fmt.Errorf("failed to parse policy file %s: %v", file, err)
+	}
+	p, err := NewPolicy(policy.Bindings)
+	if err != nil {
+		return nil, fmt.Errorf("invalid policy file %s: %v", file, err)
+	}
+	return p, nil
+}
+
+// AuthorizeWorkspace fails with codes.PermissionDenied unless the principal
+// of ctx has access to workspace. Contexts without a principal fail with
+// codes.Unauthenticated, and those of System are allowed.
+func (p *Policy) AuthorizeWorkspace(ctx context.Context, access service.Access, workspace string) error {
+	return p.authorize(ctx, access, "workspace", workspace, func(b *binding) []string { return b.Workspaces })
+}
+
+// AuthorizeNamespace fails with codes.PermissionDenied unless the principal
+// of ctx has access to the types of namespace. The empty namespace, standing
+// for all namespaces, only matches the pattern `*`.
+func (p *Policy) AuthorizeNamespace(ctx context.Context, access service.Access, namespace string) error {
+	return p.authorize(ctx, access, "type namespace", namespace, func(b *binding) []string { return b.Namespaces })
+}
+
+func (p *Policy) authorize(ctx context.Context, access service.Access, kind, name string, patterns func(*binding) []string) error {
+	principal, ok := FromContext(ctx)
+	if !ok || principal == nil {
+		return status.Error(codes.Unauthenticated, "missing principal")
+	}
+	if principal == System {
+		return nil
+	}
+	for i := range p.bindings {
+		b := &p.bindings[i]
+		if b.access >= access && b.matches(principal) && matchesAny(patterns(b), name) {
+			return nil
+		}
+	}
+	if name == "" {
+		return status.Errorf(codes.PermissionDenied, "%s has no %s access to all %ss", principal, access, kind)
+	}
+	return status.Errorf(codes.PermissionDenied, "%s has no %s access to %s %q", principal, access, kind, name)
+}
+
+// matches reports whether b applies to principal.
+func (b *binding) matches(principal *Principal) bool {
+	for _, bound := range b.Principals {
+		if bound == "*" || bound == "user:"+principal.User {
+			return true
+		}
+		for _, g := range principal.Groups {
+			if bound == "group:"+g {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// matchesAny reports whether name matches one of patterns, i.e. equals it
+// or, for patterns ending with `*`, starts with the rest of it.
+func matchesAny(patterns []string, name string) bool {
+	for _, pattern := range patterns {
+		if pattern == name || strings.HasSuffix(pattern, "*") && strings.HasPrefix(name, strings.TrimSuffix(pattern, "*")) {
+			return true
+		}
+	}
+	return false
+}
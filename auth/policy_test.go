@@ -0,0 +1,110 @@
+// Copyright 2019 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package auth
+
+import (
+	"context"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/kubeflow/metadata/service"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestPolicy(t *testing.T) {
+	dir, err := ioutil.TempDir("", "auth")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	p, err := LoadPolicy(writeTestFile(t, dir, "policy.json", `{"bindings": [
+		{"principals": ["*"], "role": "read", "workspaces": ["public"], "namespaces": ["kubeflow.org/*"]},
+		{"principals": ["group:ml-team"], "role": "write", "workspaces": ["ml-*"], "namespaces": ["kubeflow.org/alpha"]},
+		{"principals": ["user:root"], "role": "admin", "workspaces": ["*"], "namespaces": ["*"]}
+	]}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	alice := NewContext(context.Background(), &Principal{User: "alice", Groups: []string{"ml-team"}})
+	bob := NewContext(context.Background(), &Principal{User: "bob"})
+	root := NewContext(context.Background(), &Principal{User: "root"})
+	tests := []struct {
+		ctx       context.Context
+		access    service.Access
+		workspace string
+		namespace string
+		want      bool
+	}{
+		{bob, service.ReadAccess, "public", "kubeflow.org/alpha", true},
+		{bob, service.WriteAccess, "public", "kubeflow.org/alpha", false},
+		{bob, service.ReadAccess, "ml-alpha", "example.com", false},
+		{alice, service.ReadAccess, "ml-alpha", "kubeflow.org/alpha", true},
+		{alice, service.WriteAccess, "ml-alpha", "kubeflow.org/alpha", true},
+		{alice, service.WriteAccess, "public", "kubeflow.org/beta", false},
+		{alice, service.AdminAccess, "ml-alpha", "kubeflow.org/alpha", false},
+		{alice, service.WriteAccess, "ml", "kubeflow.org", false},
+		{alice, service.ReadAccess, "private", "", false},
+		{root, service.AdminAccess, "ml-alpha", "example.com", true},
+		{root, service.AdminAccess, "", "", true},
+		{NewContext(context.Background(), System), service.AdminAccess, "ml-alpha", "", true},
+		{NewContext(context.Background(), &Principal{User: System.User}), service.ReadAccess, "ml-alpha", "", false},
+	}
+	for _, test := range tests {
+		principal, _ := FromContext(test.ctx)
+		for _, got := range []struct {
+			call string
+			err  error
+		}{
+			{"AuthorizeWorkspace", p.AuthorizeWorkspace(test.ctx, test.access, test.workspace)},
+			{"AuthorizeNamespace", p.AuthorizeNamespace(test.ctx, test.access, test.namespace)},
+		} {
+			if test.want && got.err != nil || !test.want && status.Code(got.err) != codes.PermissionDenied {
+				t.Errorf("%s(%v, %s, %q, %q) = %v; want allowed: %t", got.call, principal, test.access, test.workspace, test.namespace, got.err, test.want)
+			}
+		}
+	}
+
+	ctx := context.Background()
+	if err := p.AuthorizeWorkspace(ctx, service.ReadAccess, "public"); status.Code(err) != codes.Unauthenticated {
+		t.Errorf("AuthorizeWorkspace without a principal = %v; want Unauthenticated error", err)
+	}
+	if err := p.AuthorizeNamespace(ctx, service.ReadAccess, "kubeflow.org/alpha"); status.Code(err) != codes.Unauthenticated {
+		t.Errorf("AuthorizeNamespace without a principal = %v; want Unauthenticated error", err)
+	}
+}
+
+func TestLoadPolicyErrors(t *testing.T) {
+	dir, err := ioutil.TempDir("", "auth")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	for _, content := range []string{
+		`not json`,
+		`{"bindings": [{"principals": ["*"], "role": "owner"}]}`,
+		`{"bindings": [{"principals": ["alice"], "role": "read"}]}`,
+	} {
+		if _, err := LoadPolicy(writeTestFile(t, dir, "policy.json", content)); err == nil {
+			t.Errorf("LoadPolicy of %s succeeded; want error", content)
+		}
+	}
+	if _, err := LoadPolicy(filepath.Join(dir, "missing.json")); err == nil {
+		t.Error("LoadPolicy of a missing file succeeded; want error")
+	}
+}
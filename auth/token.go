@@ -0,0 +1,91 @@
+// Copyright 2019 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package auth
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+)
+
+// TokenAuthenticator authenticates requests by bearer tokens listed in a
+// file.
+type TokenAuthenticator struct {
+	// principals are keyed by the hashes of their tokens, so that looking
+	// tokens up does not compare them byte by byte.
+	principals map[[sha256.Size]byte]*Principal
+}
+
+// NewTokenAuthenticator reads the tokens of file, a CSV file in the format of
+// the static token files of Kubernetes: each line holds a token, a user name,
+// a user id, which is ignored, and optionally a quoted, comma separated list
+// of groups, e.g.
+//
+//	31ada4fd-adec-460c-809a-9e56ceb75269,alice,1001,"ml-team,admins"
+func NewTokenAuthenticator(file string) (*TokenAuthenticator, error) {
+	f, err := os.Open(file)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	r := csv.NewReader(f)
+	r.FieldsPerRecord = -1
+	r.TrimLeadingSpace = true
+	a := &TokenAuthenticator{principals: make(map[[sha256.Size]byte]*Principal)}
+	for n := 1; ; n++ {
+		record, err := r.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to read token file %s: %v", file, err)
+		}
+		if len(record) < 3 || record[0] == "" || record[1] == "" {
+			return nil, fmt.Errorf("token file %s, entry %d: expecting token,user,uid[,groups]", file, n)
+		}
+		key := sha256.Sum256([]byte(record[0]))
+		if _, exists := a.principals[key]; exists {
+			return nil, fmt.Errorf("token file %s, entry %d: duplicate token", file, n)
+		}
+		p := &Principal{User: record[1]}
+		if len(record) > 3 && record[3] != "" {
+			for _, g := range strings.Split(record[3], ",") {
+				p.Groups = append(p.Groups, strings.TrimSpace(g))
+			}
+		}
+		a.principals[key] = p
+	}
+	return a, nil
+}
+
+// Authenticate returns the principal of the bearer token of the request, if
+// any.
+func (a *TokenAuthenticator) Authenticate(ctx context.Context) (*Principal, error) {
+	token := bearerToken(ctx)
+	if token == "" {
+		return nil, nil
+	}
+	p, ok := a.principals[sha256.Sum256([]byte(token))]
+	if !ok {
+		return nil, errors.New("unknown bearer token")
+	}
+	return p, nil
+}
@@ -0,0 +1,87 @@
+// Copyright 2019 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package auth
+
+import (
+	"context"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+// writeTestFile writes content in a new file of dir and returns its path.
+func writeTestFile(t *testing.T, dir, name, content string) string {
+	t.Helper()
+	path := filepath.Join(dir, name)
+	if err := ioutil.WriteFile(path, []byte(content), 0600); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func TestTokenAuthenticator(t *testing.T) {
+	dir, err := ioutil.TempDir("", "auth")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	a, err := NewTokenAuthenticator(writeTestFile(t, dir, "tokens.csv", `alice-token,alice,1001,"ml-team, admins"
+bob-token,bob,1002
+`))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		ctx     context.Context
+		want    *Principal
+		wantErr bool
+	}{
+		{withAuthorization("Bearer alice-token"), &Principal{User: "alice", Groups: []string{"ml-team", "admins"}}, false},
+		{withAuthorization("Bearer bob-token"), &Principal{User: "bob"}, false},
+		{withAuthorization("Bearer eve-token"), nil, true},
+		{context.Background(), nil, false},
+	}
+	for _, test := range tests {
+		got, err := a.Authenticate(test.ctx)
+		if gotErr := err != nil; gotErr != test.wantErr || !reflect.DeepEqual(got, test.want) {
+			t.Errorf("Authenticate(%q) = %v, %v; want %v, error: %t", bearerToken(test.ctx), got, err, test.want, test.wantErr)
+		}
+	}
+}
+
+func TestNewTokenAuthenticatorErrors(t *testing.T) {
+	dir, err := ioutil.TempDir("", "auth")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	for _, content := range []string{
+		"alice-token,alice\n",
+		",alice,1001\n",
+		"alice-token,,1001\n",
+		"token,alice,1001\ntoken,bob,1002\n",
+		"token,alice,\"1001\n",
+	} {
+		if _, err := NewTokenAuthenticator(writeTestFile(t, dir, "tokens.csv", content)); err == nil {
+			t.Errorf("NewTokenAuthenticator of %q succeeded; want error", content)
+		}
+	}
+	if _, err := NewTokenAuthenticator(filepath.Join(dir, "missing.csv")); err == nil {
+		t.Error("NewTokenAuthenticator of a missing file succeeded; want error")
+	}
+}
@@ -0,0 +1,144 @@
+// Copyright 2019 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package metrics exports Prometheus metrics of the metadata server: the
+// latency and status codes of its RPCs, the latency and errors of its store
+// calls, and the number of records it holds. They are registered with the
+// default Prometheus registry, served by Handler.
+package metrics
+
+import (
+	"context"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/golang/glog"
+	"github.com/kubeflow/metadata/service"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/status"
+)
+
+var (
+	rpcDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: "metadata",
+		Subsystem: "grpc",
+		Name:      "request_duration_seconds",
+		Help:      "Latency of the RPCs handled by the server.",
+		Buckets:   prometheus.DefBuckets,
+	}, []string{"method"})
+	rpcCount = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: "metadata",
+		Subsystem: "grpc",
+		Name:      "requests_total",
+		Help:      "Number of RPCs handled by the server, by status code.",
+	}, []string{"method", "code"})
+)
+
+func init() {
+	prometheus.MustRegister(rpcDuration, rpcCount, storeDuration, storeErrors)
+}
+
+// Handler returns the handler serving the metrics in the Prometheus text
+// format.
+func Handler() http.Handler {
+	return promhttp.Handler()
+}
+
+// UnaryServerInterceptor records the latency and status code of each RPC. It
+// must be the outermost interceptor to record the codes returned to clients.
+func UnaryServerInterceptor(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+	start := time.Now()
+	resp, err := handler(ctx, req)
+	rpcDuration.WithLabelValues(info.FullMethod).Observe(time.Since(start).Seconds())
+	rpcCount.WithLabelValues(info.FullMethod, status.Code(err).String()).Inc()
+	return resp, err
+}
+
+// statsCollector exports the record counts of a service as gauges, counting
+// them at the first scrape after the previous counts are older than maxAge.
+type statsCollector struct {
+	svc     *service.Service
+	timeout time.Duration
+	maxAge  time.Duration
+
+	artifactTypes  *prometheus.Desc
+	executionTypes *prometheus.Desc
+	artifacts      *prometheus.Desc
+	executions     *prometheus.Desc
+
+	mu        sync.Mutex
+	stats     *service.Stats
+	countedAt time.Time
+}
+
+// RegisterStats exports the number of types, artifacts and executions of svc,
+// counting them with service.Stats, which fails after timeout, and reusing the
+// counts across scrapes until they are older than maxAge.
+func RegisterStats(svc *service.Service, timeout, maxAge time.Duration) error {
+	return prometheus.Register(newStatsCollector(svc, timeout, maxAge))
+}
+
+func newStatsCollector(svc *service.Service, timeout, maxAge time.Duration) *statsCollector {
+	return &statsCollector{
+		svc:            svc,
+		timeout:        timeout,
+		maxAge:         maxAge,
+		artifactTypes:  prometheus.NewDesc("metadata_artifact_types", "Number of artifact types.", nil, nil),
+		executionTypes: prometheus.NewDesc("metadata_execution_types", "Number of execution types.", nil, nil),
+		artifacts:      prometheus.NewDesc("metadata_artifacts", "Number of artifacts.", nil, nil),
+		executions:     prometheus.NewDesc("metadata_executions", "Number of executions.", nil, nil),
+	}
+}
+
+func (c *statsCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- c.artifactTypes
+	ch <- c.executionTypes
+	ch <- c.artifacts
+	ch <- c.executions
+}
+
+// Collect leaves the gauges out of the scrape if the store fails, so that they
+// are missing rather than wrong.
+func (c *statsCollector) Collect(ch chan<- prometheus.Metric) {
+	stats, err := c.count()
+	if err != nil {
+		glog.Errorf("Failed to count the records of the store: %v", err)
+		return
+	}
+	ch <- prometheus.MustNewConstMetric(c.artifactTypes, prometheus.GaugeValue, float64(stats.ArtifactTypes))
+	ch <- prometheus.MustNewConstMetric(c.executionTypes, prometheus.GaugeValue, float64(stats.ExecutionTypes))
+	ch <- prometheus.MustNewConstMetric(c.artifacts, prometheus.GaugeValue, float64(stats.Artifacts))
+	ch <- prometheus.MustNewConstMetric(c.executions, prometheus.GaugeValue, float64(stats.Executions))
+}
+
+// count returns the counts of the previous call if they are recent enough, so
+// that concurrent scrapes share a single count too.
+func (c *statsCollector) count() (*service.Stats, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.stats != nil && time.Since(c.countedAt) < c.maxAge {
+		return c.stats, nil
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), c.timeout)
+	defer cancel()
+	stats, err := c.svc.Stats(ctx)
+	if err != nil {
+		return nil, err
+	}
+	c.stats, c.countedAt = stats, time.Now()
+	return stats, nil
+}
@@ -0,0 +1,185 @@
+// Copyright 2019 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package metrics
+
+import (
+	"context"
+	"fmt"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	mlpb "ml_metadata/proto/metadata_store_go_proto"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/kubeflow/metadata/api"
+	"github.com/kubeflow/metadata/mlmd"
+	"github.com/kubeflow/metadata/mlmd/sqlstore"
+	"github.com/kubeflow/metadata/service"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	dto "github.com/prometheus/client_model/go"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// sampleCount returns the number of observations of a histogram.
+func sampleCount(t *testing.T, o prometheus.Observer) uint64 {
+	t.Helper()
+	m := &dto.Metric{}
+	if err := o.(prometheus.Metric).Write(m); err != nil {
+		t.Fatal(err)
+	}
+	return m.GetHistogram().GetSampleCount()
+}
+
+func testStore(t *testing.T) service.MetadataStore {
+	t.Helper()
+	store, err := sqlstore.NewSQLiteStore(":memory:")
+	if err != nil {
+		t.Fatalf("Failed to create store: %v", err)
+	}
+	return store
+}
+
+func TestUnaryServerInterceptor(t *testing.T) {
+	const method = "/ml_metadata.MetadataService/GetArtifact"
+	info := &grpc.UnaryServerInfo{FullMethod: method}
+	for _, err := range []error{nil, status.Error(codes.NotFound, "missing"), nil} {
+		handler := func(context.Context, interface{}) (interface{}, error) { return "response", err }
+		resp, got := UnaryServerInterceptor(context.Background(), "request", info, handler)
+		if resp != "response" || got != err {
+			t.Errorf("UnaryServerInterceptor() = %v, %v; want response, %v", resp, got, err)
+		}
+	}
+
+	if got := testutil.ToFloat64(rpcCount.WithLabelValues(method, "OK")); got != 2 {
+		t.Errorf("requests with code OK = %v; want 2", got)
+	}
+	if got := testutil.ToFloat64(rpcCount.WithLabelValues(method, "NotFound")); got != 1 {
+		t.Errorf("requests with code NotFound = %v; want 1", got)
+	}
+	if got := sampleCount(t, rpcDuration.WithLabelValues(method)); got != 3 {
+		t.Errorf("request latency observations = %d; want 3", got)
+	}
+}
+
+func TestInstrumentStore(t *testing.T) {
+	store := InstrumentStore(testStore(t))
+	defer store.Close()
+	ctx := context.Background()
+
+	if _, err := store.PutArtifactType(ctx, &mlpb.ArtifactType{Name: proto.String("kubeflow.org/v1/Model")}, &mlmd.PutTypeOptions{}); err != nil {
+		t.Fatalf("PutArtifactType failed: %v", err)
+	}
+	if _, err := store.GetArtifactType(ctx, "kubeflow.org/v1/Missing"); status.Code(err) != codes.NotFound {
+		t.Fatalf("GetArtifactType of a missing type = %v; want NotFound error", err)
+	}
+	canceled, cancel := context.WithCancel(ctx)
+	cancel()
+	if _, err := store.GetArtifactType(canceled, "kubeflow.org/v1/Model"); err == nil {
+		t.Fatal("GetArtifactType with a canceled context succeeded; want error")
+	}
+
+	if got := sampleCount(t, storeDuration.WithLabelValues("PutArtifactType")); got != 1 {
+		t.Errorf("PutArtifactType latency observations = %d; want 1", got)
+	}
+	if got := sampleCount(t, storeDuration.WithLabelValues("GetArtifactType")); got != 2 {
+		t.Errorf("GetArtifactType latency observations = %d; want 2", got)
+	}
+	if got := testutil.ToFloat64(storeErrors.WithLabelValues("GetArtifactType", "NotFound")); got != 1 {
+		t.Errorf("GetArtifactType NotFound errors = %v; want 1", got)
+	}
+	if got := testutil.ToFloat64(storeErrors.WithLabelValues("GetArtifactType", "Canceled")); got != 1 {
+		t.Errorf("GetArtifactType Canceled errors = %v; want 1", got)
+	}
+}
+
+func TestStatsCollector(t *testing.T) {
+	svc := service.New(testStore(t))
+	ctx := context.Background()
+	if _, err := svc.CreateArtifactType(ctx, &api.CreateArtifactTypeRequest{ArtifactType: &mlpb.ArtifactType{Name: proto.String("kubeflow.org/v1/Model")}}); err != nil {
+		t.Fatalf("CreateArtifactType failed: %v", err)
+	}
+	if _, err := svc.CreateExecutionType(ctx, &api.CreateExecutionTypeRequest{ExecutionType: &mlpb.ExecutionType{Name: proto.String("kubeflow.org/v1/Train")}}); err != nil {
+		t.Fatalf("CreateExecutionType failed: %v", err)
+	}
+	if _, err := svc.CreateWorkspace(ctx, &api.CreateWorkspaceRequest{Workspace: &api.Workspace{Name: "teamA"}}); err != nil {
+		t.Fatalf("CreateWorkspace failed: %v", err)
+	}
+	var names []string
+	for i := 0; i < 3; i++ {
+		resp, err := svc.CreateArtifact(ctx, &api.CreateArtifactRequest{Parent: "artifact_types/kubeflow.org/v1/Model", Artifact: &mlpb.Artifact{}, Workspace: "teamA"})
+		if err != nil {
+			t.Fatalf("CreateArtifact failed: %v", err)
+		}
+		names = append(names, fmt.Sprintf("artifact_types/kubeflow.org/v1/Model/artifacts/%d", resp.GetArtifact().GetId()))
+	}
+	if _, err := svc.DeleteArtifact(ctx, &api.DeleteArtifactRequest{Name: names[0]}); err != nil {
+		t.Fatalf("DeleteArtifact failed: %v", err)
+	}
+
+	// The workspace is not counted as an artifact type, nor the deleted
+	// artifact.
+	want := `
+# HELP metadata_artifact_types Number of artifact types.
+# TYPE metadata_artifact_types gauge
+metadata_artifact_types 1
+# HELP metadata_artifacts Number of artifacts.
+# TYPE metadata_artifacts gauge
+metadata_artifacts 2
+# HELP metadata_execution_types Number of execution types.
+# TYPE metadata_execution_types gauge
+metadata_execution_types 1
+# HELP metadata_executions Number of executions.
+# TYPE metadata_executions gauge
+metadata_executions 0
+`
+	c := newStatsCollector(svc, time.Minute, time.Hour)
+	if err := testutil.CollectAndCompare(c, strings.NewReader(want)); err != nil {
+		t.Error(err)
+	}
+
+	// Recent counts are reused, and recounted once they are older than maxAge.
+	if _, err := svc.DeleteArtifact(ctx, &api.DeleteArtifactRequest{Name: names[1]}); err != nil {
+		t.Fatalf("DeleteArtifact failed: %v", err)
+	}
+	artifacts := func(n int) string {
+		return fmt.Sprintf(`
+# HELP metadata_artifacts Number of artifacts.
+# TYPE metadata_artifacts gauge
+metadata_artifacts %d
+`, n)
+	}
+	if err := testutil.CollectAndCompare(c, strings.NewReader(artifacts(2)), "metadata_artifacts"); err != nil {
+		t.Error(err)
+	}
+	c.maxAge = 0
+	if err := testutil.CollectAndCompare(c, strings.NewReader(artifacts(1)), "metadata_artifacts"); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestHandler(t *testing.T) {
+	handler := func(context.Context, interface{}) (interface{}, error) { return nil, nil }
+	UnaryServerInterceptor(context.Background(), nil, &grpc.UnaryServerInfo{FullMethod: "/test/Method"}, handler)
+	w := httptest.NewRecorder()
+	Handler().ServeHTTP(w, httptest.NewRequest("GET", "/metrics", nil))
+	if !strings.Contains(w.Body.String(), "metadata_grpc_requests_total") {
+		t.Errorf("Handler() served\n%s\nwant metadata_grpc_requests_total", w.Body.String())
+	}
+}
@@ -0,0 +1,214 @@
+// Copyright 2019 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package metrics
+
+import (
+	"context"
+	"time"
+
+	mlpb "ml_metadata/proto/metadata_store_go_proto"
+
+	"github.com/kubeflow/metadata/mlmd"
+	"github.com/kubeflow/metadata/service"
+	"github.com/prometheus/client_golang/prometheus"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+var (
+	storeDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: "metadata",
+		Subsystem: "store",
+		Name:      "call_duration_seconds",
+		Help:      "Latency of the calls to the metadata store.",
+		Buckets:   prometheus.DefBuckets,
+	}, []string{"method"})
+	storeErrors = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: "metadata",
+		Subsystem: "store",
+		Name:      "call_errors_total",
+		Help:      "Number of failed calls to the metadata store, by status code. NotFound and AlreadyExists are also returned by lookups of missing records and creations of existing types, and Unimplemented by the deletes of stores that mark records deleted instead.",
+	}, []string{"method", "code"})
+)
+
+// InstrumentStore returns store recording the latency and errors of each of
+// its calls.
+func InstrumentStore(store service.MetadataStore) service.MetadataStore {
+	return &instrumentedStore{store: store}
+}
+
+type instrumentedStore struct {
+	store service.MetadataStore
+}
+
+// observe records a call of method started at start that returned err.
+// Context errors count as codes.DeadlineExceeded or codes.Canceled, as
+// reported by service.ErrorInterceptor.
+func observe(method string, start time.Time, err error) {
+	storeDuration.WithLabelValues(method).Observe(time.Since(start).Seconds())
+	if err == nil {
+		return
+	}
+	code := status.Code(err)
+	switch err {
+	case context.DeadlineExceeded:
+		code = codes.DeadlineExceeded
+	case context.Canceled:
+		code = codes.Canceled
+	}
+	storeErrors.WithLabelValues(method, code.String()).Inc()
+}
+
+func (s *instrumentedStore) Close() {
+	s.store.Close()
+}
+
+func (s *instrumentedStore) PagedLists() bool {
+	return s.store.PagedLists()
+}
+
+func (s *instrumentedStore) InTransaction(ctx context.Context, f func(ctx context.Context) error) error {
+	return s.store.InTransaction(ctx, f)
+}
+
+func (s *instrumentedStore) PutArtifactType(ctx context.Context, atype *mlpb.ArtifactType, opts *mlmd.PutTypeOptions) (id mlmd.ArtifactTypeID, err error) {
+	defer func(start time.Time) { observe("PutArtifactType", start, err) }(time.Now())
+	return s.store.PutArtifactType(ctx, atype, opts)
+}
+
+func (s *instrumentedStore) GetArtifactType(ctx context.Context, name string) (atype *mlpb.ArtifactType, err error) {
+	defer func(start time.Time) { observe("GetArtifactType", start, err) }(time.Now())
+	return s.store.GetArtifactType(ctx, name)
+}
+
+func (s *instrumentedStore) GetArtifactTypesByID(ctx context.Context, tids []mlmd.ArtifactTypeID) (atypes []*mlpb.ArtifactType, err error) {
+	defer func(start time.Time) { observe("GetArtifactTypesByID", start, err) }(time.Now())
+	return s.store.GetArtifactTypesByID(ctx, tids)
+}
+
+func (s *instrumentedStore) GetArtifactTypes(ctx context.Context) (atypes []*mlpb.ArtifactType, err error) {
+	defer func(start time.Time) { observe("GetArtifactTypes", start, err) }(time.Now())
+	return s.store.GetArtifactTypes(ctx)
+}
+
+func (s *instrumentedStore) DeleteArtifactType(ctx context.Context, name string) (err error) {
+	defer func(start time.Time) { observe("DeleteArtifactType", start, err) }(time.Now())
+	return s.store.DeleteArtifactType(ctx, name)
+}
+
+func (s *instrumentedStore) PutArtifacts(ctx context.Context, artifacts []*mlpb.Artifact) (ids []mlmd.ArtifactID, err error) {
+	defer func(start time.Time) { observe("PutArtifacts", start, err) }(time.Now())
+	return s.store.PutArtifacts(ctx, artifacts)
+}
+
+func (s *instrumentedStore) GetArtifactsByID(ctx context.Context, aids []mlmd.ArtifactID) (artifacts []*mlpb.Artifact, err error) {
+	defer func(start time.Time) { observe("GetArtifactsByID", start, err) }(time.Now())
+	return s.store.GetArtifactsByID(ctx, aids)
+}
+
+func (s *instrumentedStore) GetArtifacts(ctx context.Context) (artifacts []*mlpb.Artifact, err error) {
+	defer func(start time.Time) { observe("GetArtifacts", start, err) }(time.Now())
+	return s.store.GetArtifacts(ctx)
+}
+
+func (s *instrumentedStore) GetArtifactsByType(ctx context.Context, typeName string) (artifacts []*mlpb.Artifact, err error) {
+	defer func(start time.Time) { observe("GetArtifactsByType", start, err) }(time.Now())
+	return s.store.GetArtifactsByType(ctx, typeName)
+}
+
+func (s *instrumentedStore) GetArtifactsByURI(ctx context.Context, uri string) (artifacts []*mlpb.Artifact, err error) {
+	defer func(start time.Time) { observe("GetArtifactsByURI", start, err) }(time.Now())
+	return s.store.GetArtifactsByURI(ctx, uri)
+}
+
+func (s *instrumentedStore) ListArtifacts(ctx context.Context, typeName string, opts *mlmd.ListOptions) (artifacts []*mlpb.Artifact, err error) {
+	defer func(start time.Time) { observe("ListArtifacts", start, err) }(time.Now())
+	return s.store.ListArtifacts(ctx, typeName, opts)
+}
+
+func (s *instrumentedStore) DeleteArtifacts(ctx context.Context, aids []mlmd.ArtifactID) (err error) {
+	defer func(start time.Time) { observe("DeleteArtifacts", start, err) }(time.Now())
+	return s.store.DeleteArtifacts(ctx, aids)
+}
+
+func (s *instrumentedStore) PutExecutionType(ctx context.Context, etype *mlpb.ExecutionType, opts *mlmd.PutTypeOptions) (id mlmd.ExecutionTypeID, err error) {
+	defer func(start time.Time) { observe("PutExecutionType", start, err) }(time.Now())
+	return s.store.PutExecutionType(ctx, etype, opts)
+}
+
+func (s *instrumentedStore) GetExecutionType(ctx context.Context, typeName string) (etype *mlpb.ExecutionType, err error) {
+	defer func(start time.Time) { observe("GetExecutionType", start, err) }(time.Now())
+	return s.store.GetExecutionType(ctx, typeName)
+}
+
+func (s *instrumentedStore) GetExecutionTypesByID(ctx context.Context, tids []mlmd.ExecutionTypeID) (etypes []*mlpb.ExecutionType, err error) {
+	defer func(start time.Time) { observe("GetExecutionTypesByID", start, err) }(time.Now())
+	return s.store.GetExecutionTypesByID(ctx, tids)
+}
+
+func (s *instrumentedStore) GetExecutionTypes(ctx context.Context) (etypes []*mlpb.ExecutionType, err error) {
+	defer func(start time.Time) { observe("GetExecutionTypes", start, err) }(time.Now())
+	return s.store.GetExecutionTypes(ctx)
+}
+
+func (s *instrumentedStore) DeleteExecutionType(ctx context.Context, typeName string) (err error) {
+	defer func(start time.Time) { observe("DeleteExecutionType", start, err) }(time.Now())
+	return s.store.DeleteExecutionType(ctx, typeName)
+}
+
+func (s *instrumentedStore) PutExecutions(ctx context.Context, executions []*mlpb.Execution) (ids []mlmd.ExecutionID, err error) {
+	defer func(start time.Time) { observe("PutExecutions", start, err) }(time.Now())
+	return s.store.PutExecutions(ctx, executions)
+}
+
+func (s *instrumentedStore) GetExecutionsByID(ctx context.Context, eids []mlmd.ExecutionID) (executions []*mlpb.Execution, err error) {
+	defer func(start time.Time) { observe("GetExecutionsByID", start, err) }(time.Now())
+	return s.store.GetExecutionsByID(ctx, eids)
+}
+
+func (s *instrumentedStore) GetExecutions(ctx context.Context) (executions []*mlpb.Execution, err error) {
+	defer func(start time.Time) { observe("GetExecutions", start, err) }(time.Now())
+	return s.store.GetExecutions(ctx)
+}
+
+func (s *instrumentedStore) GetExecutionsByType(ctx context.Context, typeName string) (executions []*mlpb.Execution, err error) {
+	defer func(start time.Time) { observe("GetExecutionsByType", start, err) }(time.Now())
+	return s.store.GetExecutionsByType(ctx, typeName)
+}
+
+func (s *instrumentedStore) ListExecutions(ctx context.Context, typeName string, opts *mlmd.ListOptions) (executions []*mlpb.Execution, err error) {
+	defer func(start time.Time) { observe("ListExecutions", start, err) }(time.Now())
+	return s.store.ListExecutions(ctx, typeName, opts)
+}
+
+func (s *instrumentedStore) DeleteExecutions(ctx context.Context, eids []mlmd.ExecutionID) (err error) {
+	defer func(start time.Time) { observe("DeleteExecutions", start, err) }(time.Now())
+	return s.store.DeleteExecutions(ctx, eids)
+}
+
+func (s *instrumentedStore) PutEvents(ctx context.Context, events []*mlpb.Event) (err error) {
+	defer func(start time.Time) { observe("PutEvents", start, err) }(time.Now())
+	return s.store.PutEvents(ctx, events)
+}
+
+func (s *instrumentedStore) GetEventsByArtifactIDs(ctx context.Context, aids []mlmd.ArtifactID) (events []*mlpb.Event, err error) {
+	defer func(start time.Time) { observe("GetEventsByArtifactIDs", start, err) }(time.Now())
+	return s.store.GetEventsByArtifactIDs(ctx, aids)
+}
+
+func (s *instrumentedStore) GetEventsByExecutionIDs(ctx context.Context, eids []mlmd.ExecutionID) (events []*mlpb.Event, err error) {
+	defer func(start time.Time) { observe("GetEventsByExecutionIDs", start, err) }(time.Now())
+	return s.store.GetEventsByExecutionIDs(ctx, eids)
+}
@@ -0,0 +1,48 @@
+// Copyright 2019 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mlmd
+
+import (
+	"fmt"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// Error is an error of a store call rejecting its request, classified by the
+// gRPC code of MLMD for it. Its message is the one of MLMD. Other errors,
+// e.g. of the database or of the context of the call, are returned as is.
+type Error struct {
+	Code    codes.Code
+	Message string
+}
+
+// Errorf returns an Error of code with a formatted message.
+func Errorf(code codes.Code, format string, args ...interface{}) error {
+	return &Error{Code: code, Message: fmt.Sprintf(format, args...)}
+}
+
+func (e *Error) Error() string {
+	return e.Message
+}
+
+// GRPCStatus returns the status of e, so that handlers can return e as is.
+func (e *Error) GRPCStatus() *status.Status {
+	return status.New(e.Code, e.Message)
+}
+
+// ErrNoRecord is the error of the Get methods of stores when their query
+// matches nothing.
+var ErrNoRecord = Errorf(codes.NotFound, "Cannot find any record")
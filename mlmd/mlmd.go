@@ -0,0 +1,52 @@
+// Copyright 2019 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package mlmd defines the types used by the implementations of the metadata
+// store of the service. They mirror those of mlmetadata.Store without
+// depending on its cgo library, so that the service can be built without it.
+package mlmd
+
+// ArtifactTypeID refers the id space of ArtifactType.
+type ArtifactTypeID int64
+
+// ExecutionTypeID refers the id space of ExecutionType.
+type ExecutionTypeID int64
+
+// ArtifactID refers the id space of Artifact.
+type ArtifactID int64
+
+// ExecutionID refers the id space of Execution.
+type ExecutionID int64
+
+// PutTypeOptions defines options for PutArtifactType and PutExecutionType
+// requests.
+type PutTypeOptions struct {
+	// CanAddFields allows adding properties to an existing type.
+	CanAddFields bool
+	// CanDeleteFields allows removing properties from an existing type.
+	// It is not supported by MLMD.
+	CanDeleteFields bool
+	// AllFieldsMustMatch requires the properties of an existing type to be
+	// those of the given type.
+	AllFieldsMustMatch bool
+}
+
+// ListOptions selects a page of the records returned by a list call, which
+// are ordered by id.
+type ListOptions struct {
+	// AfterID leaves out the records of ids up to AfterID.
+	AfterID int64
+	// Limit is the maximum number of records returned. Zero means no limit.
+	Limit int
+}
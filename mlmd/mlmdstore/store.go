@@ -0,0 +1,415 @@
+// Copyright 2019 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package mlmdstore implements the metadata store of the service with the MLMD
+// library, which requires cgo.
+package mlmdstore
+
+import (
+	"context"
+	"sort"
+	"strings"
+
+	"ml_metadata/metadata_store/mlmetadata"
+	mlpb "ml_metadata/proto/metadata_store_go_proto"
+
+	"github.com/kubeflow/metadata/mlmd"
+	"google.golang.org/grpc/codes"
+)
+
+// Store adapts a mlmetadata.Store to the types of package mlmd. Calls into
+// MLMD cannot be interrupted, so the context of a call is only checked before
+// it starts.
+type Store struct {
+	store *mlmetadata.Store
+}
+
+// NewStore creates a Store connected to the database of config.
+func NewStore(config *mlpb.ConnectionConfig) (*Store, error) {
+	store, err := mlmetadata.NewStore(config)
+	if err != nil {
+		return nil, err
+	}
+	return &Store{store}, nil
+}
+
+// Close frees the memory allocated by the MLMD library.
+func (s *Store) Close() {
+	s.store.Close()
+}
+
+// PagedLists returns false, as MLMD has no paged queries.
+func (s *Store) PagedLists() bool {
+	return false
+}
+
+// InTransaction calls f with ctx, as MLMD has no transactions across calls.
+// The writes of f are kept if it fails.
+func (s *Store) InTransaction(ctx context.Context, f func(ctx context.Context) error) error {
+	return f(ctx)
+}
+
+// PutArtifactType inserts or updates an artifact type.
+func (s *Store) PutArtifactType(ctx context.Context, atype *mlpb.ArtifactType, opts *mlmd.PutTypeOptions) (mlmd.ArtifactTypeID, error) {
+	if err := ctx.Err(); err != nil {
+		return 0, err
+	}
+	id, err := s.store.PutArtifactType(atype, putTypeOptions(opts))
+	return mlmd.ArtifactTypeID(id), storeError(err)
+}
+
+// GetArtifactType gets an artifact type by name.
+func (s *Store) GetArtifactType(ctx context.Context, name string) (*mlpb.ArtifactType, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	aType, err := s.store.GetArtifactType(name)
+	return aType, storeError(err)
+}
+
+// GetArtifactTypesByID gets a list of artifact types by ids.
+func (s *Store) GetArtifactTypesByID(ctx context.Context, tids []mlmd.ArtifactTypeID) ([]*mlpb.ArtifactType, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	ids := make([]mlmetadata.ArtifactTypeID, len(tids))
+	for i, id := range tids {
+		ids[i] = mlmetadata.ArtifactTypeID(id)
+	}
+	types, err := s.store.GetArtifactTypesByID(ids)
+	return types, storeError(err)
+}
+
+// GetArtifactTypes gets all artifact types.
+func (s *Store) GetArtifactTypes(ctx context.Context) ([]*mlpb.ArtifactType, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	types, err := s.store.GetArtifactTypes()
+	return types, storeError(err)
+}
+
+// DeleteArtifactType fails with codes.Unimplemented, as MLMD cannot remove
+// records.
+func (s *Store) DeleteArtifactType(ctx context.Context, name string) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	return errDeleteUnsupported
+}
+
+// PutArtifacts inserts and updates artifacts.
+func (s *Store) PutArtifacts(ctx context.Context, artifacts []*mlpb.Artifact) ([]mlmd.ArtifactID, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	ids, err := s.store.PutArtifacts(artifacts)
+	if err != nil {
+		return nil, storeError(err)
+	}
+	aids := make([]mlmd.ArtifactID, len(ids))
+	for i, id := range ids {
+		aids[i] = mlmd.ArtifactID(id)
+	}
+	return aids, nil
+}
+
+// GetArtifactsByID gets a list of artifacts by ids.
+func (s *Store) GetArtifactsByID(ctx context.Context, aids []mlmd.ArtifactID) ([]*mlpb.Artifact, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	artifacts, err := s.store.GetArtifactsByID(artifactIDs(aids))
+	return artifacts, storeError(err)
+}
+
+// GetArtifacts gets all artifacts.
+func (s *Store) GetArtifacts(ctx context.Context) ([]*mlpb.Artifact, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	artifacts, err := s.store.GetArtifacts()
+	return artifacts, storeError(err)
+}
+
+// GetArtifactsByType gets all artifacts of a type.
+func (s *Store) GetArtifactsByType(ctx context.Context, typeName string) ([]*mlpb.Artifact, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	artifacts, err := s.store.GetArtifactsByType(typeName)
+	return artifacts, storeError(err)
+}
+
+// GetArtifactsByURI gets all artifacts with a uri.
+func (s *Store) GetArtifactsByURI(ctx context.Context, uri string) ([]*mlpb.Artifact, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	artifacts, err := s.store.GetArtifactsByURI(uri)
+	return artifacts, storeError(err)
+}
+
+// ListArtifacts gets a page of the artifacts of the type typeName, or of all
+// types if it is empty. MLMD has no paged queries, so the page is selected out
+// of all of them.
+func (s *Store) ListArtifacts(ctx context.Context, typeName string, opts *mlmd.ListOptions) ([]*mlpb.Artifact, error) {
+	var artifacts []*mlpb.Artifact
+	var err error
+	if typeName == "" {
+		artifacts, err = s.GetArtifacts(ctx)
+	} else {
+		artifacts, err = s.GetArtifactsByType(ctx, typeName)
+	}
+	if err != nil && err != mlmd.ErrNoRecord {
+		return nil, err
+	}
+	sort.Slice(artifacts, func(i, j int) bool { return artifacts[i].GetId() < artifacts[j].GetId() })
+	start, end := page(len(artifacts), func(i int) int64 { return artifacts[i].GetId() }, opts)
+	return artifacts[start:end], nil
+}
+
+// DeleteArtifacts fails with codes.Unimplemented, as MLMD cannot remove
+// records.
+func (s *Store) DeleteArtifacts(ctx context.Context, aids []mlmd.ArtifactID) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	return errDeleteUnsupported
+}
+
+// PutExecutionType inserts or updates an execution type.
+func (s *Store) PutExecutionType(ctx context.Context, etype *mlpb.ExecutionType, opts *mlmd.PutTypeOptions) (mlmd.ExecutionTypeID, error) {
+	if err := ctx.Err(); err != nil {
+		return 0, err
+	}
+	id, err := s.store.PutExecutionType(etype, putTypeOptions(opts))
+	return mlmd.ExecutionTypeID(id), storeError(err)
+}
+
+// GetExecutionType gets an execution type by name.
+func (s *Store) GetExecutionType(ctx context.Context, typeName string) (*mlpb.ExecutionType, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	eType, err := s.store.GetExecutionType(typeName)
+	return eType, storeError(err)
+}
+
+// GetExecutionTypesByID gets a list of execution types by ids.
+func (s *Store) GetExecutionTypesByID(ctx context.Context, tids []mlmd.ExecutionTypeID) ([]*mlpb.ExecutionType, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	ids := make([]mlmetadata.ExecutionTypeID, len(tids))
+	for i, id := range tids {
+		ids[i] = mlmetadata.ExecutionTypeID(id)
+	}
+	types, err := s.store.GetExecutionTypesByID(ids)
+	return types, storeError(err)
+}
+
+// GetExecutionTypes gets all execution types.
+func (s *Store) GetExecutionTypes(ctx context.Context) ([]*mlpb.ExecutionType, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	types, err := s.store.GetExecutionTypes()
+	return types, storeError(err)
+}
+
+// DeleteExecutionType fails with codes.Unimplemented, as MLMD cannot remove
+// records.
+func (s *Store) DeleteExecutionType(ctx context.Context, typeName string) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	return errDeleteUnsupported
+}
+
+// PutExecutions inserts and updates executions.
+func (s *Store) PutExecutions(ctx context.Context, executions []*mlpb.Execution) ([]mlmd.ExecutionID, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	ids, err := s.store.PutExecutions(executions)
+	if err != nil {
+		return nil, storeError(err)
+	}
+	eids := make([]mlmd.ExecutionID, len(ids))
+	for i, id := range ids {
+		eids[i] = mlmd.ExecutionID(id)
+	}
+	return eids, nil
+}
+
+// GetExecutionsByID gets a list of executions by ids.
+func (s *Store) GetExecutionsByID(ctx context.Context, eids []mlmd.ExecutionID) ([]*mlpb.Execution, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	executions, err := s.store.GetExecutionsByID(executionIDs(eids))
+	return executions, storeError(err)
+}
+
+// GetExecutions gets all executions.
+func (s *Store) GetExecutions(ctx context.Context) ([]*mlpb.Execution, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	executions, err := s.store.GetExecutions()
+	return executions, storeError(err)
+}
+
+// GetExecutionsByType gets all executions of a type.
+func (s *Store) GetExecutionsByType(ctx context.Context, typeName string) ([]*mlpb.Execution, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	executions, err := s.store.GetExecutionsByType(typeName)
+	return executions, storeError(err)
+}
+
+// ListExecutions gets a page of the executions of the type typeName, or of
+// all types if it is empty. MLMD has no paged queries, so the page is
+// selected out of all of them.
+func (s *Store) ListExecutions(ctx context.Context, typeName string, opts *mlmd.ListOptions) ([]*mlpb.Execution, error) {
+	var executions []*mlpb.Execution
+	var err error
+	if typeName == "" {
+		executions, err = s.GetExecutions(ctx)
+	} else {
+		executions, err = s.GetExecutionsByType(ctx, typeName)
+	}
+	if err != nil && err != mlmd.ErrNoRecord {
+		return nil, err
+	}
+	sort.Slice(executions, func(i, j int) bool { return executions[i].GetId() < executions[j].GetId() })
+	start, end := page(len(executions), func(i int) int64 { return executions[i].GetId() }, opts)
+	return executions[start:end], nil
+}
+
+// DeleteExecutions fails with codes.Unimplemented, as MLMD cannot remove
+// records.
+func (s *Store) DeleteExecutions(ctx context.Context, eids []mlmd.ExecutionID) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	return errDeleteUnsupported
+}
+
+// PutEvents inserts events.
+func (s *Store) PutEvents(ctx context.Context, events []*mlpb.Event) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	return storeError(s.store.PutEvents(events))
+}
+
+// GetEventsByArtifactIDs gets all events of the artifacts of aids.
+func (s *Store) GetEventsByArtifactIDs(ctx context.Context, aids []mlmd.ArtifactID) ([]*mlpb.Event, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	events, err := s.store.GetEventsByArtifactIDs(artifactIDs(aids))
+	return events, storeError(err)
+}
+
+// GetEventsByExecutionIDs gets all events of the executions of eids.
+func (s *Store) GetEventsByExecutionIDs(ctx context.Context, eids []mlmd.ExecutionID) ([]*mlpb.Event, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	events, err := s.store.GetEventsByExecutionIDs(executionIDs(eids))
+	return events, storeError(err)
+}
+
+func putTypeOptions(opts *mlmd.PutTypeOptions) *mlmetadata.PutTypeOptions {
+	if opts == nil {
+		return nil
+	}
+	return &mlmetadata.PutTypeOptions{
+		CanAddFields:       opts.CanAddFields,
+		CanDeleteFields:    opts.CanDeleteFields,
+		AllFieldsMustMatch: opts.AllFieldsMustMatch,
+	}
+}
+
+// page returns the range [start, end) of the page of opts out of n records
+// whose ids, as returned by id, are sorted in increasing order.
+func page(n int, id func(i int) int64, opts *mlmd.ListOptions) (start, end int) {
+	start = sort.Search(n, func(i int) bool { return id(i) > opts.AfterID })
+	end = n
+	if opts.Limit > 0 && start+opts.Limit < n {
+		end = start + opts.Limit
+	}
+	return start, end
+}
+
+func artifactIDs(aids []mlmd.ArtifactID) []mlmetadata.ArtifactID {
+	ids := make([]mlmetadata.ArtifactID, len(aids))
+	for i, id := range aids {
+		ids[i] = mlmetadata.ArtifactID(id)
+	}
+	return ids
+}
+
+func executionIDs(eids []mlmd.ExecutionID) []mlmetadata.ExecutionID {
+	ids := make([]mlmetadata.ExecutionID, len(eids))
+	for i, id := range eids {
+		ids[i] = mlmetadata.ExecutionID(id)
+	}
+	return ids
+}
+
+// errDeleteUnsupported is the error of the Delete methods.
+var errDeleteUnsupported = mlmd.Errorf(codes.Unimplemented, "MLMD does not support deleting records")
+
+// errorCodes are the codes of the errors of MLMD, by the prefixes of their
+// messages. The Go API of MLMD only returns the message of its status.
+var errorCodes = []struct {
+	prefix string
+	code   codes.Code
+}{
+	{"Cannot find", codes.NotFound},
+	{"No type found for query", codes.NotFound},
+	{"Type already exists", codes.AlreadyExists},
+	{"No type name is specified", codes.InvalidArgument},
+	{"Property ", codes.InvalidArgument},
+	{"Found unknown property", codes.InvalidArgument},
+	{"Found unmatched property type", codes.InvalidArgument},
+	{"Given type_id is different", codes.InvalidArgument},
+	{"No artifact with the given id", codes.InvalidArgument},
+	{"No execution with the given id", codes.InvalidArgument},
+	{"No event type is specified", codes.InvalidArgument},
+	{"Deleting fields is not supported", codes.Unimplemented},
+}
+
+// storeError returns err as a mlmd.Error if it is one of the errors of MLMD
+// rejecting a request, and unchanged otherwise.
+func storeError(err error) error {
+	if err == nil {
+		return nil
+	}
+	msg := err.Error()
+	if msg == mlmd.ErrNoRecord.Error() {
+		return mlmd.ErrNoRecord
+	}
+	for _, e := range errorCodes {
+		if strings.HasPrefix(msg, e.prefix) {
+			return &mlmd.Error{Code: e.code, Message: msg}
+		}
+	}
+	return err
+}
@@ -0,0 +1,88 @@
+// Copyright 2019 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sqlstore
+
+import (
+	"strconv"
+	"strings"
+)
+
+// dialect describes how a database differs from MySQL, in which the queries
+// of the store are written.
+type dialect struct {
+	name string
+	// autoID declares an auto-incremented primary key column.
+	autoID string
+	// types maps the column types of MySQL to those of the database.
+	types map[string]string
+	// numbered placeholders $1, $2... replace the placeholders ? of MySQL,
+	// and identifiers are quoted with " instead of `.
+	numbered bool
+	// returning reads the ids of inserted rows with a RETURNING clause, as
+	// the driver does not support LastInsertId.
+	returning bool
+}
+
+var (
+	sqlite = &dialect{
+		name:   "sqlite",
+		autoID: "INTEGER PRIMARY KEY AUTOINCREMENT",
+	}
+	mysqlDialect = &dialect{
+		name:   "mysql",
+		autoID: "BIGINT NOT NULL AUTO_INCREMENT PRIMARY KEY",
+	}
+	postgres = &dialect{
+		name:   "postgres",
+		autoID: "BIGSERIAL PRIMARY KEY",
+		types: map[string]string{
+			"TINYINT(1)": "SMALLINT",
+			"DOUBLE":     "DOUBLE PRECISION",
+		},
+		numbered:  true,
+		returning: true,
+	}
+)
+
+// rebind returns query, written for MySQL, in the dialect d. Queries never
+// hold literal strings, so that every ? is a placeholder and every ` quotes
+// an identifier.
+func (d *dialect) rebind(query string) string {
+	if !d.numbered {
+		return query
+	}
+	var b strings.Builder
+	n := 0
+	for _, r := range query {
+		switch r {
+		case '?':
+			n++
+			b.WriteString("$" + strconv.Itoa(n))
+		case '`':
+			b.WriteRune('"')
+		default:
+			b.WriteRune(r)
+		}
+	}
+	return b.String()
+}
+
+// columnType returns the column type t of MySQL in the dialect d.
+func (d *dialect) columnType(t string) string {
+	if dt, ok := d.types[t]; ok {
+		return dt
+	}
+	return t
+}
@@ -0,0 +1,189 @@
+// Copyright 2019 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sqlstore
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	mlpb "ml_metadata/proto/metadata_store_go_proto"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/kubeflow/metadata/mlmd"
+	"google.golang.org/grpc/codes"
+)
+
+// PutEvents inserts events between existing artifacts and executions. Events
+// without time are recorded at the current time. No event is stored if one
+// of them is invalid.
+func (s *Store) PutEvents(ctx context.Context, events []*mlpb.Event) error {
+	return s.inTx(ctx, func(c conn) error {
+		for _, e := range events {
+			if err := putEvent(c, e); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+func putEvent(c conn, e *mlpb.Event) error {
+	if exists, err := rowExists(c, "Artifact", e.GetArtifactId()); err != nil {
+		return err
+	} else if !exists {
+		return mlmd.Errorf(codes.InvalidArgument, "No artifact with the given id %d", e.GetArtifactId())
+	}
+	if exists, err := rowExists(c, "Execution", e.GetExecutionId()); err != nil {
+		return err
+	} else if !exists {
+		return mlmd.Errorf(codes.InvalidArgument, "No execution with the given id %d", e.GetExecutionId())
+	}
+	if e.GetType() == mlpb.Event_UNKNOWN {
+		return mlmd.Errorf(codes.InvalidArgument, "No event type is specified")
+	}
+	millis := e.GetMillisecondsSinceEpoch()
+	if e.MillisecondsSinceEpoch == nil {
+		millis = time.Now().UnixNano() / int64(time.Millisecond)
+	}
+
+	id, err := c.insert("INSERT INTO `Event` (`artifact_id`, `execution_id`, `type`, `milliseconds_since_epoch`) VALUES (?, ?, ?, ?)",
+		e.GetArtifactId(), e.GetExecutionId(), int32(e.GetType()), millis)
+	if err != nil {
+		return err
+	}
+	for _, step := range e.GetPath().GetSteps() {
+		var index, key interface{}
+		_, isIndex := step.GetValue().(*mlpb.Event_Path_Step_Index)
+		if isIndex {
+			index = step.GetIndex()
+		} else {
+			key = step.GetKey()
+		}
+		if _, err := c.exec("INSERT INTO `EventPath` (`event_id`, `is_index_step`, `step_index`, `step_key`) VALUES (?, ?, ?, ?)", id, boolValue(isIndex), index, key); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// deleteEvents deletes the events matching the condition where on the Event
+// table, with their paths.
+func deleteEvents(c conn, where string, args ...interface{}) error {
+	if _, err := c.exec("DELETE FROM `EventPath` WHERE `event_id` IN (SELECT `id` FROM `Event` WHERE "+where+")", args...); err != nil {
+		return err
+	}
+	_, err := c.exec("DELETE FROM `Event` WHERE "+where, args...)
+	return err
+}
+
+func rowExists(c conn, table string, id int64) (bool, error) {
+	var n int
+	err := c.queryRow("SELECT COUNT(*) FROM `"+table+"` WHERE `id` = ?", id).Scan(&n)
+	return n > 0, err
+}
+
+// GetEventsByArtifactIDs gets all events of the artifacts of aids.
+func (s *Store) GetEventsByArtifactIDs(ctx context.Context, aids []mlmd.ArtifactID) ([]*mlpb.Event, error) {
+	ids := make([]int64, len(aids))
+	for i, id := range aids {
+		ids[i] = int64(id)
+	}
+	return s.getEvents(ctx, "artifact_id", ids)
+}
+
+// GetEventsByExecutionIDs gets all events of the executions of eids.
+func (s *Store) GetEventsByExecutionIDs(ctx context.Context, eids []mlmd.ExecutionID) ([]*mlpb.Event, error) {
+	ids := make([]int64, len(eids))
+	for i, id := range eids {
+		ids[i] = int64(id)
+	}
+	return s.getEvents(ctx, "execution_id", ids)
+}
+
+// getEvents returns the events whose column is one of ids, in the order they
+// were stored.
+func (s *Store) getEvents(ctx context.Context, column string, ids []int64) ([]*mlpb.Event, error) {
+	if len(ids) == 0 {
+		return nil, mlmd.ErrNoRecord
+	}
+	where, args := in(column, ids)
+	rows, err := s.conn(ctx).query("SELECT `id`, `artifact_id`, `execution_id`, `type`, `milliseconds_since_epoch` FROM `Event` WHERE "+where+" ORDER BY `id`", args...)
+	if err != nil {
+		return nil, err
+	}
+	var events []*mlpb.Event
+	byID := make(map[int64]*mlpb.Event)
+	for rows.Next() {
+		var (
+			id, artifactID, executionID int64
+			eventType                   int32
+			millis                      sql.NullInt64
+		)
+		if err := rows.Scan(&id, &artifactID, &executionID, &eventType, &millis); err != nil {
+			rows.Close()
+			return nil, err
+		}
+		e := &mlpb.Event{
+			ArtifactId:  proto.Int64(artifactID),
+			ExecutionId: proto.Int64(executionID),
+			Type:        mlpb.Event_Type(eventType).Enum(),
+		}
+		if millis.Valid {
+			e.MillisecondsSinceEpoch = proto.Int64(millis.Int64)
+		}
+		events = append(events, e)
+		byID[id] = e
+	}
+	// See getTypes.
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	if len(events) == 0 {
+		return nil, mlmd.ErrNoRecord
+	}
+
+	pathRows, err := s.conn(ctx).query("SELECT `event_id`, `is_index_step`, `step_index`, `step_key` FROM `EventPath` "+
+		"WHERE `event_id` IN (SELECT `id` FROM `Event` WHERE "+where+") ORDER BY `id`", args...)
+	if err != nil {
+		return nil, err
+	}
+	defer pathRows.Close()
+	for pathRows.Next() {
+		var (
+			id      int64
+			isIndex bool
+			index   sql.NullInt64
+			key     sql.NullString
+		)
+		if err := pathRows.Scan(&id, &isIndex, &index, &key); err != nil {
+			return nil, err
+		}
+		step := &mlpb.Event_Path_Step{Value: &mlpb.Event_Path_Step_Key{Key: key.String}}
+		if isIndex {
+			step.Value = &mlpb.Event_Path_Step_Index{Index: index.Int64}
+		}
+		e, ok := byID[id]
+		if !ok {
+			continue
+		}
+		if e.Path == nil {
+			e.Path = &mlpb.Event_Path{}
+		}
+		e.Path.Steps = append(e.Path.Steps, step)
+	}
+	return events, pathRows.Err()
+}
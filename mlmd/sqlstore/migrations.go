@@ -0,0 +1,131 @@
+// Copyright 2019 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sqlstore
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+)
+
+// migrations are the statements changing the tables of the store, in order.
+// The schema version of a database is the number of migrations applied to
+// it, recorded in the SchemaVersion table. A released migration must never
+// change: changing the tables takes a new one.
+var migrations = []func(d *dialect) []string{
+	createTables,
+	createIndexes,
+}
+
+// migrate applies the migrations missing from the database of s, each in a
+// transaction.
+func (s *Store) migrate(ctx context.Context) error {
+	c := s.conn(ctx)
+	if _, err := c.exec("CREATE TABLE IF NOT EXISTS `SchemaVersion` (`version` INT NOT NULL)"); err != nil {
+		return fmt.Errorf("failed to create table SchemaVersion: %v", err)
+	}
+	version, err := schemaVersion(c)
+	if err != nil {
+		return err
+	}
+	if version > len(migrations) {
+		return fmt.Errorf("database has schema version %d, newer than the latest version %d known to this server", version, len(migrations))
+	}
+	for v := version; v < len(migrations); v++ {
+		err := s.inTx(ctx, func(c conn) error {
+			for _, statement := range migrations[v](s.dialect) {
+				if _, err := c.exec(statement); err != nil {
+					return err
+				}
+			}
+			_, err := c.exec("INSERT INTO `SchemaVersion` (`version`) VALUES (?)", v+1)
+			return err
+		})
+		if err != nil {
+			return fmt.Errorf("failed to migrate database to schema version %d: %v", v+1, err)
+		}
+	}
+	return nil
+}
+
+// schemaVersion returns the schema version of the database, 0 if it has no
+// tables.
+func schemaVersion(c conn) (int, error) {
+	var version sql.NullInt64
+	if err := c.queryRow("SELECT MAX(`version`) FROM `SchemaVersion`").Scan(&version); err != nil {
+		return 0, fmt.Errorf("failed to read the schema version: %v", err)
+	}
+	return int(version.Int64), nil
+}
+
+// createTables creates the tables of the store. Tables created before
+// migrations were recorded are kept.
+func createTables(d *dialect) []string {
+	flag := d.columnType("TINYINT(1)")
+	properties := func(idColumn string) string {
+		return "`" + idColumn + "` BIGINT NOT NULL, " +
+			"`name` VARCHAR(255) NOT NULL, " +
+			"`is_custom_property` " + flag + " NOT NULL, " +
+			"`int_value` BIGINT, " +
+			"`double_value` " + d.columnType("DOUBLE") + ", " +
+			"`string_value` TEXT, " +
+			"PRIMARY KEY (`" + idColumn + "`, `name`, `is_custom_property`)"
+	}
+	return []string{
+		"CREATE TABLE IF NOT EXISTS `Type` (" +
+			"`id` " + d.autoID + ", " +
+			"`name` VARCHAR(255) NOT NULL, " +
+			"`is_artifact_type` " + flag + " NOT NULL, " +
+			"UNIQUE (`name`, `is_artifact_type`))",
+		"CREATE TABLE IF NOT EXISTS `TypeProperty` (" +
+			"`type_id` BIGINT NOT NULL, " +
+			"`name` VARCHAR(255) NOT NULL, " +
+			"`data_type` INT NOT NULL, " +
+			"PRIMARY KEY (`type_id`, `name`))",
+		"CREATE TABLE IF NOT EXISTS `Artifact` (" +
+			"`id` " + d.autoID + ", " +
+			"`type_id` BIGINT NOT NULL, " +
+			"`uri` TEXT)",
+		"CREATE TABLE IF NOT EXISTS `ArtifactProperty` (" + properties("artifact_id") + ")",
+		"CREATE TABLE IF NOT EXISTS `Execution` (" +
+			"`id` " + d.autoID + ", " +
+			"`type_id` BIGINT NOT NULL, " +
+			"`last_known_state` INT)",
+		"CREATE TABLE IF NOT EXISTS `ExecutionProperty` (" + properties("execution_id") + ")",
+		"CREATE TABLE IF NOT EXISTS `Event` (" +
+			"`id` " + d.autoID + ", " +
+			"`artifact_id` BIGINT NOT NULL, " +
+			"`execution_id` BIGINT NOT NULL, " +
+			"`type` INT NOT NULL, " +
+			"`milliseconds_since_epoch` BIGINT)",
+		"CREATE TABLE IF NOT EXISTS `EventPath` (" +
+			"`id` " + d.autoID + ", " +
+			"`event_id` BIGINT NOT NULL, " +
+			"`is_index_step` " + flag + " NOT NULL, " +
+			"`step_index` BIGINT, " +
+			"`step_key` TEXT)",
+	}
+}
+
+// createIndexes indexes the columns nodes and events are listed by.
+func createIndexes(d *dialect) []string {
+	return []string{
+		"CREATE INDEX `idx_artifact_type_id` ON `Artifact` (`type_id`)",
+		"CREATE INDEX `idx_execution_type_id` ON `Execution` (`type_id`)",
+		"CREATE INDEX `idx_event_artifact_id` ON `Event` (`artifact_id`)",
+		"CREATE INDEX `idx_event_execution_id` ON `Event` (`execution_id`)",
+		"CREATE INDEX `idx_event_path_event_id` ON `EventPath` (`event_id`)",
+	}
+}
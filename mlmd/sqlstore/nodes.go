@@ -0,0 +1,521 @@
+// Copyright 2019 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sqlstore
+
+import (
+	"context"
+	"database/sql"
+	"strconv"
+
+	mlpb "ml_metadata/proto/metadata_store_go_proto"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/kubeflow/metadata/mlmd"
+	"google.golang.org/grpc/codes"
+)
+
+// nodeTable describes the tables of artifacts or executions, which are both
+// typed nodes with properties and one more column.
+type nodeTable struct {
+	kind           string
+	table          string
+	column         string
+	propertyTable  string
+	idColumn       string
+	isArtifactType bool
+}
+
+var (
+	artifactTable = &nodeTable{
+		kind:           "artifact",
+		table:          "Artifact",
+		column:         "uri",
+		propertyTable:  "ArtifactProperty",
+		idColumn:       "artifact_id",
+		isArtifactType: true,
+	}
+	executionTable = &nodeTable{
+		kind:          "execution",
+		table:         "Execution",
+		column:        "last_known_state",
+		propertyTable: "ExecutionProperty",
+		idColumn:      "execution_id",
+	}
+)
+
+// node is a row of an artifact or execution table with its properties.
+type node struct {
+	id               int64
+	typeID           int64
+	uri              sql.NullString
+	lastKnownState   sql.NullInt64
+	properties       map[string]*mlpb.Value
+	customProperties map[string]*mlpb.Value
+}
+
+// PutArtifacts inserts the artifacts without id and updates the others,
+// replacing their properties. It returns the ids of artifacts. No artifact is
+// stored if one of them is invalid.
+func (s *Store) PutArtifacts(ctx context.Context, artifacts []*mlpb.Artifact) ([]mlmd.ArtifactID, error) {
+	var ids []mlmd.ArtifactID
+	err := s.inTx(ctx, func(c conn) error {
+		for _, a := range artifacts {
+			var uri interface{}
+			if a.Uri != nil {
+				uri = a.GetUri()
+			}
+			id, err := artifactTable.put(c, a.Id, a.GetTypeId(), uri, a.GetProperties(), a.GetCustomProperties())
+			if err != nil {
+				return err
+			}
+			ids = append(ids, mlmd.ArtifactID(id))
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return ids, nil
+}
+
+// GetArtifactsByID gets the artifacts of aids, skipping unknown ids.
+func (s *Store) GetArtifactsByID(ctx context.Context, aids []mlmd.ArtifactID) ([]*mlpb.Artifact, error) {
+	ids := make([]int64, len(aids))
+	for i, id := range aids {
+		ids[i] = int64(id)
+	}
+	nodes, err := artifactTable.getByID(s.conn(ctx), ids)
+	if err != nil {
+		return nil, err
+	}
+	return artifacts(nodes), nil
+}
+
+// GetArtifacts gets all artifacts.
+func (s *Store) GetArtifacts(ctx context.Context) ([]*mlpb.Artifact, error) {
+	return s.getArtifacts(ctx, "1 = 1")
+}
+
+// GetArtifactsByType gets all artifacts of the type typeName.
+func (s *Store) GetArtifactsByType(ctx context.Context, typeName string) ([]*mlpb.Artifact, error) {
+	t, err := getTypeByName(s.conn(ctx), typeName, true)
+	if err != nil {
+		return nil, err
+	}
+	return s.getArtifacts(ctx, "`type_id` = ?", t.id)
+}
+
+// GetArtifactsByURI gets all artifacts with uri.
+func (s *Store) GetArtifactsByURI(ctx context.Context, uri string) ([]*mlpb.Artifact, error) {
+	return s.getArtifacts(ctx, "`uri` = ?", uri)
+}
+
+// ListArtifacts gets a page of the artifacts of the type typeName, or of all
+// types if it is empty.
+func (s *Store) ListArtifacts(ctx context.Context, typeName string, opts *mlmd.ListOptions) ([]*mlpb.Artifact, error) {
+	where, args, err := typeCondition(s.conn(ctx), typeName, true)
+	if err != nil {
+		return nil, err
+	}
+	nodes, err := artifactTable.getPage(s.conn(ctx), where, args, opts)
+	if err != nil {
+		return nil, err
+	}
+	return artifacts(nodes), nil
+}
+
+// DeleteArtifacts deletes the artifacts of aids, skipping unknown ids, along
+// with their events.
+func (s *Store) DeleteArtifacts(ctx context.Context, aids []mlmd.ArtifactID) error {
+	if len(aids) == 0 {
+		return nil
+	}
+	ids := make([]int64, len(aids))
+	for i, id := range aids {
+		ids[i] = int64(id)
+	}
+	where, args := in("id", ids)
+	return s.inTx(ctx, func(c conn) error {
+		return artifactTable.delete(c, where, args...)
+	})
+}
+
+func (s *Store) getArtifacts(ctx context.Context, where string, args ...interface{}) ([]*mlpb.Artifact, error) {
+	nodes, err := artifactTable.get(s.conn(ctx), where, args...)
+	if err != nil {
+		return nil, err
+	}
+	if len(nodes) == 0 {
+		return nil, mlmd.ErrNoRecord
+	}
+	return artifacts(nodes), nil
+}
+
+func artifacts(nodes []*node) []*mlpb.Artifact {
+	var artifacts []*mlpb.Artifact
+	for _, n := range nodes {
+		a := &mlpb.Artifact{
+			Id:               proto.Int64(n.id),
+			TypeId:           proto.Int64(n.typeID),
+			Properties:       n.properties,
+			CustomProperties: n.customProperties,
+		}
+		if n.uri.Valid {
+			a.Uri = proto.String(n.uri.String)
+		}
+		artifacts = append(artifacts, a)
+	}
+	return artifacts
+}
+
+// PutExecutions inserts the executions without id and updates the others,
+// replacing their properties. It returns the ids of executions. No execution
+// is stored if one of them is invalid.
+func (s *Store) PutExecutions(ctx context.Context, executions []*mlpb.Execution) ([]mlmd.ExecutionID, error) {
+	var ids []mlmd.ExecutionID
+	err := s.inTx(ctx, func(c conn) error {
+		for _, e := range executions {
+			var state interface{}
+			if e.LastKnownState != nil {
+				state = int32(e.GetLastKnownState())
+			}
+			id, err := executionTable.put(c, e.Id, e.GetTypeId(), state, e.GetProperties(), e.GetCustomProperties())
+			if err != nil {
+				return err
+			}
+			ids = append(ids, mlmd.ExecutionID(id))
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return ids, nil
+}
+
+// GetExecutionsByID gets the executions of eids, skipping unknown ids.
+func (s *Store) GetExecutionsByID(ctx context.Context, eids []mlmd.ExecutionID) ([]*mlpb.Execution, error) {
+	ids := make([]int64, len(eids))
+	for i, id := range eids {
+		ids[i] = int64(id)
+	}
+	nodes, err := executionTable.getByID(s.conn(ctx), ids)
+	if err != nil {
+		return nil, err
+	}
+	return executions(nodes), nil
+}
+
+// GetExecutions gets all executions.
+func (s *Store) GetExecutions(ctx context.Context) ([]*mlpb.Execution, error) {
+	return s.getExecutions(ctx, "1 = 1")
+}
+
+// GetExecutionsByType gets all executions of the type typeName.
+func (s *Store) GetExecutionsByType(ctx context.Context, typeName string) ([]*mlpb.Execution, error) {
+	t, err := getTypeByName(s.conn(ctx), typeName, false)
+	if err != nil {
+		return nil, err
+	}
+	return s.getExecutions(ctx, "`type_id` = ?", t.id)
+}
+
+// ListExecutions gets a page of the executions of the type typeName, or of
+// all types if it is empty.
+func (s *Store) ListExecutions(ctx context.Context, typeName string, opts *mlmd.ListOptions) ([]*mlpb.Execution, error) {
+	where, args, err := typeCondition(s.conn(ctx), typeName, false)
+	if err != nil {
+		return nil, err
+	}
+	nodes, err := executionTable.getPage(s.conn(ctx), where, args, opts)
+	if err != nil {
+		return nil, err
+	}
+	return executions(nodes), nil
+}
+
+// DeleteExecutions deletes the executions of eids, skipping unknown ids,
+// along with their events.
+func (s *Store) DeleteExecutions(ctx context.Context, eids []mlmd.ExecutionID) error {
+	if len(eids) == 0 {
+		return nil
+	}
+	ids := make([]int64, len(eids))
+	for i, id := range eids {
+		ids[i] = int64(id)
+	}
+	where, args := in("id", ids)
+	return s.inTx(ctx, func(c conn) error {
+		return executionTable.delete(c, where, args...)
+	})
+}
+
+// typeCondition returns the condition selecting the nodes of the type
+// typeName, or every node if it is empty.
+func typeCondition(c conn, typeName string, isArtifactType bool) (string, []interface{}, error) {
+	if typeName == "" {
+		return "1 = 1", nil, nil
+	}
+	t, err := getTypeByName(c, typeName, isArtifactType)
+	if err != nil {
+		return "", nil, err
+	}
+	return "`type_id` = ?", []interface{}{t.id}, nil
+}
+
+func (s *Store) getExecutions(ctx context.Context, where string, args ...interface{}) ([]*mlpb.Execution, error) {
+	nodes, err := executionTable.get(s.conn(ctx), where, args...)
+	if err != nil {
+		return nil, err
+	}
+	if len(nodes) == 0 {
+		return nil, mlmd.ErrNoRecord
+	}
+	return executions(nodes), nil
+}
+
+func executions(nodes []*node) []*mlpb.Execution {
+	var executions []*mlpb.Execution
+	for _, n := range nodes {
+		e := &mlpb.Execution{
+			Id:               proto.Int64(n.id),
+			TypeId:           proto.Int64(n.typeID),
+			Properties:       n.properties,
+			CustomProperties: n.customProperties,
+		}
+		if n.lastKnownState.Valid {
+			e.LastKnownState = mlpb.Execution_State(n.lastKnownState.Int64).Enum()
+		}
+		executions = append(executions, e)
+	}
+	return executions
+}
+
+// put inserts the node if id is nil, or updates the node of id, with the
+// value of the column of t and properties. It returns the id of the node.
+func (t *nodeTable) put(c conn, id *int64, typeID int64, value interface{}, properties, customProperties map[string]*mlpb.Value) (int64, error) {
+	types, err := getTypesByID(c, []int64{typeID}, t.isArtifactType)
+	if err != nil {
+		return 0, err
+	}
+	if len(types) == 0 {
+		return 0, mlmd.Errorf(codes.NotFound, "No type found for query, type_id: %d", typeID)
+	}
+	if err := checkValues(properties, types[0].properties); err != nil {
+		return 0, err
+	}
+
+	if id == nil {
+		nodeID, err := c.insert("INSERT INTO `"+t.table+"` (`type_id`, `"+t.column+"`) VALUES (?, ?)", typeID, value)
+		if err != nil {
+			return 0, err
+		}
+		return nodeID, t.insertProperties(c, nodeID, properties, customProperties)
+	}
+
+	var storedTypeID int64
+	err = c.queryRow("SELECT `type_id` FROM `"+t.table+"` WHERE `id` = ?", *id).Scan(&storedTypeID)
+	if err == sql.ErrNoRows {
+		return 0, mlmd.Errorf(codes.NotFound, "Cannot find %s by id: %d", t.kind, *id)
+	}
+	if err != nil {
+		return 0, err
+	}
+	if storedTypeID != typeID {
+		return 0, mlmd.Errorf(codes.InvalidArgument, "Given type_id is different from the one known before")
+	}
+	if _, err := c.exec("UPDATE `"+t.table+"` SET `"+t.column+"` = ? WHERE `id` = ?", value, *id); err != nil {
+		return 0, err
+	}
+	if _, err := c.exec("DELETE FROM `"+t.propertyTable+"` WHERE `"+t.idColumn+"` = ?", *id); err != nil {
+		return 0, err
+	}
+	return *id, t.insertProperties(c, *id, properties, customProperties)
+}
+
+// checkValues checks that properties are declared by the type with
+// typeProperties, with their type.
+func checkValues(properties map[string]*mlpb.Value, typeProperties map[string]mlpb.PropertyType) error {
+	for p, v := range properties {
+		t, ok := typeProperties[p]
+		if !ok {
+			return mlmd.Errorf(codes.InvalidArgument, "Found unknown property: %s", p)
+		}
+		if valueType(v) != t {
+			return mlmd.Errorf(codes.InvalidArgument, "Found unmatched property type: %s", p)
+		}
+	}
+	return nil
+}
+
+func valueType(v *mlpb.Value) mlpb.PropertyType {
+	switch v.GetValue().(type) {
+	case *mlpb.Value_IntValue:
+		return mlpb.PropertyType_INT
+	case *mlpb.Value_DoubleValue:
+		return mlpb.PropertyType_DOUBLE
+	case *mlpb.Value_StringValue:
+		return mlpb.PropertyType_STRING
+	}
+	return mlpb.PropertyType_UNKNOWN
+}
+
+func (t *nodeTable) insertProperties(c conn, id int64, properties, customProperties map[string]*mlpb.Value) error {
+	insert := "INSERT INTO `" + t.propertyTable + "` (`" + t.idColumn + "`, `name`, `is_custom_property`, `int_value`, `double_value`, `string_value`) VALUES (?, ?, ?, ?, ?, ?)"
+	for isCustom, props := range map[bool]map[string]*mlpb.Value{false: properties, true: customProperties} {
+		for name, v := range props {
+			var intValue, doubleValue, stringValue interface{}
+			switch value := v.GetValue().(type) {
+			case *mlpb.Value_IntValue:
+				intValue = value.IntValue
+			case *mlpb.Value_DoubleValue:
+				doubleValue = value.DoubleValue
+			case *mlpb.Value_StringValue:
+				stringValue = value.StringValue
+			}
+			if _, err := c.exec(insert, id, name, boolValue(isCustom), intValue, doubleValue, stringValue); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// delete deletes the nodes matching the condition where, with their
+// properties and events. The columns of the Event table referring to nodes are
+// named like the idColumn of their property table.
+func (t *nodeTable) delete(c conn, where string, args ...interface{}) error {
+	nodes := "`" + t.idColumn + "` IN (SELECT `id` FROM `" + t.table + "` WHERE " + where + ")"
+	if err := deleteEvents(c, nodes, args...); err != nil {
+		return err
+	}
+	if _, err := c.exec("DELETE FROM `"+t.propertyTable+"` WHERE "+nodes, args...); err != nil {
+		return err
+	}
+	_, err := c.exec("DELETE FROM `"+t.table+"` WHERE "+where, args...)
+	return err
+}
+
+// getByID returns the nodes of ids in their order, skipping unknown ids.
+func (t *nodeTable) getByID(c conn, ids []int64) ([]*node, error) {
+	if len(ids) == 0 {
+		return nil, nil
+	}
+	where, args := in("id", ids)
+	nodes, err := t.get(c, where, args...)
+	if err != nil {
+		return nil, err
+	}
+	byID := make(map[int64]*node)
+	for _, n := range nodes {
+		byID[n.id] = n
+	}
+	var result []*node
+	for _, id := range ids {
+		if n, ok := byID[id]; ok {
+			result = append(result, n)
+		}
+	}
+	return result, nil
+}
+
+// get returns the nodes matching the condition where, ordered by id.
+func (t *nodeTable) get(c conn, where string, args ...interface{}) ([]*node, error) {
+	return t.getPage(c, where, args, &mlmd.ListOptions{})
+}
+
+// getPage returns the page of opts of the nodes matching the condition where,
+// ordered by id.
+func (t *nodeTable) getPage(c conn, where string, args []interface{}, opts *mlmd.ListOptions) ([]*node, error) {
+	where = "(" + where + ") AND `id` > ?"
+	args = append(args[:len(args):len(args)], opts.AfterID)
+	query := "SELECT `id`, `type_id`, `" + t.column + "` FROM `" + t.table + "` WHERE " + where + " ORDER BY `id`"
+	if opts.Limit > 0 {
+		query += " LIMIT " + strconv.Itoa(opts.Limit)
+	}
+	rows, err := c.query(query, args...)
+	if err != nil {
+		return nil, err
+	}
+	var nodes []*node
+	byID := make(map[int64]*node)
+	for rows.Next() {
+		n := &node{}
+		var value interface{} = &n.lastKnownState
+		if t.isArtifactType {
+			value = &n.uri
+		}
+		if err := rows.Scan(&n.id, &n.typeID, value); err != nil {
+			rows.Close()
+			return nil, err
+		}
+		nodes = append(nodes, n)
+		byID[n.id] = n
+	}
+	// See getTypes.
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	if len(nodes) == 0 {
+		return nil, nil
+	}
+
+	// The page ends at the last node, which selects its properties without
+	// a LIMIT in the subquery, unsupported by MySQL.
+	where += " AND `id` <= ?"
+	args = append(args, nodes[len(nodes)-1].id)
+	propRows, err := c.query("SELECT `"+t.idColumn+"`, `name`, `is_custom_property`, `int_value`, `double_value`, `string_value` FROM `"+t.propertyTable+"` "+
+		"WHERE `"+t.idColumn+"` IN (SELECT `id` FROM `"+t.table+"` WHERE "+where+")", args...)
+	if err != nil {
+		return nil, err
+	}
+	defer propRows.Close()
+	for propRows.Next() {
+		var (
+			id          int64
+			name        string
+			isCustom    bool
+			intValue    sql.NullInt64
+			doubleValue sql.NullFloat64
+			stringValue sql.NullString
+		)
+		if err := propRows.Scan(&id, &name, &isCustom, &intValue, &doubleValue, &stringValue); err != nil {
+			return nil, err
+		}
+		v := &mlpb.Value{}
+		switch {
+		case intValue.Valid:
+			v.Value = &mlpb.Value_IntValue{IntValue: intValue.Int64}
+		case doubleValue.Valid:
+			v.Value = &mlpb.Value_DoubleValue{DoubleValue: doubleValue.Float64}
+		case stringValue.Valid:
+			v.Value = &mlpb.Value_StringValue{StringValue: stringValue.String}
+		}
+		n, ok := byID[id]
+		if !ok {
+			continue
+		}
+		props := &n.properties
+		if isCustom {
+			props = &n.customProperties
+		}
+		if *props == nil {
+			*props = make(map[string]*mlpb.Value)
+		}
+		(*props)[name] = v
+	}
+	return nodes, propRows.Err()
+}
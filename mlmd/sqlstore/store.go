@@ -0,0 +1,269 @@
+// Copyright 2019 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package sqlstore implements the metadata store of the service in pure Go on
+// SQLite, MySQL and PostgreSQL, with the semantics of MLMD: the same type
+// options, property typing, events and error messages. Its tables follow the
+// layout of those of MLMD.
+package sqlstore
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/go-sql-driver/mysql"
+	// Registers the "postgres" driver.
+	_ "github.com/lib/pq"
+	// Registers the pure-Go "sqlite" driver.
+	_ "modernc.org/sqlite"
+)
+
+// Store is a metadata store backed by a SQL database. It is safe for
+// concurrent use. The queries of a call are canceled when its context is
+// done, in which case the call fails with the error of the context.
+type Store struct {
+	db      *sql.DB
+	dialect *dialect
+}
+
+// NewSQLiteStore opens the SQLite database in the file filename, creating it
+// if needed. A filename of ":memory:" opens a database held in memory, which
+// is discarded on Close.
+func NewSQLiteStore(filename string) (*Store, error) {
+	db, err := sql.Open("sqlite", filename)
+	if err != nil {
+		return nil, err
+	}
+	// SQLite does not support concurrent writes, and each connection to
+	// ":memory:" opens a distinct database.
+	db.SetMaxOpenConns(1)
+	return newStore(db, sqlite)
+}
+
+// NewMySQLStore connects to the MySQL database config.DBName, creating it if
+// needed.
+func NewMySQLStore(config *mysql.Config) (*Store, error) {
+	cfg := *config
+	cfg.DBName = ""
+	db, err := sql.Open("mysql", cfg.FormatDSN())
+	if err != nil {
+		return nil, err
+	}
+	_, err = db.Exec(fmt.Sprintf("CREATE DATABASE IF NOT EXISTS `%s`", strings.Replace(config.DBName, "`", "``", -1)))
+	db.Close()
+	if err != nil {
+		return nil, fmt.Errorf("failed to create database %q: %v", config.DBName, err)
+	}
+
+	if db, err = sql.Open("mysql", config.FormatDSN()); err != nil {
+		return nil, err
+	}
+	return newStore(db, mysqlDialect)
+}
+
+// PostgresConfig is the configuration of a connection to PostgreSQL.
+type PostgresConfig struct {
+	Host     string
+	Port     uint
+	User     string
+	Password string
+	DBName   string
+	// SSLMode is the sslmode of lib/pq, e.g. disable or verify-full.
+	SSLMode string
+}
+
+// dsn returns the connection string to the database dbName.
+func (c *PostgresConfig) dsn(dbName string) string {
+	quote := func(v string) string {
+		return "'" + strings.Replace(strings.Replace(v, `\`, `\\`, -1), "'", `\'`, -1) + "'"
+	}
+	dsn := fmt.Sprintf("host=%s port=%d user=%s password=%s dbname=%s",
+		quote(c.Host), c.Port, quote(c.User), quote(c.Password), quote(dbName))
+	if c.SSLMode != "" {
+		dsn += " sslmode=" + quote(c.SSLMode)
+	}
+	return dsn
+}
+
+// NewPostgresStore connects to the PostgreSQL database config.DBName,
+// creating it if needed.
+func NewPostgresStore(config *PostgresConfig) (*Store, error) {
+	db, err := sql.Open("postgres", config.dsn("postgres"))
+	if err != nil {
+		return nil, err
+	}
+	var exists bool
+	err = db.QueryRow("SELECT EXISTS (SELECT 1 FROM pg_database WHERE datname = $1)", config.DBName).Scan(&exists)
+	if err == nil && !exists {
+		_, err = db.Exec(fmt.Sprintf(`CREATE DATABASE "%s"`, strings.Replace(config.DBName, `"`, `""`, -1)))
+	}
+	db.Close()
+	if err != nil {
+		return nil, fmt.Errorf("failed to create database %q: %v", config.DBName, err)
+	}
+
+	if db, err = sql.Open("postgres", config.dsn(config.DBName)); err != nil {
+		return nil, err
+	}
+	return newStore(db, postgres)
+}
+
+// newStore migrates the tables of the store in db to the latest version.
+func newStore(db *sql.DB, d *dialect) (*Store, error) {
+	s := &Store{db: db, dialect: d}
+	if err := s.migrate(context.Background()); err != nil {
+		db.Close()
+		return nil, err
+	}
+	return s, nil
+}
+
+// SetConnectionPool sets the maximum numbers of open and idle connections to
+// the database, and how long connections are reused. A maxOpen or maxLifetime
+// of 0 means no limit, and a maxIdle of 0 keeps no idle connection. It is
+// ignored by SQLite stores, which use a single connection.
+func (s *Store) SetConnectionPool(maxOpen, maxIdle int, maxLifetime time.Duration) {
+	if s.dialect == sqlite {
+		return
+	}
+	s.db.SetMaxOpenConns(maxOpen)
+	s.db.SetMaxIdleConns(maxIdle)
+	s.db.SetConnMaxLifetime(maxLifetime)
+}
+
+// Close closes the database of the store.
+func (s *Store) Close() {
+	s.db.Close()
+}
+
+// PagedLists returns true, as list calls only query the requested page.
+func (s *Store) PagedLists() bool {
+	return true
+}
+
+// queryer is implemented by both *sql.DB and *sql.Tx.
+type queryer interface {
+	ExecContext(ctx context.Context, query string, args ...interface{}) (sql.Result, error)
+	QueryContext(ctx context.Context, query string, args ...interface{}) (*sql.Rows, error)
+	QueryRowContext(ctx context.Context, query string, args ...interface{}) *sql.Row
+}
+
+// conn runs queries written for MySQL in the dialect of the database, on the
+// database or in a transaction. Queries are canceled when ctx is done.
+type conn struct {
+	ctx context.Context
+	q   queryer
+	d   *dialect
+}
+
+// txKey is the key of the transaction of InTransaction in the contexts it
+// passes to f.
+type txKey struct{}
+
+// conn returns the conn of ctx, in the transaction of InTransaction if ctx
+// has one.
+func (s *Store) conn(ctx context.Context) conn {
+	if tx, ok := ctx.Value(txKey{}).(*sql.Tx); ok {
+		return conn{ctx, tx, s.dialect}
+	}
+	return conn{ctx, s.db, s.dialect}
+}
+
+func (c conn) exec(query string, args ...interface{}) (sql.Result, error) {
+	return c.q.ExecContext(queryContext(c.ctx, c.d), c.d.rebind(query), args...)
+}
+
+func (c conn) query(query string, args ...interface{}) (*sql.Rows, error) {
+	return c.q.QueryContext(queryContext(c.ctx, c.d), c.d.rebind(query), args...)
+}
+
+func (c conn) queryRow(query string, args ...interface{}) *sql.Row {
+	return c.q.QueryRowContext(queryContext(c.ctx, c.d), c.d.rebind(query), args...)
+}
+
+// queryContext returns the context to run a query of ctx with. SQLite
+// queries, which are local, run to completion: interrupting them races with
+// the closing of their connection in the driver, and a transaction rolled back
+// on cancelation discards its connection, and with it in-memory databases.
+// Queries still fail without running once ctx is done.
+func queryContext(ctx context.Context, d *dialect) context.Context {
+	if d == sqlite && ctx.Err() == nil {
+		return context.Background()
+	}
+	return ctx
+}
+
+// insert runs the INSERT statement query and returns the id of the new row.
+func (c conn) insert(query string, args ...interface{}) (int64, error) {
+	if c.d.returning {
+		var id int64
+		err := c.queryRow(query+" RETURNING `id`", args...).Scan(&id)
+		return id, err
+	}
+	result, err := c.exec(query, args...)
+	if err != nil {
+		return 0, err
+	}
+	return result.LastInsertId()
+}
+
+// inTx runs f in a transaction, which is committed if f succeeds. The
+// transaction is rolled back if ctx is done before it is committed, except on
+// SQLite, where only the queries of f fail once ctx is done. Within
+// InTransaction, f runs in its transaction instead.
+func (s *Store) inTx(ctx context.Context, f func(c conn) error) error {
+	if _, ok := ctx.Value(txKey{}).(*sql.Tx); ok {
+		return f(s.conn(ctx))
+	}
+	tx, err := s.db.BeginTx(queryContext(ctx, s.dialect), nil)
+	if err != nil {
+		return err
+	}
+	if err := f(conn{ctx, tx, s.dialect}); err != nil {
+		tx.Rollback()
+		return err
+	}
+	return tx.Commit()
+}
+
+// InTransaction calls f with a context whose store calls run in a single
+// transaction, committed if f succeeds and rolled back otherwise. Calls nested
+// in f join its transaction.
+func (s *Store) InTransaction(ctx context.Context, f func(ctx context.Context) error) error {
+	return s.inTx(ctx, func(c conn) error {
+		return f(context.WithValue(ctx, txKey{}, c.q))
+	})
+}
+
+// in returns the condition that column is one of ids, and its arguments.
+// ids must not be empty.
+func in(column string, ids []int64) (string, []interface{}) {
+	args := make([]interface{}, len(ids))
+	for i, id := range ids {
+		args[i] = id
+	}
+	return fmt.Sprintf("`%s` IN (?%s)", column, strings.Repeat(", ?", len(ids)-1)), args
+}
+
+// boolValue returns b as stored in the TINYINT(1) columns of flags, as
+// PostgreSQL does not convert booleans to integers.
+func boolValue(b bool) int {
+	if b {
+		return 1
+	}
+	return 0
+}
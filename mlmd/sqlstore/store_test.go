@@ -0,0 +1,678 @@
+// Copyright 2019 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sqlstore
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strconv"
+	"testing"
+	"time"
+
+	mlpb "ml_metadata/proto/metadata_store_go_proto"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/google/go-cmp/cmp"
+	"github.com/kubeflow/metadata/mlmd"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// forEachStore runs test against an empty store of each backend: SQLite, and
+// PostgreSQL if the environment variable POSTGRES_TEST_HOST names a server.
+// POSTGRES_TEST_PORT, POSTGRES_TEST_USER and POSTGRES_TEST_PASSWORD default
+// to 5432, postgres and no password. Each test creates then drops a database.
+func forEachStore(t *testing.T, test func(t *testing.T, store *Store)) {
+	t.Run("sqlite", func(t *testing.T) {
+		store, err := NewSQLiteStore(":memory:")
+		if err != nil {
+			t.Fatalf("NewSQLiteStore failed: %v", err)
+		}
+		defer store.Close()
+		test(t, store)
+	})
+
+	host := os.Getenv("POSTGRES_TEST_HOST")
+	if host == "" {
+		return
+	}
+	t.Run("postgres", func(t *testing.T) {
+		config := &PostgresConfig{
+			Host:     host,
+			Port:     5432,
+			User:     "postgres",
+			Password: os.Getenv("POSTGRES_TEST_PASSWORD"),
+			DBName:   fmt.Sprintf("metadata_test_%d", time.Now().UnixNano()),
+			SSLMode:  "disable",
+		}
+		if port := os.Getenv("POSTGRES_TEST_PORT"); port != "" {
+			p, err := strconv.ParseUint(port, 10, 32)
+			if err != nil {
+				t.Fatalf("invalid POSTGRES_TEST_PORT %q: %v", port, err)
+			}
+			config.Port = uint(p)
+		}
+		if user := os.Getenv("POSTGRES_TEST_USER"); user != "" {
+			config.User = user
+		}
+		store, err := NewPostgresStore(config)
+		if err != nil {
+			t.Fatalf("NewPostgresStore failed: %v", err)
+		}
+		defer dropPostgresDatabase(t, config)
+		defer store.Close()
+		test(t, store)
+	})
+}
+
+func dropPostgresDatabase(t *testing.T, config *PostgresConfig) {
+	db, err := sql.Open("postgres", config.dsn("postgres"))
+	if err != nil {
+		t.Errorf("failed to connect to postgres: %v", err)
+		return
+	}
+	defer db.Close()
+	if _, err := db.Exec(fmt.Sprintf(`DROP DATABASE "%s"`, config.DBName)); err != nil {
+		t.Errorf("failed to drop database %s: %v", config.DBName, err)
+	}
+}
+
+func TestPutType(t *testing.T) {
+	forEachStore(t, testPutType)
+}
+
+func testPutType(t *testing.T, store *Store) {
+	ctx := context.Background()
+	aType := &mlpb.ArtifactType{
+		Name:       proto.String("model"),
+		Properties: map[string]mlpb.PropertyType{"name": mlpb.PropertyType_STRING},
+	}
+	id, err := store.PutArtifactType(ctx, aType, &mlmd.PutTypeOptions{AllFieldsMustMatch: true})
+	if err != nil {
+		t.Fatalf("PutArtifactType failed: %v", err)
+	}
+	// Artifact and execution types share ids but not names.
+	if eid, err := store.PutExecutionType(ctx, &mlpb.ExecutionType{Name: proto.String("model")}, &mlmd.PutTypeOptions{}); err != nil || int64(eid) == int64(id) {
+		t.Errorf("PutExecutionType() = %v, %v\nWant an id other than %v", eid, err, id)
+	}
+
+	tests := []struct {
+		properties map[string]mlpb.PropertyType
+		opts       mlmd.PutTypeOptions
+		wantErr    string
+		wantCode   codes.Code
+	}{
+		{
+			properties: map[string]mlpb.PropertyType{"name": mlpb.PropertyType_STRING},
+			opts:       mlmd.PutTypeOptions{AllFieldsMustMatch: true},
+		},
+		{
+			properties: nil,
+			opts:       mlmd.PutTypeOptions{},
+		},
+		{
+			properties: nil,
+			opts:       mlmd.PutTypeOptions{AllFieldsMustMatch: true},
+			wantErr:    "Type already exists with different properties: model",
+			wantCode:   codes.AlreadyExists,
+		},
+		{
+			properties: map[string]mlpb.PropertyType{"name": mlpb.PropertyType_INT},
+			opts:       mlmd.PutTypeOptions{CanAddFields: true},
+			wantErr:    "Type already exists with different properties: model",
+			wantCode:   codes.AlreadyExists,
+		},
+		{
+			properties: map[string]mlpb.PropertyType{"size": mlpb.PropertyType_INT},
+			opts:       mlmd.PutTypeOptions{},
+			wantErr:    "Type already exists with different properties: model",
+			wantCode:   codes.AlreadyExists,
+		},
+		{
+			properties: map[string]mlpb.PropertyType{"size": mlpb.PropertyType_UNKNOWN},
+			opts:       mlmd.PutTypeOptions{CanAddFields: true},
+			wantErr:    "Property size type should not be UNKNOWN",
+			wantCode:   codes.InvalidArgument,
+		},
+		{
+			properties: map[string]mlpb.PropertyType{"size": mlpb.PropertyType_INT},
+			opts:       mlmd.PutTypeOptions{CanAddFields: true},
+		},
+	}
+	for _, test := range tests {
+		opts := test.opts
+		gotID, err := store.PutArtifactType(ctx, &mlpb.ArtifactType{Name: proto.String("model"), Properties: test.properties}, &opts)
+		if test.wantErr != "" {
+			if err == nil || err.Error() != test.wantErr || status.Code(err) != test.wantCode {
+				t.Errorf("PutArtifactType(%v, %+v) = %v\nWant error %q with code %v", test.properties, test.opts, err, test.wantErr, test.wantCode)
+			}
+			continue
+		}
+		if err != nil || gotID != id {
+			t.Errorf("PutArtifactType(%v, %+v) = %v, %v\nWant %v, nil", test.properties, test.opts, gotID, err, id)
+		}
+	}
+
+	got, err := store.GetArtifactType(ctx, "model")
+	if err != nil {
+		t.Fatalf("GetArtifactType failed: %v", err)
+	}
+	want := &mlpb.ArtifactType{
+		Id:   proto.Int64(int64(id)),
+		Name: proto.String("model"),
+		Properties: map[string]mlpb.PropertyType{
+			"name": mlpb.PropertyType_STRING,
+			"size": mlpb.PropertyType_INT,
+		},
+	}
+	if !proto.Equal(got, want) {
+		t.Errorf("GetArtifactType() = %v\nWant %v", got, want)
+	}
+	if _, err := store.GetArtifactType(ctx, "run"); err == nil || err.Error() != "No type found for query: run" || status.Code(err) != codes.NotFound {
+		t.Errorf("GetArtifactType of a missing type = %v\nWant error with code %v", err, codes.NotFound)
+	}
+}
+
+func TestPutArtifacts(t *testing.T) {
+	forEachStore(t, testPutArtifacts)
+}
+
+func testPutArtifacts(t *testing.T, store *Store) {
+	ctx := context.Background()
+	tid, err := store.PutArtifactType(ctx, &mlpb.ArtifactType{
+		Name:       proto.String("model"),
+		Properties: map[string]mlpb.PropertyType{"size": mlpb.PropertyType_INT},
+	}, &mlmd.PutTypeOptions{})
+	if err != nil {
+		t.Fatalf("PutArtifactType failed: %v", err)
+	}
+	typeID := proto.Int64(int64(tid))
+	artifact := &mlpb.Artifact{
+		TypeId:     typeID,
+		Uri:        proto.String("gs://model"),
+		Properties: map[string]*mlpb.Value{"size": {Value: &mlpb.Value_IntValue{IntValue: 3}}},
+		CustomProperties: map[string]*mlpb.Value{
+			"owner": {Value: &mlpb.Value_StringValue{StringValue: "kf"}},
+		},
+	}
+	ids, err := store.PutArtifacts(ctx, []*mlpb.Artifact{artifact, {TypeId: typeID}})
+	if err != nil || len(ids) != 2 {
+		t.Fatalf("PutArtifacts() = %v, %v\nWant 2 ids", ids, err)
+	}
+
+	invalid := []struct {
+		artifact *mlpb.Artifact
+		wantErr  string
+	}{
+		{&mlpb.Artifact{TypeId: proto.Int64(42)}, "No type found for query, type_id: 42"},
+		{&mlpb.Artifact{TypeId: typeID, Properties: map[string]*mlpb.Value{"name": {Value: &mlpb.Value_StringValue{}}}}, "Found unknown property: name"},
+		{&mlpb.Artifact{TypeId: typeID, Properties: map[string]*mlpb.Value{"size": {Value: &mlpb.Value_StringValue{}}}}, "Found unmatched property type: size"},
+		{&mlpb.Artifact{Id: proto.Int64(42), TypeId: typeID}, "Cannot find artifact by id: 42"},
+	}
+	for _, test := range invalid {
+		// The valid update is not stored along with the invalid artifact.
+		update := &mlpb.Artifact{Id: proto.Int64(int64(ids[1])), TypeId: typeID, Uri: proto.String("updated")}
+		if _, err := store.PutArtifacts(ctx, []*mlpb.Artifact{update, test.artifact}); err == nil || err.Error() != test.wantErr {
+			t.Errorf("PutArtifacts(%v) = %v\nWant error %q", test.artifact, err, test.wantErr)
+		}
+	}
+
+	got, err := store.GetArtifactsByID(ctx, []mlmd.ArtifactID{ids[1], 42, ids[0]})
+	if err != nil {
+		t.Fatalf("GetArtifactsByID failed: %v", err)
+	}
+	artifact.Id = proto.Int64(int64(ids[0]))
+	want := []*mlpb.Artifact{{Id: proto.Int64(int64(ids[1])), TypeId: typeID}, artifact}
+	if !cmp.Equal(got, want, cmp.Comparer(proto.Equal)) {
+		t.Errorf("GetArtifactsByID() = %v\nWant %v", got, want)
+	}
+	if got, err := store.GetArtifactsByURI(ctx, "gs://model"); err != nil || len(got) != 1 {
+		t.Errorf("GetArtifactsByURI() = %v, %v\nWant 1 artifact", got, err)
+	}
+	if _, err := store.GetArtifactsByURI(ctx, "gs://other"); err != mlmd.ErrNoRecord {
+		t.Errorf("GetArtifactsByURI of a missing uri = %v\nWant %v", err, mlmd.ErrNoRecord)
+	}
+}
+
+func TestListArtifacts(t *testing.T) {
+	forEachStore(t, testListArtifacts)
+}
+
+func testListArtifacts(t *testing.T, store *Store) {
+	ctx := context.Background()
+	var artifacts []*mlpb.Artifact
+	for _, name := range []string{"model", "dataset"} {
+		tid, err := store.PutArtifactType(ctx, &mlpb.ArtifactType{
+			Name:       proto.String(name),
+			Properties: map[string]mlpb.PropertyType{"size": mlpb.PropertyType_INT},
+		}, &mlmd.PutTypeOptions{})
+		if err != nil {
+			t.Fatalf("PutArtifactType failed: %v", err)
+		}
+		for i := 0; i < 3; i++ {
+			artifacts = append(artifacts, &mlpb.Artifact{
+				TypeId:     proto.Int64(int64(tid)),
+				Properties: map[string]*mlpb.Value{"size": {Value: &mlpb.Value_IntValue{IntValue: int64(i)}}},
+			})
+		}
+	}
+	ids, err := store.PutArtifacts(ctx, artifacts)
+	if err != nil {
+		t.Fatalf("PutArtifacts failed: %v", err)
+	}
+	for i, id := range ids {
+		artifacts[i].Id = proto.Int64(int64(id))
+	}
+
+	tests := []struct {
+		typeName string
+		opts     *mlmd.ListOptions
+		want     []*mlpb.Artifact
+	}{
+		{"", &mlmd.ListOptions{}, artifacts},
+		{"", &mlmd.ListOptions{Limit: 2}, artifacts[:2]},
+		{"", &mlmd.ListOptions{AfterID: int64(ids[1]), Limit: 3}, artifacts[2:5]},
+		{"", &mlmd.ListOptions{AfterID: int64(ids[4]), Limit: 3}, artifacts[5:]},
+		{"", &mlmd.ListOptions{AfterID: int64(ids[5])}, nil},
+		{"dataset", &mlmd.ListOptions{Limit: 2}, artifacts[3:5]},
+		{"dataset", &mlmd.ListOptions{AfterID: int64(ids[0])}, artifacts[3:]},
+	}
+	for _, test := range tests {
+		got, err := store.ListArtifacts(ctx, test.typeName, test.opts)
+		if err != nil {
+			t.Errorf("ListArtifacts(%q, %+v) = %v\nWant nil error", test.typeName, test.opts, err)
+			continue
+		}
+		if !cmp.Equal(got, test.want, cmp.Comparer(proto.Equal)) {
+			t.Errorf("ListArtifacts(%q, %+v) = %v\nWant %v", test.typeName, test.opts, got, test.want)
+		}
+	}
+	if _, err := store.ListArtifacts(ctx, "missing", &mlmd.ListOptions{}); status.Code(err) != codes.NotFound {
+		t.Errorf("ListArtifacts of a missing type = %v\nWant NotFound error", err)
+	}
+}
+
+func TestEvents(t *testing.T) {
+	forEachStore(t, testEvents)
+}
+
+func testEvents(t *testing.T, store *Store) {
+	ctx := context.Background()
+	atid, err := store.PutArtifactType(ctx, &mlpb.ArtifactType{Name: proto.String("model")}, &mlmd.PutTypeOptions{})
+	if err != nil {
+		t.Fatalf("PutArtifactType failed: %v", err)
+	}
+	etid, err := store.PutExecutionType(ctx, &mlpb.ExecutionType{Name: proto.String("train")}, &mlmd.PutTypeOptions{})
+	if err != nil {
+		t.Fatalf("PutExecutionType failed: %v", err)
+	}
+	aids, err := store.PutArtifacts(ctx, []*mlpb.Artifact{{TypeId: proto.Int64(int64(atid))}})
+	if err != nil {
+		t.Fatalf("PutArtifacts failed: %v", err)
+	}
+	eids, err := store.PutExecutions(ctx, []*mlpb.Execution{{TypeId: proto.Int64(int64(etid)), LastKnownState: mlpb.Execution_RUNNING.Enum()}})
+	if err != nil {
+		t.Fatalf("PutExecutions failed: %v", err)
+	}
+
+	event := &mlpb.Event{
+		ArtifactId:             proto.Int64(int64(aids[0])),
+		ExecutionId:            proto.Int64(int64(eids[0])),
+		Type:                   mlpb.Event_OUTPUT.Enum(),
+		MillisecondsSinceEpoch: proto.Int64(1000),
+		Path: &mlpb.Event_Path{Steps: []*mlpb.Event_Path_Step{
+			{Value: &mlpb.Event_Path_Step_Key{Key: "models"}},
+			{Value: &mlpb.Event_Path_Step_Index{Index: 2}},
+		}},
+	}
+	if err := store.PutEvents(ctx, []*mlpb.Event{event}); err != nil {
+		t.Fatalf("PutEvents failed: %v", err)
+	}
+	missing := proto.Clone(event).(*mlpb.Event)
+	missing.ArtifactId = proto.Int64(42)
+	if err := store.PutEvents(ctx, []*mlpb.Event{missing}); err == nil || err.Error() != "No artifact with the given id 42" {
+		t.Errorf("PutEvents of an event of a missing artifact = %v\nWant error", err)
+	}
+
+	got, err := store.GetEventsByExecutionIDs(ctx, eids)
+	if err != nil || len(got) != 1 || !proto.Equal(got[0], event) {
+		t.Errorf("GetEventsByExecutionIDs() = %v, %v\nWant [%v]", got, err, event)
+	}
+	if _, err := store.GetEventsByArtifactIDs(ctx, []mlmd.ArtifactID{42}); err != mlmd.ErrNoRecord {
+		t.Errorf("GetEventsByArtifactIDs of a missing artifact = %v\nWant %v", err, mlmd.ErrNoRecord)
+	}
+	executions, err := store.GetExecutionsByType(ctx, "train")
+	if err != nil || len(executions) != 1 || executions[0].GetLastKnownState() != mlpb.Execution_RUNNING {
+		t.Errorf("GetExecutionsByType() = %v, %v\nWant 1 RUNNING execution", executions, err)
+	}
+}
+
+func TestDelete(t *testing.T) {
+	forEachStore(t, testDelete)
+}
+
+func testDelete(t *testing.T, store *Store) {
+	ctx := context.Background()
+	atid, err := store.PutArtifactType(ctx, &mlpb.ArtifactType{Name: proto.String("model"), Properties: map[string]mlpb.PropertyType{"name": mlpb.PropertyType_STRING}}, &mlmd.PutTypeOptions{})
+	if err != nil {
+		t.Fatalf("PutArtifactType failed: %v", err)
+	}
+	etid, err := store.PutExecutionType(ctx, &mlpb.ExecutionType{Name: proto.String("train")}, &mlmd.PutTypeOptions{})
+	if err != nil {
+		t.Fatalf("PutExecutionType failed: %v", err)
+	}
+	aids, err := store.PutArtifacts(ctx, []*mlpb.Artifact{
+		{TypeId: proto.Int64(int64(atid)), Properties: map[string]*mlpb.Value{"name": {Value: &mlpb.Value_StringValue{StringValue: "a"}}}},
+		{TypeId: proto.Int64(int64(atid))},
+	})
+	if err != nil {
+		t.Fatalf("PutArtifacts failed: %v", err)
+	}
+	eids, err := store.PutExecutions(ctx, []*mlpb.Execution{{TypeId: proto.Int64(int64(etid))}})
+	if err != nil {
+		t.Fatalf("PutExecutions failed: %v", err)
+	}
+	var events []*mlpb.Event
+	for _, aid := range aids {
+		events = append(events, &mlpb.Event{
+			ArtifactId:  proto.Int64(int64(aid)),
+			ExecutionId: proto.Int64(int64(eids[0])),
+			Type:        mlpb.Event_INPUT.Enum(),
+			Path:        &mlpb.Event_Path{Steps: []*mlpb.Event_Path_Step{{Value: &mlpb.Event_Path_Step_Key{Key: "data"}}}},
+		})
+	}
+	if err := store.PutEvents(ctx, events); err != nil {
+		t.Fatalf("PutEvents failed: %v", err)
+	}
+
+	if err := store.DeleteArtifacts(ctx, aids[:1]); err != nil {
+		t.Fatalf("DeleteArtifacts failed: %v", err)
+	}
+	if got, err := store.GetArtifactsByID(ctx, aids[:1]); err != nil || len(got) != 0 {
+		t.Errorf("GetArtifactsByID of a deleted artifact = %v, %v\nWant no artifacts", got, err)
+	}
+	got, err := store.GetEventsByExecutionIDs(ctx, eids)
+	if err != nil || len(got) != 1 || got[0].GetArtifactId() != int64(aids[1]) {
+		t.Errorf("GetEventsByExecutionIDs() after DeleteArtifacts = %v, %v\nWant the event of artifact %d", got, err, aids[1])
+	}
+
+	if err := store.DeleteArtifactType(ctx, "model"); err != nil {
+		t.Fatalf("DeleteArtifactType failed: %v", err)
+	}
+	if _, err := store.GetArtifactType(ctx, "model"); status.Code(err) != codes.NotFound {
+		t.Errorf("GetArtifactType of a deleted type = %v\nWant NotFound error", err)
+	}
+	if _, err := store.GetEventsByExecutionIDs(ctx, eids); err != mlmd.ErrNoRecord {
+		t.Errorf("GetEventsByExecutionIDs() after DeleteArtifactType = %v\nWant %v", err, mlmd.ErrNoRecord)
+	}
+	if err := store.DeleteArtifactType(ctx, "model"); status.Code(err) != codes.NotFound {
+		t.Errorf("DeleteArtifactType of a deleted type = %v\nWant NotFound error", err)
+	}
+	// The name of a deleted type can be used again.
+	if _, err := store.PutArtifactType(ctx, &mlpb.ArtifactType{Name: proto.String("model")}, &mlmd.PutTypeOptions{}); err != nil {
+		t.Errorf("PutArtifactType reusing a deleted name = %v\nWant nil error", err)
+	}
+
+	if err := store.DeleteExecutions(ctx, eids); err != nil {
+		t.Fatalf("DeleteExecutions failed: %v", err)
+	}
+	if got, err := store.GetExecutionsByID(ctx, eids); err != nil || len(got) != 0 {
+		t.Errorf("GetExecutionsByID of a deleted execution = %v, %v\nWant no executions", got, err)
+	}
+	if err := store.DeleteExecutionType(ctx, "train"); err != nil {
+		t.Errorf("DeleteExecutionType failed: %v", err)
+	}
+}
+
+func TestConcurrentReads(t *testing.T) {
+	forEachStore(t, testConcurrentReads)
+}
+
+// testConcurrentReads reads records while others are stored, which may happen
+// between the queries of the records and of their properties.
+func testConcurrentReads(t *testing.T, store *Store) {
+	ctx := context.Background()
+	atid, err := store.PutArtifactType(ctx, &mlpb.ArtifactType{
+		Name:       proto.String("model"),
+		Properties: map[string]mlpb.PropertyType{"size": mlpb.PropertyType_INT},
+	}, &mlmd.PutTypeOptions{})
+	if err != nil {
+		t.Fatalf("PutArtifactType failed: %v", err)
+	}
+	etid, err := store.PutExecutionType(ctx, &mlpb.ExecutionType{Name: proto.String("train")}, &mlmd.PutTypeOptions{})
+	if err != nil {
+		t.Fatalf("PutExecutionType failed: %v", err)
+	}
+	eids, err := store.PutExecutions(ctx, []*mlpb.Execution{{TypeId: proto.Int64(int64(etid))}})
+	if err != nil {
+		t.Fatalf("PutExecutions failed: %v", err)
+	}
+
+	const writes = 100
+	errc := make(chan error, 1)
+	go func() {
+		for i := 0; i < writes; i++ {
+			aids, err := store.PutArtifacts(ctx, []*mlpb.Artifact{{
+				TypeId:     proto.Int64(int64(atid)),
+				Properties: map[string]*mlpb.Value{"size": {Value: &mlpb.Value_IntValue{IntValue: int64(i)}}},
+			}})
+			if err != nil {
+				errc <- err
+				return
+			}
+			if err := store.PutEvents(ctx, []*mlpb.Event{{
+				ArtifactId:  proto.Int64(int64(aids[0])),
+				ExecutionId: proto.Int64(int64(eids[0])),
+				Type:        mlpb.Event_OUTPUT.Enum(),
+				Path:        &mlpb.Event_Path{Steps: []*mlpb.Event_Path_Step{{Value: &mlpb.Event_Path_Step_Index{Index: int64(i)}}}},
+			}}); err != nil {
+				errc <- err
+				return
+			}
+			if _, err := store.PutArtifactType(ctx, &mlpb.ArtifactType{
+				Name:       proto.String(fmt.Sprintf("type%d", i)),
+				Properties: map[string]mlpb.PropertyType{"size": mlpb.PropertyType_INT},
+			}, &mlmd.PutTypeOptions{}); err != nil {
+				errc <- err
+				return
+			}
+		}
+		errc <- nil
+	}()
+
+	for done := false; !done; {
+		select {
+		case err := <-errc:
+			if err != nil {
+				t.Fatalf("Concurrent write failed: %v", err)
+			}
+			done = true
+		default:
+		}
+		if _, err := store.GetArtifacts(ctx); err != nil && err != mlmd.ErrNoRecord {
+			t.Fatalf("GetArtifacts failed: %v", err)
+		}
+		if _, err := store.GetArtifactTypes(ctx); err != nil {
+			t.Fatalf("GetArtifactTypes failed: %v", err)
+		}
+		if _, err := store.GetEventsByExecutionIDs(ctx, eids); err != nil && err != mlmd.ErrNoRecord {
+			t.Fatalf("GetEventsByExecutionIDs failed: %v", err)
+		}
+	}
+
+	artifacts, err := store.GetArtifacts(ctx)
+	if err != nil || len(artifacts) != writes {
+		t.Fatalf("GetArtifacts() = %d artifacts, %v\nWant %d", len(artifacts), err, writes)
+	}
+	for _, a := range artifacts {
+		if _, ok := a.GetProperties()["size"]; !ok {
+			t.Errorf("GetArtifacts() returned %v\nWant a size property", a)
+		}
+	}
+}
+
+func TestContext(t *testing.T) {
+	forEachStore(t, testContext)
+}
+
+func testContext(t *testing.T, store *Store) {
+	canceled, cancel := context.WithCancel(context.Background())
+	cancel()
+	if _, err := store.PutArtifactType(canceled, &mlpb.ArtifactType{Name: proto.String("model")}, &mlmd.PutTypeOptions{}); err != context.Canceled {
+		t.Errorf("PutArtifactType with a canceled context = %v\nWant %v", err, context.Canceled)
+	}
+	if _, err := store.GetArtifactTypes(canceled); err != context.Canceled {
+		t.Errorf("GetArtifactTypes with a canceled context = %v\nWant %v", err, context.Canceled)
+	}
+
+	// A transaction whose context is canceled midway is rolled back.
+	ctx, cancel := context.WithCancel(context.Background())
+	err := store.inTx(ctx, func(c conn) error {
+		if _, err := c.insert("INSERT INTO `Type` (`name`, `is_artifact_type`) VALUES (?, ?)", "model", 1); err != nil {
+			return err
+		}
+		cancel()
+		_, err := c.exec("INSERT INTO `Type` (`name`, `is_artifact_type`) VALUES (?, ?)", "run", 1)
+		return err
+	})
+	if err == nil {
+		t.Errorf("inTx canceled midway = nil error\nWant non-nil error")
+	}
+	if types, err := store.GetArtifactTypes(context.Background()); err != nil || len(types) != 0 {
+		t.Errorf("GetArtifactTypes() after canceled writes = %v, %v\nWant no types", types, err)
+	}
+}
+
+func TestInTransaction(t *testing.T) {
+	forEachStore(t, testInTransaction)
+}
+
+func testInTransaction(t *testing.T, store *Store) {
+	ctx := context.Background()
+	model := &mlpb.ArtifactType{Name: proto.String("model")}
+	err := store.InTransaction(ctx, func(ctx context.Context) error {
+		if _, err := store.PutArtifactType(ctx, model, &mlmd.PutTypeOptions{}); err != nil {
+			return err
+		}
+		// Nested transactions join the outer one.
+		if err := store.InTransaction(ctx, func(ctx context.Context) error {
+			_, err := store.PutArtifacts(ctx, []*mlpb.Artifact{{TypeId: proto.Int64(1)}})
+			return err
+		}); err != nil {
+			return err
+		}
+		if types, err := store.GetArtifactTypes(ctx); err != nil || len(types) != 1 {
+			t.Errorf("GetArtifactTypes() in the transaction = %v, %v\nWant the type written in it", types, err)
+		}
+		return status.Error(codes.Aborted, "aborted")
+	})
+	if status.Code(err) != codes.Aborted {
+		t.Errorf("InTransaction of a failing function = %v\nWant its error", err)
+	}
+	if types, err := store.GetArtifactTypes(ctx); err != nil || len(types) != 0 {
+		t.Errorf("GetArtifactTypes() after a failed transaction = %v, %v\nWant no types", types, err)
+	}
+
+	if err := store.InTransaction(ctx, func(ctx context.Context) error {
+		_, err := store.PutArtifactType(ctx, model, &mlmd.PutTypeOptions{})
+		return err
+	}); err != nil {
+		t.Fatalf("InTransaction failed: %v", err)
+	}
+	if _, err := store.GetArtifactType(ctx, "model"); err != nil {
+		t.Errorf("GetArtifactType() after a committed transaction failed: %v", err)
+	}
+}
+
+func TestSQLiteFile(t *testing.T) {
+	ctx := context.Background()
+	dir, err := ioutil.TempDir("", "sqlstore")
+	if err != nil {
+		t.Fatalf("failed to create directory: %v", err)
+	}
+	defer os.RemoveAll(dir)
+	file := filepath.Join(dir, "metadata.db")
+
+	store, err := NewSQLiteStore(file)
+	if err != nil {
+		t.Fatalf("NewSQLiteStore failed: %v", err)
+	}
+	if _, err := store.PutArtifactType(ctx, &mlpb.ArtifactType{Name: proto.String("model")}, &mlmd.PutTypeOptions{}); err != nil {
+		t.Fatalf("PutArtifactType failed: %v", err)
+	}
+	store.Close()
+
+	if store, err = NewSQLiteStore(file); err != nil {
+		t.Fatalf("NewSQLiteStore of an existing database failed: %v", err)
+	}
+	defer store.Close()
+	if _, err := store.GetArtifactType(ctx, "model"); err != nil {
+		t.Errorf("GetArtifactType of a type stored before reopening failed: %v", err)
+	}
+}
+
+func TestMigrate(t *testing.T) {
+	ctx := context.Background()
+	dir, err := ioutil.TempDir("", "sqlstore")
+	if err != nil {
+		t.Fatalf("failed to create directory: %v", err)
+	}
+	defer os.RemoveAll(dir)
+	file := filepath.Join(dir, "metadata.db")
+
+	// Databases created before migrations were recorded have the tables of
+	// the first one.
+	db, err := sql.Open("sqlite", file)
+	if err != nil {
+		t.Fatalf("failed to open database: %v", err)
+	}
+	for _, statement := range createTables(sqlite) {
+		if _, err := db.Exec(statement); err != nil {
+			t.Fatalf("failed to create tables: %v", err)
+		}
+	}
+	db.Close()
+
+	store, err := NewSQLiteStore(file)
+	if err != nil {
+		t.Fatalf("NewSQLiteStore of a database without schema version failed: %v", err)
+	}
+	if version, err := schemaVersion(store.conn(ctx)); err != nil || version != len(migrations) {
+		t.Errorf("schemaVersion() = %v, %v\nWant %v, nil", version, err, len(migrations))
+	}
+	if _, err := store.conn(ctx).exec("INSERT INTO `SchemaVersion` (`version`) VALUES (?)", len(migrations)+1); err != nil {
+		t.Fatalf("failed to set the schema version: %v", err)
+	}
+	store.Close()
+
+	if _, err := NewSQLiteStore(file); err == nil {
+		t.Error("NewSQLiteStore of a database with a newer schema version succeeded, want error")
+	}
+}
+
+func TestRebind(t *testing.T) {
+	query := "SELECT `id` FROM `Type` WHERE `name` = ? AND `id` IN (?, ?)"
+	if got := sqlite.rebind(query); got != query {
+		t.Errorf("sqlite.rebind(%q) = %q, want it unchanged", query, got)
+	}
+	want := `SELECT "id" FROM "Type" WHERE "name" = $1 AND "id" IN ($2, $3)`
+	if got := postgres.rebind(query); got != want {
+		t.Errorf("postgres.rebind(%q) = %q\nWant %q", query, got, want)
+	}
+}
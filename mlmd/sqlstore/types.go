@@ -0,0 +1,317 @@
+// Copyright 2019 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sqlstore
+
+import (
+	"context"
+
+	mlpb "ml_metadata/proto/metadata_store_go_proto"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/kubeflow/metadata/mlmd"
+	"google.golang.org/grpc/codes"
+)
+
+// storedType is a row of the Type table with its properties.
+type storedType struct {
+	id         int64
+	name       string
+	properties map[string]mlpb.PropertyType
+}
+
+// PutArtifactType inserts an artifact type, or updates the artifact type with
+// the same name as allowed by opts. It returns the id of the type.
+func (s *Store) PutArtifactType(ctx context.Context, atype *mlpb.ArtifactType, opts *mlmd.PutTypeOptions) (mlmd.ArtifactTypeID, error) {
+	id, err := s.putType(ctx, atype.GetName(), true, atype.GetProperties(), opts)
+	return mlmd.ArtifactTypeID(id), err
+}
+
+// GetArtifactType gets an artifact type by name.
+func (s *Store) GetArtifactType(ctx context.Context, name string) (*mlpb.ArtifactType, error) {
+	t, err := getTypeByName(s.conn(ctx), name, true)
+	if err != nil {
+		return nil, err
+	}
+	return t.artifactType(), nil
+}
+
+// GetArtifactTypesByID gets the artifact types of tids, skipping unknown ids.
+func (s *Store) GetArtifactTypesByID(ctx context.Context, tids []mlmd.ArtifactTypeID) ([]*mlpb.ArtifactType, error) {
+	ids := make([]int64, len(tids))
+	for i, id := range tids {
+		ids[i] = int64(id)
+	}
+	types, err := getTypesByID(s.conn(ctx), ids, true)
+	if err != nil {
+		return nil, err
+	}
+	var atypes []*mlpb.ArtifactType
+	for _, t := range types {
+		atypes = append(atypes, t.artifactType())
+	}
+	return atypes, nil
+}
+
+// GetArtifactTypes gets all artifact types.
+func (s *Store) GetArtifactTypes(ctx context.Context) ([]*mlpb.ArtifactType, error) {
+	types, err := getTypes(s.conn(ctx), true, "1 = 1")
+	if err != nil {
+		return nil, err
+	}
+	var atypes []*mlpb.ArtifactType
+	for _, t := range types {
+		atypes = append(atypes, t.artifactType())
+	}
+	return atypes, nil
+}
+
+// DeleteArtifactType deletes the artifact type name along with its artifacts
+// and their events.
+func (s *Store) DeleteArtifactType(ctx context.Context, name string) error {
+	return s.deleteType(ctx, name, artifactTable)
+}
+
+// PutExecutionType inserts an execution type, or updates the execution type
+// with the same name as allowed by opts. It returns the id of the type.
+func (s *Store) PutExecutionType(ctx context.Context, etype *mlpb.ExecutionType, opts *mlmd.PutTypeOptions) (mlmd.ExecutionTypeID, error) {
+	id, err := s.putType(ctx, etype.GetName(), false, etype.GetProperties(), opts)
+	return mlmd.ExecutionTypeID(id), err
+}
+
+// GetExecutionType gets an execution type by name.
+func (s *Store) GetExecutionType(ctx context.Context, typeName string) (*mlpb.ExecutionType, error) {
+	t, err := getTypeByName(s.conn(ctx), typeName, false)
+	if err != nil {
+		return nil, err
+	}
+	return t.executionType(), nil
+}
+
+// GetExecutionTypesByID gets the execution types of tids, skipping unknown
+// ids.
+func (s *Store) GetExecutionTypesByID(ctx context.Context, tids []mlmd.ExecutionTypeID) ([]*mlpb.ExecutionType, error) {
+	ids := make([]int64, len(tids))
+	for i, id := range tids {
+		ids[i] = int64(id)
+	}
+	types, err := getTypesByID(s.conn(ctx), ids, false)
+	if err != nil {
+		return nil, err
+	}
+	var etypes []*mlpb.ExecutionType
+	for _, t := range types {
+		etypes = append(etypes, t.executionType())
+	}
+	return etypes, nil
+}
+
+// GetExecutionTypes gets all execution types.
+func (s *Store) GetExecutionTypes(ctx context.Context) ([]*mlpb.ExecutionType, error) {
+	types, err := getTypes(s.conn(ctx), false, "1 = 1")
+	if err != nil {
+		return nil, err
+	}
+	var etypes []*mlpb.ExecutionType
+	for _, t := range types {
+		etypes = append(etypes, t.executionType())
+	}
+	return etypes, nil
+}
+
+// DeleteExecutionType deletes the execution type name along with its
+// executions and their events.
+func (s *Store) DeleteExecutionType(ctx context.Context, name string) error {
+	return s.deleteType(ctx, name, executionTable)
+}
+
+func (t *storedType) artifactType() *mlpb.ArtifactType {
+	return &mlpb.ArtifactType{Id: proto.Int64(t.id), Name: proto.String(t.name), Properties: t.properties}
+}
+
+func (t *storedType) executionType() *mlpb.ExecutionType {
+	return &mlpb.ExecutionType{Id: proto.Int64(t.id), Name: proto.String(t.name), Properties: t.properties}
+}
+
+// putType inserts the type name with properties, or adds properties to the
+// stored type of that name as allowed by opts, like MLMD.
+func (s *Store) putType(ctx context.Context, name string, isArtifactType bool, properties map[string]mlpb.PropertyType, opts *mlmd.PutTypeOptions) (int64, error) {
+	if name == "" {
+		return 0, mlmd.Errorf(codes.InvalidArgument, "No type name is specified.")
+	}
+	if opts == nil {
+		opts = &mlmd.PutTypeOptions{}
+	}
+	if opts.CanDeleteFields {
+		return 0, mlmd.Errorf(codes.Unimplemented, "Deleting fields is not supported.")
+	}
+	for p, t := range properties {
+		if t == mlpb.PropertyType_UNKNOWN {
+			return 0, mlmd.Errorf(codes.InvalidArgument, "Property %s type should not be UNKNOWN", p)
+		}
+	}
+
+	var id int64
+	err := s.inTx(ctx, func(c conn) error {
+		types, err := getTypes(c, isArtifactType, "`name` = ?", name)
+		if err != nil {
+			return err
+		}
+		if len(types) == 0 {
+			if id, err = c.insert("INSERT INTO `Type` (`name`, `is_artifact_type`) VALUES (?, ?)", name, boolValue(isArtifactType)); err != nil {
+				return err
+			}
+			return insertTypeProperties(c, id, properties)
+		}
+
+		stored := types[0]
+		id = stored.id
+		added := make(map[string]mlpb.PropertyType)
+		for p, t := range properties {
+			storedType, ok := stored.properties[p]
+			if ok && storedType != t || !ok && !opts.CanAddFields {
+				return mlmd.Errorf(codes.AlreadyExists, "Type already exists with different properties: %s", name)
+			}
+			if !ok {
+				added[p] = t
+			}
+		}
+		if opts.AllFieldsMustMatch && len(stored.properties) > len(properties) {
+			return mlmd.Errorf(codes.AlreadyExists, "Type already exists with different properties: %s", name)
+		}
+		return insertTypeProperties(c, id, added)
+	})
+	return id, err
+}
+
+// deleteType deletes the type name of the nodes of t, with its properties
+// and nodes.
+func (s *Store) deleteType(ctx context.Context, name string, t *nodeTable) error {
+	return s.inTx(ctx, func(c conn) error {
+		stored, err := getTypeByName(c, name, t.isArtifactType)
+		if err != nil {
+			return err
+		}
+		if err := t.delete(c, "`type_id` = ?", stored.id); err != nil {
+			return err
+		}
+		if _, err := c.exec("DELETE FROM `TypeProperty` WHERE `type_id` = ?", stored.id); err != nil {
+			return err
+		}
+		_, err = c.exec("DELETE FROM `Type` WHERE `id` = ?", stored.id)
+		return err
+	})
+}
+
+func insertTypeProperties(c conn, typeID int64, properties map[string]mlpb.PropertyType) error {
+	for p, t := range properties {
+		if _, err := c.exec("INSERT INTO `TypeProperty` (`type_id`, `name`, `data_type`) VALUES (?, ?, ?)", typeID, p, int32(t)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func getTypeByName(c conn, name string, isArtifactType bool) (*storedType, error) {
+	types, err := getTypes(c, isArtifactType, "`name` = ?", name)
+	if err != nil {
+		return nil, err
+	}
+	if len(types) == 0 {
+		return nil, mlmd.Errorf(codes.NotFound, "No type found for query: %s", name)
+	}
+	return types[0], nil
+}
+
+// getTypesByID returns the types of ids in their order, skipping unknown ids.
+func getTypesByID(c conn, ids []int64, isArtifactType bool) ([]*storedType, error) {
+	if len(ids) == 0 {
+		return nil, nil
+	}
+	where, args := in("id", ids)
+	types, err := getTypes(c, isArtifactType, where, args...)
+	if err != nil {
+		return nil, err
+	}
+	byID := make(map[int64]*storedType)
+	for _, t := range types {
+		byID[t.id] = t
+	}
+	var result []*storedType
+	for _, id := range ids {
+		if t, ok := byID[id]; ok {
+			result = append(result, t)
+		}
+	}
+	return result, nil
+}
+
+// getTypes returns the artifact or execution types matching the condition
+// where on the Type table, ordered by id.
+func getTypes(c conn, isArtifactType bool, where string, args ...interface{}) ([]*storedType, error) {
+	where = "`is_artifact_type` = ? AND " + where
+	args = append([]interface{}{boolValue(isArtifactType)}, args...)
+
+	rows, err := c.query("SELECT `id`, `name` FROM `Type` WHERE "+where+" ORDER BY `id`", args...)
+	if err != nil {
+		return nil, err
+	}
+	var types []*storedType
+	byID := make(map[int64]*storedType)
+	for rows.Next() {
+		t := &storedType{}
+		if err := rows.Scan(&t.id, &t.name); err != nil {
+			rows.Close()
+			return nil, err
+		}
+		types = append(types, t)
+		byID[t.id] = t
+	}
+	// The rows are closed before the next query, as a connection or
+	// transaction runs one query at a time. Records stored in between
+	// match the second query only, and are left out.
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	if len(types) == 0 {
+		return nil, nil
+	}
+
+	propRows, err := c.query("SELECT `type_id`, `name`, `data_type` FROM `TypeProperty` WHERE `type_id` IN (SELECT `id` FROM `Type` WHERE "+where+")", args...)
+	if err != nil {
+		return nil, err
+	}
+	defer propRows.Close()
+	for propRows.Next() {
+		var (
+			id       int64
+			name     string
+			dataType int32
+		)
+		if err := propRows.Scan(&id, &name, &dataType); err != nil {
+			return nil, err
+		}
+		t, ok := byID[id]
+		if !ok {
+			// The type was stored after the first query.
+			continue
+		}
+		if t.properties == nil {
+			t.properties = make(map[string]mlpb.PropertyType)
+		}
+		t.properties[name] = mlpb.PropertyType(dataType)
+	}
+	return types, propRows.Err()
+}
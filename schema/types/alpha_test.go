@@ -0,0 +1,135 @@
+// Copyright 2019 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package types
+
+import (
+	"encoding/json"
+	"testing"
+
+	mlpb "ml_metadata/proto/metadata_store_go_proto"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestModelArtifactRoundTrip(t *testing.T) {
+	model := &Model{
+		ID:        "12",
+		Name:      "MNIST",
+		URI:       "gcs://my-bucket/mnist",
+		ModelType: "neural network",
+		TrainingFramework: &ModelTrainingFramework{
+			Name:    "tensorflow",
+			Version: "v1.0",
+		},
+		Hyperparameters: map[string]interface{}{"early_stop": true},
+		Annotations:     map[string]string{"mylabel": "l1"},
+	}
+	artifact, err := model.ToArtifact()
+	if err != nil {
+		t.Fatalf("ToArtifact failed: %v", err)
+	}
+
+	if artifact.GetId() != 12 || artifact.GetUri() != model.URI {
+		t.Errorf("ToArtifact returned id %d and uri %q, want 12 and %q", artifact.GetId(), artifact.GetUri(), model.URI)
+	}
+	wantProperties := map[string]*mlpb.Value{
+		"name":                       &mlpb.Value{Value: &mlpb.Value_StringValue{StringValue: "MNIST"}},
+		"model_type":                 &mlpb.Value{Value: &mlpb.Value_StringValue{StringValue: "neural network"}},
+		"training_framework.name":    &mlpb.Value{Value: &mlpb.Value_StringValue{StringValue: "tensorflow"}},
+		"training_framework.version": &mlpb.Value{Value: &mlpb.Value_StringValue{StringValue: "v1.0"}},
+		"hyperparameters":            &mlpb.Value{Value: &mlpb.Value_StringValue{StringValue: `{"early_stop":true}`}},
+		"annotations":                &mlpb.Value{Value: &mlpb.Value_StringValue{StringValue: `{"mylabel":"l1"}`}},
+	}
+	for name, want := range wantProperties {
+		if got := artifact.GetProperties()[name]; !proto.Equal(got, want) {
+			t.Errorf("ToArtifact set property %q to %v, want %v", name, got, want)
+		}
+	}
+	var doc map[string]interface{}
+	if err := json.Unmarshal([]byte(artifact.GetProperties()[allMeta].GetStringValue()), &doc); err != nil {
+		t.Fatalf("ToArtifact set malformed %s: %v", allMeta, err)
+	}
+	if doc["kind"] != "model" || doc["category"] != "artifact" {
+		t.Errorf("ToArtifact set %s without constants: %v", allMeta, doc)
+	}
+
+	got, err := ModelFromArtifact(artifact)
+	if err != nil {
+		t.Fatalf("ModelFromArtifact failed: %v", err)
+	}
+	if diff := cmp.Diff(model, got); diff != "" {
+		t.Errorf("ModelFromArtifact(ToArtifact()) returned unexpected model. Diff (-want, +got):\n%s", diff)
+	}
+}
+
+func TestModelFromArtifactProperties(t *testing.T) {
+	// The stored document lacks the fields that have typed properties.
+	artifact := &mlpb.Artifact{
+		Id:  proto.Int64(3),
+		Uri: proto.String("gcs://my-bucket/mnist"),
+		Properties: map[string]*mlpb.Value{
+			allMeta:                   &mlpb.Value{Value: &mlpb.Value_StringValue{StringValue: `{"hyperparameters":{"layers":[10,3,1]}}`}},
+			"name":                    &mlpb.Value{Value: &mlpb.Value_StringValue{StringValue: "MNIST"}},
+			"training_framework.name": &mlpb.Value{Value: &mlpb.Value_StringValue{StringValue: "tensorflow"}},
+			"hyperparameters":         &mlpb.Value{Value: &mlpb.Value_StringValue{StringValue: `{"layers":[10,3,1]}`}},
+		},
+	}
+	got, err := ModelFromArtifact(artifact)
+	if err != nil {
+		t.Fatalf("ModelFromArtifact failed: %v", err)
+	}
+	want := &Model{
+		ID:                "3",
+		Name:              "MNIST",
+		URI:               "gcs://my-bucket/mnist",
+		TrainingFramework: &ModelTrainingFramework{Name: "tensorflow"},
+		Hyperparameters:   map[string]interface{}{"layers": []interface{}{10.0, 3.0, 1.0}},
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("ModelFromArtifact returned unexpected model. Diff (-want, +got):\n%s", diff)
+	}
+
+	artifact.Properties[allMeta] = &mlpb.Value{Value: &mlpb.Value_StringValue{StringValue: "[]"}}
+	if _, err := ModelFromArtifact(artifact); err == nil {
+		t.Error("ModelFromArtifact succeeded with a malformed document, want error")
+	}
+}
+
+func TestExecutionRoundTrip(t *testing.T) {
+	execution := &Execution{
+		Name:          "my-run",
+		ExecutableID:  "1234",
+		Configuration: &ExecutionConfiguration{Value: "apiVersion: v1"},
+	}
+	e, err := execution.ToExecution()
+	if err != nil {
+		t.Fatalf("ToExecution failed: %v", err)
+	}
+	if e.Id != nil {
+		t.Errorf("ToExecution set id %d for an execution without id", e.GetId())
+	}
+	if got := e.GetProperties()["configuration.value"].GetStringValue(); got != "apiVersion: v1" {
+		t.Errorf("ToExecution set configuration.value to %q, want %q", got, "apiVersion: v1")
+	}
+
+	got, err := ExecutionFromExecution(e)
+	if err != nil {
+		t.Fatalf("ExecutionFromExecution failed: %v", err)
+	}
+	if diff := cmp.Diff(execution, got); diff != "" {
+		t.Errorf("ExecutionFromExecution(ToExecution()) returned unexpected execution. Diff (-want, +got):\n%s", diff)
+	}
+}
@@ -0,0 +1,82 @@
+// Copyright 2019 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package main is a command line tool checking that a new version of a schema
+// directory is compatible with an old one. It prints the incompatible changes
+// and exits with a non-zero status if any of them breaks the checked
+// compatibility, so that it can gate changes to schemas, e.g.
+//
+//	git worktree add /tmp/base origin/master
+//	schemacompat --old_schema_root_dir=/tmp/base/schema/alpha --new_schema_root_dir=schema/alpha
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+
+	"github.com/golang/glog"
+	"github.com/kubeflow/metadata/schemaparser"
+)
+
+var (
+	oldSchemaRootDir = flag.String("old_schema_root_dir", "", "Root directory of the old version of the schemas.")
+	newSchemaRootDir = flag.String("new_schema_root_dir", "schema/alpha", "Root directory of the new version of the schemas.")
+	compatibility    = flag.String("compatibility", "backward", "Compatibility to enforce. Supported options: backward (new schemas accept old data), forward (old schemas accept new data), full (both), none (only report)")
+)
+
+func main() {
+	flag.Parse()
+
+	var enforced schemaparser.Compatibility
+	switch *compatibility {
+	case "backward":
+		enforced = schemaparser.BackwardIncompatible
+	case "forward":
+		enforced = schemaparser.ForwardIncompatible
+	case "full":
+		enforced = schemaparser.BackwardIncompatible | schemaparser.ForwardIncompatible
+	case "none":
+	default:
+		glog.Fatalf("Unknown compatibility %q: please choose from [backward, forward, full, none]", *compatibility)
+	}
+	if *oldSchemaRootDir == "" {
+		glog.Fatal("old_schema_root_dir must be set")
+	}
+
+	old, err := schemaparser.NewSchemaSetFromADir(*oldSchemaRootDir)
+	if err != nil {
+		glog.Fatalf("Failed to load old schemas: %v", err)
+	}
+	new, err := schemaparser.NewSchemaSetFromADir(*newSchemaRootDir)
+	if err != nil {
+		glog.Fatalf("Failed to load new schemas: %v", err)
+	}
+	changes, err := schemaparser.CheckCompatibility(old, new)
+	if err != nil {
+		glog.Fatalf("Failed to check compatibility: %v", err)
+	}
+
+	failed := false
+	for _, c := range changes {
+		fmt.Println(c)
+		if c.Compatibility&enforced != 0 {
+			failed = true
+		}
+	}
+	if failed {
+		fmt.Fprintf(os.Stderr, "Schemas in %s are not %s compatible with %s\n", *newSchemaRootDir, *compatibility, *oldSchemaRootDir)
+		os.Exit(1)
+	}
+}
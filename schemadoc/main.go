@@ -0,0 +1,63 @@
+// Copyright 2019 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package main is a command line tool generating Markdown or HTML
+// documentation for the schemas of a directory.
+package main
+
+import (
+	"flag"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+
+	"github.com/golang/glog"
+	"github.com/kubeflow/metadata/schemaparser"
+)
+
+var (
+	schemaRootDir = flag.String("schema_root_dir", "schema/alpha", "Root directory of the schemas to document.")
+	outputDir     = flag.String("output_dir", "schema/alpha/docs", "Directory to write the documentation to. Its previous content is removed.")
+	format        = flag.String("format", "markdown", "Documentation format. Supported options: markdown, html")
+)
+
+func main() {
+	flag.Parse()
+
+	docFormat, err := schemaparser.ParseDocFormat(*format)
+	if err != nil {
+		glog.Fatalf("Invalid format: %v", err)
+	}
+	ss, err := schemaparser.NewSchemaSetFromADir(*schemaRootDir)
+	if err != nil {
+		glog.Fatalf("Failed to load schemas: %v", err)
+	}
+	docs, err := schemaparser.GenerateDocs(ss, docFormat)
+	if err != nil {
+		glog.Fatalf("Failed to generate documentation: %v", err)
+	}
+
+	if err := os.RemoveAll(*outputDir); err != nil {
+		glog.Fatalf("Failed to remove %s: %v", *outputDir, err)
+	}
+	for p, doc := range docs {
+		file := filepath.Join(*outputDir, filepath.FromSlash(p))
+		if err := os.MkdirAll(filepath.Dir(file), 0755); err != nil {
+			glog.Fatalf("Failed to create directory for %s: %v", file, err)
+		}
+		if err := ioutil.WriteFile(file, doc, 0644); err != nil {
+			glog.Fatalf("Failed to write %s: %v", file, err)
+		}
+	}
+}
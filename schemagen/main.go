@@ -0,0 +1,55 @@
+// Copyright 2019 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package main is a command line tool generating Go structs for the artifact
+// and execution schemas of a directory, along with functions converting them
+// to and from MLMD artifacts and executions.
+package main
+
+import (
+	"flag"
+	"io/ioutil"
+	"os"
+
+	"github.com/golang/glog"
+	"github.com/kubeflow/metadata/schemaparser"
+)
+
+var (
+	schemaRootDir = flag.String("schema_root_dir", "schema/alpha", "Root directory of the schemas to generate Go types for.")
+	pkg           = flag.String("package", "types", "Name of the generated Go package.")
+	output        = flag.String("output", "", "File to write the generated code to. Empty writes to stdout.")
+)
+
+func main() {
+	flag.Parse()
+
+	ss, err := schemaparser.NewSchemaSetFromADir(*schemaRootDir)
+	if err != nil {
+		glog.Fatalf("Failed to load schemas: %v", err)
+	}
+	src, err := schemaparser.GenerateGo(ss, *pkg)
+	if err != nil {
+		glog.Fatalf("Failed to generate Go types: %v", err)
+	}
+	if *output == "" {
+		if _, err := os.Stdout.Write(src); err != nil {
+			glog.Fatal(err)
+		}
+		return
+	}
+	if err := ioutil.WriteFile(*output, src, 0644); err != nil {
+		glog.Fatalf("Failed to write %s: %v", *output, err)
+	}
+}
@@ -0,0 +1,608 @@
+// Copyright 2019 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package schemaparser
+
+import (
+	"bytes"
+	"fmt"
+	"go/format"
+	"sort"
+	"strings"
+	"text/template"
+	"unicode"
+)
+
+// goInitialisms are the name segments spelled in upper case in Go names.
+var goInitialisms = map[string]bool{
+	"api":  true,
+	"html": true,
+	"http": true,
+	"id":   true,
+	"json": true,
+	"uri":  true,
+	"url":  true,
+}
+
+// goName converts a JSON property name, e.g. data_set_id, to an exported Go
+// name, e.g. DataSetID.
+func goName(name string) string {
+	segments := strings.FieldsFunc(name, func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+	var b strings.Builder
+	for _, s := range segments {
+		if goInitialisms[strings.ToLower(s)] {
+			b.WriteString(strings.ToUpper(s))
+			continue
+		}
+		r := []rune(s)
+		b.WriteString(string(unicode.ToUpper(r[0])) + string(r[1:]))
+	}
+	if b.Len() == 0 || unicode.IsDigit([]rune(b.String())[0]) {
+		return "X" + b.String()
+	}
+	return b.String()
+}
+
+// goStruct is a struct generated from an object of a schema.
+type goStruct struct {
+	Name        string
+	Description string
+	Fields      []goField
+}
+
+// goField is a field of a generated struct.
+type goField struct {
+	Name        string
+	Type        string
+	JSONName    string
+	Description string
+	Required    bool
+}
+
+// goType is a type generated from an artifact or execution schema.
+type goType struct {
+	ID        string
+	Name      string
+	TypeName  string
+	Category  string
+	Constants map[string]string
+	// PropertyTypes maps the flattened names of the typed properties of the
+	// MLMD type to their JSON types.
+	PropertyTypes SimpleProperties
+	Structs       []goStruct
+}
+
+// GenerateGo returns the source of a Go package named pkg that defines a
+// struct for each artifact and execution schema of ss, along with functions
+// converting between the structs and MLMD artifacts or executions. Properties
+// of AllOf parents are flattened into the structs, and objects with declared
+// properties become nested structs. Constant properties, e.g. kind, are not
+// fields; they are filled in when converting to MLMD.
+func GenerateGo(ss *SchemaSet, pkg string) ([]byte, error) {
+	var ids []string
+	for id := range ss.Schemas {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+
+	var types []*goType
+	names := make(map[string]string)
+	for _, id := range ids {
+		t, err := newGoType(ss, id)
+		if err != nil {
+			return nil, err
+		}
+		if t == nil {
+			continue
+		}
+		for _, s := range t.Structs {
+			if other, exists := names[s.Name]; exists {
+				return nil, fmt.Errorf("type %s generated from %s collides with the one generated from %s", s.Name, id, other)
+			}
+			names[s.Name] = id
+		}
+		types = append(types, t)
+	}
+
+	var b bytes.Buffer
+	if err := goTemplate.Execute(&b, struct {
+		Package string
+		Types   []*goType
+	}{pkg, types}); err != nil {
+		return nil, fmt.Errorf("failed to generate Go code: %s", err)
+	}
+	src, err := format.Source(b.Bytes())
+	if err != nil {
+		return nil, fmt.Errorf("failed to format generated Go code: %s", err)
+	}
+	return src, nil
+}
+
+// newGoType returns the type generated from the schema of id, or nil if the
+// schema does not define an artifact or execution type.
+func newGoType(ss *SchemaSet, id string) (*goType, error) {
+	namespace, typename, err := ss.TypeName(id)
+	if err != nil {
+		return nil, nil
+	}
+	category, err := ss.ConstantStringType(id, categoryPropertyName)
+	if err != nil || category != artifactCategory && category != executionCategory {
+		return nil, nil
+	}
+
+	t := &goType{
+		ID:            id,
+		Name:          goName(typename),
+		TypeName:      namespace + "/" + typename,
+		Category:      category,
+		Constants:     make(map[string]string),
+		PropertyTypes: make(SimpleProperties),
+	}
+	properties, err := ss.FlattenedProperties(id)
+	if err != nil {
+		return nil, err
+	}
+	for name, jsonType := range properties {
+		if !isPropertyBuiltIn(name) {
+			t.PropertyTypes[name] = jsonType
+		}
+	}
+	ps, err := ss.propertySchemas(id)
+	if err != nil {
+		return nil, err
+	}
+	for name, sj := range ps.schemas {
+		if !strings.Contains(name, FlattenedPropertySeparator) && sj.Constant != "" {
+			t.Constants[name] = sj.Constant
+		}
+	}
+	if err := t.addStruct(ps, t.Name, "", ss.Schemas[id].JSON.Description); err != nil {
+		return nil, fmt.Errorf("failed to generate Go type for %s: %s", id, err)
+	}
+	return t, nil
+}
+
+// addStruct adds the struct name holding the properties of ps prefixed with
+// prefix, along with the structs of its nested objects.
+func (t *goType) addStruct(ps *propertySchemas, name, prefix, description string) error {
+	s := goStruct{Name: name, Description: description}
+	fieldNames := make(map[string]string)
+	type object struct{ name, prefix, description string }
+	var nested []object
+	for pname, sj := range ps.schemas {
+		if !strings.HasPrefix(pname, prefix) {
+			continue
+		}
+		jsonName := strings.TrimPrefix(pname, prefix)
+		if strings.Contains(jsonName, FlattenedPropertySeparator) || prefix == "" && sj.Constant != "" {
+			continue
+		}
+		f := goField{
+			Name:        goName(jsonName),
+			JSONName:    jsonName,
+			Description: sj.Description,
+			Required:    ps.required[pname],
+		}
+		if other, exists := fieldNames[f.Name]; exists {
+			return fmt.Errorf("properties %q and %q of %s map to the same field %s", other, jsonName, name, f.Name)
+		}
+		fieldNames[f.Name] = jsonName
+
+		if len(sj.Properties) > 0 || len(sj.AllOf) > 0 {
+			f.Type = "*" + name + f.Name
+			nested = append(nested, object{name + f.Name, pname + FlattenedPropertySeparator, sj.Description})
+		} else {
+			f.Type = goFieldType(sj, f.Required)
+		}
+		s.Fields = append(s.Fields, f)
+	}
+	sort.Slice(s.Fields, func(i, j int) bool { return s.Fields[i].JSONName < s.Fields[j].JSONName })
+	t.Structs = append(t.Structs, s)
+
+	sort.Slice(nested, func(i, j int) bool { return nested[i].name < nested[j].name })
+	for _, o := range nested {
+		if err := t.addStruct(ps, o.name, o.prefix, o.description); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// goFieldType returns the Go type of a field defined by sj. Optional integers,
+// numbers and booleans are pointers, so that their zero value is not mistaken
+// for an unset field.
+func goFieldType(sj *SchemaJSON, required bool) string {
+	if sj.Type == nil {
+		return "interface{}"
+	}
+	t := goScalarType(sj)
+	switch sj.GetType() {
+	case IntegerType, NumberType, BooleanType:
+		if !required {
+			return "*" + t
+		}
+		return t
+	case ArrayType:
+		if sj.Items != nil && sj.Items.Type != nil && goScalarType(sj.Items) != "" {
+			return "[]" + goScalarType(sj.Items)
+		}
+		return "[]interface{}"
+	case ObjectType:
+		if len(sj.PatternProperties) == 1 {
+			for _, values := range sj.PatternProperties {
+				if values.Type != nil && goScalarType(values) != "" {
+					return "map[string]" + goScalarType(values)
+				}
+			}
+		}
+		return "map[string]interface{}"
+	}
+	if t != "" {
+		return t
+	}
+	return "interface{}"
+}
+
+// goScalarType returns the Go type of a string, integer, number or boolean,
+// or an empty string for other types.
+func goScalarType(sj *SchemaJSON) string {
+	switch sj.GetType() {
+	case StringType:
+		return "string"
+	case IntegerType:
+		return "int64"
+	case NumberType:
+		return "float64"
+	case BooleanType:
+		return "bool"
+	}
+	return ""
+}
+
+// comment formats text as the lines of a Go comment.
+func comment(text string) string {
+	return strings.Replace(strings.TrimSpace(text), "\n", "\n// ", -1)
+}
+
+var goTemplate = template.Must(template.New("go").Funcs(template.FuncMap{
+	"comment":  comment,
+	"lower":    func(s string) string { r := []rune(s); return string(unicode.ToLower(r[0])) + string(r[1:]) },
+	"artifact": func(t *goType) bool { return t.Category == artifactCategory },
+}).Parse(`// Code generated by schemagen. DO NOT EDIT.
+
+package {{.Package}}
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+
+	mlpb "ml_metadata/proto/metadata_store_go_proto"
+
+	"github.com/golang/protobuf/proto"
+)
+{{range $t := .Types}}
+// {{$t.Name}}TypeName is the name of the {{if artifact $t}}ArtifactType{{else}}ExecutionType{{end}} defined by
+// {{$t.ID}}.
+const {{$t.Name}}TypeName = {{printf "%q" $t.TypeName}}
+
+var {{lower $t.Name}}Constants = map[string]string{
+{{- range $name, $value := $t.Constants}}
+	{{printf "%q" $name}}: {{printf "%q" $value}},
+{{- end}}
+}
+
+var {{lower $t.Name}}PropertyTypes = map[string]string{
+{{- range $name, $type := $t.PropertyTypes}}
+	{{printf "%q" $name}}: {{printf "%q" $type}},
+{{- end}}
+}
+{{range $s := $t.Structs}}
+// {{$s.Name}} is generated from {{$t.ID}}.{{if $s.Description}}
+// {{comment $s.Description}}{{end}}
+type {{$s.Name}} struct {
+{{- range $f := $s.Fields}}{{if $f.Description}}
+	// {{comment $f.Description}}{{end}}
+	{{$f.Name}} {{$f.Type}} ` + "`" + `json:"{{$f.JSONName}}{{if not $f.Required}},omitempty{{end}}"` + "`" + `
+{{- end}}
+}
+{{end}}
+{{- if artifact $t}}
+// ToArtifact returns v as an artifact of {{$t.Name}}TypeName, with its
+// properties and the whole document in __ALL_META__.
+func (v *{{$t.Name}}) ToArtifact() (*mlpb.Artifact, error) {
+	doc, err := toDocument(v, {{lower $t.Name}}Constants)
+	if err != nil {
+		return nil, err
+	}
+	return newArtifact(doc, {{lower $t.Name}}PropertyTypes)
+}
+
+// {{$t.Name}}FromArtifact returns the {{$t.Name}} held by an artifact of
+// {{$t.Name}}TypeName.
+func {{$t.Name}}FromArtifact(artifact *mlpb.Artifact) (*{{$t.Name}}, error) {
+	doc, err := artifactDocument(artifact, {{lower $t.Name}}PropertyTypes)
+	if err != nil {
+		return nil, err
+	}
+	v := new({{$t.Name}})
+	if err := fromDocument(doc, v); err != nil {
+		return nil, err
+	}
+	return v, nil
+}
+{{- else}}
+// ToExecution returns v as an execution of {{$t.Name}}TypeName, with its
+// properties and the whole document in __ALL_META__.
+func (v *{{$t.Name}}) ToExecution() (*mlpb.Execution, error) {
+	doc, err := toDocument(v, {{lower $t.Name}}Constants)
+	if err != nil {
+		return nil, err
+	}
+	return newExecution(doc, {{lower $t.Name}}PropertyTypes)
+}
+
+// {{$t.Name}}FromExecution returns the {{$t.Name}} held by an execution of
+// {{$t.Name}}TypeName.
+func {{$t.Name}}FromExecution(execution *mlpb.Execution) (*{{$t.Name}}, error) {
+	doc, err := executionDocument(execution, {{lower $t.Name}}PropertyTypes)
+	if err != nil {
+		return nil, err
+	}
+	v := new({{$t.Name}})
+	if err := fromDocument(doc, v); err != nil {
+		return nil, err
+	}
+	return v, nil
+}
+{{- end}}
+{{end}}
+` + goHelpers))
+
+// goHelpers are the functions shared by the generated conversions. They
+// follow the mapping of the metadata service: nested fields are stored in
+// properties with dotted names, booleans in INT properties, and arrays and
+// objects in STRING properties holding their JSON encoding.
+const goHelpers = `
+const allMeta = "__ALL_META__"
+
+func toDocument(v interface{}, constants map[string]string) (map[string]interface{}, error) {
+	b, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+	doc, err := parseDocument(string(b))
+	if err != nil {
+		return nil, err
+	}
+	for name, value := range constants {
+		doc[name] = value
+	}
+	return doc, nil
+}
+
+func fromDocument(doc map[string]interface{}, v interface{}) error {
+	b, err := json.Marshal(doc)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(b, v)
+}
+
+func parseDocument(s string) (map[string]interface{}, error) {
+	doc := make(map[string]interface{})
+	d := json.NewDecoder(bytes.NewReader([]byte(s)))
+	d.UseNumber()
+	if err := d.Decode(&doc); err != nil {
+		return nil, fmt.Errorf("%s must hold a JSON object: %v", allMeta, err)
+	}
+	if doc == nil {
+		return nil, fmt.Errorf("%s must hold a JSON object", allMeta)
+	}
+	return doc, nil
+}
+
+func lookupField(doc map[string]interface{}, name string) (interface{}, bool) {
+	segments := strings.Split(name, ".")
+	for _, segment := range segments[:len(segments)-1] {
+		next, ok := doc[segment].(map[string]interface{})
+		if !ok {
+			return nil, false
+		}
+		doc = next
+	}
+	field, ok := doc[segments[len(segments)-1]]
+	return field, ok
+}
+
+func setField(doc map[string]interface{}, name string, field interface{}) {
+	segments := strings.Split(name, ".")
+	for _, segment := range segments[:len(segments)-1] {
+		if _, ok := doc[segment]; !ok {
+			doc[segment] = make(map[string]interface{})
+		}
+		next, ok := doc[segment].(map[string]interface{})
+		if !ok {
+			return
+		}
+		doc = next
+	}
+	doc[segments[len(segments)-1]] = field
+}
+
+// propertyValues returns the properties holding the fields of doc, including
+// the whole document in allMeta.
+func propertyValues(doc map[string]interface{}, types map[string]string) (map[string]*mlpb.Value, error) {
+	properties := make(map[string]*mlpb.Value)
+	for name, t := range types {
+		field, ok := lookupField(doc, name)
+		if !ok || field == nil {
+			continue
+		}
+		v, err := propertyValue(field, t)
+		if err != nil {
+			return nil, fmt.Errorf("field %q: %v", name, err)
+		}
+		properties[name] = v
+	}
+	b, err := json.Marshal(doc)
+	if err != nil {
+		return nil, err
+	}
+	properties[allMeta] = &mlpb.Value{Value: &mlpb.Value_StringValue{StringValue: string(b)}}
+	return properties, nil
+}
+
+func propertyValue(field interface{}, t string) (*mlpb.Value, error) {
+	switch t {
+	case "string":
+		if s, ok := field.(string); ok {
+			return &mlpb.Value{Value: &mlpb.Value_StringValue{StringValue: s}}, nil
+		}
+	case "integer":
+		if n, ok := field.(json.Number); ok {
+			i, err := n.Int64()
+			if err != nil {
+				return nil, err
+			}
+			return &mlpb.Value{Value: &mlpb.Value_IntValue{IntValue: i}}, nil
+		}
+	case "number":
+		if n, ok := field.(json.Number); ok {
+			f, err := n.Float64()
+			if err != nil {
+				return nil, err
+			}
+			return &mlpb.Value{Value: &mlpb.Value_DoubleValue{DoubleValue: f}}, nil
+		}
+	case "boolean":
+		if b, ok := field.(bool); ok {
+			var i int64
+			if b {
+				i = 1
+			}
+			return &mlpb.Value{Value: &mlpb.Value_IntValue{IntValue: i}}, nil
+		}
+	case "array", "object":
+		b, err := json.Marshal(field)
+		if err != nil {
+			return nil, err
+		}
+		return &mlpb.Value{Value: &mlpb.Value_StringValue{StringValue: string(b)}}, nil
+	}
+	return nil, fmt.Errorf("%v is not of type %s", field, t)
+}
+
+// document returns the document held in allMeta, completed with the typed
+// properties it lacks.
+func document(properties map[string]*mlpb.Value, types map[string]string) (map[string]interface{}, error) {
+	doc := make(map[string]interface{})
+	if v, ok := properties[allMeta]; ok {
+		var err error
+		if doc, err = parseDocument(v.GetStringValue()); err != nil {
+			return nil, err
+		}
+	}
+	for name, t := range types {
+		v, ok := properties[name]
+		if !ok {
+			continue
+		}
+		if _, ok := lookupField(doc, name); ok {
+			continue
+		}
+		switch t {
+		case "string":
+			setField(doc, name, v.GetStringValue())
+		case "integer":
+			setField(doc, name, v.GetIntValue())
+		case "number":
+			setField(doc, name, v.GetDoubleValue())
+		case "boolean":
+			setField(doc, name, v.GetIntValue() != 0)
+		case "array", "object":
+			var field interface{}
+			if err := json.Unmarshal([]byte(v.GetStringValue()), &field); err != nil {
+				return nil, fmt.Errorf("property %q must hold JSON: %v", name, err)
+			}
+			setField(doc, name, field)
+		}
+	}
+	return doc, nil
+}
+
+func newArtifact(doc map[string]interface{}, types map[string]string) (*mlpb.Artifact, error) {
+	properties, err := propertyValues(doc, types)
+	if err != nil {
+		return nil, err
+	}
+	artifact := &mlpb.Artifact{Properties: properties}
+	if uri, ok := doc["uri"].(string); ok && uri != "" {
+		artifact.Uri = proto.String(uri)
+	}
+	if id, ok := doc["id"].(string); ok && id != "" {
+		i, err := strconv.ParseInt(id, 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("malformed id %q: %v", id, err)
+		}
+		artifact.Id = proto.Int64(i)
+	}
+	return artifact, nil
+}
+
+func artifactDocument(artifact *mlpb.Artifact, types map[string]string) (map[string]interface{}, error) {
+	doc, err := document(artifact.GetProperties(), types)
+	if err != nil {
+		return nil, err
+	}
+	if artifact.Uri != nil {
+		doc["uri"] = artifact.GetUri()
+	}
+	if artifact.Id != nil {
+		doc["id"] = strconv.FormatInt(artifact.GetId(), 10)
+	}
+	return doc, nil
+}
+
+func newExecution(doc map[string]interface{}, types map[string]string) (*mlpb.Execution, error) {
+	properties, err := propertyValues(doc, types)
+	if err != nil {
+		return nil, err
+	}
+	execution := &mlpb.Execution{Properties: properties}
+	if id, ok := doc["id"].(string); ok && id != "" {
+		i, err := strconv.ParseInt(id, 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("malformed id %q: %v", id, err)
+		}
+		execution.Id = proto.Int64(i)
+	}
+	return execution, nil
+}
+
+func executionDocument(execution *mlpb.Execution, types map[string]string) (map[string]interface{}, error) {
+	doc, err := document(execution.GetProperties(), types)
+	if err != nil {
+		return nil, err
+	}
+	if execution.Id != nil {
+		doc["id"] = strconv.FormatInt(execution.GetId(), 10)
+	}
+	return doc, nil
+}
+`
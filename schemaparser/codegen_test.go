@@ -0,0 +1,113 @@
+// Copyright 2019 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package schemaparser
+
+import (
+	"io/ioutil"
+	"regexp"
+	"strings"
+	"testing"
+)
+
+func TestGoName(t *testing.T) {
+	tests := map[string]string{
+		"name":             "Name",
+		"data_set_id":      "DataSetID",
+		"uri":              "URI",
+		"contentMediaType": "ContentMediaType",
+		"array-field":      "ArrayField",
+		"2d":               "X2d",
+	}
+	for name, want := range tests {
+		if got := goName(name); got != want {
+			t.Errorf("goName(%q) = %q, want %q", name, got, want)
+		}
+	}
+}
+
+// TestGeneratedTypesAreUpToDate fails when the predefined schemas change
+// without regenerating their Go types.
+func TestGeneratedTypesAreUpToDate(t *testing.T) {
+	ss, err := NewSchemaSetFromADir(schemaDir)
+	if err != nil {
+		t.Fatalf("failed to parse schemas from dir: %s", err)
+	}
+	src, err := GenerateGo(ss, "types")
+	if err != nil {
+		t.Fatalf("GenerateGo failed: %v", err)
+	}
+	checkedIn, err := ioutil.ReadFile("../schema/types/alpha.go")
+	if err != nil {
+		t.Fatalf("failed to read generated types: %v", err)
+	}
+	if string(src) != string(checkedIn) {
+		t.Error("schema/types/alpha.go is out of date: run go generate ./schema/types")
+	}
+}
+
+func TestGenerateGo(t *testing.T) {
+	ss := compatSchemaSet(t, `{
+		"$id": "http://github.com/kubeflow/metadata/schemaparser/testdata/codegen.json",
+		"allOf": [{"$ref": "http://github.com/kubeflow/metadata/schemaparser/testdata/ext.json"}],
+		"properties": {
+			"kind": {"type": "string", "constant": "training_run"},
+			"namespace": {"type": "string", "constant": "kubeflow.org"},
+			"apiversion": {"type": "string", "constant": "v1"},
+			"category": {"type": "string", "constant": "execution"},
+			"steps": {"type": "integer"},
+			"tags": {"type": "array", "items": {"type": "string"}},
+			"trainer": {
+				"type": "object",
+				"properties": {
+					"image": {"type": "string"},
+					"gpu": {"type": "boolean"}
+				},
+				"required": ["image"]
+			}
+		},
+		"required": ["steps"]
+	}`)
+	src, err := GenerateGo(ss, "gen")
+	if err != nil {
+		t.Fatalf("GenerateGo failed: %v", err)
+	}
+
+	want := []string{
+		`package gen`,
+		`const TrainingRunTypeName = "kubeflow.org/v1/training_run"`,
+		`"category": "execution",`,
+		`"trainer.gpu": "boolean",`,
+		`type TrainingRun struct {`,
+		`Steps int64 ` + "`" + `json:"steps"` + "`",
+		`Tags []string ` + "`" + `json:"tags,omitempty"` + "`",
+		`Trainer *TrainingRunTrainer ` + "`" + `json:"trainer,omitempty"` + "`",
+		`BooleanField *bool ` + "`" + `json:"boolean-field,omitempty"` + "`",
+		`type TrainingRunTrainer struct {`,
+		`Gpu *bool ` + "`" + `json:"gpu,omitempty"` + "`",
+		`Image string ` + "`" + `json:"image"` + "`",
+		`func (v *TrainingRun) ToExecution() (*mlpb.Execution, error) {`,
+		`func TrainingRunFromExecution(execution *mlpb.Execution) (*TrainingRun, error) {`,
+	}
+	// Ignore the alignment of struct fields.
+	got := regexp.MustCompile(`[ \t]+`).ReplaceAllString(string(src), " ")
+	for _, w := range want {
+		if !strings.Contains(got, w) {
+			t.Errorf("GenerateGo output lacks %s", w)
+		}
+	}
+	if strings.Contains(got, "Kind ") {
+		t.Error("GenerateGo output has a field for the constant property kind")
+	}
+}
@@ -0,0 +1,268 @@
+// Copyright 2019 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package schemaparser
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// Compatibility tells which side of a schema change can no longer read the
+// data of the other side.
+type Compatibility int
+
+const (
+	// BackwardIncompatible changes make the new schema reject data that was
+	// valid under the old schema, e.g. a newly required field.
+	BackwardIncompatible Compatibility = 1 << iota
+	// ForwardIncompatible changes make the old schema reject data that is
+	// valid under the new schema, e.g. a field no longer required.
+	ForwardIncompatible
+)
+
+// Backward reports whether the change breaks backward compatibility.
+func (c Compatibility) Backward() bool {
+	return c&BackwardIncompatible != 0
+}
+
+// Forward reports whether the change breaks forward compatibility.
+func (c Compatibility) Forward() bool {
+	return c&ForwardIncompatible != 0
+}
+
+func (c Compatibility) String() string {
+	var s []string
+	if c.Backward() {
+		s = append(s, "backward")
+	}
+	if c.Forward() {
+		s = append(s, "forward")
+	}
+	if len(s) == 0 {
+		return "compatible"
+	}
+	return strings.Join(s, " and ") + " incompatible"
+}
+
+// Change is an incompatible change between two versions of a schema.
+type Change struct {
+	// SchemaID is the $id of the changed schema.
+	SchemaID string
+	// Property is the flattened name of the changed property, e.g.
+	// training_framework.name, or empty if the change is to the whole schema.
+	Property string
+	// Compatibility tells which compatibility the change breaks.
+	Compatibility Compatibility
+	// Description explains the change.
+	Description string
+}
+
+func (c Change) String() string {
+	if c.Property == "" {
+		return fmt.Sprintf("%s: %s (%s)", c.SchemaID, c.Description, c.Compatibility)
+	}
+	return fmt.Sprintf("%s: property %q %s (%s)", c.SchemaID, c.Property, c.Description, c.Compatibility)
+}
+
+// CheckCompatibility compares the schemas of old and new with the same $id and
+// returns their incompatible changes, ordered by schema $id and property. It
+// reports removed schemas, fields that become or stop being required,
+// property type changes, and narrowed or widened enums. Schemas only in new
+// are compatible additions.
+func CheckCompatibility(old, new *SchemaSet) ([]Change, error) {
+	var changes []Change
+	for id := range old.Schemas {
+		if _, exists := new.Schemas[id]; !exists {
+			changes = append(changes, Change{
+				SchemaID:      id,
+				Compatibility: BackwardIncompatible,
+				Description:   "is removed",
+			})
+			continue
+		}
+		oldProperties, err := old.propertySchemas(id)
+		if err != nil {
+			return nil, fmt.Errorf("failed to resolve old schema: %s", err)
+		}
+		newProperties, err := new.propertySchemas(id)
+		if err != nil {
+			return nil, fmt.Errorf("failed to resolve new schema: %s", err)
+		}
+		changes = append(changes, compareProperties(id, oldProperties, newProperties)...)
+	}
+	sort.Slice(changes, func(i, j int) bool {
+		if changes[i].SchemaID != changes[j].SchemaID {
+			return changes[i].SchemaID < changes[j].SchemaID
+		}
+		if changes[i].Property != changes[j].Property {
+			return changes[i].Property < changes[j].Property
+		}
+		return changes[i].Description < changes[j].Description
+	})
+	return changes, nil
+}
+
+func compareProperties(id string, old, new *propertySchemas) []Change {
+	var changes []Change
+	add := func(property string, c Compatibility, format string, args ...interface{}) {
+		changes = append(changes, Change{
+			SchemaID:      id,
+			Property:      property,
+			Compatibility: c,
+			Description:   fmt.Sprintf(format, args...),
+		})
+	}
+
+	for name := range new.required {
+		if !old.required[name] {
+			add(name, BackwardIncompatible, "becomes required")
+		}
+	}
+	for name := range old.required {
+		if new.required[name] {
+			continue
+		}
+		if _, exists := new.schemas[name]; exists {
+			add(name, ForwardIncompatible, "is no longer required")
+		} else {
+			add(name, ForwardIncompatible, "is required but removed")
+		}
+	}
+
+	for name, oldSchema := range old.schemas {
+		newSchema, exists := new.schemas[name]
+		if !exists {
+			continue
+		}
+		if oldType, newType := oldSchema.GetType(), newSchema.GetType(); oldType != newType {
+			add(name, typeChangeCompatibility(oldType, newType), "changes type from %s to %s", oldType, newType)
+		}
+		if oldSchema.Constant != newSchema.Constant {
+			add(name, BackwardIncompatible|ForwardIncompatible, "changes constant from %q to %q", oldSchema.Constant, newSchema.Constant)
+		}
+		if removed := missingValues(oldSchema.Enum, newSchema.Enum); len(newSchema.Enum) > 0 && len(removed) > 0 {
+			add(name, BackwardIncompatible, "narrows enum, removing %s", strings.Join(removed, ", "))
+		}
+		if added := missingValues(newSchema.Enum, oldSchema.Enum); len(oldSchema.Enum) > 0 && len(added) > 0 {
+			add(name, ForwardIncompatible, "widens enum, adding %s", strings.Join(added, ", "))
+		}
+		if len(oldSchema.Enum) == 0 && len(newSchema.Enum) > 0 {
+			add(name, BackwardIncompatible, "narrows to enum %s", strings.Join(newSchema.Enum, ", "))
+		}
+		if len(oldSchema.Enum) > 0 && len(newSchema.Enum) == 0 {
+			add(name, ForwardIncompatible, "is no longer an enum")
+		}
+	}
+	return changes
+}
+
+// typeChangeCompatibility returns the compatibility broken by changing the
+// JSON type of a property. Integers are numbers, so widening an integer to a
+// number only breaks forward compatibility and narrowing a number to an
+// integer only breaks backward compatibility.
+func typeChangeCompatibility(oldType, newType string) Compatibility {
+	switch {
+	case oldType == IntegerType && newType == NumberType:
+		return ForwardIncompatible
+	case oldType == NumberType && newType == IntegerType:
+		return BackwardIncompatible
+	}
+	return BackwardIncompatible | ForwardIncompatible
+}
+
+// missingValues returns the values of from that are not in to.
+func missingValues(from, to []string) []string {
+	in := make(map[string]bool, len(to))
+	for _, v := range to {
+		in[v] = true
+	}
+	var missing []string
+	for _, v := range from {
+		if !in[v] {
+			missing = append(missing, v)
+		}
+	}
+	return missing
+}
+
+// propertySchemas holds the definitions of the properties of a schema, keyed
+// by flattened name as in FlattenedProperties, along with which of them are
+// required and the $id of the schema defining them.
+type propertySchemas struct {
+	schemas   map[string]*SchemaJSON
+	required  map[string]bool
+	definedIn map[string]string
+	// types holds the JSON types of the properties, which are kept when a
+	// redefinition only adds a constraint, e.g. a constant.
+	types map[string]string
+}
+
+// propertySchemas resolves the properties of the schema with given id,
+// including those of its AllOf parents and of nested objects.
+func (ss *SchemaSet) propertySchemas(id string) (*propertySchemas, error) {
+	schema, exists := ss.Schemas[id]
+	if !exists {
+		return nil, fmt.Errorf("failed to find schema with $id %s", id)
+	}
+	ps := &propertySchemas{
+		schemas:   make(map[string]*SchemaJSON),
+		required:  make(map[string]bool),
+		definedIn: make(map[string]string),
+		types:     make(map[string]string),
+	}
+	if err := ps.add(ss, id, schema.JSON, ""); err != nil {
+		return nil, err
+	}
+	return ps, nil
+}
+
+// add adds the properties defined by sj, prefixing their names with prefix.
+// As in FlattenedProperties, properties of AllOf parents come first and are
+// replaced by redefinitions.
+func (ps *propertySchemas) add(ss *SchemaSet, id string, sj *SchemaJSON, prefix string) error {
+	for i := range sj.AllOf {
+		parent := &sj.AllOf[i]
+		if parent.Ref != nil {
+			ref, exists := ss.Schemas[(string)(*parent.Ref)]
+			if !exists {
+				return fmt.Errorf("failed to resolve %s in AllOf fields in %s", *parent.Ref, id)
+			}
+			if err := ps.add(ss, ref.JSON.ID, ref.JSON, prefix); err != nil {
+				return err
+			}
+		}
+		if err := ps.add(ss, id, parent, prefix); err != nil {
+			return err
+		}
+	}
+	for _, name := range sj.Required {
+		ps.required[prefix+name] = true
+	}
+	for p, schema := range sj.Properties {
+		name := prefix + p
+		ps.schemas[name] = schema
+		ps.definedIn[name] = id
+		if schema.Type != nil {
+			ps.types[name] = schema.GetType()
+		}
+		if len(schema.Properties) > 0 || len(schema.AllOf) > 0 {
+			if err := ps.add(ss, id, schema, name+FlattenedPropertySeparator); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
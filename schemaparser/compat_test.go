@@ -0,0 +1,143 @@
+// Copyright 2019 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package schemaparser
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+const compatID = "http://github.com/kubeflow/metadata/schemaparser/testdata/compat.json"
+
+func compatSchemaSet(t *testing.T, schemas ...string) *SchemaSet {
+	t.Helper()
+	ss, err := NewSchemaSetFromFiles([]string{"testdata/base.json", "testdata/ext.json"})
+	if err != nil {
+		t.Fatalf("failed to parse schemas from files: %s", err)
+	}
+	for _, s := range schemas {
+		if _, err := ss.AddSchema([]byte(s)); err != nil {
+			t.Fatalf("Failed to add schema: %v", err)
+		}
+	}
+	return ss
+}
+
+func TestCheckCompatibilityOfSameSchemas(t *testing.T) {
+	old, err := NewSchemaSetFromADir(schemaDir)
+	if err != nil {
+		t.Fatalf("failed to parse schemas from dir: %s", err)
+	}
+	new, err := NewSchemaSetFromADir(schemaDir)
+	if err != nil {
+		t.Fatalf("failed to parse schemas from dir: %s", err)
+	}
+	changes, err := CheckCompatibility(old, new)
+	if err != nil {
+		t.Fatalf("CheckCompatibility failed: %v", err)
+	}
+	if len(changes) != 0 {
+		t.Errorf("CheckCompatibility of identical schemas returned %v, want no change", changes)
+	}
+}
+
+func TestCheckCompatibility(t *testing.T) {
+	old := compatSchemaSet(t, `{
+		"$id": "http://github.com/kubeflow/metadata/schemaparser/testdata/compat.json",
+		"allOf": [{"$ref": "http://github.com/kubeflow/metadata/schemaparser/testdata/ext.json"}],
+		"properties": {
+			"owner": {"type": "string"},
+			"size": {"type": "integer"},
+			"accuracy": {"type": "number"},
+			"stage": {"type": "string", "enum": ["dev", "staging", "prod"]},
+			"framework": {
+				"type": "object",
+				"properties": {
+					"name": {"type": "string"},
+					"version": {"type": "string"}
+				},
+				"required": ["name"]
+			},
+			"label": {"type": "string"}
+		},
+		"required": ["owner"]
+	}`, `{
+		"$id": "http://github.com/kubeflow/metadata/schemaparser/testdata/removed.json",
+		"properties": {"field": {"type": "string"}}
+	}`)
+	new := compatSchemaSet(t, `{
+		"$id": "http://github.com/kubeflow/metadata/schemaparser/testdata/compat.json",
+		"allOf": [{"$ref": "http://github.com/kubeflow/metadata/schemaparser/testdata/ext.json"}],
+		"properties": {
+			"size": {"type": "number"},
+			"accuracy": {"type": "string"},
+			"stage": {"type": "string", "enum": ["dev", "prod", "archived"]},
+			"framework": {
+				"type": "object",
+				"properties": {
+					"name": {"type": "string"},
+					"version": {"type": "string"}
+				},
+				"required": ["version"]
+			},
+			"label": {"type": "string", "enum": ["gold"]},
+			"added": {"type": "string"}
+		},
+		"required": ["added"]
+	}`, `{
+		"$id": "http://github.com/kubeflow/metadata/schemaparser/testdata/added.json",
+		"properties": {"field": {"type": "string"}}
+	}`)
+
+	changes, err := CheckCompatibility(old, new)
+	if err != nil {
+		t.Fatalf("CheckCompatibility failed: %v", err)
+	}
+	want := []Change{
+		{SchemaID: compatID, Property: "accuracy", Compatibility: BackwardIncompatible | ForwardIncompatible, Description: "changes type from number to string"},
+		{SchemaID: compatID, Property: "added", Compatibility: BackwardIncompatible, Description: "becomes required"},
+		{SchemaID: compatID, Property: "framework.name", Compatibility: ForwardIncompatible, Description: "is no longer required"},
+		{SchemaID: compatID, Property: "framework.version", Compatibility: BackwardIncompatible, Description: "becomes required"},
+		{SchemaID: compatID, Property: "label", Compatibility: BackwardIncompatible, Description: "narrows to enum gold"},
+		{SchemaID: compatID, Property: "owner", Compatibility: ForwardIncompatible, Description: "is required but removed"},
+		{SchemaID: compatID, Property: "size", Compatibility: ForwardIncompatible, Description: "changes type from integer to number"},
+		{SchemaID: compatID, Property: "stage", Compatibility: BackwardIncompatible, Description: "narrows enum, removing staging"},
+		{SchemaID: compatID, Property: "stage", Compatibility: ForwardIncompatible, Description: "widens enum, adding archived"},
+		{SchemaID: "http://github.com/kubeflow/metadata/schemaparser/testdata/removed.json", Compatibility: BackwardIncompatible, Description: "is removed"},
+	}
+	if diff := cmp.Diff(want, changes); diff != "" {
+		t.Errorf("CheckCompatibility returned unexpected changes. Diff (-want, +got):\n%s", diff)
+	}
+
+	// Swapping the versions swaps backward and forward incompatibilities.
+	changes, err = CheckCompatibility(new, old)
+	if err != nil {
+		t.Fatalf("CheckCompatibility failed: %v", err)
+	}
+	for _, c := range changes {
+		if c.SchemaID == compatID && c.Property == "size" && c.Compatibility != BackwardIncompatible {
+			t.Errorf("Narrowing number to integer is %s, want %s", c.Compatibility, BackwardIncompatible)
+		}
+	}
+}
+
+func TestCompatibilityString(t *testing.T) {
+	c := Change{SchemaID: compatID, Property: "stage", Compatibility: BackwardIncompatible | ForwardIncompatible, Description: "changes type from string to integer"}
+	want := compatID + `: property "stage" changes type from string to integer (backward and forward incompatible)`
+	if got := c.String(); got != want {
+		t.Errorf("String() = %q, want %q", got, want)
+	}
+}
@@ -0,0 +1,391 @@
+// Copyright 2019 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package schemaparser
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	htmltemplate "html/template"
+	"path"
+	"sort"
+	"strings"
+	"text/template"
+
+	"github.com/xeipuuv/gojsonschema"
+)
+
+// DocFormat is the format of generated documentation.
+type DocFormat string
+
+const (
+	// MarkdownDoc generates Markdown pages.
+	MarkdownDoc DocFormat = "markdown"
+	// HTMLDoc generates HTML pages.
+	HTMLDoc DocFormat = "html"
+)
+
+// ParseDocFormat parses the name of a documentation format.
+func ParseDocFormat(s string) (DocFormat, error) {
+	switch f := DocFormat(s); f {
+	case MarkdownDoc, HTMLDoc:
+		return f, nil
+	}
+	return "", fmt.Errorf("unknown documentation format %q: please choose from [%s, %s]", s, MarkdownDoc, HTMLDoc)
+}
+
+func (f DocFormat) extension() string {
+	if f == HTMLDoc {
+		return ".html"
+	}
+	return ".md"
+}
+
+func (f DocFormat) index() string {
+	if f == HTMLDoc {
+		return "index.html"
+	}
+	return "README.md"
+}
+
+// docLink links to the page of a schema.
+type docLink struct {
+	Title string
+	ID    string
+	Path  string
+	Depth int
+}
+
+// docProperty is a row of the property table of a page.
+type docProperty struct {
+	Name        string
+	Type        string
+	Required    bool
+	Description string
+	Constraints []string
+	// DefinedIn is the schema defining the property, or nil if it is defined
+	// by the schema of the page.
+	DefinedIn *docLink
+}
+
+// docPage is the documentation of a schema.
+type docPage struct {
+	docLink
+	Description string
+	TypeName    string
+	Category    string
+	Hierarchy   []docLink
+	Properties  []docProperty
+	Examples    []string
+}
+
+// GenerateDocs returns the documentation of the schemas of ss, keyed by path
+// relative to the documentation root. Each schema has a page, laid out as the
+// $id of the schemas relative to their common prefix, e.g. artifacts/model.md
+// for .../alpha/artifacts/model.json, with the properties inherited through
+// AllOf, their constraints, and the examples of the schema. An index page
+// lists all schemas. It fails if an example does not match its schema.
+func GenerateDocs(ss *SchemaSet, format DocFormat) (map[string][]byte, error) {
+	paths := docPaths(ss, format)
+	var pages []*docPage
+	for id := range ss.Schemas {
+		page, err := newDocPage(ss, id, paths)
+		if err != nil {
+			return nil, err
+		}
+		pages = append(pages, page)
+	}
+	sort.Slice(pages, func(i, j int) bool { return pages[i].Path < pages[j].Path })
+
+	pageTemplate, indexTemplate := "markdown", "markdownIndex"
+	execute := markdownTemplates.ExecuteTemplate
+	if format == HTMLDoc {
+		pageTemplate, indexTemplate = "html", "htmlIndex"
+		execute = htmlTemplates.ExecuteTemplate
+	}
+	docs := make(map[string][]byte)
+	for _, page := range pages {
+		var b bytes.Buffer
+		if err := execute(&b, pageTemplate, page); err != nil {
+			return nil, fmt.Errorf("failed to generate documentation of %s: %s", page.ID, err)
+		}
+		docs[page.Path] = b.Bytes()
+	}
+	var b bytes.Buffer
+	if err := execute(&b, indexTemplate, pages); err != nil {
+		return nil, fmt.Errorf("failed to generate documentation index: %s", err)
+	}
+	docs[format.index()] = b.Bytes()
+	return docs, nil
+}
+
+// docPaths maps the $id of the schemas of ss to the path of their pages.
+func docPaths(ss *SchemaSet, format DocFormat) map[string]string {
+	var prefix string
+	first := true
+	for id := range ss.Schemas {
+		dir := id[:strings.LastIndex(id, "/")+1]
+		if first {
+			prefix, first = dir, false
+			continue
+		}
+		for !strings.HasPrefix(dir, prefix) {
+			prefix = prefix[:strings.LastIndex(strings.TrimSuffix(prefix, "/"), "/")+1]
+		}
+	}
+	paths := make(map[string]string)
+	for id := range ss.Schemas {
+		p := strings.TrimPrefix(id, prefix)
+		paths[id] = strings.TrimSuffix(p, path.Ext(p)) + format.extension()
+	}
+	return paths
+}
+
+// relativeLink returns the path of the page to relative to the directory of
+// the page from.
+func relativeLink(from, to string) string {
+	fromDir := strings.Split(path.Dir(from), "/")
+	toParts := strings.Split(to, "/")
+	if fromDir[0] == "." {
+		fromDir = nil
+	}
+	i := 0
+	for i < len(fromDir) && i < len(toParts)-1 && fromDir[i] == toParts[i] {
+		i++
+	}
+	return strings.Repeat("../", len(fromDir)-i) + strings.Join(toParts[i:], "/")
+}
+
+func newDocPage(ss *SchemaSet, id string, paths map[string]string) (*docPage, error) {
+	schema := ss.Schemas[id]
+	link := func(target string, depth int) docLink {
+		p := paths[target]
+		return docLink{
+			Title: strings.TrimSuffix(path.Base(p), path.Ext(p)),
+			ID:    target,
+			Path:  relativeLink(paths[id], p),
+			Depth: depth,
+		}
+	}
+	page := &docPage{
+		docLink:     link(id, 0),
+		Description: schema.JSON.Description,
+	}
+	page.Path = paths[id]
+	if namespace, typename, err := ss.TypeName(id); err == nil {
+		page.TypeName = namespace + "/" + typename
+		page.Category, _ = ss.ConstantStringType(id, categoryPropertyName)
+	}
+
+	var addHierarchy func(sj *SchemaJSON, depth int)
+	addHierarchy = func(sj *SchemaJSON, depth int) {
+		for _, parent := range sj.AllOf {
+			if parent.Ref == nil {
+				continue
+			}
+			ref := (string)(*parent.Ref)
+			page.Hierarchy = append(page.Hierarchy, link(ref, depth))
+			if s, exists := ss.Schemas[ref]; exists {
+				addHierarchy(s.JSON, depth+1)
+			}
+		}
+	}
+	addHierarchy(schema.JSON, 1)
+
+	ps, err := ss.propertySchemas(id)
+	if err != nil {
+		return nil, err
+	}
+	for name, sj := range ps.schemas {
+		p := docProperty{
+			Name:        name,
+			Type:        ps.types[name],
+			Required:    ps.required[name],
+			Description: sj.Description,
+			Constraints: constraints(sj),
+		}
+		if p.Type == "" {
+			p.Type = "any"
+		}
+		if ps.definedIn[name] != id {
+			l := link(ps.definedIn[name], 0)
+			p.DefinedIn = &l
+		}
+		page.Properties = append(page.Properties, p)
+	}
+	sort.Slice(page.Properties, func(i, j int) bool { return page.Properties[i].Name < page.Properties[j].Name })
+
+	for i, example := range schema.JSON.Examples {
+		result, err := schema.Validator.Validate(gojsonschema.NewGoLoader(example))
+		if err != nil {
+			return nil, fmt.Errorf("failed to validate example %d of %s: %s", i, id, err)
+		}
+		if !result.Valid() {
+			return nil, fmt.Errorf("example %d of %s is invalid: %v", i, id, result.Errors())
+		}
+		b, err := json.MarshalIndent(example, "", "  ")
+		if err != nil {
+			return nil, err
+		}
+		page.Examples = append(page.Examples, string(b))
+	}
+	return page, nil
+}
+
+// constraints describes the constraints of a property beyond its type.
+func constraints(sj *SchemaJSON) []string {
+	var c []string
+	if sj.Constant != "" {
+		c = append(c, fmt.Sprintf("constant `%s`", sj.Constant))
+	}
+	if len(sj.Enum) > 0 {
+		c = append(c, fmt.Sprintf("one of `%s`", strings.Join(sj.Enum, "`, `")))
+	}
+	if sj.Format != "" {
+		c = append(c, fmt.Sprintf("format `%s`", sj.Format))
+	}
+	if sj.Pattern != "" {
+		c = append(c, fmt.Sprintf("pattern `%s`", sj.Pattern))
+	}
+	if sj.MinLength != nil {
+		c = append(c, fmt.Sprintf("at least %d characters", *sj.MinLength))
+	}
+	if sj.MaxLength != nil {
+		c = append(c, fmt.Sprintf("at most %d characters", *sj.MaxLength))
+	}
+	if sj.Minimum != nil {
+		c = append(c, fmt.Sprintf("minimum %v", *sj.Minimum))
+	}
+	if sj.Maximum != nil {
+		c = append(c, fmt.Sprintf("maximum %v", *sj.Maximum))
+	}
+	return c
+}
+
+// cell escapes text for a Markdown table cell.
+func cell(text string) string {
+	return strings.NewReplacer("|", `\|`, "\n", " ").Replace(text)
+}
+
+var markdownTemplates = template.Must(template.New("markdown").Funcs(template.FuncMap{
+	"cell":   cell,
+	"join":   strings.Join,
+	"indent": func(depth int) string { return strings.Repeat("  ", depth) },
+}).Parse(`# {{.Title}}
+
+` + "```" + `
+{{.ID}}
+` + "```" + `
+{{if .Description}}
+{{.Description}}
+{{end}}{{if .TypeName}}
+| Type | Category |
+|------|----------|
+| ` + "`{{.TypeName}}`" + ` | {{.Category}} |
+{{end}}
+## Schema Hierarchy
+
+* ` + "`{{.ID}}`" + `
+{{- range .Hierarchy}}
+{{indent .Depth}}* [{{.Title}}]({{.Path}}) ` + "`{{.ID}}`" + `
+{{- end}}
+
+## Properties
+
+| Property | Type | Required | Constraints | Description | Defined by |
+|----------|------|----------|-------------|-------------|------------|
+{{- range .Properties}}
+| ` + "`{{.Name}}`" + ` | ` + "`{{.Type}}`" + ` | {{if .Required}}**Required**{{else}}Optional{{end}} | {{cell (join .Constraints ", ")}} | {{cell .Description}} | {{with .DefinedIn}}[{{.Title}}]({{.Path}}){{else}}this schema{{end}} |
+{{- end}}
+{{- if .Examples}}
+
+## Examples
+{{- range .Examples}}
+
+` + "```json" + `
+{{.}}
+` + "```" + `
+{{- end}}
+{{- end}}
+`))
+
+var _ = template.Must(markdownTemplates.New("markdownIndex").Parse(`# Schemas
+
+| Schema | Type | Category | Description |
+|--------|------|----------|-------------|
+{{- range .}}
+| [{{.Title}}]({{.Path}}) | {{if .TypeName}}` + "`{{.TypeName}}`" + `{{end}} | {{.Category}} | {{cell .Description}} |
+{{- end}}
+`))
+
+var htmlTemplates = htmltemplate.Must(htmltemplate.New("html").Funcs(htmltemplate.FuncMap{
+	"join": func(constraints []string, sep string) string {
+		return strings.Replace(strings.Join(constraints, sep), "`", "", -1)
+	},
+}).Parse(`<!DOCTYPE html>
+<html>
+<head>
+<meta charset="utf-8">
+<title>{{.Title}}</title>
+</head>
+<body>
+<h1>{{.Title}}</h1>
+<pre>{{.ID}}</pre>
+{{if .Description}}<p>{{.Description}}</p>
+{{end}}{{if .TypeName}}<table>
+<tr><th>Type</th><th>Category</th></tr>
+<tr><td><code>{{.TypeName}}</code></td><td>{{.Category}}</td></tr>
+</table>
+{{end}}<h2>Schema Hierarchy</h2>
+<ul>
+<li><code>{{.ID}}</code></li>
+{{- range .Hierarchy}}
+<li style="margin-left: {{.Depth}}em"><a href="{{.Path}}">{{.Title}}</a> <code>{{.ID}}</code></li>
+{{- end}}
+</ul>
+<h2>Properties</h2>
+<table>
+<tr><th>Property</th><th>Type</th><th>Required</th><th>Constraints</th><th>Description</th><th>Defined by</th></tr>
+{{- range .Properties}}
+<tr><td><code>{{.Name}}</code></td><td><code>{{.Type}}</code></td><td>{{if .Required}}<b>Required</b>{{else}}Optional{{end}}</td><td>{{join .Constraints ", "}}</td><td>{{.Description}}</td><td>{{with .DefinedIn}}<a href="{{.Path}}">{{.Title}}</a>{{else}}this schema{{end}}</td></tr>
+{{- end}}
+</table>
+{{- if .Examples}}
+<h2>Examples</h2>
+{{- range .Examples}}
+<pre>{{.}}</pre>
+{{- end}}
+{{- end}}
+</body>
+</html>
+`))
+
+var _ = htmltemplate.Must(htmlTemplates.New("htmlIndex").Parse(`<!DOCTYPE html>
+<html>
+<head>
+<meta charset="utf-8">
+<title>Schemas</title>
+</head>
+<body>
+<h1>Schemas</h1>
+<table>
+<tr><th>Schema</th><th>Type</th><th>Category</th><th>Description</th></tr>
+{{- range .}}
+<tr><td><a href="{{.Path}}">{{.Title}}</a></td><td>{{if .TypeName}}<code>{{.TypeName}}</code>{{end}}</td><td>{{.Category}}</td><td>{{.Description}}</td></tr>
+{{- end}}
+</table>
+</body>
+</html>
+`))
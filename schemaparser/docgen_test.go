@@ -0,0 +1,123 @@
+// Copyright 2019 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package schemaparser
+
+import (
+	"io/ioutil"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestRelativeLink(t *testing.T) {
+	tests := []struct {
+		from, to, want string
+	}{
+		{"artifacts/model.md", "artifacts/artifact.md", "artifact.md"},
+		{"artifacts/model.md", "entity.md", "../entity.md"},
+		{"entity.md", "artifacts/model.md", "artifacts/model.md"},
+		{"a/b/c.md", "a/d/e.md", "../d/e.md"},
+	}
+	for _, test := range tests {
+		if got := relativeLink(test.from, test.to); got != test.want {
+			t.Errorf("relativeLink(%q, %q) = %q, want %q", test.from, test.to, got, test.want)
+		}
+	}
+}
+
+// TestGeneratedDocsAreUpToDate fails when the predefined schemas change
+// without regenerating their documentation.
+func TestGeneratedDocsAreUpToDate(t *testing.T) {
+	ss, err := NewSchemaSetFromADir(schemaDir)
+	if err != nil {
+		t.Fatalf("failed to parse schemas from dir: %s", err)
+	}
+	docs, err := GenerateDocs(ss, MarkdownDoc)
+	if err != nil {
+		t.Fatalf("GenerateDocs failed: %v", err)
+	}
+	for p, doc := range docs {
+		checkedIn, err := ioutil.ReadFile(filepath.Join(schemaDir, "docs", p))
+		if err != nil || string(checkedIn) != string(doc) {
+			t.Errorf("%s/docs/%s is out of date: run schemadoc", schemaDir, p)
+		}
+	}
+}
+
+func TestGenerateDocs(t *testing.T) {
+	ss := compatSchemaSet(t, `{
+		"$id": "http://github.com/kubeflow/metadata/schemaparser/testdata/kinds/doc.json",
+		"description": "a documented | schema",
+		"examples": [{"id": "1", "kind": "doc", "namespace": "kubeflow.org", "apiversion": "v1", "name": "n", "category": "artifact", "uri": "gs://b/o", "stage": "dev"}],
+		"allOf": [{"$ref": "http://github.com/kubeflow/metadata/schemaparser/testdata/ext.json"}],
+		"properties": {
+			"kind": {"type": "string", "constant": "doc"},
+			"namespace": {"type": "string", "constant": "kubeflow.org"},
+			"apiversion": {"type": "string", "constant": "v1"},
+			"category": {"type": "string", "constant": "artifact"},
+			"stage": {"type": "string", "enum": ["dev", "prod"], "description": "deployment stage"}
+		},
+		"required": ["stage"]
+	}`)
+
+	docs, err := GenerateDocs(ss, MarkdownDoc)
+	if err != nil {
+		t.Fatalf("GenerateDocs failed: %v", err)
+	}
+	// The pages are laid out relative to the common prefix of the $id of the
+	// schemas, which is not testdata/ since base.json has another one.
+	const docPath = "metadata/schemaparser/testdata/kinds/doc"
+	page := string(docs[docPath+".md"])
+	want := []string{
+		"# doc",
+		"a documented | schema",
+		"| `kubeflow.org/v1/doc` | artifact |",
+		"  * [ext](../ext.md) `http://github.com/kubeflow/metadata/schemaparser/testdata/ext.json`",
+		"| `stage` | `string` | **Required** | one of `dev`, `prod` | deployment stage | this schema |",
+		"| `kind` | `string` | **Required** | constant `doc` |  | this schema |",
+		"| `array-field` | `array` | Optional |  | a field of type array | [ext](../ext.md) |",
+		`"stage": "dev"`,
+	}
+	for _, w := range want {
+		if !strings.Contains(page, w) {
+			t.Errorf("Generated page lacks %q:\n%s", w, page)
+		}
+	}
+	if index := string(docs["README.md"]); !strings.Contains(index, "| [doc]("+docPath+".md) | `kubeflow.org/v1/doc` | artifact | a documented \\| schema |") {
+		t.Errorf("Generated index lacks the page of doc.json:\n%s", index)
+	}
+
+	html, err := GenerateDocs(ss, HTMLDoc)
+	if err != nil {
+		t.Fatalf("GenerateDocs failed: %v", err)
+	}
+	if page := string(html[docPath+".html"]); !strings.Contains(page, `<a href="../ext.html">ext</a>`) {
+		t.Errorf("Generated HTML page lacks link to ext.html:\n%s", page)
+	}
+	if _, exists := html["index.html"]; !exists {
+		t.Error("GenerateDocs did not generate index.html")
+	}
+}
+
+func TestGenerateDocsWithInvalidExample(t *testing.T) {
+	ss := compatSchemaSet(t, `{
+		"$id": "http://github.com/kubeflow/metadata/schemaparser/testdata/invalid_example.json",
+		"examples": [{"size": "large"}],
+		"properties": {"size": {"type": "integer"}}
+	}`)
+	if _, err := GenerateDocs(ss, MarkdownDoc); err == nil || !strings.Contains(err.Error(), "invalid") {
+		t.Errorf("GenerateDocs returned error %v, want an invalid example error", err)
+	}
+}
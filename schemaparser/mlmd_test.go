@@ -0,0 +1,36 @@
+// Copyright 2019 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build cgo
+// +build cgo
+
+package schemaparser
+
+import (
+	mlpb "ml_metadata/proto/metadata_store_go_proto"
+
+	"github.com/kubeflow/metadata/mlmd/mlmdstore"
+	"github.com/kubeflow/metadata/service"
+)
+
+// The tests also run against MLMD where cgo is available.
+func init() {
+	testStoreBackends["mlmd"] = func() (service.MetadataStore, error) {
+		return mlmdstore.NewStore(&mlpb.ConnectionConfig{
+			Config: &mlpb.ConnectionConfig_FakeDatabase{
+				FakeDatabase: &mlpb.FakeDatabaseConfig{},
+			},
+		})
+	}
+}
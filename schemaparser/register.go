@@ -16,7 +16,10 @@ package schemaparser
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
+	"reflect"
+	"strings"
 	"time"
 
 	mlpb "ml_metadata/proto/metadata_store_go_proto"
@@ -32,15 +35,30 @@ const (
 	categoryPropertyName  string = "category"
 	artifactCategory      string = "artifact"
 	executionCategory     string = "execution"
+	containerCategory     string = "container"
 	defaultTimeout               = 5 * time.Second
 )
 
 // RegisterSchemas registers all predefined schema into the metadata service and returns a list of registered type names.
+// The service validates new artifacts of the registered types against their schemas, and registers
+// further schemas at runtime. Schemas registered at runtime before a restart are registered again.
 // TODO(zhenghuiwang): adds the schemas as annotations into MLMD once it supports type annotations.
 func RegisterSchemas(service *service.Service, schemaRootDir string) ([]string, error) {
+	_, types, err := LoadSchemas(context.Background(), service, schemaRootDir)
+	return types, err
+}
+
+// LoadSchemas is RegisterSchemas, also returning the Registry holding the
+// schemas, e.g. to Watch schemaRootDir for changes. The calls to service are
+// made with ctx, each within defaultTimeout.
+func LoadSchemas(ctx context.Context, service *service.Service, schemaRootDir string) (*Registry, []string, error) {
+	state, err := schemaFilesState(schemaRootDir)
+	if err != nil {
+		return nil, nil, err
+	}
 	ss, err := NewSchemaSetFromADir(schemaRootDir)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 	var types []string
 	for id := range ss.Schemas {
@@ -51,52 +69,62 @@ func RegisterSchemas(service *service.Service, schemaRootDir string) ([]string,
 		}
 		category, err := ss.ConstantStringType(id, categoryPropertyName)
 		if err != nil {
-			return nil, fmt.Errorf("schema %q property. schema $id = %s", categoryPropertyName, id)
+			return nil, nil, fmt.Errorf("schema %q property. schema $id = %s", categoryPropertyName, id)
 		}
 		switch category {
 		case artifactCategory:
-			if err := registerArtifactType(service, ss, id, namespace, typename); err != nil {
-				return nil, fmt.Errorf("failed to register schema: %v", err)
+			if err := registerArtifactType(ctx, service, ss, id, namespace, typename); err != nil {
+				return nil, nil, fmt.Errorf("failed to register schema: %v", err)
 			}
 			types = append(types, namespace+"/"+typename)
 		case executionCategory:
-			if err := registerExecutionType(service, ss, id, namespace, typename); err != nil {
-				return nil, fmt.Errorf("failed to register schema: %v", err)
+			if err := registerExecutionType(ctx, service, ss, id, namespace, typename); err != nil {
+				return nil, nil, fmt.Errorf("failed to register schema: %v", err)
+			}
+			types = append(types, namespace+"/"+typename)
+		case containerCategory:
+			if err := registerContainerType(ctx, service, ss, id, namespace, typename); err != nil {
+				return nil, nil, fmt.Errorf("failed to register schema: %v", err)
 			}
 			types = append(types, namespace+"/"+typename)
 		default:
 			glog.Errorf("Ignored unknown category %q with type %q in %q", category, typename, id)
 		}
 	}
-	return types, nil
+
+	r := NewRegistry(service, ss)
+	r.filesState = state
+	storedCtx, cancel := context.WithTimeout(ctx, defaultTimeout)
+	stored, err := service.StoredSchemas(storedCtx)
+	cancel()
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to load registered schemas: %v", err)
+	}
+	for _, jsonSchema := range stored {
+		schema, err := r.RegisterSchema(ctx, jsonSchema, nil)
+		if err != nil {
+			glog.Errorf("Ignored previously registered schema: %v", err)
+			continue
+		}
+		if schema.GetName() != "" {
+			types = append(types, strings.TrimPrefix(schema.GetName(), "schemas/"))
+		}
+	}
+	service.SetArtifactValidator(r)
+	service.SetSchemaRegistry(r)
+	return r, types, nil
 }
 
-func registerArtifactType(service *service.Service, ss *SchemaSet, id, namespace, typename string) error {
-	properties, err := ss.SimpleProperties(id)
+func registerArtifactType(ctx context.Context, service *service.Service, ss *SchemaSet, id, namespace, typename string) error {
+	properties, err := propertyTypes(ss, id)
 	if err != nil {
 		return err
 	}
 	artifactType := &mlpb.ArtifactType{
 		Name:       proto.String(namespace + "/" + typename),
-		Properties: make(map[string]mlpb.PropertyType),
+		Properties: properties,
 	}
-	for pname, ptype := range properties {
-		if isPropertyBuiltIn(pname) {
-			continue
-		}
-		switch ptype {
-		case StringType:
-			artifactType.Properties[pname] = mlpb.PropertyType_STRING
-		case IntegerType:
-			artifactType.Properties[pname] = mlpb.PropertyType_INT
-		case NumberType:
-			artifactType.Properties[pname] = mlpb.PropertyType_DOUBLE
-		default:
-			return fmt.Errorf("internal error: unknown simple property type %q for property %q", ptype, pname)
-		}
-	}
-	artifactType.Properties[wholeMetaPropertyName] = mlpb.PropertyType_STRING
-	ctx, cancel := context.WithTimeout(context.Background(), defaultTimeout)
+	ctx, cancel := context.WithTimeout(ctx, defaultTimeout)
 	defer cancel()
 	_, err = service.CreateArtifactType(ctx, &api.CreateArtifactTypeRequest{
 		ArtifactType: artifactType,
@@ -104,43 +132,140 @@ func registerArtifactType(service *service.Service, ss *SchemaSet, id, namespace
 	if err != nil {
 		return fmt.Errorf("error response from metadata server: %s", err)
 	}
-	return nil
+	validators, err := propertyValidators(ss, id, properties)
+	if err != nil {
+		return err
+	}
+	return service.SetArtifactPropertyValidators(artifactType.GetName(), validators)
 }
 
-func registerExecutionType(service *service.Service, ss *SchemaSet, id, namespace, typename string) error {
-	properties, err := ss.SimpleProperties(id)
+func registerExecutionType(ctx context.Context, service *service.Service, ss *SchemaSet, id, namespace, typename string) error {
+	properties, err := propertyTypes(ss, id)
 	if err != nil {
 		return err
 	}
 	executionType := &mlpb.ExecutionType{
 		Name:       proto.String(namespace + "/" + typename),
-		Properties: make(map[string]mlpb.PropertyType),
+		Properties: properties,
 	}
+	ctx, cancel := context.WithTimeout(ctx, defaultTimeout)
+	defer cancel()
+	_, err = service.CreateExecutionType(ctx, &api.CreateExecutionTypeRequest{
+		ExecutionType: executionType,
+	})
+	if err != nil {
+		return fmt.Errorf("error response from metadata server: %s", err)
+	}
+	validators, err := propertyValidators(ss, id, properties)
+	if err != nil {
+		return err
+	}
+	return service.SetExecutionPropertyValidators(executionType.GetName(), validators)
+}
+
+// registerContainerType registers the type of workspaces defined by a schema
+// of category container.
+func registerContainerType(ctx context.Context, service *service.Service, ss *SchemaSet, id, namespace, typename string) error {
+	properties, err := propertyTypes(ss, id)
+	if err != nil {
+		return err
+	}
+	containerType := &mlpb.ArtifactType{
+		Name:       proto.String(namespace + "/" + typename),
+		Properties: properties,
+	}
+	ctx, cancel := context.WithTimeout(ctx, defaultTimeout)
+	defer cancel()
+	if err := service.CreateContainerType(ctx, containerType); err != nil {
+		return fmt.Errorf("error response from metadata server: %s", err)
+	}
+	return nil
+}
+
+// propertyTypes returns the MLMD properties of the type defined by the schema
+// of id. Nested properties are flattened, booleans are stored as INT 0 or 1,
+// and arrays and free-form objects as STRING holding their JSON encoding. The
+// whole JSON document is stored in the property __ALL_META__.
+func propertyTypes(ss *SchemaSet, id string) (map[string]mlpb.PropertyType, error) {
+	properties, err := ss.FlattenedProperties(id)
+	if err != nil {
+		return nil, err
+	}
+	result := make(map[string]mlpb.PropertyType)
 	for pname, ptype := range properties {
 		if isPropertyBuiltIn(pname) {
 			continue
 		}
+		if pname == wholeMetaPropertyName {
+			return nil, fmt.Errorf("property %q collides with the property holding the whole JSON document", pname)
+		}
 		switch ptype {
-		case StringType:
-			executionType.Properties[pname] = mlpb.PropertyType_STRING
-		case IntegerType:
-			executionType.Properties[pname] = mlpb.PropertyType_INT
+		case StringType, ArrayType, ObjectType:
+			result[pname] = mlpb.PropertyType_STRING
+		case IntegerType, BooleanType:
+			result[pname] = mlpb.PropertyType_INT
 		case NumberType:
-			executionType.Properties[pname] = mlpb.PropertyType_DOUBLE
+			result[pname] = mlpb.PropertyType_DOUBLE
 		default:
-			return fmt.Errorf("internal error: unknown simple property type %q for property %q", ptype, pname)
+			return nil, fmt.Errorf("internal error: unknown property type %q for property %q", ptype, pname)
 		}
 	}
-	executionType.Properties[wholeMetaPropertyName] = mlpb.PropertyType_STRING
-	ctx, cancel := context.WithTimeout(context.Background(), defaultTimeout)
-	defer cancel()
-	_, err = service.CreateExecutionType(ctx, &api.CreateExecutionTypeRequest{
-		ExecutionType: executionType,
-	})
+	result[wholeMetaPropertyName] = mlpb.PropertyType_STRING
+	return result, nil
+}
+
+// openAPIValidator holds the OpenAPI keywords of the validator of a property.
+type openAPIValidator struct {
+	Enum             []string `json:"enum,omitempty"`
+	Pattern          string   `json:"pattern,omitempty"`
+	MinLength        *int     `json:"minLength,omitempty"`
+	MaxLength        *int     `json:"maxLength,omitempty"`
+	Minimum          *float64 `json:"minimum,omitempty"`
+	Maximum          *float64 `json:"maximum,omitempty"`
+	ExclusiveMinimum bool     `json:"exclusiveMinimum,omitempty"`
+	ExclusiveMaximum bool     `json:"exclusiveMaximum,omitempty"`
+}
+
+// propertyValidators returns the validators of the properties, as returned by
+// propertyTypes, of the type defined by the schema of id. They carry over the
+// enum, pattern, length and range constraints of string, integer and number
+// properties.
+func propertyValidators(ss *SchemaSet, id string, properties map[string]mlpb.PropertyType) (map[string]*api.PropertyValidator, error) {
+	ps, err := ss.propertySchemas(id)
 	if err != nil {
-		return fmt.Errorf("error response from metadata server: %s", err)
+		return nil, err
 	}
-	return nil
+	validators := make(map[string]*api.PropertyValidator)
+	for name, sj := range ps.schemas {
+		if _, ok := properties[name]; !ok || name == wholeMetaPropertyName {
+			continue
+		}
+		var v openAPIValidator
+		switch ps.types[name] {
+		case StringType:
+			v = openAPIValidator{Enum: sj.Enum, Pattern: sj.Pattern, MinLength: sj.MinLength, MaxLength: sj.MaxLength}
+		case IntegerType, NumberType:
+			v = openAPIValidator{Minimum: sj.Minimum, Maximum: sj.Maximum, ExclusiveMinimum: sj.ExclusiveMinimum, ExclusiveMaximum: sj.ExclusiveMaximum}
+		default:
+			continue
+		}
+		if reflect.DeepEqual(v, openAPIValidator{}) {
+			continue
+		}
+		b, err := json.Marshal(v)
+		if err != nil {
+			return nil, err
+		}
+		switch ps.types[name] {
+		case StringType:
+			validators[name] = &api.PropertyValidator{Type: &api.PropertyValidator_StringType{StringType: &api.StringType{Validator: string(b)}}}
+		case IntegerType:
+			validators[name] = &api.PropertyValidator{Type: &api.PropertyValidator_IntType{IntType: &api.IntType{Validator: string(b)}}}
+		case NumberType:
+			validators[name] = &api.PropertyValidator{Type: &api.PropertyValidator_DoubleType{DoubleType: &api.DoubleType{Validator: string(b)}}}
+		}
+	}
+	return validators, nil
 }
 
 func isPropertyBuiltIn(pname string) bool {
@@ -15,22 +15,54 @@
 package schemaparser
 
 import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+	"sort"
 	"testing"
 
-	"ml_metadata/metadata_store/mlmetadata"
 	mlpb "ml_metadata/proto/metadata_store_go_proto"
 
+	"github.com/golang/protobuf/proto"
+	"github.com/google/go-cmp/cmp"
+	"github.com/kubeflow/metadata/api"
+	"github.com/kubeflow/metadata/mlmd/sqlstore"
 	"github.com/kubeflow/metadata/service"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 )
 
-func testMLMDStore(t *testing.T) *mlmetadata.Store {
-	cfg := &mlpb.ConnectionConfig{
-		Config: &mlpb.ConnectionConfig_FakeDatabase{
-			&mlpb.FakeDatabaseConfig{},
-		},
+// testStoreBackend is the name of the MetadataStore implementation the tests
+// run against, out of testStoreBackends, which creates those compiled in. MLMD
+// requires cgo, so it is added by mlmd_test.go.
+var (
+	testStoreBackend  string
+	testStoreBackends = map[string]func() (service.MetadataStore, error){
+		"sqlite": func() (service.MetadataStore, error) { return sqlstore.NewSQLiteStore(":memory:") },
+	}
+)
+
+// TestMain runs the tests against each MetadataStore implementation.
+func TestMain(m *testing.M) {
+	flag.Parse()
+	var backends []string
+	for backend := range testStoreBackends {
+		backends = append(backends, backend)
 	}
+	sort.Strings(backends)
+	code := 0
+	for _, testStoreBackend = range backends {
+		if c := m.Run(); c != 0 {
+			fmt.Fprintf(os.Stderr, "Tests failed with the %s store\n", testStoreBackend)
+			code = c
+		}
+	}
+	os.Exit(code)
+}
 
-	store, err := mlmetadata.NewStore(cfg)
+func testMLMDStore(t *testing.T) service.MetadataStore {
+	store, err := testStoreBackends[testStoreBackend]()
 	if err != nil {
 		t.Fatalf("Failed to create ML Metadata Store: %v", err)
 	}
@@ -47,6 +79,7 @@ func TestRegisterSchemas(t *testing.T) {
 		"kubeflow.org/alpha/data_set",
 		"kubeflow.org/alpha/metrics",
 		"kubeflow.org/alpha/model",
+		"kubeflow.org/alpha/workspace",
 	}
 	for _, tn := range mustInclude {
 		if !foundType(registeredTypes, tn) {
@@ -63,3 +96,136 @@ func foundType(arr []string, target string) bool {
 	}
 	return false
 }
+
+func TestRegisterFlattenedProperties(t *testing.T) {
+	svc := service.New(testMLMDStore(t))
+	if _, err := RegisterSchemas(svc, schemaDir); err != nil {
+		t.Fatalf("failed to register schemas: %v", err)
+	}
+	resp, err := svc.GetArtifactType(context.Background(), &api.GetArtifactTypeRequest{Name: "artifact_types/kubeflow.org/alpha/model"})
+	if err != nil {
+		t.Fatalf("GetArtifactType failed: %v", err)
+	}
+	properties := resp.GetArtifactType().GetProperties()
+	expected := map[string]mlpb.PropertyType{
+		"training_framework.name":    mlpb.PropertyType_STRING,
+		"training_framework.version": mlpb.PropertyType_STRING,
+		"hyperparameters":            mlpb.PropertyType_STRING,
+		"annotations":                mlpb.PropertyType_STRING,
+		"__ALL_META__":               mlpb.PropertyType_STRING,
+	}
+	for name, ptype := range expected {
+		if got, ok := properties[name]; !ok || got != ptype {
+			t.Errorf("Registered model type has property %q of type %v, %v\nWant %v, true", name, got, ok, ptype)
+		}
+	}
+	if _, ok := properties["training_framework"]; ok {
+		t.Errorf("Registered model type has property %q, which should be flattened", "training_framework")
+	}
+}
+
+func TestRegisterContainerSchemas(t *testing.T) {
+	svc := service.New(testMLMDStore(t))
+	svc.SetValidationMode(service.StrictValidation)
+	if _, err := RegisterSchemas(svc, schemaDir); err != nil {
+		t.Fatalf("failed to register schemas: %v", err)
+	}
+	ctx := context.Background()
+	doc := func(s string) map[string]*mlpb.Value {
+		return map[string]*mlpb.Value{"__ALL_META__": {Value: &mlpb.Value_StringValue{StringValue: s}}}
+	}
+
+	req := &api.CreateWorkspaceRequest{Workspace: &api.Workspace{
+		Name:       "mnist",
+		Type:       "kubeflow.org/alpha/workspace",
+		Properties: doc(`{"owner": "someone@kubeflow.org"}`),
+	}}
+	if _, err := svc.CreateWorkspace(ctx, req); err != nil {
+		t.Fatalf("CreateWorkspace(%v) failed: %v", req, err)
+	}
+	resp, err := svc.GetWorkspace(ctx, &api.GetWorkspaceRequest{Name: "workspaces/mnist"})
+	if err != nil {
+		t.Fatalf("GetWorkspace failed: %v", err)
+	}
+	if got := resp.GetWorkspace().GetProperties()["name"].GetStringValue(); got != "mnist" {
+		t.Errorf("GetWorkspace returned name property %q, want %q", got, "mnist")
+	}
+
+	req.Workspace = &api.Workspace{
+		Name:       "invalid",
+		Type:       "kubeflow.org/alpha/workspace",
+		Properties: doc(`{"owner": 1}`),
+	}
+	if _, err := svc.CreateWorkspace(ctx, req); status.Code(err) != codes.InvalidArgument {
+		t.Errorf("CreateWorkspace with an invalid document = %v\nWant InvalidArgument error", err)
+	}
+}
+
+func TestRegisterPropertyValidators(t *testing.T) {
+	svc := service.New(testMLMDStore(t))
+	if _, err := RegisterSchemas(svc, schemaDir); err != nil {
+		t.Fatalf("failed to register schemas: %v", err)
+	}
+	ctx := context.Background()
+	resp, err := svc.GetArtifactType(ctx, &api.GetArtifactTypeRequest{Name: "artifact_types/kubeflow.org/alpha/metrics"})
+	if err != nil {
+		t.Fatalf("GetArtifactType failed: %v", err)
+	}
+	want := `{"enum":["training","validation","testing","production"]}`
+	if got := resp.GetPropertyValidators()["metrics_type"].GetStringType().GetValidator(); got != want {
+		t.Errorf("Validator of metrics_type = %q, want %q", got, want)
+	}
+	if v, exists := resp.GetPropertyValidators()["model_id"]; exists {
+		t.Errorf("Unconstrained property model_id has validator %v", v)
+	}
+
+	// Property validators are enforced in lenient validation mode too.
+	_, err = svc.CreateArtifact(ctx, &api.CreateArtifactRequest{
+		Parent: "artifact_types/kubeflow.org/alpha/metrics",
+		Artifact: &mlpb.Artifact{
+			Uri: proto.String("gs://bucket/metrics"),
+			Properties: map[string]*mlpb.Value{
+				"name":         {Value: &mlpb.Value_StringValue{StringValue: "mnist-eval"}},
+				"metrics_type": {Value: &mlpb.Value_StringValue{StringValue: "benchmark"}},
+			},
+		},
+	})
+	if status.Code(err) != codes.InvalidArgument {
+		t.Errorf("CreateArtifact with metrics_type not in enum = %v\nWant InvalidArgument error", err)
+	}
+}
+
+func TestPropertyValidators(t *testing.T) {
+	ss := compatSchemaSet(t, `{
+		"$id": "http://github.com/kubeflow/metadata/schemaparser/testdata/constrained.json",
+		"properties": {
+			"kind": {"type": "string", "constant": "constrained"},
+			"namespace": {"type": "string", "constant": "kubeflow.org"},
+			"apiversion": {"type": "string", "constant": "v1"},
+			"category": {"type": "string", "constant": "artifact"},
+			"tag": {"type": "string", "pattern": "^v[0-9]+$", "minLength": 2},
+			"layers": {"type": "integer", "minimum": 0},
+			"score": {"type": "number", "maximum": 1, "exclusiveMaximum": true},
+			"trainer": {"type": "object", "properties": {"gpus": {"type": "integer", "maximum": 8}}},
+			"tags": {"type": "array", "items": {"type": "string"}, "minItems": 1}
+		}
+	}`)
+	const id = "http://github.com/kubeflow/metadata/schemaparser/testdata/constrained.json"
+	properties, err := propertyTypes(ss, id)
+	if err != nil {
+		t.Fatalf("propertyTypes failed: %v", err)
+	}
+	validators, err := propertyValidators(ss, id, properties)
+	if err != nil {
+		t.Fatalf("propertyValidators failed: %v", err)
+	}
+	want := map[string]*api.PropertyValidator{
+		"tag":          {Type: &api.PropertyValidator_StringType{StringType: &api.StringType{Validator: `{"pattern":"^v[0-9]+$","minLength":2}`}}},
+		"layers":       {Type: &api.PropertyValidator_IntType{IntType: &api.IntType{Validator: `{"minimum":0}`}}},
+		"score":        {Type: &api.PropertyValidator_DoubleType{DoubleType: &api.DoubleType{Validator: `{"maximum":1,"exclusiveMaximum":true}`}}},
+		"trainer.gpus": {Type: &api.PropertyValidator_IntType{IntType: &api.IntType{Validator: `{"maximum":8}`}}},
+	}
+	if !cmp.Equal(validators, want, cmp.Comparer(proto.Equal)) {
+		t.Errorf("propertyValidators() = %v\nWant %v", validators, want)
+	}
+}
@@ -0,0 +1,185 @@
+// Copyright 2019 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package schemaparser
+
+import (
+	"context"
+	"sync"
+
+	mlpb "ml_metadata/proto/metadata_store_go_proto"
+
+	"github.com/kubeflow/metadata/api"
+	"github.com/kubeflow/metadata/service"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// Registry holds the schemas defining the types of a metadata service, and
+// registers new ones at runtime. It implements service.SchemaRegistry and
+// service.ArtifactValidator.
+type Registry struct {
+	service *service.Service
+
+	// mu guards ss, validator and registered, which schemas are added to while
+	// the service validates artifacts.
+	mu        sync.RWMutex
+	ss        *SchemaSet
+	validator *ArtifactValidator
+	// registered holds the $id of the schemas registered at runtime, in the
+	// order they were registered, which Reload keeps.
+	registered []string
+	// filesState identifies the versions of the schema files the schemas were
+	// last loaded from, for Watch to detect changes.
+	filesState string
+}
+
+// NewRegistry returns a Registry holding the schemas of ss, whose types must
+// already be registered with service.
+func NewRegistry(service *service.Service, ss *SchemaSet) *Registry {
+	return &Registry{
+		service:   service,
+		ss:        ss,
+		validator: NewArtifactValidator(ss),
+	}
+}
+
+// RegisterSchema adds jsonSchema to the registry and registers the type it
+// defines with the service, if any, with ctx. It then calls save, if not nil.
+// If save fails, jsonSchema is removed from the registry and the property
+// validators of its type are cleared, but the type is left in the store: it
+// has no records, and registering the schema again reuses it.
+func (r *Registry) RegisterSchema(ctx context.Context, jsonSchema string, save func() error) (*api.Schema, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	sj, err := NewSchemaJSON([]byte(jsonSchema))
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid schema: %v", err)
+	}
+	if _, exists := r.ss.Schemas[sj.ID]; exists {
+		return nil, status.Errorf(codes.AlreadyExists, "schema with $id %s already exists", sj.ID)
+	}
+	id, err := r.ss.AddSchema([]byte(jsonSchema))
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid schema: %v", err)
+	}
+
+	if err := r.registerType(ctx, id); err != nil {
+		if rerr := r.ss.removeSchema(id); rerr != nil {
+			return nil, status.Errorf(codes.Internal, "%v; failed to remove schema %s: %v", err, id, rerr)
+		}
+		return nil, err
+	}
+	if save != nil {
+		if err := save(); err != nil {
+			err = status.Errorf(codes.Internal, "failed to persist schema %s: %v", id, err)
+			if rerr := r.unregisterType(id); rerr != nil {
+				return nil, status.Errorf(codes.Internal, "%v; failed to clear the validators of schema %s: %v", err, id, rerr)
+			}
+			if rerr := r.ss.removeSchema(id); rerr != nil {
+				return nil, status.Errorf(codes.Internal, "%v; failed to remove schema %s: %v", err, id, rerr)
+			}
+			return nil, err
+		}
+	}
+	r.validator.addSchema(id)
+	r.registered = append(r.registered, id)
+	return r.schema(id), nil
+}
+
+// registerType registers the type defined by the schema of id, if any.
+func (r *Registry) registerType(ctx context.Context, id string) error {
+	namespace, typename, err := r.ss.TypeName(id)
+	if err != nil {
+		// Schemas without constant kind, apiversion and namespace define no
+		// type, but may be referred to by other schemas.
+		return nil
+	}
+	category, err := r.ss.ConstantStringType(id, categoryPropertyName)
+	if err != nil {
+		return status.Errorf(codes.InvalidArgument, "schema %s defines type %s/%s without a constant %q property", id, namespace, typename, categoryPropertyName)
+	}
+
+	switch category {
+	case artifactCategory:
+		err = registerArtifactType(ctx, r.service, r.ss, id, namespace, typename)
+	case executionCategory:
+		err = registerExecutionType(ctx, r.service, r.ss, id, namespace, typename)
+	case containerCategory:
+		err = registerContainerType(ctx, r.service, r.ss, id, namespace, typename)
+	default:
+		return status.Errorf(codes.InvalidArgument, "schema %s has unsupported category %q: must be one of [%s, %s, %s]", id, category, artifactCategory, executionCategory, containerCategory)
+	}
+	if err != nil {
+		return status.Errorf(codes.InvalidArgument, "failed to register the type defined by schema %s: %v", id, err)
+	}
+	return nil
+}
+
+// unregisterType clears the property validators of the type defined by the
+// schema of id, if any, which registerType set.
+func (r *Registry) unregisterType(id string) error {
+	namespace, typename, err := r.ss.TypeName(id)
+	if err != nil {
+		return nil
+	}
+	category, err := r.ss.ConstantStringType(id, categoryPropertyName)
+	if err != nil {
+		return nil
+	}
+	switch category {
+	case artifactCategory:
+		return r.service.SetArtifactPropertyValidators(namespace+"/"+typename, nil)
+	case executionCategory:
+		return r.service.SetExecutionPropertyValidators(namespace+"/"+typename, nil)
+	}
+	return nil
+}
+
+// schema returns the API representation of the schema of id.
+func (r *Registry) schema(id string) *api.Schema {
+	schema := &api.Schema{
+		Id:         id,
+		JsonSchema: string(r.ss.Schemas[id].Source),
+	}
+	if namespace, typename, err := r.ss.TypeName(id); err == nil {
+		category, err := r.ss.ConstantStringType(id, categoryPropertyName)
+		if err == nil && (category == artifactCategory || category == executionCategory || category == containerCategory) {
+			schema.Name = "schemas/" + namespace + "/" + typename
+		}
+	}
+	return schema
+}
+
+// Schemas returns all the schemas in the registry.
+func (r *Registry) Schemas() []*api.Schema {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	var schemas []*api.Schema
+	for id := range r.ss.Schemas {
+		schemas = append(schemas, r.schema(id))
+	}
+	return schemas
+}
+
+// ValidateArtifact validates artifact against the schema of the type named
+// typeName. See ArtifactValidator.ValidateArtifact.
+func (r *Registry) ValidateArtifact(typeName string, artifact *mlpb.Artifact) ([]string, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	return r.validator.ValidateArtifact(typeName, artifact)
+}
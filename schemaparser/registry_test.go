@@ -0,0 +1,223 @@
+// Copyright 2019 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package schemaparser
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	mlpb "ml_metadata/proto/metadata_store_go_proto"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/kubeflow/metadata/api"
+	"github.com/kubeflow/metadata/service"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	customBaseSchema = `{
+		"$id": "http://example.com/schemas/base.json",
+		"allOf": [{"$ref": "http://github.com/kubeflow/metadata/schema/alpha/artifacts/artifact.json"}],
+		"properties": {"rows": {"type": "integer"}}
+	}`
+	customSchema = `{
+		"$id": "http://example.com/schemas/table.json",
+		"allOf": [{"$ref": "http://example.com/schemas/base.json"}],
+		"properties": {
+			"namespace": {"type": "string", "constant": "example.com"},
+			"apiversion": {"type": "string", "constant": "v1"},
+			"kind": {"type": "string", "constant": "table"},
+			"category": {"type": "string", "constant": "artifact"}
+		},
+		"required": ["name", "uri", "rows"]
+	}`
+)
+
+func TestRegisterSchemaAtRuntime(t *testing.T) {
+	store := testMLMDStore(t)
+	svc := service.New(store)
+	svc.SetValidationMode(service.StrictValidation)
+	if _, err := RegisterSchemas(svc, schemaDir); err != nil {
+		t.Fatalf("failed to register schemas: %v", err)
+	}
+	ctx := context.Background()
+
+	// Schemas may refer to predefined schemas and to schemas registered at
+	// runtime.
+	base, err := svc.RegisterSchema(ctx, &api.RegisterSchemaRequest{JsonSchema: customBaseSchema})
+	if err != nil {
+		t.Fatalf("RegisterSchema(base) failed: %v", err)
+	}
+	if base.GetSchema().GetName() != "" {
+		t.Errorf("RegisterSchema(base) returned name %q\nWant none, since it defines no type", base.GetSchema().GetName())
+	}
+	table, err := svc.RegisterSchema(ctx, &api.RegisterSchemaRequest{JsonSchema: customSchema})
+	if err != nil {
+		t.Fatalf("RegisterSchema(table) failed: %v", err)
+	}
+	if got, want := table.GetSchema().GetName(), "schemas/example.com/v1/table"; got != want {
+		t.Errorf("RegisterSchema(table) returned name %q\nWant %q", got, want)
+	}
+
+	aType, err := svc.GetArtifactType(ctx, &api.GetArtifactTypeRequest{Name: "artifact_types/example.com/v1/table"})
+	if err != nil {
+		t.Fatalf("GetArtifactType of the registered type failed: %v", err)
+	}
+	if aType.GetArtifactType().GetProperties()["rows"] != mlpb.PropertyType_INT {
+		t.Errorf("Registered type %v lacks INT property rows", aType.GetArtifactType())
+	}
+
+	// Artifacts of the new type are validated against its schema.
+	artifact := &mlpb.Artifact{
+		Uri: proto.String("gs://bucket/table"),
+		Properties: map[string]*mlpb.Value{
+			"name": &mlpb.Value{Value: &mlpb.Value_StringValue{StringValue: "table"}},
+		},
+	}
+	req := &api.CreateArtifactRequest{Parent: "artifact_types/example.com/v1/table", Artifact: artifact}
+	if _, err := svc.CreateArtifact(ctx, req); status.Code(err) != codes.InvalidArgument {
+		t.Errorf("CreateArtifact without required rows = %v\nWant InvalidArgument error", err)
+	}
+	artifact.Properties["rows"] = &mlpb.Value{Value: &mlpb.Value_IntValue{IntValue: 10}}
+	if _, err := svc.CreateArtifact(ctx, req); err != nil {
+		t.Errorf("CreateArtifact failed: %v", err)
+	}
+
+	get, err := svc.GetSchema(ctx, &api.GetSchemaRequest{Name: "schemas/example.com/v1/table"})
+	if err != nil || get.GetSchema().GetJsonSchema() != customSchema {
+		t.Errorf("GetSchema = %v, %v\nWant the registered schema", get, err)
+	}
+	if _, err := svc.GetSchema(ctx, &api.GetSchemaRequest{Name: "schemas/kubeflow.org/alpha/model"}); err != nil {
+		t.Errorf("GetSchema of a predefined schema failed: %v", err)
+	}
+	if _, err := svc.GetSchema(ctx, &api.GetSchemaRequest{Name: "schemas/example.com/v1/missing"}); status.Code(err) != codes.NotFound {
+		t.Errorf("GetSchema of a missing schema = %v\nWant NotFound error", err)
+	}
+	list, err := svc.ListSchemas(ctx, &api.ListSchemasRequest{})
+	if err != nil {
+		t.Fatalf("ListSchemas failed: %v", err)
+	}
+	ids := make(map[string]bool)
+	for _, schema := range list.GetSchemas() {
+		ids[schema.GetId()] = true
+	}
+	for _, id := range []string{"http://example.com/schemas/base.json", "http://example.com/schemas/table.json", dataSetID} {
+		if !ids[id] {
+			t.Errorf("ListSchemas() = %v\nWant it to include %s", list, id)
+		}
+	}
+
+	tests := []struct {
+		desc   string
+		schema string
+		code   codes.Code
+	}{
+		{"duplicated $id", customSchema, codes.AlreadyExists},
+		{"malformed JSON", `{`, codes.InvalidArgument},
+		{"missing $id", `{"type": "object"}`, codes.InvalidArgument},
+		{"unknown reference", `{"$id": "http://example.com/schemas/dangling.json", "allOf": [{"$ref": "http://example.com/schemas/missing.json"}]}`, codes.InvalidArgument},
+		{"unknown category", `{
+			"$id": "http://example.com/schemas/unknown.json",
+			"properties": {
+				"namespace": {"type": "string", "constant": "example.com"},
+				"apiversion": {"type": "string", "constant": "v1"},
+				"kind": {"type": "string", "constant": "unknown"},
+				"category": {"type": "string", "constant": "unknown"}
+			}
+		}`, codes.InvalidArgument},
+	}
+	for _, test := range tests {
+		if _, err := svc.RegisterSchema(ctx, &api.RegisterSchemaRequest{JsonSchema: test.schema}); status.Code(err) != test.code {
+			t.Errorf("Test %q: RegisterSchema = %v\nWant %v error", test.desc, err, test.code)
+		}
+	}
+
+	// Schemas failing to register can be fixed and registered again.
+	dangling := `{"$id": "http://example.com/schemas/dangling.json", "allOf": [{"$ref": "http://example.com/schemas/base.json"}]}`
+	if _, err := svc.RegisterSchema(ctx, &api.RegisterSchemaRequest{JsonSchema: dangling}); err != nil {
+		t.Errorf("RegisterSchema of a fixed schema failed: %v", err)
+	}
+
+	// Schemas failing to persist are left out, and can be registered again.
+	loaded := service.New(store)
+	r, _, err := LoadSchemas(ctx, loaded, schemaDir)
+	if err != nil {
+		t.Fatalf("LoadSchemas failed: %v", err)
+	}
+	unsaved := `{"$id": "http://example.com/schemas/unsaved.json", "allOf": [{"$ref": "http://example.com/schemas/base.json"}]}`
+	failingSave := func() error { return errors.New("save failed") }
+	if _, err := r.RegisterSchema(ctx, unsaved, failingSave); status.Code(err) != codes.Internal {
+		t.Errorf("RegisterSchema with a failing save = %v\nWant Internal error", err)
+	}
+	for _, schema := range r.Schemas() {
+		if schema.GetId() == "http://example.com/schemas/unsaved.json" {
+			t.Errorf("Schemas() = %v\nWant it to exclude the schema failing to persist", r.Schemas())
+		}
+	}
+	if _, err := r.RegisterSchema(ctx, unsaved, nil); err != nil {
+		t.Errorf("RegisterSchema of a schema that failed to persist failed: %v", err)
+	}
+
+	// The type of a schema failing to persist is left without validators.
+	unsavedType := `{
+		"$id": "http://example.com/schemas/unsaved_type.json",
+		"properties": {
+			"namespace": {"type": "string", "constant": "example.com"},
+			"apiversion": {"type": "string", "constant": "v1"},
+			"kind": {"type": "string", "constant": "unsaved"},
+			"category": {"type": "string", "constant": "artifact"},
+			"label": {"type": "string", "pattern": "^[a-z]+$"}
+		}
+	}`
+	if _, err := r.RegisterSchema(ctx, unsavedType, failingSave); status.Code(err) != codes.Internal {
+		t.Errorf("RegisterSchema with a failing save = %v\nWant Internal error", err)
+	}
+	created, err := loaded.CreateArtifact(ctx, &api.CreateArtifactRequest{
+		Parent: "artifact_types/example.com/v1/unsaved",
+		Artifact: &mlpb.Artifact{Properties: map[string]*mlpb.Value{
+			"label": &mlpb.Value{Value: &mlpb.Value_StringValue{StringValue: "NOT LOWERCASE"}},
+		}},
+	})
+	if err != nil {
+		t.Errorf("CreateArtifact of the type of a schema that failed to persist = %v\nWant nil error", err)
+	} else {
+		name := fmt.Sprintf("artifact_types/example.com/v1/unsaved/artifacts/%d", created.GetArtifact().GetId())
+		if _, err := loaded.DeleteArtifact(ctx, &api.DeleteArtifactRequest{Name: name}); err != nil {
+			t.Fatalf("DeleteArtifact(%q) failed: %v", name, err)
+		}
+	}
+
+	// Registered schemas survive restarts.
+	restarted := service.New(store)
+	types, err := RegisterSchemas(restarted, schemaDir)
+	if err != nil {
+		t.Fatalf("failed to register schemas after restart: %v", err)
+	}
+	if !foundType(types, "example.com/v1/table") {
+		t.Errorf("RegisterSchemas after restart = %v\nWant it to include example.com/v1/table", types)
+	}
+	if _, err := restarted.GetSchema(ctx, &api.GetSchemaRequest{Name: "schemas/example.com/v1/table"}); err != nil {
+		t.Errorf("GetSchema after restart failed: %v", err)
+	}
+
+	// The artifacts persisting schemas are hidden.
+	artifacts, err := restarted.ListArtifacts(ctx, &api.ListArtifactsRequest{})
+	if err != nil || len(artifacts.GetArtifacts()) != 1 {
+		t.Errorf("ListArtifacts() = %v, %v\nWant the single table artifact", artifacts, err)
+	}
+}
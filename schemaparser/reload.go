@@ -0,0 +1,225 @@
+// Copyright 2019 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package schemaparser
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	mlpb "ml_metadata/proto/metadata_store_go_proto"
+
+	"github.com/bmatcuk/doublestar"
+	"github.com/golang/glog"
+	"github.com/golang/protobuf/proto"
+	"github.com/kubeflow/metadata/api"
+	"github.com/kubeflow/metadata/service"
+)
+
+// Reload replaces the schemas of r with those in schemaRootDir, keeping the
+// schemas registered at runtime. Types defined by new schemas are created and
+// types of changed schemas gain their new properties; properties are never
+// removed from types. Backward incompatible changes are rejected, leaving the
+// schemas of r unchanged, as metadata already logged may not match the new
+// schemas. Reload returns the incompatible changes it found, including the
+// forward incompatible ones it applied. The types are updated with ctx, each
+// within defaultTimeout; if some fail to update, the new schemas are still
+// used and Reload returns an error naming the failed ones.
+func (r *Registry) Reload(ctx context.Context, schemaRootDir string) ([]Change, error) {
+	state, err := schemaFilesState(schemaRootDir)
+	if err != nil {
+		return nil, err
+	}
+	ss, err := NewSchemaSetFromADir(schemaRootDir)
+	if err != nil {
+		return nil, err
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	var registered []string
+	for _, id := range r.registered {
+		if _, exists := ss.Schemas[id]; exists {
+			// The schema is now defined in schemaRootDir.
+			continue
+		}
+		if _, err := ss.AddSchema(r.ss.Schemas[id].Source); err != nil {
+			return nil, fmt.Errorf("failed to keep registered schema %s: %v", id, err)
+		}
+		registered = append(registered, id)
+	}
+
+	changes, err := CheckCompatibility(r.ss, ss)
+	if err != nil {
+		return nil, err
+	}
+	var rejected []string
+	for _, c := range changes {
+		if c.Compatibility.Backward() {
+			rejected = append(rejected, c.String())
+		}
+	}
+	if len(rejected) > 0 {
+		return changes, fmt.Errorf("rejected backward incompatible changes: %s", strings.Join(rejected, "; "))
+	}
+
+	// Every type is updated even if others fail, and the new schemas are used
+	// either way: properties are only added, so the types that failed to
+	// update keep accepting the metadata they accepted, and are updated again
+	// by the next reload.
+	ids := make([]string, 0, len(ss.Schemas))
+	for id := range ss.Schemas {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+	var failed []string
+	for _, id := range ids {
+		if err := updateType(ctx, r.service, ss, id); err != nil {
+			failed = append(failed, fmt.Sprintf("%s: %v", id, err))
+		}
+	}
+	r.ss = ss
+	r.validator = NewArtifactValidator(ss)
+	r.registered = registered
+	r.filesState = state
+	if len(failed) > 0 {
+		return changes, fmt.Errorf("failed to update the types defined by schemas %s", strings.Join(failed, "; "))
+	}
+	return changes, nil
+}
+
+// updateType creates or adds properties to the type defined by the schema of
+// id, if any, keeping the properties of the existing type.
+func updateType(ctx context.Context, service *service.Service, ss *SchemaSet, id string) error {
+	namespace, typename, err := ss.TypeName(id)
+	if err != nil {
+		return nil
+	}
+	category, err := ss.ConstantStringType(id, categoryPropertyName)
+	if err != nil {
+		return fmt.Errorf("schema defines type %s/%s without a constant %q property", namespace, typename, categoryPropertyName)
+	}
+	properties, err := propertyTypes(ss, id)
+	if err != nil {
+		return err
+	}
+	// The validators only cover the properties defined by the schema.
+	validators, err := propertyValidators(ss, id, properties)
+	if err != nil {
+		return err
+	}
+	name := namespace + "/" + typename
+
+	ctx, cancel := context.WithTimeout(ctx, defaultTimeout)
+	defer cancel()
+	switch category {
+	case artifactCategory:
+		if resp, err := service.GetArtifactType(ctx, &api.GetArtifactTypeRequest{Name: "artifact_types/" + name}); err == nil {
+			keepProperties(properties, resp.GetArtifactType().GetProperties())
+		}
+		if _, err := service.UpdateArtifactType(ctx, &api.UpdateArtifactTypeRequest{
+			ArtifactType: &mlpb.ArtifactType{Name: proto.String(name), Properties: properties},
+		}); err != nil {
+			return err
+		}
+		return service.SetArtifactPropertyValidators(name, validators)
+	case executionCategory:
+		if resp, err := service.GetExecutionType(ctx, &api.GetExecutionTypeRequest{Name: "execution_types/" + name}); err == nil {
+			keepProperties(properties, resp.GetExecutionType().GetProperties())
+		}
+		if _, err := service.UpdateExecutionType(ctx, &api.UpdateExecutionTypeRequest{
+			ExecutionType: &mlpb.ExecutionType{Name: proto.String(name), Properties: properties},
+		}); err != nil {
+			return err
+		}
+		return service.SetExecutionPropertyValidators(name, validators)
+	case containerCategory:
+		return service.UpdateContainerType(ctx, &mlpb.ArtifactType{Name: proto.String(name), Properties: properties})
+	}
+	glog.Errorf("Ignored unknown category %q with type %q in %q", category, typename, id)
+	return nil
+}
+
+// keepProperties adds the stored properties missing from properties.
+func keepProperties(properties, stored map[string]mlpb.PropertyType) {
+	for p, t := range stored {
+		if _, exists := properties[p]; !exists {
+			properties[p] = t
+		}
+	}
+}
+
+// Watch reloads the schemas of r from schemaRootDir whenever a schema file in
+// it is added, removed or modified since they were loaded. It polls the paths,
+// sizes and modification times of the files every interval until ctx is done.
+// Incompatible changes and failed reloads are logged, and the reload is
+// retried once the files change again.
+func (r *Registry) Watch(ctx context.Context, schemaRootDir string, interval time.Duration) {
+	r.mu.RLock()
+	last := r.filesState
+	r.mu.RUnlock()
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+		state, err := schemaFilesState(schemaRootDir)
+		if err != nil {
+			glog.Errorf("Failed to read schema directory %s: %v", schemaRootDir, err)
+			continue
+		}
+		if state == last {
+			continue
+		}
+		last = state
+
+		changes, err := r.Reload(ctx, schemaRootDir)
+		for _, c := range changes {
+			glog.Warningf("Incompatible schema change: %s", c)
+		}
+		if err != nil {
+			glog.Errorf("Failed to reload schemas from %s: %v", schemaRootDir, err)
+			continue
+		}
+		glog.Infof("Reloaded schemas from %s", schemaRootDir)
+	}
+}
+
+// schemaFilesState returns the paths, sizes and modification times of the
+// schema files in dir, which change whenever a schema file does.
+func schemaFilesState(dir string) (string, error) {
+	files, err := doublestar.Glob(filepath.Join(dir, "**/*.json"))
+	if err != nil {
+		return "", err
+	}
+	sort.Strings(files)
+	var state strings.Builder
+	for _, file := range files {
+		info, err := os.Stat(file)
+		if err != nil {
+			return "", err
+		}
+		fmt.Fprintf(&state, "%s %d %d\n", file, info.Size(), info.ModTime().UnixNano())
+	}
+	return state.String(), nil
+}
@@ -0,0 +1,219 @@
+// Copyright 2019 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package schemaparser
+
+import (
+	"context"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	mlpb "ml_metadata/proto/metadata_store_go_proto"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/kubeflow/metadata/api"
+	"github.com/kubeflow/metadata/service"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	reloadSchema = `{
+		"$id": "http://github.com/kubeflow/metadata/reload/thing.json",
+		"properties": {
+			"kind": {"type": "string", "constant": "thing"},
+			"namespace": {"type": "string", "constant": "kubeflow.org"},
+			"apiversion": {"type": "string", "constant": "v1"},
+			"category": {"type": "string", "constant": "artifact"},
+			"name": {"type": "string"}
+		},
+		"required": ["name"]
+	}`
+	// reloadSchemaWithSize adds an optional property size to reloadSchema.
+	reloadSchemaWithSize = `{
+		"$id": "http://github.com/kubeflow/metadata/reload/thing.json",
+		"properties": {
+			"kind": {"type": "string", "constant": "thing"},
+			"namespace": {"type": "string", "constant": "kubeflow.org"},
+			"apiversion": {"type": "string", "constant": "v1"},
+			"category": {"type": "string", "constant": "artifact"},
+			"name": {"type": "string"},
+			"size": {"type": "integer"}
+		},
+		"required": ["name"]
+	}`
+	// reloadSchemaWithRequiredSize makes size required, which is backward
+	// incompatible.
+	reloadSchemaWithRequiredSize = `{
+		"$id": "http://github.com/kubeflow/metadata/reload/thing.json",
+		"properties": {
+			"kind": {"type": "string", "constant": "thing"},
+			"namespace": {"type": "string", "constant": "kubeflow.org"},
+			"apiversion": {"type": "string", "constant": "v1"},
+			"category": {"type": "string", "constant": "artifact"},
+			"name": {"type": "string"},
+			"size": {"type": "integer"}
+		},
+		"required": ["name", "size"]
+	}`
+	reloadExecutionSchema = `{
+		"$id": "http://github.com/kubeflow/metadata/reload/run.json",
+		"properties": {
+			"kind": {"type": "string", "constant": "run"},
+			"namespace": {"type": "string", "constant": "kubeflow.org"},
+			"apiversion": {"type": "string", "constant": "v1"},
+			"category": {"type": "string", "constant": "execution"},
+			"steps": {"type": "integer"}
+		}
+	}`
+	// uncategorizedSchema defines a type without a category, which fails to
+	// be created.
+	uncategorizedSchema = `{
+		"$id": "http://github.com/kubeflow/metadata/reload/part.json",
+		"properties": {
+			"kind": {"type": "string", "constant": "part"},
+			"namespace": {"type": "string", "constant": "kubeflow.org"},
+			"apiversion": {"type": "string", "constant": "v1"}
+		}
+	}`
+	registeredSchema = `{
+		"$id": "http://github.com/kubeflow/metadata/registered/note.json",
+		"properties": {
+			"kind": {"type": "string", "constant": "note"},
+			"namespace": {"type": "string", "constant": "kubeflow.org"},
+			"apiversion": {"type": "string", "constant": "v1"},
+			"category": {"type": "string", "constant": "artifact"}
+		}
+	}`
+)
+
+// writeSchema writes a schema file named name into dir.
+func writeSchema(t *testing.T, dir, name, schema string) {
+	if err := ioutil.WriteFile(filepath.Join(dir, name), []byte(schema), 0644); err != nil {
+		t.Fatalf("failed to write schema %s: %v", name, err)
+	}
+}
+
+func reloadTestDir(t *testing.T) string {
+	dir, err := ioutil.TempDir("", "schemas")
+	if err != nil {
+		t.Fatalf("failed to create schema directory: %v", err)
+	}
+	writeSchema(t, dir, "thing.json", reloadSchema)
+	return dir
+}
+
+func createThing(svc *service.Service, doc string) error {
+	_, err := svc.CreateArtifact(context.Background(), &api.CreateArtifactRequest{
+		Parent: "artifact_types/kubeflow.org/v1/thing",
+		Artifact: &mlpb.Artifact{
+			Uri: proto.String("gs://bucket/thing"),
+			Properties: map[string]*mlpb.Value{
+				"name":         {Value: &mlpb.Value_StringValue{StringValue: "t"}},
+				"__ALL_META__": {Value: &mlpb.Value_StringValue{StringValue: doc}},
+			},
+		},
+	})
+	return err
+}
+
+func TestReload(t *testing.T) {
+	dir := reloadTestDir(t)
+	defer os.RemoveAll(dir)
+	svc := service.New(testMLMDStore(t))
+	svc.SetValidationMode(service.StrictValidation)
+	ctx := context.Background()
+	r, _, err := LoadSchemas(ctx, svc, dir)
+	if err != nil {
+		t.Fatalf("LoadSchemas failed: %v", err)
+	}
+	if _, err := r.RegisterSchema(ctx, registeredSchema, nil); err != nil {
+		t.Fatalf("RegisterSchema failed: %v", err)
+	}
+
+	writeSchema(t, dir, "thing.json", reloadSchemaWithSize)
+	writeSchema(t, dir, "run.json", reloadExecutionSchema)
+	if changes, err := r.Reload(ctx, dir); err != nil || len(changes) != 0 {
+		t.Fatalf("Reload() = %v, %v\nWant no changes, nil", changes, err)
+	}
+	aType, err := svc.GetArtifactType(ctx, &api.GetArtifactTypeRequest{Name: "artifact_types/kubeflow.org/v1/thing"})
+	if err != nil {
+		t.Fatalf("GetArtifactType failed: %v", err)
+	}
+	if got := aType.GetArtifactType().GetProperties()["size"]; got != mlpb.PropertyType_INT {
+		t.Errorf("Reloaded type has property size of type %v, want INT", got)
+	}
+	if _, err := svc.GetExecutionType(ctx, &api.GetExecutionTypeRequest{Name: "execution_types/kubeflow.org/v1/run"}); err != nil {
+		t.Errorf("GetExecutionType of the type of a new schema failed: %v", err)
+	}
+	if err := createThing(svc, `{"size": "big"}`); status.Code(err) != codes.InvalidArgument {
+		t.Errorf("CreateArtifact not matching the reloaded schema = %v\nWant InvalidArgument error", err)
+	}
+	if _, err := svc.GetSchema(ctx, &api.GetSchemaRequest{Name: "schemas/kubeflow.org/v1/note"}); err != nil {
+		t.Errorf("GetSchema of a schema registered before Reload failed: %v", err)
+	}
+
+	writeSchema(t, dir, "thing.json", reloadSchemaWithRequiredSize)
+	changes, err := r.Reload(ctx, dir)
+	if err == nil || len(changes) != 1 || !changes[0].Compatibility.Backward() {
+		t.Errorf("Reload() of a backward incompatible change = %v, %v\nWant the change and an error", changes, err)
+	}
+	if err := createThing(svc, `{}`); err != nil {
+		t.Errorf("CreateArtifact valid under the active schema failed after a rejected Reload: %v", err)
+	}
+
+	writeSchema(t, dir, "thing.json", reloadSchemaWithSize)
+	writeSchema(t, dir, "part.json", uncategorizedSchema)
+	writeSchema(t, dir, "step.json", strings.Replace(reloadExecutionSchema, "run", "step", -1))
+	if _, err := r.Reload(ctx, dir); err == nil || !strings.Contains(err.Error(), "reload/part.json") {
+		t.Errorf("Reload() of a schema whose type fails to update = %v\nWant error naming the schema", err)
+	}
+	if _, err := svc.GetExecutionType(ctx, &api.GetExecutionTypeRequest{Name: "execution_types/kubeflow.org/v1/step"}); err != nil {
+		t.Errorf("GetExecutionType of the type of a schema reloaded with a failed one failed: %v", err)
+	}
+	if _, err := svc.GetSchema(ctx, &api.GetSchemaRequest{Name: "schemas/kubeflow.org/v1/step"}); err != nil {
+		t.Errorf("GetSchema of a schema reloaded with a failed one failed: %v", err)
+	}
+	os.Remove(filepath.Join(dir, "part.json"))
+
+	writeSchema(t, dir, "thing.json", "{")
+	if _, err := r.Reload(ctx, dir); err == nil {
+		t.Error("Reload() of a malformed schema succeeded, want error")
+	}
+}
+
+func TestWatch(t *testing.T) {
+	dir := reloadTestDir(t)
+	defer os.RemoveAll(dir)
+	svc := service.New(testMLMDStore(t))
+	ctx, cancel := context.WithCancel(context.Background())
+	r, _, err := LoadSchemas(ctx, svc, dir)
+	if err != nil {
+		t.Fatalf("LoadSchemas failed: %v", err)
+	}
+	defer cancel()
+	go r.Watch(ctx, dir, 10*time.Millisecond)
+
+	writeSchema(t, dir, "run.json", reloadExecutionSchema)
+	for deadline := time.Now().Add(5 * time.Second); time.Now().Before(deadline); time.Sleep(10 * time.Millisecond) {
+		if _, err := svc.GetExecutionType(ctx, &api.GetExecutionTypeRequest{Name: "execution_types/kubeflow.org/v1/run"}); err == nil {
+			return
+		}
+	}
+	t.Error("Watch did not reload the schema added to the schema directory")
+}
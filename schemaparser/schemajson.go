@@ -18,6 +18,7 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"regexp"
 )
 
 const (
@@ -45,10 +46,11 @@ type SchemaJSON struct {
 	Description string `json:"description,omitempty"`
 	Version     string `json:"version,omitempty"`
 
-	Default  interface{} `json:"default,omitempty"`
-	ReadOnly bool        `json:"readOnly,omitempty"`
-	Example  interface{} `json:"example,omitempty"`
-	Format   string      `json:"format,omitempty"`
+	Default  interface{}   `json:"default,omitempty"`
+	ReadOnly bool          `json:"readOnly,omitempty"`
+	Example  interface{}   `json:"example,omitempty"`
+	Examples []interface{} `json:"examples,omitempty"`
+	Format   string        `json:"format,omitempty"`
 
 	Type interface{} `json:"type,omitempty"`
 
@@ -58,15 +60,15 @@ type SchemaJSON struct {
 	Definitions map[string]*SchemaJSON `json:"definitions,omitempty"`
 
 	// Numbers
-	MultipleOf       float64 `json:"multipleOf,omitempty"`
-	Maximum          float64 `json:"maximum,omitempty"`
-	ExclusiveMaximum bool    `json:"exclusiveMaximum,omitempty"`
-	Minimum          float64 `json:"minimum,omitempty"`
-	ExclusiveMinimum bool    `json:"exclusiveMinimum,omitempty"`
+	MultipleOf       float64  `json:"multipleOf,omitempty"`
+	Maximum          *float64 `json:"maximum,omitempty"`
+	ExclusiveMaximum bool     `json:"exclusiveMaximum,omitempty"`
+	Minimum          *float64 `json:"minimum,omitempty"`
+	ExclusiveMinimum bool     `json:"exclusiveMinimum,omitempty"`
 
 	// Strings
-	MinLength int    `json:"minLength,omitempty"`
-	MaxLength int    `json:"maxLength,omitempty"`
+	MinLength *int   `json:"minLength,omitempty"`
+	MaxLength *int   `json:"maxLength,omitempty"`
 	Pattern   string `json:"pattern,omitempty"`
 
 	// Objects
@@ -146,5 +148,52 @@ func NewSchemaJSON(b []byte) (*SchemaJSON, error) {
 	if schemajson.ID == "" {
 		return nil, errors.New("missing $id")
 	}
+	if err := schemajson.checkPatterns(); err != nil {
+		return nil, err
+	}
 	return schemajson, nil
 }
+
+// checkPatterns fails if a pattern of sj or of its subschemas is not RE2
+// syntax. JSON Schema patterns are ECMA 262 regular expressions, but schemas
+// are compiled and property values validated with Go regular expressions,
+// which lack e.g. lookarounds and backreferences.
+func (sj *SchemaJSON) checkPatterns() error {
+	if sj == nil {
+		return nil
+	}
+	if sj.Pattern != "" {
+		if _, err := regexp.Compile(sj.Pattern); err != nil {
+			return fmt.Errorf("unsupported pattern %q: patterns must be RE2 syntax, see https://github.com/google/re2/wiki/Syntax: %v", sj.Pattern, err)
+		}
+	}
+	for pattern, p := range sj.PatternProperties {
+		if _, err := regexp.Compile(pattern); err != nil {
+			return fmt.Errorf("unsupported patternProperties pattern %q: patterns must be RE2 syntax, see https://github.com/google/re2/wiki/Syntax: %v", pattern, err)
+		}
+		if err := p.checkPatterns(); err != nil {
+			return err
+		}
+	}
+	for name, p := range sj.Properties {
+		if err := p.checkPatterns(); err != nil {
+			return fmt.Errorf("property %s: %v", name, err)
+		}
+	}
+	for name, d := range sj.Definitions {
+		if err := d.checkPatterns(); err != nil {
+			return fmt.Errorf("definition %s: %v", name, err)
+		}
+	}
+	for _, subschemas := range [][]SchemaJSON{sj.AllOf, sj.AnyOf, sj.OneOf} {
+		for i := range subschemas {
+			if err := subschemas[i].checkPatterns(); err != nil {
+				return err
+			}
+		}
+	}
+	if err := sj.Items.checkPatterns(); err != nil {
+		return err
+	}
+	return sj.Not.checkPatterns()
+}
@@ -15,13 +15,14 @@
 package schemaparser
 
 import (
+	"strings"
 	"testing"
 )
 
 const testSchemaFile = "testdata/ext.json"
 
 func TestLoadSchemaJSON(t *testing.T) {
-	sj, err := schemaJSON(testSchemaFile)
+	sj, _, err := schemaJSON(testSchemaFile)
 	if err != nil {
 		t.Fatal("Failed to load schema into SchemaJSON struct.")
 	}
@@ -57,3 +58,25 @@ func TestLoadSchemaJSON(t *testing.T) {
 		}
 	}
 }
+
+func TestNewSchemaJSONPatterns(t *testing.T) {
+	tests := []struct {
+		schema  string
+		wantErr bool
+	}{
+		{`{"$id": "s", "properties": {"tag": {"type": "string", "pattern": "^v[0-9]+$"}}}`, false},
+		{`{"$id": "s", "properties": {"tag": {"type": "string", "pattern": "^(?!latest$)"}}}`, true},
+		{`{"$id": "s", "allOf": [{"properties": {"tag": {"pattern": "(a)\\1"}}}]}`, true},
+		{`{"$id": "s", "definitions": {"tags": {"items": {"pattern": "\\u00e9"}}}}`, true},
+		{`{"$id": "s", "patternProperties": {"^(?=x)": {"type": "string"}}}`, true},
+	}
+	for _, test := range tests {
+		_, err := NewSchemaJSON([]byte(test.schema))
+		if gotErr := err != nil; gotErr != test.wantErr {
+			t.Errorf("NewSchemaJSON(%s) = %v; want error: %t", test.schema, err, test.wantErr)
+		}
+		if err != nil && !strings.Contains(err.Error(), "RE2") {
+			t.Errorf("NewSchemaJSON(%s) = %v; want an error mentioning RE2", test.schema, err)
+		}
+	}
+}
@@ -19,6 +19,7 @@ import (
 	"fmt"
 	"io/ioutil"
 	"path/filepath"
+	"strings"
 
 	"github.com/bmatcuk/doublestar"
 	"github.com/xeipuuv/gojsonschema"
@@ -30,7 +31,7 @@ const (
 	versionPropertyName   = "apiversion"
 	idPropertyName        = "id"
 	namePropertyName      = "name"
-	uriPropertyName      = "uri"
+	uriPropertyName       = "uri"
 )
 
 // SimpleProperties are properties of type integer, double and string. The map is from property name to its type.
@@ -42,6 +43,8 @@ type Schema struct {
 	JSON *SchemaJSON
 	// Validator is a compiled schema for validating JSON data.
 	Validator *gojsonschema.Schema
+	// Source is the schema as it was read.
+	Source []byte
 }
 
 // SchemaSet maps schema $id to its Schema. SchemaSet is inclusive, i.e. all references of schemas in this schema set must also be included in the set.
@@ -62,19 +65,28 @@ func NewSchemaSetFromADir(dir string) (*SchemaSet, error) {
 // NewSchemaSetFromFiles create a SchemaSet from an arry of files. These files can refer each other in their definitions.
 func NewSchemaSetFromFiles(files []string) (*SchemaSet, error) {
 	result := make(map[string]*Schema)
-	sl, err := loadSchemas(files)
-	if err != nil {
-		return nil, err
-	}
-	for _, file := range files {
+	// The schemas are parsed before they are loaded, so that unsupported
+	// patterns are reported as such rather than as failures to load.
+	paths := make([]string, len(files))
+	schemajsons := make([]*SchemaJSON, len(files))
+	sources := make([][]byte, len(files))
+	for i, file := range files {
 		path, err := filepath.Abs(file)
 		if err != nil {
 			return nil, fmt.Errorf("failed to get absolute file path: %s", err)
 		}
-		schemajson, err := schemaJSON(path)
+		schemajson, b, err := schemaJSON(path)
 		if err != nil {
 			return nil, err
 		}
+		paths[i], schemajsons[i], sources[i] = path, schemajson, b
+	}
+	sl, err := loadSchemas(files)
+	if err != nil {
+		return nil, err
+	}
+	for i, file := range files {
+		path, schemajson, b := paths[i], schemajsons[i], sources[i]
 		validator, err := sl.Compile(gojsonschema.NewReferenceLoader("file://" + path))
 		if err != nil {
 			return nil, fmt.Errorf("failed to compile schema in %s: %s", path, err)
@@ -85,6 +97,7 @@ func NewSchemaSetFromFiles(files []string) (*SchemaSet, error) {
 		result[schemajson.ID] = &Schema{
 			JSON:      schemajson,
 			Validator: validator,
+			Source:    b,
 		}
 	}
 	return &SchemaSet{
@@ -102,18 +115,43 @@ func (ss *SchemaSet) AddSchema(b []byte) (string, error) {
 	if _, exists := ss.Schemas[sj.ID]; exists {
 		return sj.ID, nil
 	}
-	sl := gojsonschema.NewBytesLoader(b)
-	validator, err := ss.loader.Compile(sl)
+	// Adding the schema to the loader by $id lets later schemas refer to it.
+	if err := ss.loader.AddSchemas(gojsonschema.NewBytesLoader(b)); err != nil {
+		return "", fmt.Errorf("failed to add schema: %s", err)
+	}
+	validator, err := ss.loader.Compile(gojsonschema.NewReferenceLoader(sj.ID))
 	if err != nil {
+		if rerr := ss.removeSchema(sj.ID); rerr != nil {
+			return "", fmt.Errorf("failed to compile schema: %s; failed to remove it: %s", err, rerr)
+		}
 		return "", fmt.Errorf("failed to compile schema: %s", err)
 	}
 	ss.Schemas[sj.ID] = &Schema{
 		JSON:      sj,
 		Validator: validator,
+		Source:    b,
 	}
 	return sj.ID, nil
 }
 
+// removeSchema removes the schema of id from the set, so that a schema with
+// the same id can be added again. Schemas referring to it keep their compiled
+// validators.
+func (ss *SchemaSet) removeSchema(id string) error {
+	delete(ss.Schemas, id)
+	// Schemas cannot be removed from a loader, so a new one is filled with
+	// the remaining schemas.
+	sl := gojsonschema.NewSchemaLoader()
+	sl.Validate = true
+	for _, schema := range ss.Schemas {
+		if err := sl.AddSchemas(gojsonschema.NewBytesLoader(schema.Source)); err != nil {
+			return err
+		}
+	}
+	ss.loader = sl
+	return nil
+}
+
 // TypeName extract the {namespace}/{version} as type namepace and {kind} as type name,
 // where {namespace}, {kind}, and {version} are from constant string properties defined in JSON schema.
 func (ss *SchemaSet) TypeName(id string) (namespace string, name string, err error) {
@@ -208,6 +246,78 @@ func addSimpleProperties(properties map[string]*SchemaJSON, output SimplePropert
 	}
 }
 
+// FlattenedPropertySeparator separates the segments of the names of flattened
+// properties, e.g. training_framework.name.
+const FlattenedPropertySeparator = "."
+
+// FlattenedProperties returns the properties of the schema with given id that
+// can be stored as typed properties, keyed by flattened name. Properties of
+// objects with declared properties are flattened into dotted names, e.g.
+// training_framework.name. Booleans, arrays and free-form objects are kept
+// with their JSON type, so that callers can map them to a storable type. It
+// fails if two properties collide, i.e. if a property name contains the
+// separator or if a property is both a scalar and an object across AllOf.
+func (ss *SchemaSet) FlattenedProperties(id string) (SimpleProperties, error) {
+	schema, exists := ss.Schemas[id]
+	if !exists {
+		return nil, fmt.Errorf("failed to find schema with $id %s", id)
+	}
+	result := make(SimpleProperties)
+	for _, parent := range schema.JSON.AllOf {
+		if parent.Ref != nil {
+			props, err := ss.FlattenedProperties((string)(*parent.Ref))
+			if err != nil {
+				return nil, fmt.Errorf("failed to resolve %s in AllOf fields in %s: %s", *parent.Ref, id, err)
+			}
+			for k, v := range props {
+				if err := addFlattenedProperty(result, k, v); err != nil {
+					return nil, fmt.Errorf("collision in %s: %s", id, err)
+				}
+			}
+		}
+		if err := addFlattenedProperties(parent.Properties, "", result); err != nil {
+			return nil, fmt.Errorf("collision in %s: %s", id, err)
+		}
+	}
+	if err := addFlattenedProperties(schema.JSON.Properties, "", result); err != nil {
+		return nil, fmt.Errorf("collision in %s: %s", id, err)
+	}
+	return result, nil
+}
+
+func addFlattenedProperties(properties map[string]*SchemaJSON, prefix string, output SimpleProperties) error {
+	for p, schema := range properties {
+		if strings.Contains(p, FlattenedPropertySeparator) {
+			return fmt.Errorf("property %q contains the separator %q of flattened property names", prefix+p, FlattenedPropertySeparator)
+		}
+		name := prefix + p
+		switch {
+		case len(schema.Properties) > 0:
+			if err := addFlattenedProperties(schema.Properties, name+FlattenedPropertySeparator, output); err != nil {
+				return err
+			}
+		case schema.IsSimpleType(), schema.Type != nil && (schema.GetType() == BooleanType || schema.GetType() == ArrayType || schema.GetType() == ObjectType):
+			if err := addFlattenedProperty(output, name, schema.GetType()); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// addFlattenedProperty adds the property name to output. A property redefined
+// with another type replaces the earlier definition, but a property cannot be
+// both a scalar and an object with nested properties.
+func addFlattenedProperty(output SimpleProperties, name, t string) error {
+	for existing := range output {
+		if strings.HasPrefix(existing, name+FlattenedPropertySeparator) || strings.HasPrefix(name, existing+FlattenedPropertySeparator) {
+			return fmt.Errorf("property %q collides with property %q", name, existing)
+		}
+	}
+	output[name] = t
+	return nil
+}
+
 func loadSchemas(files []string) (*gojsonschema.SchemaLoader, error) {
 	sl := gojsonschema.NewSchemaLoader()
 	sl.Validate = true
@@ -223,18 +333,21 @@ func loadSchemas(files []string) (*gojsonschema.SchemaLoader, error) {
 	return sl, nil
 }
 
-func schemaJSON(file string) (*SchemaJSON, error) {
+func schemaJSON(file string) (*SchemaJSON, []byte, error) {
 	schemajson := &SchemaJSON{}
 	b, err := ioutil.ReadFile(file)
 	if err != nil {
-		return nil, fmt.Errorf("failed to read file %s: %s", file, err)
+		return nil, nil, fmt.Errorf("failed to read file %s: %s", file, err)
 	}
 	err = json.Unmarshal(b, schemajson)
 	if err != nil {
-		return nil, fmt.Errorf("failed to unmarshal schema in %s: %s", file, err)
+		return nil, nil, fmt.Errorf("failed to unmarshal schema in %s: %s", file, err)
 	}
 	if schemajson.ID == "" {
-		return nil, fmt.Errorf("missing $id in file %s", file)
+		return nil, nil, fmt.Errorf("missing $id in file %s", file)
+	}
+	if err := schemajson.checkPatterns(); err != nil {
+		return nil, nil, fmt.Errorf("invalid schema in %s: %s", file, err)
 	}
-	return schemajson, nil
+	return schemajson, b, nil
 }
@@ -119,3 +119,83 @@ func TestAddSchema(t *testing.T) {
 		t.Fatalf("Failed to add schema: got id %s, err %v", id, err)
 	}
 }
+
+func TestFlattenedProperties(t *testing.T) {
+	ss, err := NewSchemaSetFromFiles([]string{"testdata/base.json", "testdata/ext.json"})
+	if err != nil {
+		t.Fatalf("failed to parse schemas from files: %s", err)
+	}
+	id, err := ss.AddSchema([]byte(`{
+		"$id": "http://github.com/kubeflow/metadata/schemaparser/testdata/nested.json",
+		"allOf": [{"$ref": "http://github.com/kubeflow/metadata/schemaparser/testdata/ext.json"}],
+		"properties": {
+			"nested": {
+				"type": "object",
+				"properties": {
+					"string-field": {"type": "string"},
+					"deeper": {"properties": {"boolean-field": {"type": "boolean"}}}
+				}
+			},
+			"untyped-field": {"description": "a field without type"}
+		}
+	}`))
+	if err != nil {
+		t.Fatalf("Failed to add schema: %v", err)
+	}
+	fp, err := ss.FlattenedProperties(id)
+	if err != nil {
+		t.Fatalf("failed to get flattened properties: %s", err)
+	}
+	expectedMap := map[string]string{
+		"id":                          "string",
+		"apiversion":                  "string",
+		"category":                    "string",
+		"kind":                        "string",
+		"name":                        "string",
+		"namespace":                   "string",
+		"annotations":                 "object",
+		"string-field":                "string",
+		"integer-field":               "integer",
+		"number-field":                "number",
+		"array-field":                 "array",
+		"boolean-field":               "boolean",
+		"object-field":                "object",
+		"nested.string-field":         "string",
+		"nested.deeper.boolean-field": "boolean",
+	}
+	if diff := cmp.Diff(expectedMap, map[string]string(fp)); diff != "" {
+		t.Fatalf("Unexpected flattened properties. Diff (-want, +got):\n%s", diff)
+	}
+}
+
+func TestFlattenedPropertiesCollisions(t *testing.T) {
+	schemas := []string{
+		`{
+			"$id": "http://github.com/kubeflow/metadata/schemaparser/testdata/dotted.json",
+			"properties": {
+				"nested": {"properties": {"a": {"type": "string"}}},
+				"nested.a": {"type": "string"}
+			}
+		}`,
+		`{
+			"$id": "http://github.com/kubeflow/metadata/schemaparser/testdata/scalar-and-object.json",
+			"allOf": [
+				{"properties": {"field": {"type": "string"}}},
+				{"properties": {"field": {"properties": {"a": {"type": "string"}}}}}
+			]
+		}`,
+	}
+	for _, schema := range schemas {
+		ss, err := NewSchemaSetFromFiles(nil)
+		if err != nil {
+			t.Fatalf("failed to create schema set: %s", err)
+		}
+		id, err := ss.AddSchema([]byte(schema))
+		if err != nil {
+			t.Fatalf("Failed to add schema: %v", err)
+		}
+		if fp, err := ss.FlattenedProperties(id); err == nil {
+			t.Errorf("FlattenedProperties(%s) = %v, nil\nWant non-nil error", id, fp)
+		}
+	}
+}
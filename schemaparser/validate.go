@@ -0,0 +1,116 @@
+// Copyright 2019 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package schemaparser
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	mlpb "ml_metadata/proto/metadata_store_go_proto"
+
+	"github.com/xeipuuv/gojsonschema"
+)
+
+// ArtifactValidator validates artifacts against the schemas their types were
+// registered from. It implements service.ArtifactValidator.
+type ArtifactValidator struct {
+	ss *SchemaSet
+	// schemaIDs maps type names to the $id of their schema.
+	schemaIDs map[string]string
+}
+
+// NewArtifactValidator returns an ArtifactValidator for the artifact types
+// defined by the schemas in ss.
+func NewArtifactValidator(ss *SchemaSet) *ArtifactValidator {
+	v := &ArtifactValidator{ss: ss, schemaIDs: make(map[string]string)}
+	for id := range ss.Schemas {
+		v.addSchema(id)
+	}
+	return v
+}
+
+// addSchema makes v validate the artifacts of the type defined by the schema
+// of id in v's SchemaSet, if it defines an artifact type. Workspaces of
+// container types are validated as artifacts as well.
+func (v *ArtifactValidator) addSchema(id string) {
+	namespace, typename, err := v.ss.TypeName(id)
+	if err != nil {
+		return
+	}
+	if category, err := v.ss.ConstantStringType(id, categoryPropertyName); err != nil || category != artifactCategory && category != containerCategory {
+		return
+	}
+	v.schemaIDs[namespace+"/"+typename] = id
+}
+
+// ValidateArtifact returns the violations of the schema of the type named
+// typeName by artifact. The artifact is validated as the JSON object stored in
+// its __ALL_META__ property, completed with its other top-level properties and
+// its uri. Fields implied by the type, e.g. kind, are filled in when missing.
+func (v *ArtifactValidator) ValidateArtifact(typeName string, artifact *mlpb.Artifact) ([]string, error) {
+	id, ok := v.schemaIDs[typeName]
+	if !ok {
+		return nil, nil
+	}
+
+	doc := make(map[string]interface{})
+	if meta, ok := artifact.GetProperties()[wholeMetaPropertyName]; ok {
+		if err := json.Unmarshal([]byte(meta.GetStringValue()), &doc); err != nil {
+			return []string{fmt.Sprintf("property %s is not a JSON object: %v", wholeMetaPropertyName, err)}, nil
+		}
+	}
+	for name, value := range artifact.GetProperties() {
+		if _, ok := doc[name]; ok || name == wholeMetaPropertyName || strings.Contains(name, FlattenedPropertySeparator) {
+			continue
+		}
+		switch value.GetValue().(type) {
+		case *mlpb.Value_IntValue:
+			doc[name] = value.GetIntValue()
+		case *mlpb.Value_DoubleValue:
+			doc[name] = value.GetDoubleValue()
+		case *mlpb.Value_StringValue:
+			doc[name] = value.GetStringValue()
+		}
+	}
+	if _, ok := doc[uriPropertyName]; !ok && artifact.Uri != nil {
+		doc[uriPropertyName] = artifact.GetUri()
+	}
+	for _, p := range []string{categoryPropertyName, namespacePropertyName, kindPropertyName, versionPropertyName} {
+		if _, ok := doc[p]; ok {
+			continue
+		}
+		if c, err := v.ss.ConstantStringType(id, p); err == nil {
+			doc[p] = c
+		}
+	}
+	if _, ok := doc[idPropertyName]; !ok {
+		// Ids are only assigned once the artifact is stored, after validation.
+		doc[idPropertyName] = strconv.FormatInt(artifact.GetId(), 10)
+	}
+
+	result, err := v.ss.Schemas[id].Validator.Validate(gojsonschema.NewGoLoader(doc))
+	if err != nil {
+		return nil, err
+	}
+	var violations []string
+	for _, e := range result.Errors() {
+		violations = append(violations, e.String())
+	}
+	sort.Strings(violations)
+	return violations, nil
+}
@@ -0,0 +1,125 @@
+// Copyright 2019 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package schemaparser
+
+import (
+	"context"
+	"testing"
+
+	mlpb "ml_metadata/proto/metadata_store_go_proto"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/kubeflow/metadata/api"
+	"github.com/kubeflow/metadata/service"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestValidateArtifacts(t *testing.T) {
+	tests := []struct {
+		name     string
+		artifact string
+		valid    bool
+		// malformed artifacts are rejected in every validation mode.
+		malformed bool
+	}{
+		{
+			name: "valid model",
+			artifact: `
+				uri: "gs://bucket/mnist"
+				properties { key: "name" value { string_value: "mnist" }}
+				properties { key: "__ALL_META__" value { string_value: "{\"hyperparameters\": {\"layers\": [10, 3, 1]}}" }}`,
+			valid: true,
+		},
+		{
+			name: "valid model without __ALL_META__",
+			artifact: `
+				uri: "gs://bucket/mnist"
+				properties { key: "name" value { string_value: "mnist" }}`,
+			valid: true,
+		},
+		{
+			name: "missing name",
+			artifact: `
+				uri: "gs://bucket/mnist"`,
+		},
+		{
+			name: "missing uri",
+			artifact: `
+				properties { key: "name" value { string_value: "mnist" }}`,
+		},
+		{
+			name: "malformed __ALL_META__",
+			artifact: `
+				uri: "gs://bucket/mnist"
+				properties { key: "name" value { string_value: "mnist" }}
+				properties { key: "__ALL_META__" value { string_value: "{" }}`,
+			malformed: true,
+		},
+		{
+			name: "wrong type in __ALL_META__",
+			artifact: `
+				uri: "gs://bucket/mnist"
+				properties { key: "name" value { string_value: "mnist" }}
+				properties { key: "__ALL_META__" value { string_value: "{\"hyperparameters\": 1}" }}`,
+		},
+		{
+			name: "wrong kind",
+			artifact: `
+				uri: "gs://bucket/mnist"
+				properties { key: "name" value { string_value: "mnist" }}
+				properties { key: "__ALL_META__" value { string_value: "{\"kind\": 1}" }}`,
+		},
+	}
+
+	for _, mode := range []service.ValidationMode{service.LenientValidation, service.StrictValidation} {
+		svc := service.New(testMLMDStore(t))
+		svc.SetValidationMode(mode)
+		if _, err := RegisterSchemas(svc, schemaDir); err != nil {
+			t.Fatalf("failed to register schemas: %v", err)
+		}
+
+		for _, test := range tests {
+			artifact := &mlpb.Artifact{}
+			if err := proto.UnmarshalText(test.artifact, artifact); err != nil {
+				t.Fatalf("Test %q: proto.UnmarshalText failure: %v", test.name, err)
+			}
+			_, err := svc.CreateArtifact(context.Background(), &api.CreateArtifactRequest{
+				Parent:   "artifact_types/kubeflow.org/alpha/model",
+				Artifact: artifact,
+			})
+			if !test.malformed && (test.valid || mode == service.LenientValidation) {
+				if err != nil {
+					t.Errorf("Test %q in mode %v: CreateArtifact failed: %v", test.name, mode, err)
+				}
+				continue
+			}
+			if status.Code(err) != codes.InvalidArgument {
+				t.Errorf("Test %q in mode %v: CreateArtifact = %v\nWant InvalidArgument error", test.name, mode, err)
+			}
+		}
+	}
+}
+
+func TestValidateArtifactOfUnknownType(t *testing.T) {
+	ss, err := NewSchemaSetFromADir(schemaDir)
+	if err != nil {
+		t.Fatalf("NewSchemaSetFromADir failed: %v", err)
+	}
+	violations, err := NewArtifactValidator(ss).ValidateArtifact("kubeflow.org/alpha/unknown", &mlpb.Artifact{})
+	if len(violations) != 0 || err != nil {
+		t.Errorf("ValidateArtifact of an unknown type = %v, %v\nWant no violations", violations, err)
+	}
+}
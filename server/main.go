@@ -23,18 +23,21 @@ import (
 	"math/rand"
 	"net"
 	"net/http"
+	"strings"
 	"time"
 
-	"ml_metadata/metadata_store/mlmetadata"
-	mlpb "ml_metadata/proto/metadata_store_go_proto"
-
+	"github.com/go-sql-driver/mysql"
 	"github.com/golang/glog"
-	"github.com/golang/protobuf/proto"
 	"github.com/grpc-ecosystem/grpc-gateway/runtime"
 	pb "github.com/kubeflow/metadata/api"
+	"github.com/kubeflow/metadata/auth"
+	"github.com/kubeflow/metadata/metrics"
+	"github.com/kubeflow/metadata/mlmd/sqlstore"
 	"github.com/kubeflow/metadata/schemaparser"
 	"github.com/kubeflow/metadata/service"
+	"github.com/kubeflow/metadata/tlsconfig"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
 	"google.golang.org/grpc/reflection"
 )
 
@@ -42,54 +45,99 @@ var (
 	rpcPort       = flag.Int("rpc_port", 9090, "RPC serving port.")
 	httpPort      = flag.Int("http_port", 8080, "HTTP serving port.")
 	schemaRootDir = flag.String("schema_root_dir", "schema/alpha", "Root directory for the predefined schemas.")
+	schemaReload  = flag.Duration("schema_reload_interval", 10*time.Second, "How often to poll the schema files in schema_root_dir for changes, comparing their sizes and modification times; changed schemas are reloaded without restarting. 0 disables reloading.")
+	validation    = flag.String("schema_validation", "lenient", "How to handle artifacts that do not match the schema of their type. Supported options: lenient (log and accept), strict (reject)")
+
+	tlsCertFile   = flag.String("tls_cert_file", "", "PEM encoded certificate chain of the server. Enables TLS on rpc_port and http_port with tls_key_file.")
+	tlsKeyFile    = flag.String("tls_key_file", "", "PEM encoded private key of tls_cert_file.")
+	tlsClientCA   = flag.String("tls_client_ca_file", "", "PEM encoded certificates of the authorities issuing client certificates, required unless tls_client_auth is none.")
+	tlsClientAuth = flag.String("tls_client_auth", "none", "How to verify client certificates. Supported options: none, optional (verify if given), require. With require, tls_cert_file must also be valid as a client certificate, which the HTTP gateway presents to rpc_port.")
+	tlsReload     = flag.Duration("tls_reload_interval", 10*time.Second, "How often to check the TLS files for changes, which are reloaded without restarting. 0 disables reloading.")
+
+	authTokenFile        = flag.String("auth_token_file", "", "CSV file of static bearer tokens, with lines token,user,uid[,\"group1,group2\"].")
+	authJWKSFile         = flag.String("auth_jwks_file", "", "JSON Web Key Set verifying the signatures of JWT bearer tokens.")
+	authJWTIssuer        = flag.String("auth_jwt_issuer", "", "Required iss claim of JWT bearer tokens, if set.")
+	authJWTAudience      = flag.String("auth_jwt_audience", "", "Required aud claim of JWT bearer tokens, if set.")
+	authJWTUserClaim     = flag.String("auth_jwt_user_claim", "sub", "Claim of JWT bearer tokens holding the user name.")
+	authJWTGroupsClaim   = flag.String("auth_jwt_groups_claim", "", "Claim of JWT bearer tokens holding the groups of the user, if any.")
+	authProxyNames       = flag.String("auth_proxy_names", "", "Comma-separated common names of the client certificates of the authenticating proxies trusted to set auth_proxy_user_header. Requires tls_client_auth optional or require. The HTTP gateway connects to rpc_port with tls_cert_file, so its common name must be included for the gateway to forward the headers of trusted proxies.")
+	authProxyUserHeader  = flag.String("auth_proxy_user_header", "X-Remote-User", "Header of the user authenticated by a trusted proxy.")
+	authProxyGroupHeader = flag.String("auth_proxy_group_header", "X-Remote-Group", "Header of the comma-separated groups of the user authenticated by a trusted proxy.")
+	authPolicyFile       = flag.String("auth_policy_file", "", "JSON file of the bindings granting principals read, write or admin access to workspaces and type namespaces. Requires an authentication method. Without a policy, authenticated callers may access everything.")
 
-	mlmdDBType           = flag.String("mlmd_db_type", "mysql", "Database type to use when creating MLMD instance. Supported options: in-memory, mysql, sqlite")
-	mlmdDBName           = flag.String("mlmd_db_name", "mlmetadata", "Database name to use when creating MLMD instance.")
-	mySQLServiceHost     = flag.String("mysql_service_host", "localhost", "MySQL Service Hostname.")
-	mySQLServicePort     = flag.Uint("mysql_service_port", 3306, "MySQL Service Port.")
-	mySQLServiceUser     = flag.String("mysql_service_user", "root", "MySQL Service Username.")
-	mySQLServicePassword = flag.String("mysql_service_password", "", "MySQL Service Password.")
-	retryNum             = flag.Int("retries_on_transaction_failure", 10, "Number of retries for exponential backoff.")
-	sqliteFilenameUri    = flag.String("sqlite_filename_uri", "mlmetadata", "Sqlite Filename URI")
-	sqliteConnMode       = flag.Int("sqlite_conn_mode", 3, "Sqlite Connection Mode. Supported options: 0(UNKNOWN), 1(READONLY), 2(READWRITE), 3(READWRITE_OPENCREATE)")
+	metricsAddr          = flag.String("metrics_addr", "", "Address of a separate plain HTTP listener serving /metrics, e.g. :9100. Empty serves /metrics on http_port. /metrics is not authenticated.")
+	metricsStatsTimeout  = flag.Duration("metrics_stats_timeout", 10*time.Second, "How long counting the records of the store for the gauges of /metrics may take.")
+	metricsStatsInterval = flag.Duration("metrics_stats_interval", time.Minute, "How long the record counts of /metrics are reused across scrapes before counting the records of the store again.")
+
+	mlmdDBType              = flag.String("mlmd_db_type", "mysql", "Database type to use when creating MLMD instance. Supported options: in-memory, mysql, sqlite (MLMD library, requires cgo), go-mysql, go-sqlite, postgres (pure Go)")
+	mlmdDBName              = flag.String("mlmd_db_name", "mlmetadata", "Database name to use when creating MLMD instance.")
+	mySQLServiceHost        = flag.String("mysql_service_host", "localhost", "MySQL Service Hostname.")
+	mySQLServicePort        = flag.Uint("mysql_service_port", 3306, "MySQL Service Port.")
+	mySQLServiceUser        = flag.String("mysql_service_user", "root", "MySQL Service Username.")
+	mySQLServicePassword    = flag.String("mysql_service_password", "", "MySQL Service Password.")
+	postgresServiceHost     = flag.String("postgres_service_host", "localhost", "PostgreSQL Service Hostname.")
+	postgresServicePort     = flag.Uint("postgres_service_port", 5432, "PostgreSQL Service Port.")
+	postgresServiceUser     = flag.String("postgres_service_user", "postgres", "PostgreSQL Service Username.")
+	postgresServicePassword = flag.String("postgres_service_password", "", "PostgreSQL Service Password.")
+	postgresSSLMode         = flag.String("postgres_sslmode", "disable", "PostgreSQL SSL mode. Supported options: disable, require, verify-ca, verify-full")
+	dbMaxOpenConns          = flag.Int("db_max_open_conns", 0, "Maximum number of open connections to the database of go-mysql and postgres. 0 means unlimited.")
+	dbMaxIdleConns          = flag.Int("db_max_idle_conns", 2, "Maximum number of idle connections to the database of go-mysql and postgres.")
+	dbConnMaxLifetime       = flag.Duration("db_conn_max_lifetime", 0, "How long connections to the database of go-mysql and postgres are reused. 0 means forever.")
+	retryNum                = flag.Int("retries_on_transaction_failure", 10, "Number of retries for exponential backoff.")
+	sqliteFilenameUri       = flag.String("sqlite_filename_uri", "mlmetadata", "Sqlite Filename URI. For go-sqlite, the database file, or :memory: for an in-memory database.")
+	sqliteConnMode          = flag.Int("sqlite_conn_mode", 3, "Sqlite Connection Mode. Supported options: 0(UNKNOWN), 1(READONLY), 2(READWRITE), 3(READWRITE_OPENCREATE)")
 )
 
-func mlmdStoreOrDie() *mlmetadata.Store {
-	var cfg *mlpb.ConnectionConfig
+// metadataStoreOrDie connects to the metadata store of mlmd_db_type, retrying
+// with exponential backoff.
+func metadataStoreOrDie() service.MetadataStore {
+	var newStore func() (service.MetadataStore, error)
 	switch *mlmdDBType {
-	case "in-memory":
-		cfg = &mlpb.ConnectionConfig{
-			Config: &mlpb.ConnectionConfig_FakeDatabase{
-				&mlpb.FakeDatabaseConfig{},
-			},
+	case "in-memory", "mysql", "sqlite":
+		newStore = newMLMDStore
+	case "go-sqlite":
+		newStore = func() (service.MetadataStore, error) {
+			return sqlstore.NewSQLiteStore(*sqliteFilenameUri)
+		}
+	case "go-mysql":
+		cfg := mysql.NewConfig()
+		cfg.Net = "tcp"
+		cfg.Addr = fmt.Sprintf("%s:%d", *mySQLServiceHost, *mySQLServicePort)
+		cfg.User = *mySQLServiceUser
+		cfg.Passwd = *mySQLServicePassword
+		cfg.DBName = *mlmdDBName
+		newStore = func() (service.MetadataStore, error) {
+			store, err := sqlstore.NewMySQLStore(cfg)
+			if err != nil {
+				return nil, err
+			}
+			store.SetConnectionPool(*dbMaxOpenConns, *dbMaxIdleConns, *dbConnMaxLifetime)
+			return store, nil
 		}
-	case "mysql":
-		cfg = &mlpb.ConnectionConfig{
-			Config: &mlpb.ConnectionConfig_Mysql{
-				&mlpb.MySQLDatabaseConfig{
-					Host:     mySQLServiceHost,
-					Port:     proto.Uint32(uint32(*mySQLServicePort)),
-					Database: mlmdDBName,
-					User:     mySQLServiceUser,
-					Password: mySQLServicePassword,
-				},
-			},
+	case "postgres":
+		cfg := &sqlstore.PostgresConfig{
+			Host:     *postgresServiceHost,
+			Port:     *postgresServicePort,
+			User:     *postgresServiceUser,
+			Password: *postgresServicePassword,
+			DBName:   *mlmdDBName,
+			SSLMode:  *postgresSSLMode,
 		}
-	case "sqlite":
-		cfg = &mlpb.ConnectionConfig{
-			Config: &mlpb.ConnectionConfig_Sqlite{
-				&mlpb.SqliteMetadataSourceConfig{
-					FilenameUri:    sqliteFilenameUri,
-					ConnectionMode: mlpb.SqliteMetadataSourceConfig_ConnectionMode.Enum(mlpb.SqliteMetadataSourceConfig_ConnectionMode(*sqliteConnMode)),
-				},
-			},
+		newStore = func() (service.MetadataStore, error) {
+			store, err := sqlstore.NewPostgresStore(cfg)
+			if err != nil {
+				return nil, err
+			}
+			store.SetConnectionPool(*dbMaxOpenConns, *dbMaxIdleConns, *dbConnMaxLifetime)
+			return store, nil
 		}
 	default:
-		glog.Fatalf("Unknown mlmd_db_type %q: please choose from [in-memory, mysql, sqlite]", *mlmdDBType)
+		glog.Fatalf("Unknown mlmd_db_type %q: please choose from [in-memory, mysql, sqlite, go-mysql, go-sqlite, postgres]", *mlmdDBType)
 	}
+	var store service.MetadataStore
 	var err error
 	for r := 0; r < *retryNum; r++ {
-		store, err := mlmetadata.NewStore(cfg)
+		store, err = newStore()
 		if err == nil {
 			return store
 		}
@@ -98,27 +146,144 @@ func mlmdStoreOrDie() *mlmetadata.Store {
 		glog.Errorf("Failed to create ML Metadata Store: %v.\nRetry %d/%d.\nSleep %v", err, r+1, *retryNum, backoff)
 		time.Sleep(backoff)
 	}
-	glog.Fatalf("Failed to create ML Metadata Store with config %v: %v.\n", cfg, err)
+	glog.Fatalf("Failed to create ML Metadata Store of type %s: %v.\n", *mlmdDBType, err)
 	return nil
 }
 
+// tlsReloaderOrDie loads the certificates of the tls_ flags, returning nil if
+// TLS is disabled.
+func tlsReloaderOrDie() *tlsconfig.Reloader {
+	clientAuth, err := tlsconfig.ParseClientAuth(*tlsClientAuth)
+	if err != nil {
+		glog.Fatalf("Invalid tls_client_auth: %v", err)
+	}
+	if *tlsCertFile == "" && *tlsKeyFile == "" {
+		if clientAuth != tlsconfig.NoClientCert || *tlsClientCA != "" {
+			glog.Fatal("Verifying client certificates requires tls_cert_file and tls_key_file")
+		}
+		return nil
+	}
+	certs, err := tlsconfig.NewReloader(tlsconfig.Config{
+		CertFile:     *tlsCertFile,
+		KeyFile:      *tlsKeyFile,
+		ClientCAFile: *tlsClientCA,
+		ClientAuth:   clientAuth,
+	})
+	if err != nil {
+		glog.Fatalf("Failed to load TLS certificates: %v", err)
+	}
+	return certs
+}
+
+// authenticatorsOrDie returns the authenticators of the auth_ flags, and the
+// proxy header one, if any, separately for the HTTP gateway.
+func authenticatorsOrDie(certs *tlsconfig.Reloader) ([]auth.Authenticator, *auth.HeaderAuthenticator) {
+	var authenticators []auth.Authenticator
+	if *authTokenFile != "" {
+		a, err := auth.NewTokenAuthenticator(*authTokenFile)
+		if err != nil {
+			glog.Fatalf("Failed to load auth_token_file: %v", err)
+		}
+		authenticators = append(authenticators, a)
+	}
+	if *authJWKSFile != "" {
+		a, err := auth.NewJWTAuthenticator(auth.JWTConfig{
+			JWKSFile:    *authJWKSFile,
+			Issuer:      *authJWTIssuer,
+			Audience:    *authJWTAudience,
+			UserClaim:   *authJWTUserClaim,
+			GroupsClaim: *authJWTGroupsClaim,
+		})
+		if err != nil {
+			glog.Fatalf("Failed to load auth_jwks_file: %v", err)
+		}
+		authenticators = append(authenticators, a)
+	}
+	var headerAuth *auth.HeaderAuthenticator
+	if *authProxyNames != "" {
+		if certs == nil || *tlsClientAuth == "none" {
+			glog.Fatal("auth_proxy_names requires verifying client certificates with tls_client_auth")
+		}
+		var err error
+		headerAuth, err = auth.NewHeaderAuthenticator(*authProxyUserHeader, *authProxyGroupHeader, strings.Split(*authProxyNames, ","))
+		if err != nil {
+			glog.Fatalf("Invalid auth_proxy_ flags: %v", err)
+		}
+		authenticators = append(authenticators, headerAuth)
+	}
+	return authenticators, headerAuth
+}
+
+// chainUnaryInterceptors returns an interceptor calling interceptors in order,
+// the first one being the outermost.
+func chainUnaryInterceptors(interceptors ...grpc.UnaryServerInterceptor) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		for i := len(interceptors) - 1; i >= 0; i-- {
+			interceptor, next := interceptors[i], handler
+			handler = func(ctx context.Context, req interface{}) (interface{}, error) {
+				return interceptor(ctx, req, info, next)
+			}
+		}
+		return handler(ctx, req)
+	}
+}
+
 func main() {
 	flag.Parse()
 	ctx := context.Background()
 	ctx, cancel := context.WithCancel(ctx)
 	defer cancel()
 
-	service := service.New(mlmdStoreOrDie())
+	validationMode, err := service.ParseValidationMode(*validation)
+	if err != nil {
+		glog.Fatalf("Invalid schema_validation: %v", err)
+	}
+	svc := service.New(metrics.InstrumentStore(metadataStoreOrDie()))
+	svc.SetValidationMode(validationMode)
 
-	predefinedTypes, err := schemaparser.RegisterSchemas(service, *schemaRootDir)
+	// Schemas are loaded and reloaded as the server itself, whatever the
+	// policy.
+	systemCtx := auth.NewContext(ctx, auth.System)
+	registry, predefinedTypes, err := schemaparser.LoadSchemas(systemCtx, svc, *schemaRootDir)
 	if err != nil {
 		glog.Fatalf("Failed to load predefined types: %v\n", err)
 	}
 	glog.Infof("Loaded predefined types: %v\n", predefinedTypes)
+	if *schemaReload > 0 {
+		go registry.Watch(systemCtx, *schemaRootDir, *schemaReload)
+	}
+
+	certs := tlsReloaderOrDie()
+	if certs != nil && *tlsReload > 0 {
+		go certs.Watch(ctx, *tlsReload)
+	}
+
+	if err := metrics.RegisterStats(svc, *metricsStatsTimeout, *metricsStatsInterval); err != nil {
+		glog.Fatalf("Failed to register metrics: %v", err)
+	}
+	interceptors := []grpc.UnaryServerInterceptor{metrics.UnaryServerInterceptor, service.ErrorInterceptor}
+	authenticators, headerAuth := authenticatorsOrDie(certs)
+	if len(authenticators) > 0 {
+		interceptors = append(interceptors, auth.UnaryServerInterceptor(authenticators...))
+	}
+	if *authPolicyFile != "" {
+		if len(authenticators) == 0 {
+			glog.Fatal("auth_policy_file requires auth_token_file, auth_jwks_file or auth_proxy_names")
+		}
+		policy, err := auth.LoadPolicy(*authPolicyFile)
+		if err != nil {
+			glog.Fatalf("Failed to load auth_policy_file: %v", err)
+		}
+		svc.SetAuthorizer(policy)
+	}
 
 	rpcEndpoint := fmt.Sprintf(":%d", *rpcPort)
-	rpcServer := grpc.NewServer()
-	pb.RegisterMetadataServiceServer(rpcServer, service)
+	serverOpts := []grpc.ServerOption{grpc.UnaryInterceptor(chainUnaryInterceptors(interceptors...))}
+	if certs != nil {
+		serverOpts = append(serverOpts, grpc.Creds(credentials.NewTLS(certs.ServerConfig())))
+	}
+	rpcServer := grpc.NewServer(serverOpts...)
+	pb.RegisterMetadataServiceServer(rpcServer, svc)
 
 	go func() {
 		listen, err := net.Listen("tcp", rpcEndpoint)
@@ -131,16 +296,46 @@ func main() {
 		}
 	}()
 
-	mux := runtime.NewServeMux()
+	var muxOpts []runtime.ServeMuxOption
+	if headerAuth != nil {
+		muxOpts = append(muxOpts, runtime.WithIncomingHeaderMatcher(headerAuth.HeaderMatcher))
+	}
+	mux := runtime.NewServeMux(muxOpts...)
 
 	opts := []grpc.DialOption{grpc.WithInsecure()}
+	if certs != nil {
+		opts = []grpc.DialOption{grpc.WithTransportCredentials(credentials.NewTLS(certs.GatewayConfig()))}
+	}
 	if err := pb.RegisterMetadataServiceHandlerFromEndpoint(ctx, mux, rpcEndpoint, opts); err != nil {
 		glog.Fatal(err)
 	}
 
 	httpEndpoint := fmt.Sprintf(":%d", *httpPort)
 	glog.Infof("HTTP server listening on %s", httpEndpoint)
-	if err := http.ListenAndServe(httpEndpoint, mux); err != nil {
+	var gateway http.Handler = mux
+	if headerAuth != nil {
+		gateway = headerAuth.HTTPHandler(mux)
+	}
+	httpMux := http.NewServeMux()
+	if *metricsAddr == "" {
+		httpMux.Handle("/metrics", metrics.Handler())
+	} else {
+		metricsMux := http.NewServeMux()
+		metricsMux.Handle("/metrics", metrics.Handler())
+		glog.Infof("Metrics server listening on %s", *metricsAddr)
+		go func() {
+			glog.Fatal(http.ListenAndServe(*metricsAddr, metricsMux))
+		}()
+	}
+	httpMux.Handle("/", gateway)
+	httpServer := &http.Server{Addr: httpEndpoint, Handler: httpMux}
+	if certs != nil {
+		httpServer.TLSConfig = certs.ServerConfig()
+		err = httpServer.ListenAndServeTLS("", "")
+	} else {
+		err = httpServer.ListenAndServe()
+	}
+	if err != nil {
 		glog.Fatal(err)
 	}
 }
@@ -0,0 +1,61 @@
+// Copyright 2019 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build cgo
+// +build cgo
+
+package main
+
+import (
+	mlpb "ml_metadata/proto/metadata_store_go_proto"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/kubeflow/metadata/mlmd/mlmdstore"
+	"github.com/kubeflow/metadata/service"
+)
+
+// newMLMDStore creates a metadata store of mlmd_db_type with the MLMD library.
+func newMLMDStore() (service.MetadataStore, error) {
+	var cfg *mlpb.ConnectionConfig
+	switch *mlmdDBType {
+	case "in-memory":
+		cfg = &mlpb.ConnectionConfig{
+			Config: &mlpb.ConnectionConfig_FakeDatabase{
+				&mlpb.FakeDatabaseConfig{},
+			},
+		}
+	case "mysql":
+		cfg = &mlpb.ConnectionConfig{
+			Config: &mlpb.ConnectionConfig_Mysql{
+				&mlpb.MySQLDatabaseConfig{
+					Host:     mySQLServiceHost,
+					Port:     proto.Uint32(uint32(*mySQLServicePort)),
+					Database: mlmdDBName,
+					User:     mySQLServiceUser,
+					Password: mySQLServicePassword,
+				},
+			},
+		}
+	case "sqlite":
+		cfg = &mlpb.ConnectionConfig{
+			Config: &mlpb.ConnectionConfig_Sqlite{
+				&mlpb.SqliteMetadataSourceConfig{
+					FilenameUri:    sqliteFilenameUri,
+					ConnectionMode: mlpb.SqliteMetadataSourceConfig_ConnectionMode.Enum(mlpb.SqliteMetadataSourceConfig_ConnectionMode(*sqliteConnMode)),
+				},
+			},
+		}
+	}
+	return mlmdstore.NewStore(cfg)
+}
@@ -0,0 +1,29 @@
+// Copyright 2019 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build !cgo
+// +build !cgo
+
+package main
+
+import (
+	"github.com/golang/glog"
+	"github.com/kubeflow/metadata/service"
+)
+
+// newMLMDStore fails, as the MLMD library requires cgo.
+func newMLMDStore() (service.MetadataStore, error) {
+	glog.Fatalf("mlmd_db_type %q requires the MLMD library, which is not available without cgo: use go-mysql or go-sqlite", *mlmdDBType)
+	return nil, nil
+}
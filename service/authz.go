@@ -0,0 +1,312 @@
+// Copyright 2019 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package service
+
+import (
+	"context"
+	"strings"
+
+	mlpb "ml_metadata/proto/metadata_store_go_proto"
+
+	"github.com/kubeflow/metadata/mlmd"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// Access is the access to a workspace or a type namespace that a request
+// needs. Each access includes the lower ones.
+type Access int
+
+const (
+	// ReadAccess allows getting and listing records, types and schemas.
+	ReadAccess Access = iota + 1
+	// WriteAccess allows creating, updating and deleting artifacts,
+	// executions and events.
+	WriteAccess
+	// AdminAccess allows creating and deleting workspaces, and managing the
+	// types of namespaces.
+	AdminAccess
+)
+
+func (a Access) String() string {
+	switch a {
+	case ReadAccess:
+		return "read"
+	case WriteAccess:
+		return "write"
+	case AdminAccess:
+		return "admin"
+	}
+	return "unknown"
+}
+
+// Authorizer decides which workspaces and type namespaces the caller of a
+// request may access. Artifacts and executions need access both to their
+// workspace and to the namespace of their type.
+type Authorizer interface {
+	// AuthorizeWorkspace fails with codes.PermissionDenied, or
+	// codes.Unauthenticated, unless the caller of ctx has access to
+	// workspace.
+	AuthorizeWorkspace(ctx context.Context, access Access, workspace string) error
+	// AuthorizeNamespace fails likewise unless the caller of ctx has access
+	// to the types of namespace. The empty namespace stands for all of them,
+	// e.g. for registering schemas, which may define types of any namespace.
+	AuthorizeNamespace(ctx context.Context, access Access, namespace string) error
+}
+
+// SetAuthorizer sets the authorizer of requests. It must be called before the
+// service starts serving. Without one, every request is allowed. Lists,
+// events and lineage graphs only include the records the caller may read.
+func (s *Service) SetAuthorizer(a Authorizer) {
+	s.authorizer = a
+}
+
+// namespaceOf returns the namespace of a type name `{namespace}/{name}`, or
+// the default one if it has none.
+func namespaceOf(typeName string) string {
+	if i := strings.LastIndex(typeName, "/"); i >= 0 {
+		return typeName[:i]
+	}
+	return kfDefaultNamespace
+}
+
+// isDenied returns true if err is the error of an Authorizer denying access,
+// as opposed to failing to authorize.
+func isDenied(err error) bool {
+	return status.Code(err) == codes.PermissionDenied
+}
+
+func (s *Service) authorizeWorkspace(ctx context.Context, access Access, workspace string) error {
+	if s.authorizer == nil {
+		return nil
+	}
+	return s.authorizer.AuthorizeWorkspace(ctx, access, workspace)
+}
+
+func (s *Service) authorizeNamespace(ctx context.Context, access Access, namespace string) error {
+	if s.authorizer == nil {
+		return nil
+	}
+	return s.authorizer.AuthorizeNamespace(ctx, access, namespace)
+}
+
+// authorizeType checks access to the namespace of the type typeName.
+func (s *Service) authorizeType(ctx context.Context, access Access, typeName string) error {
+	return s.authorizeNamespace(ctx, access, namespaceOf(typeName))
+}
+
+// authorizeRecord checks access to the workspace of a record and to the
+// namespace of its type.
+func (s *Service) authorizeRecord(ctx context.Context, access Access, typeName string, customProperties map[string]*mlpb.Value) error {
+	if err := s.authorizeType(ctx, access, typeName); err != nil {
+		return err
+	}
+	return s.authorizeWorkspace(ctx, access, workspaceOf(customProperties))
+}
+
+// artifactTypeNames returns the names of the types of artifacts by id.
+func (s *Service) artifactTypeNames(ctx context.Context, artifacts []*mlpb.Artifact) (map[int64]string, error) {
+	var ids []mlmd.ArtifactTypeID
+	names := make(map[int64]string)
+	for _, artifact := range artifacts {
+		if _, seen := names[artifact.GetTypeId()]; !seen {
+			names[artifact.GetTypeId()] = ""
+			ids = append(ids, mlmd.ArtifactTypeID(artifact.GetTypeId()))
+		}
+	}
+	if len(ids) == 0 {
+		return names, nil
+	}
+	types, err := s.store.GetArtifactTypesByID(ctx, ids)
+	if err != nil {
+		return nil, err
+	}
+	for _, t := range types {
+		names[t.GetId()] = t.GetName()
+	}
+	return names, nil
+}
+
+// executionTypeNames returns the names of the types of executions by id.
+func (s *Service) executionTypeNames(ctx context.Context, executions []*mlpb.Execution) (map[int64]string, error) {
+	var ids []mlmd.ExecutionTypeID
+	names := make(map[int64]string)
+	for _, execution := range executions {
+		if _, seen := names[execution.GetTypeId()]; !seen {
+			names[execution.GetTypeId()] = ""
+			ids = append(ids, mlmd.ExecutionTypeID(execution.GetTypeId()))
+		}
+	}
+	if len(ids) == 0 {
+		return names, nil
+	}
+	types, err := s.store.GetExecutionTypesByID(ctx, ids)
+	if err != nil {
+		return nil, err
+	}
+	for _, t := range types {
+		names[t.GetId()] = t.GetName()
+	}
+	return names, nil
+}
+
+// authorizeArtifacts checks access to each of artifacts.
+func (s *Service) authorizeArtifacts(ctx context.Context, access Access, artifacts ...*mlpb.Artifact) error {
+	if s.authorizer == nil {
+		return nil
+	}
+	typeNames, err := s.artifactTypeNames(ctx, artifacts)
+	if err != nil {
+		return err
+	}
+	for _, artifact := range artifacts {
+		if err := s.authorizeRecord(ctx, access, typeNames[artifact.GetTypeId()], artifact.GetCustomProperties()); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// authorizeExecutions checks access to each of executions.
+func (s *Service) authorizeExecutions(ctx context.Context, access Access, executions ...*mlpb.Execution) error {
+	if s.authorizer == nil {
+		return nil
+	}
+	typeNames, err := s.executionTypeNames(ctx, executions)
+	if err != nil {
+		return err
+	}
+	for _, execution := range executions {
+		if err := s.authorizeRecord(ctx, access, typeNames[execution.GetTypeId()], execution.GetCustomProperties()); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// readableArtifacts returns the artifacts the caller of ctx may read.
+func (s *Service) readableArtifacts(ctx context.Context, artifacts []*mlpb.Artifact) ([]*mlpb.Artifact, error) {
+	if s.authorizer == nil {
+		return artifacts, nil
+	}
+	typeNames, err := s.artifactTypeNames(ctx, artifacts)
+	if err != nil {
+		return nil, err
+	}
+	readable := artifacts[:0:0]
+	for _, artifact := range artifacts {
+		err := s.authorizeRecord(ctx, ReadAccess, typeNames[artifact.GetTypeId()], artifact.GetCustomProperties())
+		if isDenied(err) {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		readable = append(readable, artifact)
+	}
+	return readable, nil
+}
+
+// readableExecutions returns the executions the caller of ctx may read.
+func (s *Service) readableExecutions(ctx context.Context, executions []*mlpb.Execution) ([]*mlpb.Execution, error) {
+	if s.authorizer == nil {
+		return executions, nil
+	}
+	typeNames, err := s.executionTypeNames(ctx, executions)
+	if err != nil {
+		return nil, err
+	}
+	readable := executions[:0:0]
+	for _, execution := range executions {
+		err := s.authorizeRecord(ctx, ReadAccess, typeNames[execution.GetTypeId()], execution.GetCustomProperties())
+		if isDenied(err) {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		readable = append(readable, execution)
+	}
+	return readable, nil
+}
+
+// readableGraph drops from artifacts and executions the nodes the caller of
+// ctx may not read, and returns the events between the remaining ones.
+func (s *Service) readableGraph(ctx context.Context, events []*mlpb.Event, artifacts map[int64]*mlpb.Artifact, executions map[int64]*mlpb.Execution) ([]*mlpb.Event, error) {
+	if s.authorizer == nil {
+		return events, nil
+	}
+	var artifactList []*mlpb.Artifact
+	for _, artifact := range artifacts {
+		artifactList = append(artifactList, artifact)
+	}
+	readableArtifacts, err := s.readableArtifacts(ctx, artifactList)
+	if err != nil {
+		return nil, err
+	}
+	var executionList []*mlpb.Execution
+	for _, execution := range executions {
+		executionList = append(executionList, execution)
+	}
+	readableExecutions, err := s.readableExecutions(ctx, executionList)
+	if err != nil {
+		return nil, err
+	}
+
+	for id := range artifacts {
+		delete(artifacts, id)
+	}
+	for _, artifact := range readableArtifacts {
+		artifacts[artifact.GetId()] = artifact
+	}
+	for id := range executions {
+		delete(executions, id)
+	}
+	for _, execution := range readableExecutions {
+		executions[execution.GetId()] = execution
+	}
+	var readable []*mlpb.Event
+	for _, e := range events {
+		_, artifactOK := artifacts[e.GetArtifactId()]
+		_, executionOK := executions[e.GetExecutionId()]
+		if artifactOK && executionOK {
+			readable = append(readable, e)
+		}
+	}
+	return readable, nil
+}
+
+// authorizeEvents checks that the caller of ctx may write the executions of
+// events, and read the artifacts they take as input or write the other
+// artifacts, e.g. their outputs. artifacts and executions hold the nodes of
+// events by id.
+func (s *Service) authorizeEvents(ctx context.Context, events []*mlpb.Event, artifacts map[int64]*mlpb.Artifact, executions map[int64]*mlpb.Execution) error {
+	if s.authorizer == nil {
+		return nil
+	}
+	for _, e := range events {
+		if err := s.authorizeExecutions(ctx, WriteAccess, executions[e.GetExecutionId()]); err != nil {
+			return err
+		}
+		access := WriteAccess
+		if isInputEvent(e.GetType()) {
+			access = ReadAccess
+		}
+		if err := s.authorizeArtifacts(ctx, access, artifacts[e.GetArtifactId()]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,211 @@
+// Copyright 2019 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package service
+
+import (
+	"context"
+	"strings"
+
+	mlpb "ml_metadata/proto/metadata_store_go_proto"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/kubeflow/metadata/api"
+	"github.com/kubeflow/metadata/mlmd"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// Container types are the types of workspaces, defined by schemas of category
+// container. Each one is recorded as a reserved ArtifactType, and each
+// workspace of a container type has a kfInternal artifact of that type
+// holding its properties.
+const (
+	// kfContainerTypePrefix prefixes the names of the reserved ArtifactTypes
+	// recording container types.
+	kfContainerTypePrefix = "__kf_containers/"
+	// kfContainerTypeProperty prefixes the name of the property of the
+	// reserved ArtifactType of a workspace that records its container type,
+	// as MLMD types cannot hold values.
+	kfContainerTypeProperty = "__kf_container_type/"
+)
+
+// CreateContainerType creates a type of workspaces. Its properties are those
+// its workspaces can have. It is not exposed as an RPC: container types are
+// defined by registering schemas of category container.
+func (s *Service) CreateContainerType(ctx context.Context, containerType *mlpb.ArtifactType) error {
+	name, err := getNamespacedName(containerType.GetName())
+	if err != nil {
+		return err
+	}
+	cType := proto.Clone(containerType).(*mlpb.ArtifactType)
+	cType.Name = proto.String(kfContainerTypePrefix + name)
+	_, err = s.store.PutArtifactType(ctx, cType, &mlmd.PutTypeOptions{AllFieldsMustMatch: true})
+	return err
+}
+
+// UpdateContainerType adds the properties of containerType to the type of
+// workspaces with its name, creating it if needed. Properties missing from
+// containerType are kept, as workspaces may still hold them.
+func (s *Service) UpdateContainerType(ctx context.Context, containerType *mlpb.ArtifactType) error {
+	name, err := getNamespacedName(containerType.GetName())
+	if err != nil {
+		return err
+	}
+	cType := proto.Clone(containerType).(*mlpb.ArtifactType)
+	cType.Name = proto.String(kfContainerTypePrefix + name)
+	if stored, err := s.store.GetArtifactType(ctx, cType.GetName()); err == nil {
+		if cType.Properties == nil {
+			cType.Properties = make(map[string]mlpb.PropertyType)
+		}
+		for p, t := range stored.GetProperties() {
+			if _, exists := cType.Properties[p]; !exists {
+				cType.Properties[p] = t
+			}
+		}
+	}
+	_, err = s.store.PutArtifactType(ctx, cType, &mlmd.PutTypeOptions{
+		AllFieldsMustMatch: true,
+		CanAddFields:       true,
+	})
+	return err
+}
+
+func (s *Service) getContainerType(ctx context.Context, name string) (*mlpb.ArtifactType, error) {
+	cType, err := s.store.GetArtifactType(ctx, kfContainerTypePrefix+name)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "unknown container type %q: container types are defined by registering schemas of category container", name)
+	}
+	return cType, nil
+}
+
+// containerTypeOf returns the container type recorded in the properties of
+// the reserved ArtifactType of a workspace, if any.
+func containerTypeOf(wsType *mlpb.ArtifactType) string {
+	for p := range wsType.GetProperties() {
+		if strings.HasPrefix(p, kfContainerTypeProperty) {
+			return strings.TrimPrefix(p, kfContainerTypeProperty)
+		}
+	}
+	return ""
+}
+
+// newContainerArtifact returns the artifact holding the properties of ws,
+// which has a container type.
+func (s *Service) newContainerArtifact(ctx context.Context, ws *api.Workspace) (*mlpb.Artifact, error) {
+	cType, err := s.getContainerType(ctx, ws.GetType())
+	if err != nil {
+		return nil, err
+	}
+	artifact := &mlpb.Artifact{
+		TypeId:     proto.Int64(cType.GetId()),
+		Properties: make(map[string]*mlpb.Value),
+		CustomProperties: map[string]*mlpb.Value{
+			kfInternal:  nowValue(),
+			kfWorkspace: &mlpb.Value{Value: &mlpb.Value_StringValue{StringValue: ws.GetName()}},
+		},
+	}
+	for name, v := range ws.GetProperties() {
+		if _, ok := cType.GetProperties()[name]; !ok {
+			return nil, status.Errorf(codes.InvalidArgument, "property %q is not declared by container type %q", name, ws.GetType())
+		}
+		artifact.Properties[name] = v
+	}
+	if err := projectArtifactDocument(cType, artifact, false); err != nil {
+		return nil, err
+	}
+	// The workspace name is its name property, which cannot differ.
+	if t, ok := cType.GetProperties()[documentName]; ok && t == mlpb.PropertyType_STRING {
+		artifact.Properties[documentName] = &mlpb.Value{Value: &mlpb.Value_StringValue{StringValue: ws.GetName()}}
+	}
+
+	a := proto.Clone(artifact).(*mlpb.Artifact)
+	a.Properties = containerDocument(artifact.GetProperties(), ws.GetName())
+	if err := s.validateArtifact(ws.GetType(), a); err != nil {
+		return nil, err
+	}
+	return artifact, nil
+}
+
+// containerDocument returns properties with the full document of a workspace,
+// which is identified by its name.
+func containerDocument(properties map[string]*mlpb.Value, workspace string) map[string]*mlpb.Value {
+	return reconstructDocument(properties, map[string]interface{}{documentID: workspace})
+}
+
+// containerArtifact returns the artifact holding the properties of the
+// workspace, of container type cType. The latest one is used, since a failed
+// CreateWorkspace may have stored properties without recording the workspace.
+func (s *Service) containerArtifact(ctx context.Context, cType, workspace string) (*mlpb.Artifact, error) {
+	artifacts, err := s.containerArtifacts(ctx, cType)
+	if err != nil {
+		return nil, err
+	}
+	return latestContainerArtifact(artifacts, cType, workspace)
+}
+
+// containerArtifacts returns the artifacts holding the properties of the
+// workspaces of container type cType.
+func (s *Service) containerArtifacts(ctx context.Context, cType string) ([]*mlpb.Artifact, error) {
+	artifacts, err := s.store.GetArtifactsByType(ctx, kfContainerTypePrefix+cType)
+	if err != nil && !noRecordFound(err) {
+		return nil, err
+	}
+	return artifacts, nil
+}
+
+// latestContainerArtifact returns the latest of artifacts, of container type
+// cType, holding the properties of workspace. See containerArtifact.
+func latestContainerArtifact(artifacts []*mlpb.Artifact, cType, workspace string) (*mlpb.Artifact, error) {
+	var latest *mlpb.Artifact
+	for _, artifact := range artifacts {
+		if _, deleted := artifact.GetCustomProperties()[kfDeleted]; deleted || workspaceOf(artifact.GetCustomProperties()) != workspace {
+			continue
+		}
+		if latest == nil || artifact.GetId() > latest.GetId() {
+			latest = artifact
+		}
+	}
+	if latest == nil {
+		return nil, status.Errorf(codes.Internal, "no properties found for Workspace %q of container type %q", workspace, cType)
+	}
+	return latest, nil
+}
+
+// workspace returns the workspace recorded by wsType, with its properties if
+// it has a container type. The artifacts holding the properties are cached in
+// containers by container type, so that listing workspaces loads those of
+// each type once.
+func (s *Service) workspace(ctx context.Context, wsType *mlpb.ArtifactType, containers map[string][]*mlpb.Artifact) (*api.Workspace, error) {
+	ws := &api.Workspace{Name: strings.TrimPrefix(wsType.GetName(), kfWorkspaceTypePrefix)}
+	cType := containerTypeOf(wsType)
+	if cType == "" {
+		return ws, nil
+	}
+	artifacts, ok := containers[cType]
+	if !ok {
+		var err error
+		if artifacts, err = s.containerArtifacts(ctx, cType); err != nil {
+			return nil, err
+		}
+		containers[cType] = artifacts
+	}
+	artifact, err := latestContainerArtifact(artifacts, cType, ws.GetName())
+	if err != nil {
+		return nil, err
+	}
+	ws.Type = cType
+	ws.Properties = containerDocument(artifact.GetProperties(), ws.GetName())
+	return ws, nil
+}
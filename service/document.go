@@ -0,0 +1,360 @@
+// Copyright 2019 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package service
+
+import (
+	"bytes"
+	"encoding/json"
+	"strconv"
+	"strings"
+
+	mlpb "ml_metadata/proto/metadata_store_go_proto"
+
+	"github.com/golang/protobuf/proto"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// Types registered from JSON schemas declare the STRING property allMeta,
+// which holds the JSON document describing an instance. Fields of the document
+// that have a typed property, as well as the uri and id, are projected out of
+// it when writing and merged back when reading, so that the stored document
+// only keeps the fields MLMD cannot store, e.g. free-form objects, and never
+// goes stale when typed properties are updated. Nested fields map to
+// properties with dotted names, e.g. training_framework.name.
+//
+// Booleans stored as INT 0 or 1, and arrays and objects stored as STRING
+// holding their JSON encoding, cannot be converted back losslessly. They are
+// kept in the document, which remains authoritative for them, and their
+// properties are derived from it on every write.
+const (
+	allMeta = "__ALL_META__"
+
+	fieldSeparator = "."
+
+	documentID   = "id"
+	documentName = "name"
+	documentURI  = "uri"
+)
+
+// parseDocument returns the JSON object held by the allMeta property, or an
+// empty object if the property is not set.
+func parseDocument(properties map[string]*mlpb.Value) (map[string]interface{}, error) {
+	doc := make(map[string]interface{})
+	v, ok := properties[allMeta]
+	if !ok {
+		return doc, nil
+	}
+	d := json.NewDecoder(bytes.NewReader([]byte(v.GetStringValue())))
+	d.UseNumber()
+	if err := d.Decode(&doc); err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "property %s must hold a JSON object: %v", allMeta, err)
+	}
+	if doc == nil || d.More() {
+		return nil, status.Errorf(codes.InvalidArgument, "property %s must hold a single JSON object", allMeta)
+	}
+	return doc, nil
+}
+
+func documentValue(doc map[string]interface{}) (*mlpb.Value, error) {
+	b, err := json.Marshal(doc)
+	if err != nil {
+		return nil, err
+	}
+	return &mlpb.Value{Value: &mlpb.Value_StringValue{StringValue: string(b)}}, nil
+}
+
+// propertyValue converts a document field to a value of the given type. It
+// returns false if the field does not fit in the type.
+func propertyValue(field interface{}, t mlpb.PropertyType) (*mlpb.Value, bool) {
+	switch t {
+	case mlpb.PropertyType_STRING:
+		if s, ok := field.(string); ok {
+			return &mlpb.Value{Value: &mlpb.Value_StringValue{StringValue: s}}, true
+		}
+	case mlpb.PropertyType_INT:
+		if n, ok := field.(json.Number); ok {
+			if i, err := n.Int64(); err == nil {
+				return &mlpb.Value{Value: &mlpb.Value_IntValue{IntValue: i}}, true
+			}
+		}
+	case mlpb.PropertyType_DOUBLE:
+		if n, ok := field.(json.Number); ok {
+			if f, err := n.Float64(); err == nil {
+				return &mlpb.Value{Value: &mlpb.Value_DoubleValue{DoubleValue: f}}, true
+			}
+		}
+	}
+	return nil, false
+}
+
+// derivedPropertyValue converts a document field that cannot be stored
+// losslessly in a property of the given type, i.e. a boolean in an INT or an
+// array or object in a STRING. It returns false if the field does not fit.
+func derivedPropertyValue(field interface{}, t mlpb.PropertyType) (*mlpb.Value, bool) {
+	switch field.(type) {
+	case bool:
+		if t != mlpb.PropertyType_INT {
+			return nil, false
+		}
+		var i int64
+		if field.(bool) {
+			i = 1
+		}
+		return &mlpb.Value{Value: &mlpb.Value_IntValue{IntValue: i}}, true
+	case []interface{}, map[string]interface{}:
+		if t != mlpb.PropertyType_STRING {
+			return nil, false
+		}
+		b, err := json.Marshal(field)
+		if err != nil {
+			return nil, false
+		}
+		return &mlpb.Value{Value: &mlpb.Value_StringValue{StringValue: string(b)}}, true
+	}
+	return nil, false
+}
+
+// isDerivedField reports whether field is kept in the document for its
+// property to be derived from.
+func isDerivedField(field interface{}) bool {
+	switch field.(type) {
+	case bool, []interface{}, map[string]interface{}:
+		return true
+	}
+	return false
+}
+
+// lookupField returns the field of doc at the dotted path name.
+func lookupField(doc map[string]interface{}, name string) (interface{}, bool) {
+	segments := strings.Split(name, fieldSeparator)
+	for _, segment := range segments[:len(segments)-1] {
+		next, ok := doc[segment].(map[string]interface{})
+		if !ok {
+			return nil, false
+		}
+		doc = next
+	}
+	field, ok := doc[segments[len(segments)-1]]
+	return field, ok
+}
+
+// deleteField removes the field of doc at the dotted path name, along with the
+// objects left empty by its removal.
+func deleteField(doc map[string]interface{}, name string) {
+	segments := strings.SplitN(name, fieldSeparator, 2)
+	if len(segments) == 1 {
+		delete(doc, name)
+		return
+	}
+	if next, ok := doc[segments[0]].(map[string]interface{}); ok {
+		deleteField(next, segments[1])
+		if len(next) == 0 {
+			delete(doc, segments[0])
+		}
+	}
+}
+
+// setField sets the field of doc at the dotted path name, creating the
+// enclosing objects as needed. It does nothing if a field on the path is not
+// an object.
+func setField(doc map[string]interface{}, name string, field interface{}) {
+	segments := strings.Split(name, fieldSeparator)
+	for _, segment := range segments[:len(segments)-1] {
+		if _, ok := doc[segment]; !ok {
+			doc[segment] = make(map[string]interface{})
+		}
+		next, ok := doc[segment].(map[string]interface{})
+		if !ok {
+			return
+		}
+		doc = next
+	}
+	doc[segments[len(segments)-1]] = field
+}
+
+// projectDocument moves the fields of doc that fit a typed property of
+// propertyTypes into properties. A property already set keeps its value unless
+// override is set. Properties derived from fields that cannot be stored
+// losslessly are always set, and the fields kept in doc. Fields that do not
+// fit their property's type are left in doc, for schema validation to report.
+func projectDocument(doc map[string]interface{}, propertyTypes map[string]mlpb.PropertyType, properties map[string]*mlpb.Value, override bool) map[string]*mlpb.Value {
+	if properties == nil {
+		properties = make(map[string]*mlpb.Value)
+	}
+	for name, t := range propertyTypes {
+		if name == allMeta {
+			continue
+		}
+		field, ok := lookupField(doc, name)
+		if !ok {
+			continue
+		}
+		if v, ok := propertyValue(field, t); ok {
+			if _, set := properties[name]; override || !set {
+				properties[name] = v
+			}
+			deleteField(doc, name)
+		} else if v, ok := derivedPropertyValue(field, t); ok {
+			properties[name] = v
+		}
+	}
+	return properties
+}
+
+// projectArtifactDocument projects the allMeta document of artifact, an
+// instance of aType, into its typed properties and uri. The document is stored
+// even if empty for types declaring allMeta, so that it can be reconstructed
+// on reads. override is set when the document replaces the artifact's fields,
+// as opposed to completing them.
+func projectArtifactDocument(aType *mlpb.ArtifactType, artifact *mlpb.Artifact, override bool) error {
+	if _, ok := aType.GetProperties()[allMeta]; !ok {
+		return nil
+	}
+	doc, err := parseDocument(artifact.GetProperties())
+	if err != nil {
+		return err
+	}
+
+	if uri, ok := doc[documentURI].(string); ok {
+		if override || artifact.Uri == nil {
+			artifact.Uri = proto.String(uri)
+		}
+		delete(doc, documentURI)
+	}
+	delete(doc, documentID)
+	artifact.Properties = projectDocument(doc, aType.GetProperties(), artifact.Properties, override)
+
+	v, err := documentValue(doc)
+	if err != nil {
+		return err
+	}
+	artifact.Properties[allMeta] = v
+	return nil
+}
+
+// projectExecutionDocument projects the allMeta document of execution, an
+// instance of eType, into its typed properties. See projectArtifactDocument.
+func projectExecutionDocument(eType *mlpb.ExecutionType, execution *mlpb.Execution, override bool) error {
+	if _, ok := eType.GetProperties()[allMeta]; !ok {
+		return nil
+	}
+	doc, err := parseDocument(execution.GetProperties())
+	if err != nil {
+		return err
+	}
+
+	delete(doc, documentID)
+	execution.Properties = projectDocument(doc, eType.GetProperties(), execution.Properties, override)
+
+	v, err := documentValue(doc)
+	if err != nil {
+		return err
+	}
+	execution.Properties[allMeta] = v
+	return nil
+}
+
+// reconstructDocument merges the typed properties and the given fields back
+// into the document stored in properties. Properties derived from fields kept
+// in the document are skipped. Properties holding no document, or a document
+// that cannot be parsed, are returned as is.
+func reconstructDocument(properties map[string]*mlpb.Value, fields map[string]interface{}) map[string]*mlpb.Value {
+	if _, ok := properties[allMeta]; !ok {
+		return properties
+	}
+	doc, err := parseDocument(properties)
+	if err != nil {
+		return properties
+	}
+	for name, v := range properties {
+		if field, ok := lookupField(doc, name); name == allMeta || ok && isDerivedField(field) {
+			continue
+		}
+		switch v.GetValue().(type) {
+		case *mlpb.Value_IntValue:
+			setField(doc, name, v.GetIntValue())
+		case *mlpb.Value_DoubleValue:
+			setField(doc, name, v.GetDoubleValue())
+		case *mlpb.Value_StringValue:
+			setField(doc, name, v.GetStringValue())
+		}
+	}
+	for name, v := range fields {
+		doc[name] = v
+	}
+
+	v, err := documentValue(doc)
+	if err != nil {
+		return properties
+	}
+	reconstructed := make(map[string]*mlpb.Value, len(properties))
+	for name, p := range properties {
+		reconstructed[name] = p
+	}
+	reconstructed[allMeta] = v
+	return reconstructed
+}
+
+// withArtifactDocument returns artifact with its full document in the allMeta
+// property. artifact itself is left unchanged.
+func withArtifactDocument(artifact *mlpb.Artifact) *mlpb.Artifact {
+	if _, ok := artifact.GetProperties()[allMeta]; !ok {
+		return artifact
+	}
+	fields := map[string]interface{}{documentID: strconv.FormatInt(artifact.GetId(), 10)}
+	if artifact.Uri != nil {
+		fields[documentURI] = artifact.GetUri()
+	}
+	a := proto.Clone(artifact).(*mlpb.Artifact)
+	a.Properties = reconstructDocument(artifact.GetProperties(), fields)
+	return a
+}
+
+// withExecutionDocument returns execution with its full document in the
+// allMeta property. execution itself is left unchanged.
+func withExecutionDocument(execution *mlpb.Execution) *mlpb.Execution {
+	if _, ok := execution.GetProperties()[allMeta]; !ok {
+		return execution
+	}
+	e := proto.Clone(execution).(*mlpb.Execution)
+	e.Properties = reconstructDocument(execution.GetProperties(), map[string]interface{}{
+		documentID: strconv.FormatInt(execution.GetId(), 10),
+	})
+	return e
+}
+
+func withArtifactDocuments(artifacts []*mlpb.Artifact) []*mlpb.Artifact {
+	res := make([]*mlpb.Artifact, len(artifacts))
+	for i, a := range artifacts {
+		res[i] = withArtifactDocument(a)
+	}
+	return res
+}
+
+func withExecutionDocuments(executions []*mlpb.Execution) []*mlpb.Execution {
+	res := make([]*mlpb.Execution, len(executions))
+	for i, e := range executions {
+		res[i] = withExecutionDocument(e)
+	}
+	return res
+}
+
+// documentChanged reports whether an update changed the allMeta property from
+// before to after, in which case the new document overrides the properties
+// it sets.
+func documentChanged(before, after map[string]*mlpb.Value) bool {
+	return !proto.Equal(before[allMeta], after[allMeta])
+}
@@ -0,0 +1,66 @@
+// Copyright 2019 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package service
+
+import (
+	"context"
+	"fmt"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// ErrorInterceptor is a gRPC unary server interceptor completing the codes of
+// the errors of handlers. Handlers return status errors, or the mlmd.Error of
+// stores rejecting a request, whose codes grpc-gateway maps to HTTP status
+// codes, e.g. codes.NotFound to 404 and codes.FailedPrecondition to 412.
+// Context errors, of requests past their deadline or canceled, are reported
+// with codes.DeadlineExceeded or codes.Canceled, and other errors without a
+// code, e.g. of the database, with codes.Internal.
+func ErrorInterceptor(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+	resp, err := handler(ctx, req)
+	if err != nil {
+		err = statusError(err)
+	}
+	return resp, err
+}
+
+// statusError returns err with codes.DeadlineExceeded or codes.Canceled if it
+// is a context error, err as is if it has a code, and err with codes.Internal
+// otherwise.
+func statusError(err error) error {
+	switch err {
+	case context.DeadlineExceeded:
+		return status.Error(codes.DeadlineExceeded, err.Error())
+	case context.Canceled:
+		return status.Error(codes.Canceled, err.Error())
+	}
+	if status.Code(err) == codes.Unknown {
+		return status.Error(codes.Internal, err.Error())
+	}
+	return err
+}
+
+// wrapError returns err with its message prefixed by a formatted one, keeping
+// its code, or that of its context error.
+func wrapError(err error, format string, args ...interface{}) error {
+	switch err {
+	case context.DeadlineExceeded, context.Canceled:
+		err = statusError(err)
+	}
+	s := status.Convert(err)
+	return status.Errorf(s.Code(), "%s: %s", fmt.Sprintf(format, args...), s.Message())
+}
@@ -0,0 +1,219 @@
+// Copyright 2019 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package service
+
+import (
+	"strings"
+
+	mlpb "ml_metadata/proto/metadata_store_go_proto"
+
+	"github.com/golang/protobuf/proto"
+	"google.golang.org/genproto/protobuf/field_mask"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// Field mask paths are matched ignoring case and underscores, so that both
+// `custom_properties` and the `CustomProperties` form produced by the HTTP
+// gateway are accepted.
+const (
+	maskID               = "id"
+	maskTypeID           = "typeid"
+	maskURI              = "uri"
+	maskLastKnownState   = "lastknownstate"
+	maskProperties       = "properties"
+	maskCustomProperties = "customproperties"
+)
+
+func normalizeMaskName(name string) string {
+	return strings.ToLower(strings.Replace(name, "_", "", -1))
+}
+
+// maskPath is a parsed field mask path. key is only set for paths that select
+// a single property, e.g. `properties.accuracy`, and holds all the segments
+// after the field since names of nested properties contain dots.
+type maskPath struct {
+	field string
+	key   string
+}
+
+// parseMask checks update_mask against the fields that may be updated. Paths
+// to a property may carry trailing segments, as in the
+// `Properties.Accuracy.DoubleValue` paths the HTTP gateway derives from a
+// request body; those are ignored since a property is always replaced whole.
+func parseMask(mask *field_mask.FieldMask, updatable ...string) ([]maskPath, error) {
+	if len(mask.GetPaths()) == 0 {
+		return nil, status.Error(codes.InvalidArgument, "update_mask must list at least one field")
+	}
+
+	var paths []maskPath
+	for _, p := range mask.GetPaths() {
+		segments := strings.Split(p, ".")
+		field := normalizeMaskName(segments[0])
+		switch field {
+		case maskID, maskTypeID:
+			return nil, status.Errorf(codes.InvalidArgument, "field %q cannot be updated", p)
+		case maskProperties, maskCustomProperties:
+			if len(segments) > 1 {
+				paths = append(paths, maskPath{field: field, key: strings.Join(segments[1:], ".")})
+				continue
+			}
+		default:
+			if len(segments) > 1 {
+				return nil, status.Errorf(codes.InvalidArgument, "invalid update_mask path %q", p)
+			}
+		}
+
+		supported := false
+		for _, u := range updatable {
+			supported = supported || field == u
+		}
+		if !supported {
+			return nil, status.Errorf(codes.InvalidArgument, "unsupported update_mask path %q", p)
+		}
+		paths = append(paths, maskPath{field: field})
+	}
+	return paths, nil
+}
+
+// resolveKey returns the property key that the mask path key refers to among
+// the keys of the given maps. The longest leading segments of key naming a
+// property win, so that trailing segments such as `StringValue` are ignored.
+// An exact match wins; otherwise the key is matched ignoring case and
+// underscores, which the HTTP gateway strips. If no property matches, the
+// first segment of key is returned.
+func resolveKey(key string, maps ...map[string]*mlpb.Value) string {
+	segments := strings.Split(key, ".")
+	for n := len(segments); n > 0; n-- {
+		candidate := strings.Join(segments[:n], ".")
+		for _, m := range maps {
+			if _, ok := m[candidate]; ok {
+				return candidate
+			}
+		}
+		for _, m := range maps {
+			for k := range m {
+				if normalizeMaskName(k) == normalizeMaskName(candidate) {
+					return k
+				}
+			}
+		}
+	}
+	return segments[0]
+}
+
+// mergeProperties updates dst, a stored set of properties, with the values of
+// src selected by path. Reserved custom properties cannot be updated and are
+// preserved when all custom properties are replaced.
+func mergeProperties(dst map[string]*mlpb.Value, src map[string]*mlpb.Value, path maskPath) (map[string]*mlpb.Value, error) {
+	if dst == nil {
+		dst = make(map[string]*mlpb.Value)
+	}
+	reserved := path.field == maskCustomProperties
+
+	if path.key == "" {
+		for k := range dst {
+			if !reserved || !strings.HasPrefix(k, kfReservedPrefix) {
+				delete(dst, k)
+			}
+		}
+		for k, v := range src {
+			if reserved && strings.HasPrefix(k, kfReservedPrefix) {
+				return nil, status.Errorf(codes.InvalidArgument, "custom property %q is reserved and cannot be updated", k)
+			}
+			dst[k] = v
+		}
+		return dst, nil
+	}
+
+	key := resolveKey(path.key, src, dst)
+	if reserved && strings.HasPrefix(key, kfReservedPrefix) {
+		return nil, status.Errorf(codes.InvalidArgument, "custom property %q is reserved and cannot be updated", key)
+	}
+	if v, ok := src[key]; ok {
+		dst[key] = v
+	} else {
+		delete(dst, key)
+	}
+	return dst, nil
+}
+
+// applyArtifactMask returns a copy of stored with the fields of update listed
+// in mask.
+func applyArtifactMask(stored, update *mlpb.Artifact, mask *field_mask.FieldMask) (*mlpb.Artifact, error) {
+	if update.Id != nil && update.GetId() != stored.GetId() {
+		return nil, status.Errorf(codes.InvalidArgument, "Artifact id cannot be changed from %d to %d", stored.GetId(), update.GetId())
+	}
+	if update.TypeId != nil && update.GetTypeId() != stored.GetTypeId() {
+		return nil, status.Errorf(codes.InvalidArgument, "Artifact type_id cannot be changed from %d to %d", stored.GetTypeId(), update.GetTypeId())
+	}
+
+	paths, err := parseMask(mask, maskURI, maskProperties, maskCustomProperties)
+	if err != nil {
+		return nil, err
+	}
+
+	artifact := proto.Clone(stored).(*mlpb.Artifact)
+	for _, p := range paths {
+		switch p.field {
+		case maskURI:
+			artifact.Uri = update.Uri
+		case maskProperties:
+			artifact.Properties, err = mergeProperties(artifact.Properties, update.GetProperties(), p)
+		case maskCustomProperties:
+			artifact.CustomProperties, err = mergeProperties(artifact.CustomProperties, update.GetCustomProperties(), p)
+		default:
+			err = status.Errorf(codes.Internal, "unhandled update_mask field %q", p.field)
+		}
+		if err != nil {
+			return nil, err
+		}
+	}
+	return artifact, nil
+}
+
+// applyExecutionMask returns a copy of stored with the fields of update listed
+// in mask.
+func applyExecutionMask(stored, update *mlpb.Execution, mask *field_mask.FieldMask) (*mlpb.Execution, error) {
+	if update.Id != nil && update.GetId() != stored.GetId() {
+		return nil, status.Errorf(codes.InvalidArgument, "Execution id cannot be changed from %d to %d", stored.GetId(), update.GetId())
+	}
+	if update.TypeId != nil && update.GetTypeId() != stored.GetTypeId() {
+		return nil, status.Errorf(codes.InvalidArgument, "Execution type_id cannot be changed from %d to %d", stored.GetTypeId(), update.GetTypeId())
+	}
+
+	paths, err := parseMask(mask, maskLastKnownState, maskProperties, maskCustomProperties)
+	if err != nil {
+		return nil, err
+	}
+
+	execution := proto.Clone(stored).(*mlpb.Execution)
+	for _, p := range paths {
+		switch p.field {
+		case maskLastKnownState:
+			execution.LastKnownState = update.LastKnownState
+		case maskProperties:
+			execution.Properties, err = mergeProperties(execution.Properties, update.GetProperties(), p)
+		case maskCustomProperties:
+			execution.CustomProperties, err = mergeProperties(execution.CustomProperties, update.GetCustomProperties(), p)
+		default:
+			err = status.Errorf(codes.Internal, "unhandled update_mask field %q", p.field)
+		}
+		if err != nil {
+			return nil, err
+		}
+	}
+	return execution, nil
+}
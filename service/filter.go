@@ -0,0 +1,538 @@
+// Copyright 2019 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package service
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"unicode"
+
+	mlpb "ml_metadata/proto/metadata_store_go_proto"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// A filter is a boolean expression over the fields of an artifact or an
+// execution, used to restrict the results of list calls. The grammar is
+//
+//	expr       := term { OR term }
+//	term       := factor { AND factor }
+//	factor     := NOT factor | "(" expr ")" | comparison
+//	comparison := field op literal
+//	field      := "uri" | "name" | "workspace"
+//	            | "properties." key { "." key } | "custom_properties." key { "." key }
+//	op         := "=" | "!=" | "<" | "<=" | ">" | ">="
+//	literal    := number | string
+//
+// where key is an identifier or a double-quoted string, and string literals
+// are double-quoted with Go escape sequences. Keywords are case-insensitive.
+// name is short for properties.name, the property holding the name of the
+// Kubeflow types. For example:
+//
+//	properties.accuracy > 0.9 AND (uri = "gs://bucket/model" OR NOT name = "test")
+//
+// Numbers only match INT and DOUBLE values and strings only match STRING
+// values; a comparison against a missing field or a value of another type is
+// false. Filters are at most maxFilterLength bytes long and nest NOT and
+// parentheses at most maxFilterDepth levels deep.
+type filter interface {
+	matches(fields filterFields) bool
+}
+
+// filterFields looks up the value of a field of the artifact or execution a
+// filter is evaluated against.
+type filterFields func(f filterField) (*mlpb.Value, bool)
+
+// filterField identifies a field in a filter, e.g. {"properties", "accuracy"}
+// or {"uri", ""}.
+type filterField struct {
+	name string
+	key  string
+}
+
+const (
+	uriField              = "uri"
+	nameField             = "name"
+	workspaceField        = "workspace"
+	propertiesField       = "properties"
+	customPropertiesField = "custom_properties"
+)
+
+const (
+	maxFilterLength = 4096
+	maxFilterDepth  = 32
+)
+
+type andFilter struct{ left, right filter }
+
+func (f *andFilter) matches(fields filterFields) bool {
+	return f.left.matches(fields) && f.right.matches(fields)
+}
+
+type orFilter struct{ left, right filter }
+
+func (f *orFilter) matches(fields filterFields) bool {
+	return f.left.matches(fields) || f.right.matches(fields)
+}
+
+type notFilter struct{ operand filter }
+
+func (f *notFilter) matches(fields filterFields) bool {
+	return !f.operand.matches(fields)
+}
+
+type comparisonFilter struct {
+	field filterField
+	op    string
+	value *mlpb.Value
+}
+
+func (f *comparisonFilter) matches(fields filterFields) bool {
+	v, ok := fields(f.field)
+	if !ok || v == nil {
+		return false
+	}
+
+	var c int
+	switch want := f.value.GetValue().(type) {
+	case *mlpb.Value_StringValue:
+		got, ok := v.GetValue().(*mlpb.Value_StringValue)
+		if !ok {
+			return false
+		}
+		c = strings.Compare(got.StringValue, want.StringValue)
+	case *mlpb.Value_IntValue:
+		switch got := v.GetValue().(type) {
+		case *mlpb.Value_IntValue:
+			c = compareInt64(got.IntValue, want.IntValue)
+		case *mlpb.Value_DoubleValue:
+			c = compareFloat64(got.DoubleValue, float64(want.IntValue))
+		default:
+			return false
+		}
+	case *mlpb.Value_DoubleValue:
+		switch got := v.GetValue().(type) {
+		case *mlpb.Value_IntValue:
+			c = compareFloat64(float64(got.IntValue), want.DoubleValue)
+		case *mlpb.Value_DoubleValue:
+			c = compareFloat64(got.DoubleValue, want.DoubleValue)
+		default:
+			return false
+		}
+	default:
+		return false
+	}
+
+	switch f.op {
+	case "=":
+		return c == 0
+	case "!=":
+		return c != 0
+	case "<":
+		return c < 0
+	case "<=":
+		return c <= 0
+	case ">":
+		return c > 0
+	case ">=":
+		return c >= 0
+	}
+	return false
+}
+
+func compareInt64(a, b int64) int {
+	switch {
+	case a < b:
+		return -1
+	case a > b:
+		return 1
+	}
+	return 0
+}
+
+func compareFloat64(a, b float64) int {
+	switch {
+	case a < b:
+		return -1
+	case a > b:
+		return 1
+	}
+	return 0
+}
+
+// artifactFilterFields returns the filterFields of an artifact.
+func artifactFilterFields(artifact *mlpb.Artifact) filterFields {
+	return func(f filterField) (*mlpb.Value, bool) {
+		switch f.name {
+		case uriField:
+			if artifact.Uri == nil {
+				return nil, false
+			}
+			return &mlpb.Value{Value: &mlpb.Value_StringValue{StringValue: artifact.GetUri()}}, true
+		case nameField:
+			v, ok := artifact.GetProperties()[nameField]
+			return v, ok
+		case workspaceField:
+			return workspaceValue(artifact.GetCustomProperties()), true
+		case propertiesField:
+			v, ok := artifact.GetProperties()[f.key]
+			return v, ok
+		case customPropertiesField:
+			v, ok := artifact.GetCustomProperties()[f.key]
+			return v, ok
+		}
+		return nil, false
+	}
+}
+
+// executionFilterFields returns the filterFields of an execution.
+func executionFilterFields(execution *mlpb.Execution) filterFields {
+	return func(f filterField) (*mlpb.Value, bool) {
+		switch f.name {
+		case nameField:
+			v, ok := execution.GetProperties()[nameField]
+			return v, ok
+		case workspaceField:
+			return workspaceValue(execution.GetCustomProperties()), true
+		case propertiesField:
+			v, ok := execution.GetProperties()[f.key]
+			return v, ok
+		case customPropertiesField:
+			v, ok := execution.GetCustomProperties()[f.key]
+			return v, ok
+		}
+		return nil, false
+	}
+}
+
+var (
+	artifactFilterFieldNames  = []string{uriField, nameField, workspaceField, propertiesField, customPropertiesField}
+	executionFilterFieldNames = []string{nameField, workspaceField, propertiesField, customPropertiesField}
+)
+
+// parseArtifactFilter parses a filter over artifacts. An empty expression
+// returns a nil filter. Malformed expressions result in an InvalidArgument
+// error.
+func parseArtifactFilter(expr string) (filter, error) {
+	return parseFilter(expr, artifactFilterFieldNames)
+}
+
+// parseExecutionFilter parses a filter over executions. An empty expression
+// returns a nil filter. Malformed expressions result in an InvalidArgument
+// error.
+func parseExecutionFilter(expr string) (filter, error) {
+	return parseFilter(expr, executionFilterFieldNames)
+}
+
+func parseFilter(expr string, fieldNames []string) (filter, error) {
+	if strings.TrimSpace(expr) == "" {
+		return nil, nil
+	}
+	if len(expr) > maxFilterLength {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid filter: longer than %d bytes", maxFilterLength)
+	}
+	tokens, err := tokenizeFilter(expr)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid filter %q: %v", expr, err)
+	}
+	p := &filterParser{tokens: tokens, fieldNames: fieldNames}
+	f, err := p.parseExpr()
+	if err == nil && p.peek().kind != eofToken {
+		err = fmt.Errorf("unexpected %s at offset %d", p.peek(), p.peek().pos)
+	}
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid filter %q: %v", expr, err)
+	}
+	return f, nil
+}
+
+type filterTokenKind int
+
+const (
+	eofToken filterTokenKind = iota
+	identToken
+	stringToken
+	numberToken
+	opToken
+	dotToken
+	lparenToken
+	rparenToken
+)
+
+type filterToken struct {
+	kind filterTokenKind
+	text string
+	pos  int
+}
+
+func (t filterToken) String() string {
+	if t.kind == eofToken {
+		return "end of filter"
+	}
+	return fmt.Sprintf("%q", t.text)
+}
+
+func isIdentStart(r rune) bool {
+	return r == '_' || unicode.IsLetter(r)
+}
+
+func isIdentPart(r rune) bool {
+	return r == '_' || r == '-' || unicode.IsLetter(r) || unicode.IsDigit(r)
+}
+
+func tokenizeFilter(expr string) ([]filterToken, error) {
+	var tokens []filterToken
+	runes := []rune(expr)
+	for i := 0; i < len(runes); {
+		r := runes[i]
+		switch {
+		case unicode.IsSpace(r):
+			i++
+		case r == '(':
+			tokens = append(tokens, filterToken{lparenToken, "(", i})
+			i++
+		case r == ')':
+			tokens = append(tokens, filterToken{rparenToken, ")", i})
+			i++
+		case r == '.':
+			tokens = append(tokens, filterToken{dotToken, ".", i})
+			i++
+		case r == '=' || r == '!' || r == '<' || r == '>':
+			op := string(r)
+			if i+1 < len(runes) && runes[i+1] == '=' {
+				op += "="
+			}
+			if op == "!" {
+				return nil, fmt.Errorf("unexpected '!' at offset %d", i)
+			}
+			tokens = append(tokens, filterToken{opToken, op, i})
+			i += len(op)
+		case r == '"':
+			j := i + 1
+			for ; j < len(runes) && runes[j] != '"'; j++ {
+				if runes[j] == '\\' {
+					j++
+				}
+			}
+			if j >= len(runes) {
+				return nil, fmt.Errorf("unterminated string at offset %d", i)
+			}
+			s, err := strconv.Unquote(string(runes[i : j+1]))
+			if err != nil {
+				return nil, fmt.Errorf("malformed string at offset %d: %v", i, err)
+			}
+			tokens = append(tokens, filterToken{stringToken, s, i})
+			i = j + 1
+		case r == '-' || r == '+' || unicode.IsDigit(r):
+			j := i + 1
+			for j < len(runes) && (unicode.IsDigit(runes[j]) || runes[j] == '.' || runes[j] == 'e' || runes[j] == 'E' ||
+				((runes[j] == '-' || runes[j] == '+') && (runes[j-1] == 'e' || runes[j-1] == 'E'))) {
+				j++
+			}
+			tokens = append(tokens, filterToken{numberToken, string(runes[i:j]), i})
+			i = j
+		case isIdentStart(r):
+			j := i + 1
+			for j < len(runes) && isIdentPart(runes[j]) {
+				j++
+			}
+			tokens = append(tokens, filterToken{identToken, string(runes[i:j]), i})
+			i = j
+		default:
+			return nil, fmt.Errorf("unexpected character %q at offset %d", r, i)
+		}
+	}
+	return append(tokens, filterToken{eofToken, "", len(runes)}), nil
+}
+
+type filterParser struct {
+	tokens     []filterToken
+	next       int
+	fieldNames []string
+	// depth counts the NOT operators and parentheses enclosing the next
+	// token.
+	depth int
+}
+
+func (p *filterParser) peek() filterToken {
+	return p.tokens[p.next]
+}
+
+func (p *filterParser) advance() filterToken {
+	t := p.tokens[p.next]
+	if t.kind != eofToken {
+		p.next++
+	}
+	return t
+}
+
+func (p *filterParser) peekKeyword(keyword string) bool {
+	t := p.peek()
+	return t.kind == identToken && strings.EqualFold(t.text, keyword)
+}
+
+func (p *filterParser) parseExpr() (filter, error) {
+	left, err := p.parseTerm()
+	if err != nil {
+		return nil, err
+	}
+	for p.peekKeyword("OR") {
+		p.advance()
+		right, err := p.parseTerm()
+		if err != nil {
+			return nil, err
+		}
+		left = &orFilter{left, right}
+	}
+	return left, nil
+}
+
+func (p *filterParser) parseTerm() (filter, error) {
+	left, err := p.parseFactor()
+	if err != nil {
+		return nil, err
+	}
+	for p.peekKeyword("AND") {
+		p.advance()
+		right, err := p.parseFactor()
+		if err != nil {
+			return nil, err
+		}
+		left = &andFilter{left, right}
+	}
+	return left, nil
+}
+
+// enter descends into the operand of a NOT or a parenthesized expression
+// starting at t, returning a function to leave it.
+func (p *filterParser) enter(t filterToken) (func(), error) {
+	if p.depth == maxFilterDepth {
+		return nil, fmt.Errorf("expression at offset %d nested deeper than %d levels", t.pos, maxFilterDepth)
+	}
+	p.depth++
+	return func() { p.depth-- }, nil
+}
+
+func (p *filterParser) parseFactor() (filter, error) {
+	if p.peekKeyword("NOT") {
+		leave, err := p.enter(p.advance())
+		if err != nil {
+			return nil, err
+		}
+		defer leave()
+		operand, err := p.parseFactor()
+		if err != nil {
+			return nil, err
+		}
+		return &notFilter{operand}, nil
+	}
+
+	if p.peek().kind == lparenToken {
+		leave, err := p.enter(p.advance())
+		if err != nil {
+			return nil, err
+		}
+		defer leave()
+		f, err := p.parseExpr()
+		if err != nil {
+			return nil, err
+		}
+		if t := p.advance(); t.kind != rparenToken {
+			return nil, fmt.Errorf("expected \")\" at offset %d, got %s", t.pos, t)
+		}
+		return f, nil
+	}
+
+	return p.parseComparison()
+}
+
+func (p *filterParser) parseComparison() (filter, error) {
+	field, err := p.parseField()
+	if err != nil {
+		return nil, err
+	}
+
+	op := p.advance()
+	if op.kind != opToken {
+		return nil, fmt.Errorf("expected a comparison operator at offset %d, got %s", op.pos, op)
+	}
+
+	value, err := p.parseLiteral()
+	if err != nil {
+		return nil, err
+	}
+
+	return &comparisonFilter{field: field, op: op.text, value: value}, nil
+}
+
+func (p *filterParser) parseField() (filterField, error) {
+	t := p.advance()
+	if t.kind != identToken {
+		return filterField{}, fmt.Errorf("expected a field at offset %d, got %s", t.pos, t)
+	}
+
+	valid := false
+	for _, name := range p.fieldNames {
+		valid = valid || name == t.text
+	}
+	if !valid {
+		return filterField{}, fmt.Errorf("unknown field %q at offset %d; supported fields are %s", t.text, t.pos, strings.Join(p.fieldNames, ", "))
+	}
+
+	field := filterField{name: t.text}
+	if field.name != propertiesField && field.name != customPropertiesField {
+		return field, nil
+	}
+
+	if dot := p.advance(); dot.kind != dotToken {
+		return filterField{}, fmt.Errorf("expected \".\" and a property name after %q at offset %d, got %s", t.text, dot.pos, dot)
+	}
+	// Names of nested properties, e.g. training_framework.name, may be
+	// written with dots between their segments.
+	var segments []string
+	for {
+		key := p.advance()
+		if key.kind != identToken && key.kind != stringToken {
+			return filterField{}, fmt.Errorf("expected a property name at offset %d, got %s", key.pos, key)
+		}
+		segments = append(segments, key.text)
+		if p.peek().kind != dotToken {
+			break
+		}
+		p.advance()
+	}
+	field.key = strings.Join(segments, ".")
+	return field, nil
+}
+
+func (p *filterParser) parseLiteral() (*mlpb.Value, error) {
+	t := p.advance()
+	switch t.kind {
+	case stringToken:
+		return &mlpb.Value{Value: &mlpb.Value_StringValue{StringValue: t.text}}, nil
+	case numberToken:
+		if i, err := strconv.ParseInt(t.text, 10, 64); err == nil {
+			return &mlpb.Value{Value: &mlpb.Value_IntValue{IntValue: i}}, nil
+		}
+		d, err := strconv.ParseFloat(t.text, 64)
+		if err != nil {
+			return nil, fmt.Errorf("malformed number %q at offset %d", t.text, t.pos)
+		}
+		return &mlpb.Value{Value: &mlpb.Value_DoubleValue{DoubleValue: d}}, nil
+	}
+	return nil, fmt.Errorf("expected a number or a double-quoted string at offset %d, got %s", t.pos, t)
+}
@@ -0,0 +1,137 @@
+// Copyright 2019 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package service
+
+import (
+	"strings"
+	"testing"
+
+	mlpb "ml_metadata/proto/metadata_store_go_proto"
+
+	"github.com/golang/protobuf/proto"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestArtifactFilter(t *testing.T) {
+	artifact := &mlpb.Artifact{}
+	if err := proto.UnmarshalText(`
+		uri: "gs://bucket/model"
+		properties { key: "accuracy" value { double_value: 0.95 }}
+		properties { key: "epochs" value { int_value: 10 }}
+		properties { key: "framework" value { string_value: "tensorflow" }}
+		properties { key: "name" value { string_value: "my-model" }}
+		properties { key: "training_framework.name" value { string_value: "tensorflow" }}
+		custom_properties { key: "team" value { string_value: "vision" }}
+		custom_properties { key: "__kf_workspace" value { string_value: "ws1" }}`, artifact); err != nil {
+		t.Fatalf("proto.UnmarshalText failure: %v", err)
+	}
+
+	tests := []struct {
+		filter string
+		want   bool
+	}{
+		{`properties.accuracy > 0.9`, true},
+		{`properties.accuracy > 0.95`, false},
+		{`properties.accuracy >= 0.95`, true},
+		{`properties.epochs = 10`, true},
+		{`properties.epochs < 10.5`, true},
+		{`properties.epochs != 10`, false},
+		{`properties.framework = "tensorflow"`, true},
+		{`properties.framework = 1`, false},
+		{`properties.epochs = "10"`, false},
+		{`properties.missing = 1`, false},
+		{`properties.training_framework.name = "tensorflow"`, true},
+		{`properties."training_framework.name" = "tensorflow"`, true},
+		{`properties.training_framework."name" = "tensorflow"`, true},
+		{`properties.training_framework = "tensorflow"`, false},
+		{`custom_properties.team = "vision"`, true},
+		{`custom_properties."team" = "vision"`, true},
+		{`uri = "gs://bucket/model"`, true},
+		{`name = "my-model"`, true},
+		{`properties.name = "my-model"`, true},
+		{`workspace = "ws1"`, true},
+		{`workspace = "ws2"`, false},
+		{`properties.accuracy > 0.9 AND custom_properties.team = "vision"`, true},
+		{`properties.accuracy > 0.99 AND custom_properties.team = "vision"`, false},
+		{`properties.accuracy > 0.99 OR custom_properties.team = "vision"`, true},
+		{`NOT properties.accuracy > 0.99`, true},
+		{`not (properties.epochs = 10 or properties.epochs = 20)`, false},
+		{`properties.epochs = 1 OR properties.epochs = 2 AND properties.epochs = 10`, false},
+		{`(properties.epochs = 1 OR properties.epochs = 10) AND name = "my-model"`, true},
+		{`properties.accuracy > -1e3`, true},
+	}
+
+	for _, test := range tests {
+		f, err := parseArtifactFilter(test.filter)
+		if err != nil {
+			t.Errorf("parseArtifactFilter(%q) = %v\nWant nil error", test.filter, err)
+			continue
+		}
+		if got := f.matches(artifactFilterFields(artifact)); got != test.want {
+			t.Errorf("Filter %q matches artifact = %v\nWant %v", test.filter, got, test.want)
+		}
+	}
+}
+
+func TestMalformedFilters(t *testing.T) {
+	filters := []string{
+		`properties.accuracy >`,
+		`properties.accuracy 0.9`,
+		`properties > 0.9`,
+		`properties.`,
+		`properties.training_framework. = "a"`,
+		`accuracy > 0.9`,
+		`(uri = "a"`,
+		`uri = "a")`,
+		`uri = "a`,
+		`uri = a`,
+		`uri ! "a"`,
+		`uri = "a" AND`,
+		`uri = "a" uri = "b"`,
+		`properties.accuracy > 1.2.3`,
+		`uri = "a" & name = "b"`,
+	}
+
+	for _, filter := range filters {
+		if _, err := parseArtifactFilter(filter); status.Code(err) != codes.InvalidArgument {
+			t.Errorf("parseArtifactFilter(%q) = %v\nWant InvalidArgument error", filter, err)
+		}
+	}
+
+	if _, err := parseExecutionFilter(`uri = "a"`); status.Code(err) != codes.InvalidArgument {
+		t.Errorf("parseExecutionFilter(%q) = %v\nWant InvalidArgument error", `uri = "a"`, err)
+	}
+
+	deep := strings.Repeat("(", maxFilterDepth+1) + `uri = "a"` + strings.Repeat(")", maxFilterDepth+1)
+	if _, err := parseArtifactFilter(deep); status.Code(err) != codes.InvalidArgument {
+		t.Errorf("parseArtifactFilter of %d nested parentheses = %v\nWant InvalidArgument error", maxFilterDepth+1, err)
+	}
+	if _, err := parseArtifactFilter(strings.Repeat("NOT ", maxFilterDepth+1) + `uri = "a"`); status.Code(err) != codes.InvalidArgument {
+		t.Errorf("parseArtifactFilter of %d nested NOTs = %v\nWant InvalidArgument error", maxFilterDepth+1, err)
+	}
+	nested := strings.Repeat("NOT (", maxFilterDepth/2) + `uri = "a"` + strings.Repeat(")", maxFilterDepth/2)
+	if _, err := parseArtifactFilter(nested); err != nil {
+		t.Errorf("parseArtifactFilter(%q) = %v\nWant nil error", nested, err)
+	}
+	long := `uri = "` + strings.Repeat("a", maxFilterLength) + `"`
+	if _, err := parseArtifactFilter(long); status.Code(err) != codes.InvalidArgument {
+		t.Errorf("parseArtifactFilter of a %d bytes filter = %v\nWant InvalidArgument error", len(long), err)
+	}
+
+	if f, err := parseArtifactFilter("  "); f != nil || err != nil {
+		t.Errorf("parseArtifactFilter of a blank filter = %v, %v\nWant nil, nil", f, err)
+	}
+}
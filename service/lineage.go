@@ -0,0 +1,246 @@
+// Copyright 2019 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package service
+
+import (
+	"context"
+	"strconv"
+	"strings"
+
+	mlpb "ml_metadata/proto/metadata_store_go_proto"
+
+	"github.com/kubeflow/metadata/mlmd"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// maxLineageNodes is the largest number of nodes a lineage subgraph may have,
+// so that walks without max_depth are bounded.
+var maxLineageNodes = 10000
+
+// lineageNode identifies an artifact or an execution in the lineage graph.
+type lineageNode struct {
+	artifact bool
+	id       int64
+}
+
+// parseNodeName parses names of the form `artifacts/{id}`,
+// `executions/{id}` or full artifact and execution names.
+func parseNodeName(name string) (lineageNode, error) {
+	var node lineageNode
+	var idStr string
+	if tokens := artifactNameRE.FindStringSubmatch(name); len(tokens) == 2 {
+		node.artifact, idStr = true, tokens[1]
+	} else if tokens := executionNameRE.FindStringSubmatch(name); len(tokens) == 2 {
+		idStr = tokens[1]
+	} else if strings.HasPrefix(name, artifactCollection) {
+		node.artifact, idStr = true, strings.TrimPrefix(name, artifactCollection)
+	} else if strings.HasPrefix(name, executionCollection) {
+		idStr = strings.TrimPrefix(name, executionCollection)
+	} else {
+		return node, status.Errorf(codes.InvalidArgument, "malformed name %q: must name an artifact or an execution", name)
+	}
+
+	id, err := strconv.ParseInt(idStr, 10, 64)
+	if err != nil {
+		return node, status.Errorf(codes.InvalidArgument, "failed to parse id from %q: %v", name, err)
+	}
+	node.id = id
+	return node, nil
+}
+
+func isInputEvent(t mlpb.Event_Type) bool {
+	return t == mlpb.Event_INPUT || t == mlpb.Event_DECLARED_INPUT
+}
+
+func isOutputEvent(t mlpb.Event_Type) bool {
+	return t == mlpb.Event_OUTPUT || t == mlpb.Event_DECLARED_OUTPUT
+}
+
+// crosses reports whether a walk in the given direction leaving an artifact
+// (or an execution, if fromArtifact is false) follows event e. Upstream, an
+// artifact leads to the executions that output it and an execution to the
+// artifacts it takes as input; downstream is the opposite.
+func crosses(e *mlpb.Event, fromArtifact, upstream bool) bool {
+	if fromArtifact == upstream {
+		return isOutputEvent(e.GetType())
+	}
+	return isInputEvent(e.GetType())
+}
+
+type lineageEdge struct {
+	artifactID  int64
+	executionID int64
+	eventType   mlpb.Event_Type
+	timestamp   int64
+}
+
+// lineageGraph accumulates the events and nodes found by lineage walks.
+type lineageGraph struct {
+	events     []*mlpb.Event
+	edges      map[lineageEdge]bool
+	artifacts  map[int64]*mlpb.Artifact
+	executions map[int64]*mlpb.Execution
+}
+
+func newLineageGraph() *lineageGraph {
+	return &lineageGraph{
+		edges:      make(map[lineageEdge]bool),
+		artifacts:  make(map[int64]*mlpb.Artifact),
+		executions: make(map[int64]*mlpb.Execution),
+	}
+}
+
+func (g *lineageGraph) addEvent(e *mlpb.Event) {
+	edge := lineageEdge{e.GetArtifactId(), e.GetExecutionId(), e.GetType(), e.GetMillisecondsSinceEpoch()}
+	if !g.edges[edge] {
+		g.edges[edge] = true
+		g.events = append(g.events, e)
+	}
+}
+
+// addRoot adds the node a lineage walk starts from, failing if it does not
+// exist or has been deleted.
+func (s *Service) addRoot(ctx context.Context, g *lineageGraph, root lineageNode) error {
+	if root.artifact {
+		artifacts, err := s.store.GetArtifactsByID(ctx, []mlmd.ArtifactID{mlmd.ArtifactID(root.id)})
+		if err != nil {
+			return err
+		}
+		if len(artifacts) != 1 || isDeleted(artifacts[0].GetCustomProperties()) {
+			return status.Errorf(codes.NotFound, "Artifact %d not found", root.id)
+		}
+		g.artifacts[root.id] = artifacts[0]
+		return nil
+	}
+
+	executions, err := s.store.GetExecutionsByID(ctx, []mlmd.ExecutionID{mlmd.ExecutionID(root.id)})
+	if err != nil {
+		return err
+	}
+	if len(executions) != 1 || isDeleted(executions[0].GetCustomProperties()) {
+		return status.Errorf(codes.NotFound, "Execution %d not found", root.id)
+	}
+	g.executions[root.id] = executions[0]
+	return nil
+}
+
+// walkLineage adds to g the events and nodes reachable from root in the given
+// direction by following at most maxDepth events, or any number of events if
+// maxDepth is zero. Events referencing deleted nodes or nodes the caller of
+// ctx may not read are not followed. The walk fails with codes.OutOfRange once
+// g has more than maxLineageNodes nodes.
+func (s *Service) walkLineage(ctx context.Context, g *lineageGraph, root lineageNode, upstream bool, maxDepth int32) error {
+	visited := map[lineageNode]bool{root: true}
+	frontier := []lineageNode{root}
+	for depth := int32(0); len(frontier) > 0 && (maxDepth == 0 || depth < maxDepth); depth++ {
+		var artifactIDs []mlmd.ArtifactID
+		var executionIDs []mlmd.ExecutionID
+		for _, n := range frontier {
+			if n.artifact {
+				artifactIDs = append(artifactIDs, mlmd.ArtifactID(n.id))
+			} else {
+				executionIDs = append(executionIDs, mlmd.ExecutionID(n.id))
+			}
+		}
+		frontier = nil
+
+		for _, fromArtifact := range []bool{true, false} {
+			var events []*mlpb.Event
+			var err error
+			if fromArtifact && len(artifactIDs) > 0 {
+				events, err = s.store.GetEventsByArtifactIDs(ctx, artifactIDs)
+			} else if !fromArtifact && len(executionIDs) > 0 {
+				events, err = s.store.GetEventsByExecutionIDs(ctx, executionIDs)
+			}
+			if err != nil && !noRecordFound(err) {
+				return err
+			}
+
+			var followed []*mlpb.Event
+			for _, e := range events {
+				if crosses(e, fromArtifact, upstream) {
+					followed = append(followed, e)
+				}
+			}
+			followed, artifacts, executions, err := s.getLiveEventsAndNodes(ctx, followed)
+			if err != nil {
+				return err
+			}
+			if followed, err = s.readableGraph(ctx, followed, artifacts, executions); err != nil {
+				return err
+			}
+
+			for _, e := range followed {
+				g.addEvent(e)
+				next := lineageNode{artifact: true, id: e.GetArtifactId()}
+				if fromArtifact {
+					next = lineageNode{artifact: false, id: e.GetExecutionId()}
+				}
+				if visited[next] {
+					continue
+				}
+				visited[next] = true
+				frontier = append(frontier, next)
+				if next.artifact {
+					g.artifacts[next.id] = artifacts[next.id]
+				} else {
+					g.executions[next.id] = executions[next.id]
+				}
+			}
+			if len(g.artifacts)+len(g.executions) > maxLineageNodes {
+				return status.Errorf(codes.OutOfRange, "lineage has more than %d nodes: set a lower max_depth", maxLineageNodes)
+			}
+		}
+	}
+	return nil
+}
+
+// lineage returns the lineage subgraph around the node with the given name,
+// which the caller must be allowed to read. The subgraph only holds the nodes
+// the caller may read, and those reached through them.
+func (s *Service) lineage(ctx context.Context, name string, upstream, downstream bool, maxDepth int32) (*lineageGraph, error) {
+	if maxDepth < 0 {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid max_depth %d: must not be negative", maxDepth)
+	}
+	root, err := parseNodeName(name)
+	if err != nil {
+		return nil, err
+	}
+
+	g := newLineageGraph()
+	if err := s.addRoot(ctx, g, root); err != nil {
+		return nil, err
+	}
+	if root.artifact {
+		err = s.authorizeArtifacts(ctx, ReadAccess, g.artifacts[root.id])
+	} else {
+		err = s.authorizeExecutions(ctx, ReadAccess, g.executions[root.id])
+	}
+	if err != nil {
+		return nil, err
+	}
+	if upstream {
+		if err := s.walkLineage(ctx, g, root, true, maxDepth); err != nil {
+			return nil, wrapError(err, "failed to walk upstream lineage of %q", name)
+		}
+	}
+	if downstream {
+		if err := s.walkLineage(ctx, g, root, false, maxDepth); err != nil {
+			return nil, wrapError(err, "failed to walk downstream lineage of %q", name)
+		}
+	}
+	return g, nil
+}
@@ -15,36 +15,70 @@
 package service
 
 import (
-	"ml_metadata/metadata_store/mlmetadata"
+	"context"
+
 	mlpb "ml_metadata/proto/metadata_store_go_proto"
+
+	"github.com/kubeflow/metadata/mlmd"
 )
 
 // MetadataStore defines the interface of methods exported by mlmetadata.Store.
+// It is implemented by mlmd/mlmdstore on top of MLMD, and by mlmd/sqlstore in
+// pure Go. Every method but Close and PagedLists takes the context of the
+// request it serves, and fails with the error of the context once it is done.
+// Cancelation only takes effect between store calls: MLMD checks the context
+// before each call, and SQLite queries run to completion once started, so a
+// request past its deadline still waits for its current call to return.
+// Stores that cannot remove records, like MLMD, fail the Delete methods with
+// codes.Unimplemented, in which case the service marks the records deleted
+// instead.
 type MetadataStore interface {
 	Close()
+	// PagedLists reports whether ListArtifacts and ListExecutions read only
+	// the requested page. Stores without paged queries, like MLMD, read all
+	// the records of a type for each page, so they are better read at once.
+	PagedLists() bool
+	// InTransaction calls f with a context whose store calls run in a single
+	// transaction, committed if f succeeds and rolled back otherwise. Stores
+	// without transactions across calls, like MLMD, call f with ctx, keeping
+	// the writes of f if it fails.
+	InTransaction(ctx context.Context, f func(ctx context.Context) error) error
+
+	PutArtifactType(ctx context.Context, atype *mlpb.ArtifactType, opts *mlmd.PutTypeOptions) (mlmd.ArtifactTypeID, error)
+	GetArtifactType(ctx context.Context, name string) (*mlpb.ArtifactType, error)
+	GetArtifactTypesByID(ctx context.Context, tids []mlmd.ArtifactTypeID) ([]*mlpb.ArtifactType, error)
+	GetArtifactTypes(ctx context.Context) ([]*mlpb.ArtifactType, error)
+	// DeleteArtifactType removes the artifact type name along with its
+	// artifacts and their events.
+	DeleteArtifactType(ctx context.Context, name string) error
+
+	PutArtifacts(ctx context.Context, artifacts []*mlpb.Artifact) ([]mlmd.ArtifactID, error)
+	GetArtifactsByID(ctx context.Context, aids []mlmd.ArtifactID) ([]*mlpb.Artifact, error)
+	GetArtifacts(ctx context.Context) ([]*mlpb.Artifact, error)
+	GetArtifactsByType(ctx context.Context, typeName string) ([]*mlpb.Artifact, error)
+	GetArtifactsByURI(ctx context.Context, uri string) ([]*mlpb.Artifact, error)
+	ListArtifacts(ctx context.Context, typeName string, opts *mlmd.ListOptions) ([]*mlpb.Artifact, error)
+	// DeleteArtifacts removes the artifacts of aids along with their events.
+	DeleteArtifacts(ctx context.Context, aids []mlmd.ArtifactID) error
+
+	PutExecutionType(ctx context.Context, etype *mlpb.ExecutionType, opts *mlmd.PutTypeOptions) (mlmd.ExecutionTypeID, error)
+	GetExecutionType(ctx context.Context, typeName string) (*mlpb.ExecutionType, error)
+	GetExecutionTypesByID(ctx context.Context, tids []mlmd.ExecutionTypeID) ([]*mlpb.ExecutionType, error)
+	GetExecutionTypes(ctx context.Context) ([]*mlpb.ExecutionType, error)
+	// DeleteExecutionType removes the execution type name along with its
+	// executions and their events.
+	DeleteExecutionType(ctx context.Context, name string) error
+
+	PutExecutions(ctx context.Context, executions []*mlpb.Execution) ([]mlmd.ExecutionID, error)
+	GetExecutionsByID(ctx context.Context, eids []mlmd.ExecutionID) ([]*mlpb.Execution, error)
+	GetExecutions(ctx context.Context) ([]*mlpb.Execution, error)
+	GetExecutionsByType(ctx context.Context, typeName string) ([]*mlpb.Execution, error)
+	ListExecutions(ctx context.Context, typeName string, opts *mlmd.ListOptions) ([]*mlpb.Execution, error)
+	// DeleteExecutions removes the executions of eids along with their
+	// events.
+	DeleteExecutions(ctx context.Context, eids []mlmd.ExecutionID) error
 
-	PutArtifactType(atype *mlpb.ArtifactType, opts *mlmetadata.PutTypeOptions) (mlmetadata.ArtifactTypeID, error)
-	GetArtifactType(name string) (*mlpb.ArtifactType, error)
-	GetArtifactTypesByID(tids []mlmetadata.ArtifactTypeID) ([]*mlpb.ArtifactType, error)
-	GetArtifactTypes() ([]*mlpb.ArtifactType, error)
-
-	PutArtifacts(artifacts []*mlpb.Artifact) ([]mlmetadata.ArtifactID, error)
-	GetArtifactsByID(aids []mlmetadata.ArtifactID) ([]*mlpb.Artifact, error)
-	GetArtifacts() ([]*mlpb.Artifact, error)
-	GetArtifactsByType(typeName string) ([]*mlpb.Artifact, error)
-	GetArtifactsByURI(uri string) ([]*mlpb.Artifact, error)
-
-	PutExecutionType(etype *mlpb.ExecutionType, opts *mlmetadata.PutTypeOptions) (mlmetadata.ExecutionTypeID, error)
-	GetExecutionType(typeName string) (*mlpb.ExecutionType, error)
-	GetExecutionTypesByID(tids []mlmetadata.ExecutionTypeID) ([]*mlpb.ExecutionType, error)
-	GetExecutionTypes() ([]*mlpb.ExecutionType, error)
-
-	PutExecutions(executions []*mlpb.Execution) ([]mlmetadata.ExecutionID, error)
-	GetExecutionsByID(eids []mlmetadata.ExecutionID) ([]*mlpb.Execution, error)
-	GetExecutions() ([]*mlpb.Execution, error)
-	GetExecutionsByType(typeName string) ([]*mlpb.Execution, error)
-
-	PutEvents(events []*mlpb.Event) error
-	GetEventsByArtifactIDs([]mlmetadata.ArtifactID) ([]*mlpb.Event, error)
-	GetEventsByExecutionIDs([]mlmetadata.ExecutionID) ([]*mlpb.Event, error)
+	PutEvents(ctx context.Context, events []*mlpb.Event) error
+	GetEventsByArtifactIDs(ctx context.Context, aids []mlmd.ArtifactID) ([]*mlpb.Event, error)
+	GetEventsByExecutionIDs(ctx context.Context, eids []mlmd.ExecutionID) ([]*mlpb.Event, error)
 }
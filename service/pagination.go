@@ -0,0 +1,148 @@
+// Copyright 2019 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package service
+
+import (
+	"context"
+	"encoding/base64"
+	"strconv"
+	"strings"
+
+	mlpb "ml_metadata/proto/metadata_store_go_proto"
+
+	"github.com/kubeflow/metadata/mlmd"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	pageTokenPrefix = "after:"
+
+	// defaultPageSize is the size of the pages of list calls without page
+	// size, and maxPageSize that of the largest ones.
+	defaultPageSize = 100
+	maxPageSize     = 1000
+
+	// minBatchSize is the least number of records list calls read once
+	// records left out of a page made them read more.
+	minBatchSize = 1000
+)
+
+// nextBatchSize returns the number of records to read after a batch of limit
+// records that did not fill a page. Batches grow, since stores without paged
+// queries, like MLMD, read all the records of a type for each of them.
+func nextBatchSize(limit int) int {
+	if limit < minBatchSize {
+		return minBatchSize
+	}
+	return 2 * limit
+}
+
+// encodePageToken returns an opaque token for the page that starts right after
+// the item with the given id. Since ids are assigned in increasing order,
+// tokens remain valid when new items are inserted.
+func encodePageToken(lastID int64) string {
+	return base64.RawURLEncoding.EncodeToString([]byte(pageTokenPrefix + strconv.FormatInt(lastID, 10)))
+}
+
+// decodePageToken returns the id encoded by encodePageToken. An empty token
+// decodes to 0, which precedes every id.
+func decodePageToken(token string) (int64, error) {
+	if token == "" {
+		return 0, nil
+	}
+	b, err := base64.RawURLEncoding.DecodeString(token)
+	if err != nil || !strings.HasPrefix(string(b), pageTokenPrefix) {
+		return 0, status.Errorf(codes.InvalidArgument, "invalid page token %q", token)
+	}
+	id, err := strconv.ParseInt(strings.TrimPrefix(string(b), pageTokenPrefix), 10, 64)
+	if err != nil {
+		return 0, status.Errorf(codes.InvalidArgument, "invalid page token %q", token)
+	}
+	return id, nil
+}
+
+// pageOptions returns the size of the page of pageSize records following
+// pageToken, defaulting to defaultPageSize and capped at maxPageSize, and the
+// id of the record it follows. List calls read at least one record more than
+// the page, so that the page ends with a token only if a record follows it.
+func pageOptions(pageSize int32, pageToken string) (size int, afterID int64, err error) {
+	if pageSize < 0 {
+		return 0, 0, status.Errorf(codes.InvalidArgument, "invalid page size %d: must not be negative", pageSize)
+	}
+	size = int(pageSize)
+	switch {
+	case size == 0:
+		size = defaultPageSize
+	case size > maxPageSize:
+		size = maxPageSize
+	}
+	afterID, err = decodePageToken(pageToken)
+	if err != nil {
+		return 0, 0, err
+	}
+	return size, afterID, nil
+}
+
+// forEachArtifactPage calls f with the artifacts of every type, a page of
+// maxPageSize at a time to bound the memory of large stores. Stores without
+// paged lists are read at once, as they read every artifact for each page.
+func (s *Service) forEachArtifactPage(ctx context.Context, f func(artifacts []*mlpb.Artifact) error) error {
+	if !s.store.PagedLists() {
+		artifacts, err := s.store.GetArtifacts(ctx)
+		if err != nil && !noRecordFound(err) {
+			return err
+		}
+		return f(artifacts)
+	}
+	for afterID := int64(0); ; {
+		artifacts, err := s.store.ListArtifacts(ctx, "", &mlmd.ListOptions{AfterID: afterID, Limit: maxPageSize})
+		if err != nil {
+			return err
+		}
+		if err := f(artifacts); err != nil {
+			return err
+		}
+		if len(artifacts) < maxPageSize {
+			return nil
+		}
+		afterID = artifacts[len(artifacts)-1].GetId()
+	}
+}
+
+// forEachExecutionPage calls f with the executions of every type. See
+// forEachArtifactPage.
+func (s *Service) forEachExecutionPage(ctx context.Context, f func(executions []*mlpb.Execution) error) error {
+	if !s.store.PagedLists() {
+		executions, err := s.store.GetExecutions(ctx)
+		if err != nil && !noRecordFound(err) {
+			return err
+		}
+		return f(executions)
+	}
+	for afterID := int64(0); ; {
+		executions, err := s.store.ListExecutions(ctx, "", &mlmd.ListOptions{AfterID: afterID, Limit: maxPageSize})
+		if err != nil {
+			return err
+		}
+		if err := f(executions); err != nil {
+			return err
+		}
+		if len(executions) < maxPageSize {
+			return nil
+		}
+		afterID = executions[len(executions)-1].GetId()
+	}
+}
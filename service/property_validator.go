@@ -0,0 +1,271 @@
+// Copyright 2019 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package service
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+	"unicode/utf8"
+
+	mlpb "ml_metadata/proto/metadata_store_go_proto"
+
+	"github.com/kubeflow/metadata/api"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// typeValidators holds the validators of the properties of a type.
+type typeValidators struct {
+	validators  map[string]*api.PropertyValidator
+	constraints map[string]*propertyConstraints
+}
+
+// propertyConstraints are the OpenAPI keywords of the validator of a property.
+type propertyConstraints struct {
+	Enum             []interface{} `json:"enum"`
+	Pattern          string        `json:"pattern"`
+	MinLength        *int          `json:"minLength"`
+	MaxLength        *int          `json:"maxLength"`
+	Minimum          *float64      `json:"minimum"`
+	Maximum          *float64      `json:"maximum"`
+	ExclusiveMinimum bool          `json:"exclusiveMinimum"`
+	ExclusiveMaximum bool          `json:"exclusiveMaximum"`
+
+	propertyType mlpb.PropertyType
+	pattern      *regexp.Regexp
+}
+
+// SetArtifactPropertyValidators sets the validators of the properties of the
+// ArtifactType named typeName, replacing previous ones. Validators are not
+// stored: those of types defined by schemas are set again when the schemas
+// are registered on restart.
+func (s *Service) SetArtifactPropertyValidators(typeName string, validators map[string]*api.PropertyValidator) error {
+	tv, err := newTypeValidators(validators)
+	if err != nil {
+		return err
+	}
+	s.validatorsMu.Lock()
+	defer s.validatorsMu.Unlock()
+	if s.artifactPropertyValidators == nil {
+		s.artifactPropertyValidators = make(map[string]*typeValidators)
+	}
+	s.artifactPropertyValidators[typeName] = tv
+	return nil
+}
+
+// SetExecutionPropertyValidators sets the validators of the properties of the
+// ExecutionType named typeName. See SetArtifactPropertyValidators.
+func (s *Service) SetExecutionPropertyValidators(typeName string, validators map[string]*api.PropertyValidator) error {
+	tv, err := newTypeValidators(validators)
+	if err != nil {
+		return err
+	}
+	s.validatorsMu.Lock()
+	defer s.validatorsMu.Unlock()
+	if s.executionPropertyValidators == nil {
+		s.executionPropertyValidators = make(map[string]*typeValidators)
+	}
+	s.executionPropertyValidators[typeName] = tv
+	return nil
+}
+
+func (s *Service) artifactValidators(typeName string) *typeValidators {
+	s.validatorsMu.RLock()
+	defer s.validatorsMu.RUnlock()
+	return s.artifactPropertyValidators[typeName]
+}
+
+func (s *Service) executionValidators(typeName string) *typeValidators {
+	s.validatorsMu.RLock()
+	defer s.validatorsMu.RUnlock()
+	return s.executionPropertyValidators[typeName]
+}
+
+func newTypeValidators(validators map[string]*api.PropertyValidator) (*typeValidators, error) {
+	tv := &typeValidators{
+		validators:  validators,
+		constraints: make(map[string]*propertyConstraints),
+	}
+	for name, v := range validators {
+		c, err := parsePropertyValidator(v)
+		if err != nil {
+			return nil, status.Errorf(codes.InvalidArgument, "invalid validator of property %q: %v", name, err)
+		}
+		if c != nil {
+			tv.constraints[name] = c
+		}
+	}
+	return tv, nil
+}
+
+// parsePropertyValidator returns the constraints of v, or nil if it has none.
+func parsePropertyValidator(v *api.PropertyValidator) (*propertyConstraints, error) {
+	var validator string
+	c := &propertyConstraints{}
+	switch t := v.GetType().(type) {
+	case *api.PropertyValidator_StringType:
+		validator, c.propertyType = t.StringType.GetValidator(), mlpb.PropertyType_STRING
+	case *api.PropertyValidator_IntType:
+		validator, c.propertyType = t.IntType.GetValidator(), mlpb.PropertyType_INT
+	case *api.PropertyValidator_DoubleType:
+		validator, c.propertyType = t.DoubleType.GetValidator(), mlpb.PropertyType_DOUBLE
+	default:
+		return nil, fmt.Errorf("unspecified type")
+	}
+	if validator == "" {
+		return nil, nil
+	}
+
+	d := json.NewDecoder(strings.NewReader(validator))
+	d.DisallowUnknownFields()
+	d.UseNumber()
+	if err := d.Decode(c); err != nil {
+		return nil, fmt.Errorf("malformed validator %s: %v", validator, err)
+	}
+	if c.propertyType == mlpb.PropertyType_STRING {
+		if c.Minimum != nil || c.Maximum != nil {
+			return nil, fmt.Errorf("minimum and maximum only apply to numbers")
+		}
+		for _, e := range c.Enum {
+			if _, ok := e.(string); !ok {
+				return nil, fmt.Errorf("enum value %v is not a string", e)
+			}
+		}
+		if c.Pattern != "" {
+			pattern, err := regexp.Compile(c.Pattern)
+			if err != nil {
+				return nil, fmt.Errorf("invalid pattern: patterns must be RE2 syntax: %v", err)
+			}
+			c.pattern = pattern
+		}
+		return c, nil
+	}
+
+	if c.Pattern != "" || c.MinLength != nil || c.MaxLength != nil {
+		return nil, fmt.Errorf("pattern, minLength and maxLength only apply to strings")
+	}
+	for _, e := range c.Enum {
+		n, ok := e.(json.Number)
+		if !ok {
+			return nil, fmt.Errorf("enum value %v is not a number", e)
+		}
+		if _, err := n.Int64(); err != nil && c.propertyType == mlpb.PropertyType_INT {
+			return nil, fmt.Errorf("enum value %v is not an integer", e)
+		}
+	}
+	return c, nil
+}
+
+// violation returns how v violates c, or an empty string. Values of another
+// type than the property are left to MLMD to reject.
+func (c *propertyConstraints) violation(v *mlpb.Value) string {
+	switch value := v.GetValue().(type) {
+	case *mlpb.Value_StringValue:
+		if c.propertyType == mlpb.PropertyType_STRING {
+			return c.stringViolation(value.StringValue)
+		}
+	case *mlpb.Value_IntValue:
+		if c.propertyType == mlpb.PropertyType_INT {
+			return c.numberViolation(float64(value.IntValue), func(e json.Number) bool {
+				n, err := e.Int64()
+				return err == nil && n == value.IntValue
+			})
+		}
+	case *mlpb.Value_DoubleValue:
+		if c.propertyType == mlpb.PropertyType_DOUBLE {
+			return c.numberViolation(value.DoubleValue, func(e json.Number) bool {
+				n, err := e.Float64()
+				return err == nil && n == value.DoubleValue
+			})
+		}
+	}
+	return ""
+}
+
+func (c *propertyConstraints) stringViolation(s string) string {
+	if len(c.Enum) > 0 && !c.inEnum(func(e interface{}) bool { return e.(string) == s }) {
+		return fmt.Sprintf("%q is not one of %s", s, enumString(c.Enum))
+	}
+	if c.pattern != nil && !c.pattern.MatchString(s) {
+		return fmt.Sprintf("%q does not match pattern %q", s, c.Pattern)
+	}
+	length := utf8.RuneCountInString(s)
+	if c.MinLength != nil && length < *c.MinLength {
+		return fmt.Sprintf("%q is shorter than %d characters", s, *c.MinLength)
+	}
+	if c.MaxLength != nil && length > *c.MaxLength {
+		return fmt.Sprintf("%q is longer than %d characters", s, *c.MaxLength)
+	}
+	return ""
+}
+
+// numberViolation returns how n violates c, where equals tells whether an
+// enum value equals n.
+func (c *propertyConstraints) numberViolation(n float64, equals func(json.Number) bool) string {
+	if len(c.Enum) > 0 && !c.inEnum(func(e interface{}) bool { return equals(e.(json.Number)) }) {
+		return fmt.Sprintf("%v is not one of %s", n, enumString(c.Enum))
+	}
+	switch {
+	case c.Minimum != nil && c.ExclusiveMinimum && n <= *c.Minimum:
+		return fmt.Sprintf("%v is not greater than %v", n, *c.Minimum)
+	case c.Minimum != nil && n < *c.Minimum:
+		return fmt.Sprintf("%v is less than %v", n, *c.Minimum)
+	case c.Maximum != nil && c.ExclusiveMaximum && n >= *c.Maximum:
+		return fmt.Sprintf("%v is not less than %v", n, *c.Maximum)
+	case c.Maximum != nil && n > *c.Maximum:
+		return fmt.Sprintf("%v is greater than %v", n, *c.Maximum)
+	}
+	return ""
+}
+
+func (c *propertyConstraints) inEnum(equals func(interface{}) bool) bool {
+	for _, e := range c.Enum {
+		if equals(e) {
+			return true
+		}
+	}
+	return false
+}
+
+func enumString(enum []interface{}) string {
+	var b bytes.Buffer
+	json.NewEncoder(&b).Encode(enum)
+	return strings.TrimSpace(b.String())
+}
+
+// checkProperties returns an InvalidArgument error listing the properties
+// violating their validator in tv, which may be nil.
+func checkProperties(tv *typeValidators, kind, typeName string, properties map[string]*mlpb.Value) error {
+	if tv == nil {
+		return nil
+	}
+	var violations []string
+	for name, v := range properties {
+		if c, ok := tv.constraints[name]; ok {
+			if violation := c.violation(v); violation != "" {
+				violations = append(violations, fmt.Sprintf("property %q: %s", name, violation))
+			}
+		}
+	}
+	if len(violations) == 0 {
+		return nil
+	}
+	sort.Strings(violations)
+	return status.Errorf(codes.InvalidArgument, "%s does not match the property validators of %q: %s", kind, typeName, strings.Join(violations, "; "))
+}
@@ -0,0 +1,191 @@
+// Copyright 2019 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package service
+
+import (
+	"context"
+	"sort"
+	"strings"
+
+	mlpb "ml_metadata/proto/metadata_store_go_proto"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/kubeflow/metadata/api"
+	"github.com/kubeflow/metadata/mlmd"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	// kfSchemaType is the reserved ArtifactType of the artifacts recording the
+	// schemas registered through RegisterSchema, so that they survive
+	// restarts. These artifacts are marked kfInternal.
+	kfSchemaType = "__kf_schemas"
+	// kfJSONSchema is the property of kfSchemaType holding the JSON schema.
+	kfJSONSchema = "json_schema"
+
+	schemasCollection = "schemas/"
+)
+
+// SchemaRegistry holds the JSON schemas defining types.
+type SchemaRegistry interface {
+	// RegisterSchema adds a JSON schema and creates the type it defines, if
+	// any, with ctx. It then calls save, if not nil, and leaves the schema
+	// out if save fails, though the type it created may remain. It fails
+	// with AlreadyExists if a schema with the same $id exists.
+	RegisterSchema(ctx context.Context, jsonSchema string, save func() error) (*api.Schema, error)
+	// Schemas returns all the registered schemas.
+	Schemas() []*api.Schema
+}
+
+// SetSchemaRegistry sets the registry backing the schema RPCs. It must be
+// called before the service starts serving.
+func (s *Service) SetSchemaRegistry(r SchemaRegistry) {
+	s.schemaRegistry = r
+}
+
+// schemaOfType returns the id of the registered schema defining the type
+// typeName, or "" if there is none.
+func (s *Service) schemaOfType(typeName string) string {
+	if s.schemaRegistry == nil {
+		return ""
+	}
+	for _, schema := range s.schemaRegistry.Schemas() {
+		if schema.GetName() == schemasCollection+typeName {
+			return schema.GetId()
+		}
+	}
+	return ""
+}
+
+func (s *Service) checkSchemaRegistry() error {
+	if s.schemaRegistry == nil {
+		return status.Error(codes.Unimplemented, "no schema registry is configured")
+	}
+	return nil
+}
+
+// StoredSchemas returns the JSON schemas registered through RegisterSchema, in
+// the order they were registered, so that each schema comes after the
+// schemas it refers to.
+func (s *Service) StoredSchemas(ctx context.Context) ([]string, error) {
+	if _, err := s.store.GetArtifactType(ctx, kfSchemaType); err != nil {
+		// No schema has been registered yet.
+		return nil, nil
+	}
+	artifacts, err := s.store.GetArtifactsByType(ctx, kfSchemaType)
+	if err != nil {
+		if noRecordFound(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	sort.Slice(artifacts, func(i, j int) bool { return artifacts[i].GetId() < artifacts[j].GetId() })
+
+	var schemas []string
+	for _, artifact := range artifacts {
+		schemas = append(schemas, artifact.GetProperties()[kfJSONSchema].GetStringValue())
+	}
+	return schemas, nil
+}
+
+// storeSchema records jsonSchema so that it is registered again on restart.
+func (s *Service) storeSchema(ctx context.Context, jsonSchema string) error {
+	schemaType := &mlpb.ArtifactType{
+		Name:       proto.String(kfSchemaType),
+		Properties: map[string]mlpb.PropertyType{kfJSONSchema: mlpb.PropertyType_STRING},
+	}
+	typeID, err := s.store.PutArtifactType(ctx, schemaType, &mlmd.PutTypeOptions{AllFieldsMustMatch: true})
+	if err != nil {
+		return err
+	}
+
+	artifact := &mlpb.Artifact{
+		TypeId: proto.Int64(int64(typeID)),
+		Properties: map[string]*mlpb.Value{
+			kfJSONSchema: &mlpb.Value{Value: &mlpb.Value_StringValue{StringValue: jsonSchema}},
+		},
+		CustomProperties: map[string]*mlpb.Value{kfInternal: nowValue()},
+	}
+	_, err = s.store.PutArtifacts(ctx, []*mlpb.Artifact{artifact})
+	return err
+}
+
+// RegisterSchema registers a JSON schema and creates the type it defines. The
+// schema is persisted and registered again when the server restarts. It needs
+// admin access to all type namespaces.
+func (s *Service) RegisterSchema(ctx context.Context, req *api.RegisterSchemaRequest) (*api.RegisterSchemaResponse, error) {
+	if err := s.checkSchemaRegistry(); err != nil {
+		return nil, err
+	}
+	if req.GetJsonSchema() == "" {
+		return nil, status.Error(codes.InvalidArgument, "unspecified json_schema")
+	}
+	// The namespace of the type a schema defines is only known once it is
+	// registered.
+	if err := s.authorizeNamespace(ctx, AdminAccess, ""); err != nil {
+		return nil, err
+	}
+
+	// The schema is persisted before it is added to the schemas validating
+	// artifacts, so that none is validated against a schema lost on restart.
+	save := func() error { return s.storeSchema(ctx, req.GetJsonSchema()) }
+	schema, err := s.schemaRegistry.RegisterSchema(ctx, req.GetJsonSchema(), save)
+	if err != nil {
+		return nil, err
+	}
+
+	return &api.RegisterSchemaResponse{Schema: schema}, nil
+}
+
+// GetSchema returns the schema defining the requested type.
+func (s *Service) GetSchema(ctx context.Context, req *api.GetSchemaRequest) (*api.GetSchemaResponse, error) {
+	if err := s.checkSchemaRegistry(); err != nil {
+		return nil, err
+	}
+	if !strings.HasPrefix(req.GetName(), schemasCollection) {
+		return nil, status.Errorf(codes.InvalidArgument, "malformed Schema name %q: must be of the form `schemas/{namespace}/{typename}`", req.GetName())
+	}
+
+	for _, schema := range s.schemaRegistry.Schemas() {
+		if schema.GetName() == req.GetName() {
+			if err := s.authorizeType(ctx, ReadAccess, strings.TrimPrefix(schema.GetName(), schemasCollection)); err != nil {
+				return nil, err
+			}
+			return &api.GetSchemaResponse{Schema: schema}, nil
+		}
+	}
+	return nil, status.Errorf(codes.NotFound, "Schema %q not found", req.GetName())
+}
+
+// ListSchemas lists all registered schemas, including the predefined ones,
+// ordered by $id.
+func (s *Service) ListSchemas(ctx context.Context, req *api.ListSchemasRequest) (*api.ListSchemasResponse, error) {
+	if err := s.checkSchemaRegistry(); err != nil {
+		return nil, err
+	}
+
+	var schemas []*api.Schema
+	for _, schema := range s.schemaRegistry.Schemas() {
+		if err := s.authorizeType(ctx, ReadAccess, strings.TrimPrefix(schema.GetName(), schemasCollection)); isDenied(err) {
+			continue
+		} else if err != nil {
+			return nil, err
+		}
+		schemas = append(schemas, schema)
+	}
+	sort.Slice(schemas, func(i, j int) bool { return schemas[i].GetId() < schemas[j].GetId() })
+	return &api.ListSchemasResponse{Schemas: schemas}, nil
+}
@@ -18,31 +18,44 @@ package service
 
 import (
 	"context"
-	"errors"
-	"fmt"
 	"regexp"
 	"strconv"
 	"strings"
+	"sync"
 	"time"
 
-	"ml_metadata/metadata_store/mlmetadata"
 	mlpb "ml_metadata/proto/metadata_store_go_proto"
 
+	"github.com/golang/glog"
 	"github.com/golang/protobuf/proto"
 	"github.com/golang/protobuf/ptypes/empty"
 	"github.com/kubeflow/metadata/api"
+	"github.com/kubeflow/metadata/mlmd"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 )
 
 // Service implements the gRPC service MetadataService defined in the metadata
 // API spec.
 type Service struct {
 	store MetadataStore
+
+	artifactValidator ArtifactValidator
+	validationMode    ValidationMode
+	schemaRegistry    SchemaRegistry
+	authorizer        Authorizer
+
+	// validatorsMu guards the property validators of types, which are set
+	// while the service serves when schemas are registered or reloaded.
+	validatorsMu                sync.RWMutex
+	artifactPropertyValidators  map[string]*typeValidators
+	executionPropertyValidators map[string]*typeValidators
 }
 
-// New returns a new instance of Service.
-// TODO(neuromage): All the handlers work synchronously. If DB lookups result in
-// problems later down the road, consider plumbing context through to the
-// underlying MLMD store and handling timeouts and cancelation gracefully.
+// New returns a new instance of Service. Handlers pass the context of their
+// request to every store call, so that calls stop once the client gives up or
+// its deadline passes. ErrorInterceptor reports such requests with the
+// matching gRPC code.
 func New(store MetadataStore) *Service {
 	return &Service{store: store}
 }
@@ -66,13 +79,31 @@ const (
 	kfArtifactName  = "__kf_artifact_name"
 	kfExecutionName = "__kf_execution_name"
 
+	// kfDeleted marks deleted entities in stores that cannot remove records,
+	// like MLMD. Artifacts and executions carry it as an INT custom property
+	// holding the deletion time in seconds since epoch. Types carry it as an
+	// INT property in their schema.
+	kfDeleted = "__kf_deleted"
+	// kfPending marks artifacts and executions written by a RecordExecution
+	// call that has not completed yet. Like deleted ones, they are hidden.
+	kfPending = "__kf_pending"
+	// kfInternal marks artifacts the service stores for its own bookkeeping,
+	// e.g. registered schemas. They are hidden as well.
+	kfInternal = "__kf_internal"
+
 	kfDefaultNamespace = "types.kubeflow.org/default"
 	kfDefaultWorkspace = "__kf_default_workspace"
 
+	// kfWorkspaceTypePrefix prefixes the names of the reserved ArtifactTypes
+	// that record workspaces, since MLMD has no notion of them. The default
+	// workspace always exists and is not recorded.
+	kfWorkspaceTypePrefix = "__kf_workspaces/"
+
 	artifactTypesCollection  = "artifact_types/"
 	artifactCollection       = "artifacts/"
 	executionTypesCollection = "execution_types/"
 	executionCollection      = "executions/"
+	workspacesCollection     = "workspaces/"
 )
 
 var (
@@ -81,6 +112,7 @@ var (
 	validNamespaceRE = regexp.MustCompile(`^[A-Za-z][^ ]*[^/]$`)
 	artifactNameRE   = regexp.MustCompile(`artifact_types/.*/artifacts/([0-9]+)`)
 	executionNameRE  = regexp.MustCompile(`execution_types/.*/executions/([0-9]+)`)
+	validWorkspaceRE = regexp.MustCompile(`^[A-Za-z][^ /]*$`)
 )
 
 func validTypeName(n string) error {
@@ -88,7 +120,7 @@ func validTypeName(n string) error {
 		return nil
 	}
 
-	return fmt.Errorf("invalid type name %q: type names must begin with an alphabet and not contain spaces or slashes", n)
+	return status.Errorf(codes.InvalidArgument, "invalid type name %q: type names must begin with an alphabet and not contain spaces or slashes", n)
 }
 
 func validNamespace(n string) error {
@@ -96,7 +128,24 @@ func validNamespace(n string) error {
 		return nil
 	}
 
-	return fmt.Errorf("invalid namespace %q: namespaces must begin with an alphabet, should not contain spaces, or end with a trailing /", n)
+	return status.Errorf(codes.InvalidArgument, "invalid namespace %q: namespaces must begin with an alphabet, should not contain spaces, or end with a trailing /", n)
+}
+
+func validWorkspaceName(n string) error {
+	if validWorkspaceRE.MatchString(n) {
+		return nil
+	}
+
+	return status.Errorf(codes.InvalidArgument, "invalid workspace name %q: workspace names must begin with an alphabet and not contain spaces or slashes", n)
+}
+
+// checkNotReservedTypeName returns an error if n is reserved for types
+// managed by the service itself.
+func checkNotReservedTypeName(n string) error {
+	if strings.HasPrefix(n, kfReservedPrefix) {
+		return status.Errorf(codes.InvalidArgument, "type name %q is reserved: names must not start with %q", n, kfReservedPrefix)
+	}
+	return nil
 }
 
 // getNamespacedName checks that n is a valid name of the type
@@ -107,12 +156,12 @@ func validNamespace(n string) error {
 func getNamespacedName(n string) (string, error) {
 	ns := strings.Split(n, "/")
 	if len(ns) == 0 {
-		return "", fmt.Errorf("malformed type name: %q", n)
+		return "", status.Errorf(codes.InvalidArgument, "malformed type name: %q", n)
 	}
 
 	name := ns[len(ns)-1]
 	if len(name) == 0 {
-		return "", fmt.Errorf("empty type name: %q", n)
+		return "", status.Errorf(codes.InvalidArgument, "empty type name: %q", n)
 	}
 
 	namespace := kfDefaultNamespace
@@ -131,24 +180,103 @@ func getNamespacedName(n string) (string, error) {
 	return namespace + "/" + name, nil
 }
 
-func (s *Service) getArtifactType(name string) (*mlpb.ArtifactType, error) {
+// noRecordFound returns true if err is the error stores return when a query
+// matches nothing.
+func noRecordFound(err error) bool {
+	return err == mlmd.ErrNoRecord
+}
+
+// isDeleted returns true for deleted records, for records whose
+// RecordExecution call has not completed and for internal records, which are
+// hidden alike.
+func isDeleted(customProperties map[string]*mlpb.Value) bool {
+	_, deleted := customProperties[kfDeleted]
+	_, pending := customProperties[kfPending]
+	_, internal := customProperties[kfInternal]
+	return deleted || pending || internal
+}
+
+func isDeletedType(properties map[string]mlpb.PropertyType) bool {
+	_, ok := properties[kfDeleted]
+	return ok
+}
+
+// deletionUnsupported reports whether err is the error of a store that cannot
+// remove records, in which case they are marked deleted instead.
+func deletionUnsupported(err error) bool {
+	return status.Code(err) == codes.Unimplemented
+}
+
+// checkNotReservedCustomProperties returns an error if customProperties, of a
+// record to create, hold reserved ones, which only the service sets.
+func checkNotReservedCustomProperties(customProperties map[string]*mlpb.Value) error {
+	for k := range customProperties {
+		if strings.HasPrefix(k, kfReservedPrefix) {
+			return status.Errorf(codes.InvalidArgument, "custom property %q is reserved and cannot be set", k)
+		}
+	}
+	return nil
+}
+
+// nowValue returns the current time in seconds since epoch, as recorded by
+// kfDeleted and kfPending.
+func nowValue() *mlpb.Value {
+	return &mlpb.Value{Value: &mlpb.Value_IntValue{IntValue: timeNowFn().Unix()}}
+}
+
+func (s *Service) getArtifactType(ctx context.Context, name string) (*mlpb.ArtifactType, error) {
 	name = strings.TrimPrefix(name, artifactTypesCollection)
-	return s.store.GetArtifactType(name)
+	if err := checkNotReservedTypeName(name); err != nil {
+		return nil, err
+	}
+	aType, err := s.store.GetArtifactType(ctx, name)
+	if err != nil {
+		return nil, err
+	}
+	if isDeletedType(aType.GetProperties()) {
+		return nil, status.Errorf(codes.NotFound, "ArtifactType %q has been deleted", name)
+	}
+	return aType, nil
+}
+
+// purgeDeletedArtifactType removes the ArtifactType with the given name if it
+// has been marked deleted, so that the name can be used again. Stores that
+// cannot remove records keep the names of deleted types, which fail with
+// codes.FailedPrecondition.
+func (s *Service) purgeDeletedArtifactType(ctx context.Context, name string) error {
+	aType, err := s.store.GetArtifactType(ctx, name)
+	if err != nil || !isDeletedType(aType.GetProperties()) {
+		return nil
+	}
+	err = s.store.DeleteArtifactType(ctx, name)
+	if deletionUnsupported(err) {
+		return status.Errorf(codes.FailedPrecondition, "ArtifactType %q has been deleted and its name cannot be reused", name)
+	}
+	return err
 }
 
 // CreateArtifactType creates a new artifact type.
 func (s *Service) CreateArtifactType(ctx context.Context, req *api.CreateArtifactTypeRequest) (*api.CreateArtifactTypeResponse, error) {
 	if req.ArtifactType == nil {
-		return nil, errors.New("no ArtifactType specified")
+		return nil, status.Error(codes.InvalidArgument, "no ArtifactType specified")
+	}
+	if err := checkNotReservedTypeName(req.ArtifactType.GetName()); err != nil {
+		return nil, err
+	}
+	if err := s.authorizeType(ctx, AdminAccess, req.ArtifactType.GetName()); err != nil {
+		return nil, err
+	}
+	if err := s.purgeDeletedArtifactType(ctx, req.ArtifactType.GetName()); err != nil {
+		return nil, err
 	}
 
 	_, err := s.store.PutArtifactType(
-		req.ArtifactType, &mlmetadata.PutTypeOptions{AllFieldsMustMatch: true})
+		ctx, req.ArtifactType, &mlmd.PutTypeOptions{AllFieldsMustMatch: true})
 	if err != nil {
 		return nil, err
 	}
 
-	aType, err := s.getArtifactType(req.ArtifactType.GetName())
+	aType, err := s.getArtifactType(ctx, req.ArtifactType.GetName())
 	if err != nil {
 		return nil, err
 	}
@@ -163,9 +291,18 @@ func (s *Service) CreateArtifactType(ctx context.Context, req *api.CreateArtifac
 // Deleting field is not supported and all fields must be matched.
 func (s *Service) UpdateArtifactType(ctx context.Context, req *api.UpdateArtifactTypeRequest) (*api.UpdateArtifactTypeResponse, error) {
 	if req.ArtifactType == nil {
-		return nil, errors.New("no ArtifactType specified")
+		return nil, status.Error(codes.InvalidArgument, "no ArtifactType specified")
+	}
+	if err := checkNotReservedTypeName(req.ArtifactType.GetName()); err != nil {
+		return nil, err
+	}
+	if err := s.authorizeType(ctx, AdminAccess, req.ArtifactType.GetName()); err != nil {
+		return nil, err
+	}
+	if err := s.purgeDeletedArtifactType(ctx, req.ArtifactType.GetName()); err != nil {
+		return nil, err
 	}
-	_, err := s.store.PutArtifactType(req.ArtifactType, &mlmetadata.PutTypeOptions{
+	_, err := s.store.PutArtifactType(ctx, req.ArtifactType, &mlmd.PutTypeOptions{
 		AllFieldsMustMatch: true,
 		CanAddFields:       true,
 	})
@@ -173,7 +310,7 @@ func (s *Service) UpdateArtifactType(ctx context.Context, req *api.UpdateArtifac
 		return nil, err
 	}
 
-	aType, err := s.getArtifactType(req.ArtifactType.GetName())
+	aType, err := s.getArtifactType(ctx, req.ArtifactType.GetName())
 	if err != nil {
 		return nil, err
 	}
@@ -185,149 +322,495 @@ func (s *Service) UpdateArtifactType(ctx context.Context, req *api.UpdateArtifac
 
 // GetArtifactType returns the requested artifact type.
 func (s *Service) GetArtifactType(ctx context.Context, req *api.GetArtifactTypeRequest) (*api.GetArtifactTypeResponse, error) {
-	aType, err := s.getArtifactType(req.GetName())
+	aType, err := s.getArtifactType(ctx, req.GetName())
 	if err != nil {
 		return nil, err
 	}
+	if err := s.authorizeType(ctx, ReadAccess, aType.GetName()); err != nil {
+		return nil, err
+	}
 
-	return &api.GetArtifactTypeResponse{
-		ArtifactType: aType,
-	}, nil
+	resp := &api.GetArtifactTypeResponse{ArtifactType: aType}
+	if tv := s.artifactValidators(aType.GetName()); tv != nil {
+		resp.PropertyValidators = tv.validators
+	}
+	return resp, nil
 }
 
 // ListArtifactTypes lists all artifact types.
 func (s *Service) ListArtifactTypes(ctx context.Context, req *api.ListArtifactTypesRequest) (*api.ListArtifactTypesResponse, error) {
-	aTypes, err := s.store.GetArtifactTypes()
+	aTypes, err := s.store.GetArtifactTypes(ctx)
 	if err != nil {
 		return nil, err
 	}
 
 	res := &api.ListArtifactTypesResponse{}
 	for _, aType := range aTypes {
+		if isDeletedType(aType.GetProperties()) || strings.HasPrefix(aType.GetName(), kfReservedPrefix) {
+			continue
+		}
+		if err := s.authorizeType(ctx, ReadAccess, aType.GetName()); isDenied(err) {
+			continue
+		} else if err != nil {
+			return nil, err
+		}
 		res.ArtifactTypes = append(res.ArtifactTypes, aType)
 	}
 
 	return res, nil
 }
 
-// DeleteArtifactType deletes the specified artifact type.
+// DeleteArtifactType deletes the specified artifact type. Deletion fails while
+// artifacts of the type exist, unless req.Force is set, in which case those
+// artifacts and the events referencing them are deleted as well.
 func (s *Service) DeleteArtifactType(ctx context.Context, req *api.DeleteArtifactTypeRequest) (*empty.Empty, error) {
-	return nil, errors.New("not implemented error: DeleteArtifactType")
+	aType, err := s.getArtifactType(ctx, req.GetName())
+	if err != nil {
+		return nil, err
+	}
+	if err := s.authorizeType(ctx, AdminAccess, aType.GetName()); err != nil {
+		return nil, err
+	}
+
+	artifacts, err := s.store.GetArtifactsByType(ctx, aType.GetName())
+	if err != nil && !noRecordFound(err) {
+		return nil, err
+	}
+	var live []*mlpb.Artifact
+	for _, artifact := range artifacts {
+		if !isDeleted(artifact.GetCustomProperties()) {
+			live = append(live, artifact)
+		}
+	}
+	if len(live) > 0 && !req.GetForce() {
+		return nil, status.Errorf(codes.FailedPrecondition, "ArtifactType %q still has %d artifact(s); set force to delete them along with the type", aType.GetName(), len(live))
+	}
+	if err := s.store.DeleteArtifactType(ctx, aType.GetName()); !deletionUnsupported(err) {
+		if err != nil {
+			return nil, err
+		}
+		return &empty.Empty{}, nil
+	}
+
+	// The store keeps its records, so the type and its artifacts are marked
+	// deleted instead. The types of schemas are kept, as their names could
+	// not be used again to create them when the schemas are loaded.
+	if id := s.schemaOfType(aType.GetName()); id != "" {
+		return nil, status.Errorf(codes.FailedPrecondition, "ArtifactType %q is defined by schema %s and cannot be deleted, as the store cannot remove records", aType.GetName(), id)
+	}
+	if len(live) > 0 {
+		for _, artifact := range live {
+			markArtifactDeleted(artifact)
+		}
+		if _, err := s.store.PutArtifacts(ctx, live); err != nil {
+			return nil, err
+		}
+	}
+
+	deleted := proto.Clone(aType).(*mlpb.ArtifactType)
+	deleted.Id = nil
+	if deleted.Properties == nil {
+		deleted.Properties = make(map[string]mlpb.PropertyType)
+	}
+	deleted.Properties[kfDeleted] = mlpb.PropertyType_INT
+	_, err = s.store.PutArtifactType(ctx, deleted, &mlmd.PutTypeOptions{
+		AllFieldsMustMatch: true,
+		CanAddFields:       true,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &empty.Empty{}, nil
+}
+
+func markArtifactDeleted(artifact *mlpb.Artifact) {
+	if artifact.CustomProperties == nil {
+		artifact.CustomProperties = make(map[string]*mlpb.Value)
+	}
+	artifact.CustomProperties[kfDeleted] = nowValue()
 }
 
-func (s *Service) getStoredArtifact(name string) (*mlpb.Artifact, error) {
+func (s *Service) getStoredArtifact(ctx context.Context, name string) (*mlpb.Artifact, error) {
 	tokens := artifactNameRE.FindStringSubmatch(name)
 	if len(tokens) != 2 {
-		return nil, fmt.Errorf("malformed Artifact name %q. Must match pattern %q", name, artifactNameRE)
+		return nil, status.Errorf(codes.InvalidArgument, "malformed Artifact name %q. Must match pattern %q", name, artifactNameRE)
 	}
 
 	id, err := strconv.ParseInt(tokens[1], 10, 64)
 	if err != nil {
-		return nil, fmt.Errorf("failed to parse Artifact id from %q: %v", tokens[1], err)
+		return nil, status.Errorf(codes.InvalidArgument, "failed to parse Artifact id from %q: %v", tokens[1], err)
 	}
 
-	artifacts, err := s.store.GetArtifactsByID([]mlmetadata.ArtifactID{mlmetadata.ArtifactID(id)})
+	artifacts, err := s.store.GetArtifactsByID(ctx, []mlmd.ArtifactID{mlmd.ArtifactID(id)})
 	if err != nil {
 		return nil, err
 	}
 
+	if len(artifacts) == 0 {
+		return nil, status.Errorf(codes.NotFound, "Artifact %q not found", name)
+	}
 	if len(artifacts) != 1 {
-		return nil, fmt.Errorf("internal error: expecting single new Artifact id, got instead : %v", artifacts)
+		return nil, status.Errorf(codes.Internal, "expecting single new Artifact id, got instead : %v", artifacts)
+	}
+
+	if isDeleted(artifacts[0].GetCustomProperties()) {
+		return nil, status.Errorf(codes.NotFound, "Artifact %q has been deleted", name)
 	}
 
 	return artifacts[0], nil
 }
 
-// CreateArtifact creates a new artifact.
-func (s *Service) CreateArtifact(ctx context.Context, req *api.CreateArtifactRequest) (*api.CreateArtifactResponse, error) {
+// newArtifact validates req and returns the artifact to store for it.
+func (s *Service) newArtifact(ctx context.Context, req *api.CreateArtifactRequest) (*mlpb.Artifact, error) {
 	if req.Artifact == nil {
-		return nil, errors.New("unspecified Artifact")
+		return nil, status.Error(codes.InvalidArgument, "unspecified Artifact")
 	}
 
 	if req.Artifact.Id != nil {
-		return nil, errors.New("id should remain unspecified when creating Artifact")
+		return nil, status.Error(codes.InvalidArgument, "id should remain unspecified when creating Artifact")
+	}
+	if err := checkNotReservedCustomProperties(req.Artifact.GetCustomProperties()); err != nil {
+		return nil, err
+	}
+
+	aType, err := s.getArtifactType(ctx, req.Parent)
+	if err != nil {
+		return nil, wrapError(err, "failed to resolve ArtifactType under %q", req.Parent)
+	}
+
+	artifact := proto.Clone(req.Artifact).(*mlpb.Artifact)
+	if err := projectArtifactDocument(aType, artifact, false); err != nil {
+		return nil, err
+	}
+
+	if err := checkProperties(s.artifactValidators(aType.GetName()), "Artifact", aType.GetName(), artifact.GetProperties()); err != nil {
+		return nil, err
+	}
+
+	if err := s.validateArtifact(aType.GetName(), withArtifactDocument(artifact)); err != nil {
+		return nil, err
+	}
+
+	workspace, err := s.resolveWorkspace(ctx, req.GetWorkspace())
+	if err != nil {
+		return nil, err
+	}
+	if err := s.authorizeType(ctx, WriteAccess, aType.GetName()); err != nil {
+		return nil, err
+	}
+	if err := s.authorizeWorkspace(ctx, WriteAccess, workspace); err != nil {
+		return nil, err
+	}
+
+	artifact.TypeId = proto.Int64(aType.GetId())
+	artifact.CustomProperties = setWorkspace(artifact.CustomProperties, workspace)
+	return artifact, nil
+}
+
+// createArtifacts stores the artifacts of reqs with a single PutArtifacts call,
+// so that either all or none of them are created, and returns them in order.
+func (s *Service) createArtifacts(ctx context.Context, reqs []*api.CreateArtifactRequest) ([]*mlpb.Artifact, error) {
+	artifacts := make([]*mlpb.Artifact, 0, len(reqs))
+	for i, req := range reqs {
+		artifact, err := s.newArtifact(ctx, req)
+		if err != nil {
+			if len(reqs) > 1 {
+				return nil, wrapError(err, "request %d", i)
+			}
+			return nil, err
+		}
+		artifacts = append(artifacts, artifact)
+	}
+	if len(artifacts) == 0 {
+		return artifacts, nil
 	}
 
-	aType, err := s.getArtifactType(req.Parent)
+	ids, err := s.store.PutArtifacts(ctx, artifacts)
 	if err != nil {
-		return nil, fmt.Errorf("failed to resolve ArtifactType under %q: %v", req.Parent, err)
+		return nil, err
 	}
 
-	req.Artifact.TypeId = proto.Int64(aType.GetId())
+	if len(ids) != len(artifacts) {
+		return nil, status.Errorf(codes.Internal, "expecting %d new Artifact ids, got instead : %v", len(artifacts), ids)
+	}
 
-	ids, err := s.store.PutArtifacts([]*mlpb.Artifact{req.Artifact})
+	stored, err := s.store.GetArtifactsByID(ctx, ids)
 	if err != nil {
 		return nil, err
 	}
+	byID := make(map[int64]*mlpb.Artifact)
+	for _, artifact := range stored {
+		byID[artifact.GetId()] = artifact
+	}
+	for i, id := range ids {
+		artifact, ok := byID[int64(id)]
+		if !ok {
+			return nil, status.Errorf(codes.Internal, "new Artifact %d not found", id)
+		}
+		artifacts[i] = artifact
+	}
 
-	if len(ids) != 1 {
-		return nil, fmt.Errorf("internal error: expecting single new Artifact id, got instead : %v", ids)
+	return artifacts, nil
+}
+
+// CreateArtifact creates a new artifact.
+func (s *Service) CreateArtifact(ctx context.Context, req *api.CreateArtifactRequest) (*api.CreateArtifactResponse, error) {
+	artifacts, err := s.createArtifacts(ctx, []*api.CreateArtifactRequest{req})
+	if err != nil {
+		return nil, err
 	}
 
-	artifactName := fmt.Sprintf("%s/artifacts/%d", req.Parent, ids[0])
-	artifact, err := s.getStoredArtifact(artifactName)
+	return &api.CreateArtifactResponse{Artifact: withArtifactDocument(artifacts[0])}, nil
+}
+
+// BatchCreateArtifacts creates multiple artifacts at once. Either all of them
+// are created or none is.
+func (s *Service) BatchCreateArtifacts(ctx context.Context, req *api.BatchCreateArtifactsRequest) (*api.BatchCreateArtifactsResponse, error) {
+	artifacts, err := s.createArtifacts(ctx, req.GetRequests())
 	if err != nil {
 		return nil, err
 	}
 
-	return &api.CreateArtifactResponse{Artifact: artifact}, nil
+	return &api.BatchCreateArtifactsResponse{Artifacts: withArtifactDocuments(artifacts)}, nil
 }
 
 // GetArtifact returns the requested artifact.
 func (s *Service) GetArtifact(ctx context.Context, req *api.GetArtifactRequest) (*api.GetArtifactResponse, error) {
-	artifact, err := s.getStoredArtifact(req.GetName())
+	artifact, err := s.getStoredArtifact(ctx, req.GetName())
 	if err != nil {
 		return nil, err
 	}
+	if err := s.authorizeArtifacts(ctx, ReadAccess, artifact); err != nil {
+		return nil, err
+	}
 
-	return &api.GetArtifactResponse{Artifact: artifact}, nil
+	return &api.GetArtifactResponse{Artifact: withArtifactDocument(artifact)}, nil
 }
 
 // ListArtifacts lists all known artifacts if artfact type name is not set or lists all artifacts of a given type name.
+// Only artifacts in req.Workspace and matching req.Filter, if set, are
+// returned. Artifacts are ordered by id and returned a page at a time, of
+// req.PageSize artifacts or defaultPageSize if it is not set.
 func (s *Service) ListArtifacts(ctx context.Context, req *api.ListArtifactsRequest) (*api.ListArtifactsResponse, error) {
-	var err error
-	var artifacts []*mlpb.Artifact
-	if req.Name == "" {
-		// Return all artifacts.
-		artifacts, err = s.store.GetArtifacts()
-	} else {
-		typeName := strings.TrimPrefix(req.Name, artifactTypesCollection)
-		artifacts, err = s.store.GetArtifactsByType(typeName)
+	f, err := parseArtifactFilter(req.GetFilter())
+	if err != nil {
+		return nil, err
+	}
+
+	var workspace string
+	if req.GetWorkspace() != "" {
+		if workspace, err = s.resolveWorkspace(ctx, req.GetWorkspace()); err != nil {
+			return nil, err
+		}
+		if err := s.authorizeWorkspace(ctx, ReadAccess, workspace); err != nil {
+			return nil, err
+		}
+	}
+
+	var typeName string
+	if req.Name != "" {
+		typeName = strings.TrimPrefix(req.Name, artifactTypesCollection)
+		if err := s.authorizeType(ctx, ReadAccess, typeName); err != nil {
+			return nil, err
+		}
+	}
+	size, afterID, err := pageOptions(req.GetPageSize(), req.GetPageToken())
+	if err != nil {
+		return nil, err
+	}
+
+	page := []*mlpb.Artifact{}
+	for limit := size + 1; len(page) <= size; limit = nextBatchSize(limit) {
+		artifacts, err := s.store.ListArtifacts(ctx, typeName, &mlmd.ListOptions{AfterID: afterID, Limit: limit})
+		if err != nil {
+			return nil, err
+		}
+		live := []*mlpb.Artifact{}
+		for _, artifact := range artifacts {
+			if isDeleted(artifact.GetCustomProperties()) {
+				continue
+			}
+			if workspace != "" && workspaceOf(artifact.GetCustomProperties()) != workspace {
+				continue
+			}
+			if f != nil && !f.matches(artifactFilterFields(artifact)) {
+				continue
+			}
+			live = append(live, artifact)
+		}
+		if live, err = s.readableArtifacts(ctx, live); err != nil {
+			return nil, err
+		}
+		page = append(page, live...)
+		if len(artifacts) < limit {
+			break
+		}
+		afterID = artifacts[len(artifacts)-1].GetId()
+	}
+
+	var nextPageToken string
+	if len(page) > size {
+		page = page[:size]
+		nextPageToken = encodePageToken(page[size-1].GetId())
+	}
+	return &api.ListArtifactsResponse{Artifacts: withArtifactDocuments(page), NextPageToken: nextPageToken}, nil
+}
+
+// UpdateArtifact updates the fields of an artifact listed in req.UpdateMask.
+// The artifact's id and type cannot be changed.
+func (s *Service) UpdateArtifact(ctx context.Context, req *api.UpdateArtifactRequest) (*api.UpdateArtifactResponse, error) {
+	if req.Artifact == nil {
+		return nil, status.Error(codes.InvalidArgument, "unspecified Artifact")
+	}
+
+	stored, err := s.getStoredArtifact(ctx, req.GetName())
+	if err != nil {
+		return nil, err
+	}
+	if err := s.authorizeArtifacts(ctx, WriteAccess, stored); err != nil {
+		return nil, err
+	}
+
+	artifact, err := applyArtifactMask(stored, req.Artifact, req.GetUpdateMask())
+	if err != nil {
+		return nil, err
 	}
 
+	types, err := s.store.GetArtifactTypesByID(ctx, []mlmd.ArtifactTypeID{mlmd.ArtifactTypeID(artifact.GetTypeId())})
 	if err != nil {
-		// An empty list is returned if no artifact is found.
-		if err.Error() != "Cannot find any record" {
+		return nil, err
+	}
+	if len(types) != 1 {
+		return nil, status.Errorf(codes.Internal, "expecting single ArtifactType with id %d, got instead : %v", artifact.GetTypeId(), types)
+	}
+	if _, ok := artifact.GetProperties()[allMeta]; ok {
+		if err := projectArtifactDocument(types[0], artifact, documentChanged(stored.GetProperties(), artifact.GetProperties())); err != nil {
 			return nil, err
 		}
-		artifacts = []*mlpb.Artifact{}
+	}
+	if err := checkProperties(s.artifactValidators(types[0].GetName()), "Artifact", types[0].GetName(), artifact.GetProperties()); err != nil {
+		return nil, err
+	}
+
+	if _, err := s.store.PutArtifacts(ctx, []*mlpb.Artifact{artifact}); err != nil {
+		return nil, err
 	}
 
-	return &api.ListArtifactsResponse{Artifacts: artifacts}, nil
+	artifact, err = s.getStoredArtifact(ctx, req.GetName())
+	if err != nil {
+		return nil, err
+	}
+
+	return &api.UpdateArtifactResponse{Artifact: withArtifactDocument(artifact)}, nil
 }
 
-// DeleteArtifact deletes the specified artifact.
+// DeleteArtifact deletes the specified artifact. Deletion fails while events
+// reference the artifact, unless req.Cascade is set, in which case those
+// events are deleted as well.
 func (s *Service) DeleteArtifact(ctx context.Context, req *api.DeleteArtifactRequest) (*empty.Empty, error) {
-	return nil, errors.New("not implemented error: DeleteArtifact")
+	artifact, err := s.getStoredArtifact(ctx, req.GetName())
+	if err != nil {
+		return nil, err
+	}
+	if err := s.authorizeArtifacts(ctx, WriteAccess, artifact); err != nil {
+		return nil, err
+	}
+
+	if !req.GetCascade() {
+		events, err := s.store.GetEventsByArtifactIDs(ctx, []mlmd.ArtifactID{mlmd.ArtifactID(artifact.GetId())})
+		if err != nil && !noRecordFound(err) {
+			return nil, err
+		}
+		events, err = s.liveEvents(ctx, events)
+		if err != nil {
+			return nil, err
+		}
+		if len(events) > 0 {
+			return nil, status.Errorf(codes.FailedPrecondition, "Artifact %q is referenced by %d event(s); set cascade to delete them along with the artifact", req.GetName(), len(events))
+		}
+	}
+
+	if err := s.deleteArtifacts(ctx, []*mlpb.Artifact{artifact}); err != nil {
+		return nil, err
+	}
+
+	return &empty.Empty{}, nil
+}
+
+// deleteArtifacts removes artifacts along with the events referencing them. If
+// the store keeps its records, they are marked deleted instead, which hides
+// the events referencing them.
+func (s *Service) deleteArtifacts(ctx context.Context, artifacts []*mlpb.Artifact) error {
+	if len(artifacts) == 0 {
+		return nil
+	}
+	aids := make([]mlmd.ArtifactID, len(artifacts))
+	for i, artifact := range artifacts {
+		aids[i] = mlmd.ArtifactID(artifact.GetId())
+	}
+	if err := s.store.DeleteArtifacts(ctx, aids); !deletionUnsupported(err) {
+		return err
+	}
+	for _, artifact := range artifacts {
+		markArtifactDeleted(artifact)
+	}
+	_, err := s.store.PutArtifacts(ctx, artifacts)
+	return err
 }
 
-func (s *Service) getExecutionType(name string) (*mlpb.ExecutionType, error) {
+func (s *Service) getExecutionType(ctx context.Context, name string) (*mlpb.ExecutionType, error) {
 	name = strings.TrimPrefix(name, executionTypesCollection)
-	return s.store.GetExecutionType(name)
+	if err := checkNotReservedTypeName(name); err != nil {
+		return nil, err
+	}
+	eType, err := s.store.GetExecutionType(ctx, name)
+	if err != nil {
+		return nil, err
+	}
+	if isDeletedType(eType.GetProperties()) {
+		return nil, status.Errorf(codes.NotFound, "ExecutionType %q has been deleted", name)
+	}
+	return eType, nil
+}
+
+// purgeDeletedExecutionType removes the ExecutionType with the given name if
+// it has been marked deleted, so that the name can be used again. Stores that
+// cannot remove records keep the names of deleted types, which fail with
+// codes.FailedPrecondition.
+func (s *Service) purgeDeletedExecutionType(ctx context.Context, name string) error {
+	eType, err := s.store.GetExecutionType(ctx, name)
+	if err != nil || !isDeletedType(eType.GetProperties()) {
+		return nil
+	}
+	err = s.store.DeleteExecutionType(ctx, name)
+	if deletionUnsupported(err) {
+		return status.Errorf(codes.FailedPrecondition, "ExecutionType %q has been deleted and its name cannot be reused", name)
+	}
+	return err
 }
 
 // CreateExecutionType creates the specified execution type.
 func (s *Service) CreateExecutionType(ctx context.Context, req *api.CreateExecutionTypeRequest) (*api.CreateExecutionTypeResponse, error) {
 	if req.ExecutionType == nil {
-		return nil, errors.New("no ExecutionType specified")
+		return nil, status.Error(codes.InvalidArgument, "no ExecutionType specified")
+	}
+	if err := checkNotReservedTypeName(req.ExecutionType.GetName()); err != nil {
+		return nil, err
+	}
+	if err := s.authorizeType(ctx, AdminAccess, req.ExecutionType.GetName()); err != nil {
+		return nil, err
+	}
+	if err := s.purgeDeletedExecutionType(ctx, req.ExecutionType.GetName()); err != nil {
+		return nil, err
 	}
 
-	_, err := s.store.PutExecutionType(req.ExecutionType, &mlmetadata.PutTypeOptions{AllFieldsMustMatch: true})
+	_, err := s.store.PutExecutionType(ctx, req.ExecutionType, &mlmd.PutTypeOptions{AllFieldsMustMatch: true})
 	if err != nil {
 		return nil, err
 	}
 
-	eType, err := s.getExecutionType(req.ExecutionType.GetName())
+	eType, err := s.getExecutionType(ctx, req.ExecutionType.GetName())
 	if err != nil {
 		return nil, err
 	}
@@ -340,9 +823,18 @@ func (s *Service) CreateExecutionType(ctx context.Context, req *api.CreateExecut
 // Deleting field is not supported and all fields must be matched.
 func (s *Service) UpdateExecutionType(ctx context.Context, req *api.UpdateExecutionTypeRequest) (*api.UpdateExecutionTypeResponse, error) {
 	if req.ExecutionType == nil {
-		return nil, errors.New("no ExecutionType specified")
+		return nil, status.Error(codes.InvalidArgument, "no ExecutionType specified")
+	}
+	if err := checkNotReservedTypeName(req.ExecutionType.GetName()); err != nil {
+		return nil, err
+	}
+	if err := s.authorizeType(ctx, AdminAccess, req.ExecutionType.GetName()); err != nil {
+		return nil, err
+	}
+	if err := s.purgeDeletedExecutionType(ctx, req.ExecutionType.GetName()); err != nil {
+		return nil, err
 	}
-	_, err := s.store.PutExecutionType(req.ExecutionType, &mlmetadata.PutTypeOptions{
+	_, err := s.store.PutExecutionType(ctx, req.ExecutionType, &mlmd.PutTypeOptions{
 		AllFieldsMustMatch: true,
 		CanAddFields:       true,
 	})
@@ -350,7 +842,7 @@ func (s *Service) UpdateExecutionType(ctx context.Context, req *api.UpdateExecut
 		return nil, err
 	}
 
-	eType, err := s.getExecutionType(req.ExecutionType.GetName())
+	eType, err := s.getExecutionType(ctx, req.ExecutionType.GetName())
 	if err != nil {
 		return nil, err
 	}
@@ -362,202 +854,1043 @@ func (s *Service) UpdateExecutionType(ctx context.Context, req *api.UpdateExecut
 
 // GetExecutionType return the specified execution type.
 func (s *Service) GetExecutionType(ctx context.Context, req *api.GetExecutionTypeRequest) (*api.GetExecutionTypeResponse, error) {
-	eType, err := s.getExecutionType(req.GetName())
+	eType, err := s.getExecutionType(ctx, req.GetName())
 	if err != nil {
 		return nil, err
 	}
+	if err := s.authorizeType(ctx, ReadAccess, eType.GetName()); err != nil {
+		return nil, err
+	}
 
-	return &api.GetExecutionTypeResponse{
-		ExecutionType: eType,
-	}, nil
+	resp := &api.GetExecutionTypeResponse{ExecutionType: eType}
+	if tv := s.executionValidators(eType.GetName()); tv != nil {
+		resp.PropertyValidators = tv.validators
+	}
+	return resp, nil
 }
 
 // ListExecutionTypes lists all execution types.
 func (s *Service) ListExecutionTypes(ctx context.Context, req *api.ListExecutionTypesRequest) (*api.ListExecutionTypesResponse, error) {
-	eTypes, err := s.store.GetExecutionTypes()
+	eTypes, err := s.store.GetExecutionTypes(ctx)
 	if err != nil {
 		return nil, err
 	}
 
 	res := &api.ListExecutionTypesResponse{}
 	for _, eType := range eTypes {
+		if isDeletedType(eType.GetProperties()) || strings.HasPrefix(eType.GetName(), kfReservedPrefix) {
+			continue
+		}
+		if err := s.authorizeType(ctx, ReadAccess, eType.GetName()); isDenied(err) {
+			continue
+		} else if err != nil {
+			return nil, err
+		}
 		res.ExecutionTypes = append(res.ExecutionTypes, eType)
 	}
 
 	return res, nil
 }
 
-// DeleteExecutionType deletes the specified execution.
+// DeleteExecutionType deletes the specified execution type. Deletion fails
+// while executions of the type exist, unless req.Force is set, in which case
+// those executions and the events referencing them are deleted as well.
 func (s *Service) DeleteExecutionType(ctx context.Context, req *api.DeleteExecutionTypeRequest) (*empty.Empty, error) {
-	return nil, errors.New("not implemented error: DeleteExecutionType")
-}
-
-func (s *Service) getExecution(name string) (*mlpb.Execution, error) {
-	tokens := executionNameRE.FindStringSubmatch(name)
+	eType, err := s.getExecutionType(ctx, req.GetName())
+	if err != nil {
+		return nil, err
+	}
+	if err := s.authorizeType(ctx, AdminAccess, eType.GetName()); err != nil {
+		return nil, err
+	}
+
+	executions, err := s.store.GetExecutionsByType(ctx, eType.GetName())
+	if err != nil && !noRecordFound(err) {
+		return nil, err
+	}
+	var live []*mlpb.Execution
+	for _, execution := range executions {
+		if !isDeleted(execution.GetCustomProperties()) {
+			live = append(live, execution)
+		}
+	}
+	if len(live) > 0 && !req.GetForce() {
+		return nil, status.Errorf(codes.FailedPrecondition, "ExecutionType %q still has %d execution(s); set force to delete them along with the type", eType.GetName(), len(live))
+	}
+	if err := s.store.DeleteExecutionType(ctx, eType.GetName()); !deletionUnsupported(err) {
+		if err != nil {
+			return nil, err
+		}
+		return &empty.Empty{}, nil
+	}
+
+	// The store keeps its records, so the type and its executions are marked
+	// deleted instead. The types of schemas are kept, as their names could
+	// not be used again to create them when the schemas are loaded.
+	if id := s.schemaOfType(eType.GetName()); id != "" {
+		return nil, status.Errorf(codes.FailedPrecondition, "ExecutionType %q is defined by schema %s and cannot be deleted, as the store cannot remove records", eType.GetName(), id)
+	}
+	if len(live) > 0 {
+		for _, execution := range live {
+			markExecutionDeleted(execution)
+		}
+		if _, err := s.store.PutExecutions(ctx, live); err != nil {
+			return nil, err
+		}
+	}
+
+	deleted := proto.Clone(eType).(*mlpb.ExecutionType)
+	deleted.Id = nil
+	if deleted.Properties == nil {
+		deleted.Properties = make(map[string]mlpb.PropertyType)
+	}
+	deleted.Properties[kfDeleted] = mlpb.PropertyType_INT
+	_, err = s.store.PutExecutionType(ctx, deleted, &mlmd.PutTypeOptions{
+		AllFieldsMustMatch: true,
+		CanAddFields:       true,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &empty.Empty{}, nil
+}
+
+func markExecutionDeleted(execution *mlpb.Execution) {
+	if execution.CustomProperties == nil {
+		execution.CustomProperties = make(map[string]*mlpb.Value)
+	}
+	execution.CustomProperties[kfDeleted] = nowValue()
+}
+
+func (s *Service) getExecution(ctx context.Context, name string) (*mlpb.Execution, error) {
+	tokens := executionNameRE.FindStringSubmatch(name)
 	if len(tokens) != 2 {
-		return nil, fmt.Errorf("malformed Execution name %q. Must match pattern %q", name, executionNameRE)
+		return nil, status.Errorf(codes.InvalidArgument, "malformed Execution name %q. Must match pattern %q", name, executionNameRE)
 	}
 
 	id, err := strconv.ParseInt(tokens[1], 10, 64)
 	if err != nil {
-		return nil, fmt.Errorf("failed to parse Execution id from %q: %v", tokens[1], err)
+		return nil, status.Errorf(codes.InvalidArgument, "failed to parse Execution id from %q: %v", tokens[1], err)
 	}
 
-	executions, err := s.store.GetExecutionsByID([]mlmetadata.ExecutionID{mlmetadata.ExecutionID(id)})
+	executions, err := s.store.GetExecutionsByID(ctx, []mlmd.ExecutionID{mlmd.ExecutionID(id)})
 	if err != nil {
 		return nil, err
 	}
 
+	if len(executions) == 0 {
+		return nil, status.Errorf(codes.NotFound, "Execution %q not found", name)
+	}
 	if len(executions) != 1 {
-		return nil, fmt.Errorf("internal error: expecting single Execution, got instead : %v", executions)
+		return nil, status.Errorf(codes.Internal, "expecting single Execution, got instead : %v", executions)
+	}
+
+	if isDeleted(executions[0].GetCustomProperties()) {
+		return nil, status.Errorf(codes.NotFound, "Execution %q has been deleted", name)
 	}
 
 	return executions[0], nil
 }
 
-// CreateExecution creates the specified execution.
-func (s *Service) CreateExecution(ctx context.Context, req *api.CreateExecutionRequest) (*api.CreateExecutionResponse, error) {
+// newExecution validates req and returns the execution to store for it.
+func (s *Service) newExecution(ctx context.Context, req *api.CreateExecutionRequest) (*mlpb.Execution, error) {
 	if req.Execution == nil {
-		return nil, errors.New("unspecified Execution")
+		return nil, status.Error(codes.InvalidArgument, "unspecified Execution")
 	}
 
 	if req.Execution.Id != nil {
-		return nil, errors.New("id should remain unspecified when creating Execution")
+		return nil, status.Error(codes.InvalidArgument, "id should remain unspecified when creating Execution")
+	}
+	if err := checkNotReservedCustomProperties(req.Execution.GetCustomProperties()); err != nil {
+		return nil, err
 	}
 
-	eType, err := s.getExecutionType(req.Parent)
+	eType, err := s.getExecutionType(ctx, req.Parent)
 	if err != nil {
-		return nil, fmt.Errorf("failed to resolve ExecutionType under %q: %v", req.Parent, err)
+		return nil, wrapError(err, "failed to resolve ExecutionType under %q", req.Parent)
+	}
+
+	execution := proto.Clone(req.Execution).(*mlpb.Execution)
+	if err := projectExecutionDocument(eType, execution, false); err != nil {
+		return nil, err
 	}
 
-	req.Execution.TypeId = proto.Int64(eType.GetId())
-	ids, err := s.store.PutExecutions([]*mlpb.Execution{req.Execution})
+	if err := checkProperties(s.executionValidators(eType.GetName()), "Execution", eType.GetName(), execution.GetProperties()); err != nil {
+		return nil, err
+	}
+
+	workspace, err := s.resolveWorkspace(ctx, req.GetWorkspace())
 	if err != nil {
 		return nil, err
 	}
+	if err := s.authorizeType(ctx, WriteAccess, eType.GetName()); err != nil {
+		return nil, err
+	}
+	if err := s.authorizeWorkspace(ctx, WriteAccess, workspace); err != nil {
+		return nil, err
+	}
+
+	execution.TypeId = proto.Int64(eType.GetId())
+	execution.CustomProperties = setWorkspace(execution.CustomProperties, workspace)
+	return execution, nil
+}
+
+// createExecutions stores the executions of reqs with a single PutExecutions
+// call, so that either all or none of them are created, and returns them in
+// order.
+func (s *Service) createExecutions(ctx context.Context, reqs []*api.CreateExecutionRequest) ([]*mlpb.Execution, error) {
+	executions := make([]*mlpb.Execution, 0, len(reqs))
+	for i, req := range reqs {
+		execution, err := s.newExecution(ctx, req)
+		if err != nil {
+			if len(reqs) > 1 {
+				return nil, wrapError(err, "request %d", i)
+			}
+			return nil, err
+		}
+		executions = append(executions, execution)
+	}
+	if len(executions) == 0 {
+		return executions, nil
+	}
+
+	ids, err := s.store.PutExecutions(ctx, executions)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(ids) != len(executions) {
+		return nil, status.Errorf(codes.Internal, "expecting %d new Execution ids, got instead : %v", len(executions), ids)
+	}
+
+	stored, err := s.store.GetExecutionsByID(ctx, ids)
+	if err != nil {
+		return nil, err
+	}
+	byID := make(map[int64]*mlpb.Execution)
+	for _, execution := range stored {
+		byID[execution.GetId()] = execution
+	}
+	for i, id := range ids {
+		execution, ok := byID[int64(id)]
+		if !ok {
+			return nil, status.Errorf(codes.Internal, "new Execution %d not found", id)
+		}
+		executions[i] = execution
+	}
+
+	return executions, nil
+}
+
+// CreateExecution creates the specified execution.
+func (s *Service) CreateExecution(ctx context.Context, req *api.CreateExecutionRequest) (*api.CreateExecutionResponse, error) {
+	executions, err := s.createExecutions(ctx, []*api.CreateExecutionRequest{req})
+	if err != nil {
+		return nil, err
+	}
+
+	return &api.CreateExecutionResponse{Execution: withExecutionDocument(executions[0])}, nil
+}
+
+// BatchCreateExecutions creates multiple executions at once. Either all of
+// them are created or none is.
+func (s *Service) BatchCreateExecutions(ctx context.Context, req *api.BatchCreateExecutionsRequest) (*api.BatchCreateExecutionsResponse, error) {
+	executions, err := s.createExecutions(ctx, req.GetRequests())
+	if err != nil {
+		return nil, err
+	}
+
+	return &api.BatchCreateExecutionsResponse{Executions: withExecutionDocuments(executions)}, nil
+}
+
+// RecordExecution creates an execution together with its output artifacts,
+// and the events linking it to its input and output artifacts. They are
+// written in a single transaction on stores that have them. MLMD has none, so
+// the execution and outputs are written as pending, hidden records and only
+// revealed once all events are written. A failure midway thus leaves nothing
+// visible, unless hiding the revealed execution again fails too, but leaves
+// the hidden records in MLMD, as it cannot delete them.
+func (s *Service) RecordExecution(ctx context.Context, req *api.RecordExecutionRequest) (*api.RecordExecutionResponse, error) {
+	if req.Execution == nil {
+		return nil, status.Error(codes.InvalidArgument, "unspecified Execution")
+	}
+	execution, err := s.newExecution(ctx, req.Execution)
+	if err != nil {
+		return nil, err
+	}
+
+	var inputIDs []mlmd.ArtifactID
+	for _, name := range req.GetInputArtifacts() {
+		node, err := parseNodeName(name)
+		if err != nil {
+			return nil, err
+		}
+		if !node.artifact {
+			return nil, status.Errorf(codes.InvalidArgument, "input %q is not an Artifact", name)
+		}
+		inputIDs = append(inputIDs, mlmd.ArtifactID(node.id))
+	}
+	if len(inputIDs) > 0 {
+		inputs, err := s.store.GetArtifactsByID(ctx, inputIDs)
+		if err != nil {
+			return nil, err
+		}
+		live := make(map[int64]bool)
+		for _, input := range inputs {
+			live[input.GetId()] = !isDeleted(input.GetCustomProperties())
+		}
+		for i, id := range inputIDs {
+			if !live[int64(id)] {
+				return nil, status.Errorf(codes.NotFound, "input Artifact %q not found", req.GetInputArtifacts()[i])
+			}
+		}
+		if err := s.authorizeArtifacts(ctx, ReadAccess, inputs...); err != nil {
+			return nil, err
+		}
+	}
 
-	if len(ids) != 1 {
-		return nil, fmt.Errorf("internal error: expecting single new Execution id, got instead : %v", ids)
+	var outputs []*mlpb.Artifact
+	for i, r := range req.GetOutputArtifacts() {
+		output, err := s.newArtifact(ctx, r)
+		if err != nil {
+			return nil, wrapError(err, "output %d", i)
+		}
+		output.CustomProperties[kfPending] = nowValue()
+		outputs = append(outputs, output)
+	}
+	execution.CustomProperties[kfPending] = nowValue()
+
+	var executionIDs []mlmd.ExecutionID
+	var outputIDs []mlmd.ArtifactID
+	var events []*mlpb.Event
+	if err := s.store.InTransaction(ctx, func(ctx context.Context) error {
+		var err error
+		executionIDs, outputIDs, events, err = s.recordExecution(ctx, execution, inputIDs, outputs)
+		return err
+	}); err != nil {
+		return nil, err
 	}
 
-	executionName := fmt.Sprintf("%s/executions/%d", req.Parent, ids[0])
-	exec, err := s.getExecution(executionName)
+	stored, err := s.store.GetExecutionsByID(ctx, executionIDs)
 	if err != nil {
 		return nil, err
 	}
+	if len(stored) != 1 {
+		return nil, status.Errorf(codes.Internal, "expecting single Execution, got instead : %v", stored)
+	}
+	res := &api.RecordExecutionResponse{Execution: withExecutionDocument(stored[0]), Events: events}
+	if len(outputIDs) > 0 {
+		storedOutputs, err := s.store.GetArtifactsByID(ctx, outputIDs)
+		if err != nil {
+			return nil, err
+		}
+		byID := make(map[int64]*mlpb.Artifact)
+		for _, output := range storedOutputs {
+			byID[output.GetId()] = output
+		}
+		for _, id := range outputIDs {
+			res.OutputArtifacts = append(res.OutputArtifacts, withArtifactDocument(byID[int64(id)]))
+		}
+	}
+
+	return res, nil
+}
+
+// recordExecution writes the execution, outputs and events of
+// RecordExecution, and returns the ids of the execution and outputs and the
+// events.
+func (s *Service) recordExecution(ctx context.Context, execution *mlpb.Execution, inputIDs []mlmd.ArtifactID, outputs []*mlpb.Artifact) ([]mlmd.ExecutionID, []mlmd.ArtifactID, []*mlpb.Event, error) {
+	executionIDs, err := s.store.PutExecutions(ctx, []*mlpb.Execution{execution})
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	if len(executionIDs) != 1 {
+		return nil, nil, nil, status.Errorf(codes.Internal, "expecting single new Execution id, got instead : %v", executionIDs)
+	}
+	execution.Id = proto.Int64(int64(executionIDs[0]))
 
-	return &api.CreateExecutionResponse{Execution: exec}, nil
+	var outputIDs []mlmd.ArtifactID
+	if len(outputs) > 0 {
+		outputIDs, err = s.store.PutArtifacts(ctx, outputs)
+		if err != nil {
+			return nil, nil, nil, err
+		}
+		if len(outputIDs) != len(outputs) {
+			return nil, nil, nil, status.Errorf(codes.Internal, "expecting %d new Artifact ids, got instead : %v", len(outputs), outputIDs)
+		}
+		for i, id := range outputIDs {
+			outputs[i].Id = proto.Int64(int64(id))
+		}
+	}
 
+	var events []*mlpb.Event
+	for _, id := range inputIDs {
+		events = append(events, &mlpb.Event{
+			ArtifactId:  proto.Int64(int64(id)),
+			ExecutionId: execution.Id,
+			Type:        mlpb.Event_INPUT.Enum(),
+		})
+	}
+	for _, id := range outputIDs {
+		events = append(events, &mlpb.Event{
+			ArtifactId:  proto.Int64(int64(id)),
+			ExecutionId: execution.Id,
+			Type:        mlpb.Event_OUTPUT.Enum(),
+		})
+	}
+	if len(events) > 0 {
+		if err := s.store.PutEvents(ctx, events); err != nil {
+			return nil, nil, nil, err
+		}
+	}
+
+	// Reveal the execution first, so that hiding it again undoes the call if
+	// its outputs, revealed all at once, cannot be.
+	delete(execution.CustomProperties, kfPending)
+	if _, err := s.store.PutExecutions(ctx, []*mlpb.Execution{execution}); err != nil {
+		return nil, nil, nil, err
+	}
+	if len(outputs) > 0 {
+		for _, output := range outputs {
+			delete(output.CustomProperties, kfPending)
+		}
+		if _, err := s.store.PutArtifacts(ctx, outputs); err != nil {
+			execution.CustomProperties[kfPending] = nowValue()
+			if _, hideErr := s.store.PutExecutions(ctx, []*mlpb.Execution{execution}); hideErr != nil {
+				glog.Errorf("Failed to hide Execution %d of a failed RecordExecution call: %v", execution.GetId(), hideErr)
+			}
+			return nil, nil, nil, err
+		}
+	}
+	return executionIDs, outputIDs, events, nil
 }
 
 // GetExecution returns the specified execution.
 func (s *Service) GetExecution(ctx context.Context, req *api.GetExecutionRequest) (*api.GetExecutionResponse, error) {
-	exec, err := s.getExecution(req.GetName())
+	exec, err := s.getExecution(ctx, req.GetName())
 	if err != nil {
 		return nil, err
 	}
+	if err := s.authorizeExecutions(ctx, ReadAccess, exec); err != nil {
+		return nil, err
+	}
 
-	return &api.GetExecutionResponse{Execution: exec}, nil
+	return &api.GetExecutionResponse{Execution: withExecutionDocument(exec)}, nil
 }
 
-// ListExecutions returns all executions.
+// ListExecutions returns all executions, or all executions of a given type
+// name. Only executions in req.Workspace and matching req.Filter, if set, are
+// returned. Executions are ordered by id and returned a page at a time, of
+// req.PageSize executions or defaultPageSize if it is not set.
 func (s *Service) ListExecutions(ctx context.Context, req *api.ListExecutionsRequest) (*api.ListExecutionsResponse, error) {
-	var err error
-	var executions []*mlpb.Execution
-	if req.Name == "" {
-		executions, err = s.store.GetExecutions()
-	} else {
-		typeName := strings.TrimPrefix(req.Name, executionTypesCollection)
-		executions, err = s.store.GetExecutionsByType(typeName)
+	f, err := parseExecutionFilter(req.GetFilter())
+	if err != nil {
+		return nil, err
 	}
 
+	var workspace string
+	if req.GetWorkspace() != "" {
+		if workspace, err = s.resolveWorkspace(ctx, req.GetWorkspace()); err != nil {
+			return nil, err
+		}
+		if err := s.authorizeWorkspace(ctx, ReadAccess, workspace); err != nil {
+			return nil, err
+		}
+	}
+
+	var typeName string
+	if req.Name != "" {
+		typeName = strings.TrimPrefix(req.Name, executionTypesCollection)
+		if err := s.authorizeType(ctx, ReadAccess, typeName); err != nil {
+			return nil, err
+		}
+	}
+	size, afterID, err := pageOptions(req.GetPageSize(), req.GetPageToken())
 	if err != nil {
-		// An empty list is returned if no executions is found.
-		if err.Error() != "Cannot find any record" {
+		return nil, err
+	}
+
+	page := []*mlpb.Execution{}
+	for limit := size + 1; len(page) <= size; limit = nextBatchSize(limit) {
+		executions, err := s.store.ListExecutions(ctx, typeName, &mlmd.ListOptions{AfterID: afterID, Limit: limit})
+		if err != nil {
+			return nil, err
+		}
+		live := []*mlpb.Execution{}
+		for _, execution := range executions {
+			if isDeleted(execution.GetCustomProperties()) {
+				continue
+			}
+			if workspace != "" && workspaceOf(execution.GetCustomProperties()) != workspace {
+				continue
+			}
+			if f != nil && !f.matches(executionFilterFields(execution)) {
+				continue
+			}
+			live = append(live, execution)
+		}
+		if live, err = s.readableExecutions(ctx, live); err != nil {
 			return nil, err
 		}
-		executions = []*mlpb.Execution{}
+		page = append(page, live...)
+		if len(executions) < limit {
+			break
+		}
+		afterID = executions[len(executions)-1].GetId()
 	}
 
-	return &api.ListExecutionsResponse{Executions: executions}, nil
+	var nextPageToken string
+	if len(page) > size {
+		page = page[:size]
+		nextPageToken = encodePageToken(page[size-1].GetId())
+	}
+	return &api.ListExecutionsResponse{Executions: withExecutionDocuments(page), NextPageToken: nextPageToken}, nil
 }
 
-// DeleteExecution deletes the specified execution.
+// UpdateExecution updates the fields of an execution listed in
+// req.UpdateMask, e.g. its last_known_state. The execution's id and type
+// cannot be changed.
+func (s *Service) UpdateExecution(ctx context.Context, req *api.UpdateExecutionRequest) (*api.UpdateExecutionResponse, error) {
+	if req.Execution == nil {
+		return nil, status.Error(codes.InvalidArgument, "unspecified Execution")
+	}
+
+	stored, err := s.getExecution(ctx, req.GetName())
+	if err != nil {
+		return nil, err
+	}
+	if err := s.authorizeExecutions(ctx, WriteAccess, stored); err != nil {
+		return nil, err
+	}
+
+	execution, err := applyExecutionMask(stored, req.Execution, req.GetUpdateMask())
+	if err != nil {
+		return nil, err
+	}
+
+	types, err := s.store.GetExecutionTypesByID(ctx, []mlmd.ExecutionTypeID{mlmd.ExecutionTypeID(execution.GetTypeId())})
+	if err != nil {
+		return nil, err
+	}
+	if len(types) != 1 {
+		return nil, status.Errorf(codes.Internal, "expecting single ExecutionType with id %d, got instead : %v", execution.GetTypeId(), types)
+	}
+	if _, ok := execution.GetProperties()[allMeta]; ok {
+		if err := projectExecutionDocument(types[0], execution, documentChanged(stored.GetProperties(), execution.GetProperties())); err != nil {
+			return nil, err
+		}
+	}
+	if err := checkProperties(s.executionValidators(types[0].GetName()), "Execution", types[0].GetName(), execution.GetProperties()); err != nil {
+		return nil, err
+	}
+
+	if _, err := s.store.PutExecutions(ctx, []*mlpb.Execution{execution}); err != nil {
+		return nil, err
+	}
+
+	execution, err = s.getExecution(ctx, req.GetName())
+	if err != nil {
+		return nil, err
+	}
+
+	return &api.UpdateExecutionResponse{Execution: withExecutionDocument(execution)}, nil
+}
+
+// DeleteExecution deletes the specified execution. Deletion fails while events
+// reference the execution, unless req.Cascade is set, in which case those
+// events are deleted as well.
 func (s *Service) DeleteExecution(ctx context.Context, req *api.DeleteExecutionRequest) (*empty.Empty, error) {
-	return nil, errors.New("not implemented error: DeleteExecution")
+	execution, err := s.getExecution(ctx, req.GetName())
+	if err != nil {
+		return nil, err
+	}
+	if err := s.authorizeExecutions(ctx, WriteAccess, execution); err != nil {
+		return nil, err
+	}
+
+	if !req.GetCascade() {
+		events, err := s.store.GetEventsByExecutionIDs(ctx, []mlmd.ExecutionID{mlmd.ExecutionID(execution.GetId())})
+		if err != nil && !noRecordFound(err) {
+			return nil, err
+		}
+		events, err = s.liveEvents(ctx, events)
+		if err != nil {
+			return nil, err
+		}
+		if len(events) > 0 {
+			return nil, status.Errorf(codes.FailedPrecondition, "Execution %q is referenced by %d event(s); set cascade to delete them along with the execution", req.GetName(), len(events))
+		}
+	}
+
+	if err := s.deleteExecutions(ctx, []*mlpb.Execution{execution}); err != nil {
+		return nil, err
+	}
+
+	return &empty.Empty{}, nil
+}
+
+// deleteExecutions removes executions along with the events referencing them.
+// See deleteArtifacts.
+func (s *Service) deleteExecutions(ctx context.Context, executions []*mlpb.Execution) error {
+	if len(executions) == 0 {
+		return nil
+	}
+	eids := make([]mlmd.ExecutionID, len(executions))
+	for i, execution := range executions {
+		eids[i] = mlmd.ExecutionID(execution.GetId())
+	}
+	if err := s.store.DeleteExecutions(ctx, eids); !deletionUnsupported(err) {
+		return err
+	}
+	for _, execution := range executions {
+		markExecutionDeleted(execution)
+	}
+	_, err := s.store.PutExecutions(ctx, executions)
+	return err
+}
+
+// workspaceValue returns the workspace recorded in customProperties. Records
+// created without a workspace belong to the default one.
+func workspaceValue(customProperties map[string]*mlpb.Value) *mlpb.Value {
+	if v, ok := customProperties[kfWorkspace]; ok {
+		return v
+	}
+	return &mlpb.Value{Value: &mlpb.Value_StringValue{StringValue: kfDefaultWorkspace}}
+}
+
+func workspaceOf(customProperties map[string]*mlpb.Value) string {
+	return workspaceValue(customProperties).GetStringValue()
+}
+
+func setWorkspace(customProperties map[string]*mlpb.Value, workspace string) map[string]*mlpb.Value {
+	if customProperties == nil {
+		customProperties = make(map[string]*mlpb.Value)
+	}
+	customProperties[kfWorkspace] = &mlpb.Value{Value: &mlpb.Value_StringValue{StringValue: workspace}}
+	return customProperties
+}
+
+func (s *Service) getWorkspace(ctx context.Context, name string) (*api.Workspace, error) {
+	name = strings.TrimPrefix(name, workspacesCollection)
+	if name == kfDefaultWorkspace {
+		return &api.Workspace{Name: name}, nil
+	}
+	if err := validWorkspaceName(name); err != nil {
+		return nil, err
+	}
+
+	wsType, err := s.store.GetArtifactType(ctx, kfWorkspaceTypePrefix+name)
+	// Stores report missing types with their own NotFound errors rather than
+	// mlmd.ErrNoRecord.
+	if status.Code(err) == codes.NotFound {
+		return nil, status.Errorf(codes.NotFound, "Workspace %q not found: %v", name, err)
+	}
+	if err != nil {
+		return nil, wrapError(err, "failed to get Workspace %q", name)
+	}
+	if isDeletedType(wsType.GetProperties()) {
+		return nil, status.Errorf(codes.NotFound, "Workspace %q has been deleted", name)
+	}
+	return s.workspace(ctx, wsType, map[string][]*mlpb.Artifact{})
+}
+
+// resolveWorkspace returns the name of the existing workspace that name
+// refers to, or the default workspace if name is empty.
+func (s *Service) resolveWorkspace(ctx context.Context, name string) (string, error) {
+	if name == "" {
+		return kfDefaultWorkspace, nil
+	}
+	ws, err := s.getWorkspace(ctx, name)
+	if err != nil {
+		return "", err
+	}
+	return ws.GetName(), nil
+}
+
+// CreateWorkspace creates a new workspace. Names of deleted workspaces cannot
+// be reused. Workspaces of a container type can have the properties it
+// declares, and are validated against the schema defining it.
+func (s *Service) CreateWorkspace(ctx context.Context, req *api.CreateWorkspaceRequest) (*api.CreateWorkspaceResponse, error) {
+	if req.Workspace == nil {
+		return nil, status.Error(codes.InvalidArgument, "unspecified Workspace")
+	}
+	name := req.Workspace.GetName()
+	if err := validWorkspaceName(name); err != nil {
+		return nil, err
+	}
+	if err := s.authorizeWorkspace(ctx, AdminAccess, name); err != nil {
+		return nil, err
+	}
+
+	if wsType, err := s.store.GetArtifactType(ctx, kfWorkspaceTypePrefix+name); err == nil {
+		if isDeletedType(wsType.GetProperties()) {
+			return nil, status.Errorf(codes.FailedPrecondition, "Workspace %q has been deleted and its name cannot be reused", name)
+		}
+		return nil, status.Errorf(codes.AlreadyExists, "Workspace %q already exists", name)
+	}
+
+	wsType := &mlpb.ArtifactType{Name: proto.String(kfWorkspaceTypePrefix + name)}
+	if req.Workspace.GetType() == "" {
+		if len(req.Workspace.GetProperties()) > 0 {
+			return nil, status.Errorf(codes.InvalidArgument, "Workspace %q has properties but no container type", name)
+		}
+	} else {
+		artifact, err := s.newContainerArtifact(ctx, req.Workspace)
+		if err != nil {
+			return nil, err
+		}
+		// The properties are stored first, so that a recorded workspace
+		// always has them.
+		if _, err := s.store.PutArtifacts(ctx, []*mlpb.Artifact{artifact}); err != nil {
+			return nil, err
+		}
+		wsType.Properties = map[string]mlpb.PropertyType{kfContainerTypeProperty + req.Workspace.GetType(): mlpb.PropertyType_STRING}
+	}
+	if _, err := s.store.PutArtifactType(ctx, wsType, &mlmd.PutTypeOptions{AllFieldsMustMatch: true}); err != nil {
+		return nil, err
+	}
+
+	ws, err := s.getWorkspace(ctx, name)
+	if err != nil {
+		return nil, err
+	}
+	return &api.CreateWorkspaceResponse{Workspace: ws}, nil
+}
+
+// GetWorkspace returns the requested workspace.
+func (s *Service) GetWorkspace(ctx context.Context, req *api.GetWorkspaceRequest) (*api.GetWorkspaceResponse, error) {
+	ws, err := s.getWorkspace(ctx, req.GetName())
+	if err != nil {
+		return nil, err
+	}
+	if err := s.authorizeWorkspace(ctx, ReadAccess, ws.GetName()); err != nil {
+		return nil, err
+	}
+
+	return &api.GetWorkspaceResponse{Workspace: ws}, nil
+}
+
+// ListWorkspaces lists all workspaces, starting with the default one.
+func (s *Service) ListWorkspaces(ctx context.Context, req *api.ListWorkspacesRequest) (*api.ListWorkspacesResponse, error) {
+	aTypes, err := s.store.GetArtifactTypes(ctx)
+	if err != nil && !noRecordFound(err) {
+		return nil, err
+	}
+
+	workspaces := []*api.Workspace{&api.Workspace{Name: kfDefaultWorkspace}}
+	containers := make(map[string][]*mlpb.Artifact)
+	for _, aType := range aTypes {
+		if !strings.HasPrefix(aType.GetName(), kfWorkspaceTypePrefix) || isDeletedType(aType.GetProperties()) {
+			continue
+		}
+		ws, err := s.workspace(ctx, aType, containers)
+		if err != nil {
+			return nil, err
+		}
+		workspaces = append(workspaces, ws)
+	}
+
+	res := &api.ListWorkspacesResponse{}
+	for _, ws := range workspaces {
+		if err := s.authorizeWorkspace(ctx, ReadAccess, ws.GetName()); isDenied(err) {
+			continue
+		} else if err != nil {
+			return nil, err
+		}
+		res.Workspaces = append(res.Workspaces, ws)
+	}
+
+	return res, nil
+}
+
+// DeleteWorkspace deletes the specified workspace. Deletion fails while
+// artifacts or executions belong to the workspace, unless req.Force is set, in
+// which case those and the events referencing them are deleted as well. The
+// default workspace cannot be deleted.
+func (s *Service) DeleteWorkspace(ctx context.Context, req *api.DeleteWorkspaceRequest) (*empty.Empty, error) {
+	ws, err := s.getWorkspace(ctx, req.GetName())
+	if err != nil {
+		return nil, err
+	}
+	if ws.GetName() == kfDefaultWorkspace {
+		return nil, status.Error(codes.FailedPrecondition, "the default Workspace cannot be deleted")
+	}
+	if err := s.authorizeWorkspace(ctx, AdminAccess, ws.GetName()); err != nil {
+		return nil, err
+	}
+
+	// Only the records of the workspace are kept while the others are read.
+	var liveArtifacts []*mlpb.Artifact
+	err = s.forEachArtifactPage(ctx, func(artifacts []*mlpb.Artifact) error {
+		for _, artifact := range artifacts {
+			if !isDeleted(artifact.GetCustomProperties()) && workspaceOf(artifact.GetCustomProperties()) == ws.GetName() {
+				liveArtifacts = append(liveArtifacts, artifact)
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	var liveExecutions []*mlpb.Execution
+	err = s.forEachExecutionPage(ctx, func(executions []*mlpb.Execution) error {
+		for _, execution := range executions {
+			if !isDeleted(execution.GetCustomProperties()) && workspaceOf(execution.GetCustomProperties()) == ws.GetName() {
+				liveExecutions = append(liveExecutions, execution)
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if len(liveArtifacts) > 0 || len(liveExecutions) > 0 {
+		if !req.GetForce() {
+			return nil, status.Errorf(codes.FailedPrecondition, "Workspace %q still has %d artifact(s) and %d execution(s); set force to delete them along with the workspace", ws.GetName(), len(liveArtifacts), len(liveExecutions))
+		}
+		if err := s.deleteArtifacts(ctx, liveArtifacts); err != nil {
+			return nil, err
+		}
+		if err := s.deleteExecutions(ctx, liveExecutions); err != nil {
+			return nil, err
+		}
+	}
+
+	deleted := &mlpb.ArtifactType{
+		Name:       proto.String(kfWorkspaceTypePrefix + ws.GetName()),
+		Properties: map[string]mlpb.PropertyType{kfDeleted: mlpb.PropertyType_INT},
+	}
+	if ws.GetType() != "" {
+		deleted.Properties[kfContainerTypeProperty+ws.GetType()] = mlpb.PropertyType_STRING
+	}
+	_, err = s.store.PutArtifactType(ctx, deleted, &mlmd.PutTypeOptions{
+		AllFieldsMustMatch: true,
+		CanAddFields:       true,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if ws.GetType() != "" {
+		artifact, err := s.containerArtifact(ctx, ws.GetType(), ws.GetName())
+		if err != nil {
+			return nil, err
+		}
+		markArtifactDeleted(artifact)
+		if _, err := s.store.PutArtifacts(ctx, []*mlpb.Artifact{artifact}); err != nil {
+			return nil, err
+		}
+	}
+
+	return &empty.Empty{}, nil
+}
+
+// createEvents stores events with a single PutEvents call, so that either all
+// or none of them are created.
+func (s *Service) createEvents(ctx context.Context, events []*mlpb.Event) error {
+	if len(events) == 0 {
+		return nil
+	}
+	live, artifacts, executions, err := s.getLiveEventsAndNodes(ctx, events)
+	if err != nil {
+		return err
+	}
+	if len(live) != len(events) {
+		return status.Error(codes.FailedPrecondition, "cannot create an event referencing a deleted Artifact or Execution")
+	}
+	if err := s.authorizeEvents(ctx, events, artifacts, executions); err != nil {
+		return err
+	}
+	return s.store.PutEvents(ctx, events)
 }
 
 func (s *Service) CreateEvent(ctx context.Context, req *api.CreateEventRequest) (*empty.Empty, error) {
-	err := s.store.PutEvents([]*mlpb.Event{req.GetEvent()})
+	err := s.createEvents(ctx, []*mlpb.Event{req.GetEvent()})
+	return &empty.Empty{}, err
+}
+
+// BatchCreateEvents creates multiple events at once. Either all of them are
+// created or none is.
+func (s *Service) BatchCreateEvents(ctx context.Context, req *api.BatchCreateEventsRequest) (*empty.Empty, error) {
+	err := s.createEvents(ctx, req.GetEvents())
 	return &empty.Empty{}, err
 }
 
 func (s *Service) ListEvents(ctx context.Context, req *api.ListEventsRequest) (*api.ListEventsResponse, error) {
 	name := req.GetName()
 	var events []*mlpb.Event
+	var id int64
 	var err error
 	if strings.HasPrefix(name, artifactCollection) {
-		id, err := strconv.ParseInt(strings.TrimPrefix(name, artifactCollection), 10, 64)
+		id, err = strconv.ParseInt(strings.TrimPrefix(name, artifactCollection), 10, 64)
 		if err != nil {
-			return nil, fmt.Errorf("failed to parse Artifact id from %q: %v", name, err)
+			return nil, status.Errorf(codes.InvalidArgument, "failed to parse Artifact id from %q: %v", name, err)
 		}
-		events, err = s.store.GetEventsByArtifactIDs([]mlmetadata.ArtifactID{mlmetadata.ArtifactID(id)})
+		events, err = s.store.GetEventsByArtifactIDs(ctx, []mlmd.ArtifactID{mlmd.ArtifactID(id)})
 	} else {
-		id, err := strconv.ParseInt(strings.TrimPrefix(name, executionCollection), 10, 64)
+		id, err = strconv.ParseInt(strings.TrimPrefix(name, executionCollection), 10, 64)
 		if err != nil {
-			return nil, fmt.Errorf("failed to parse Artifact id from %q: %v", name, err)
+			return nil, status.Errorf(codes.InvalidArgument, "failed to parse Execution id from %q: %v", name, err)
 		}
-		events, err = s.store.GetEventsByExecutionIDs([]mlmetadata.ExecutionID{mlmetadata.ExecutionID(id)})
+		events, err = s.store.GetEventsByExecutionIDs(ctx, []mlmd.ExecutionID{mlmd.ExecutionID(id)})
 	}
-	artifacts, err := s.getArtifactsInEvents(events)
-	if err != nil {
-		return nil, fmt.Errorf("failed to get artifacts in events: %v", err)
+	// An empty list is returned if no event is found.
+	if err != nil && !noRecordFound(err) {
+		return nil, err
 	}
-	executions, err := s.getExecutionsInEvents(events)
+	events, artifacts, executions, err := s.getLiveEventsAndNodes(ctx, events)
 	if err != nil {
-		return nil, fmt.Errorf("failed to get executions in events: %v", err)
+		return nil, err
+	}
+	if events, err = s.readableGraph(ctx, events, artifacts, executions); err != nil {
+		return nil, err
+	}
+	for id, artifact := range artifacts {
+		artifacts[id] = withArtifactDocument(artifact)
+	}
+	for id, execution := range executions {
+		executions[id] = withExecutionDocument(execution)
 	}
 	return &api.ListEventsResponse{
 		Events:     events,
 		Artifacts:  artifacts,
 		Executions: executions,
-	}, err
+	}, nil
 }
 
-func (s *Service) getArtifactsInEvents(events []*mlpb.Event) (map[int64]*mlpb.Artifact, error) {
-	results := make(map[int64]*mlpb.Artifact)
+// GetLineage returns the events and nodes reachable from an artifact or an
+// execution by walking events upstream, downstream or both, up to
+// req.MaxDepth events away.
+func (s *Service) GetLineage(ctx context.Context, req *api.GetLineageRequest) (*api.GetLineageResponse, error) {
+	direction := req.GetDirection()
+	upstream := direction == api.GetLineageRequest_BOTH || direction == api.GetLineageRequest_UPSTREAM
+	downstream := direction == api.GetLineageRequest_BOTH || direction == api.GetLineageRequest_DOWNSTREAM
+	if !upstream && !downstream {
+		return nil, status.Errorf(codes.InvalidArgument, "unknown lineage direction %v", direction)
+	}
+
+	g, err := s.lineage(ctx, req.GetName(), upstream, downstream, req.GetMaxDepth())
+	if err != nil {
+		return nil, err
+	}
+	res := &api.GetLineageResponse{
+		Events:     g.events,
+		Artifacts:  make(map[int64]*mlpb.Artifact),
+		Executions: make(map[int64]*mlpb.Execution),
+	}
+	for id, artifact := range g.artifacts {
+		res.Artifacts[id] = withArtifactDocument(artifact)
+	}
+	for id, execution := range g.executions {
+		res.Executions[id] = withExecutionDocument(execution)
+	}
+	return res, nil
+}
+
+// getLiveEventsAndNodes drops the events that reference a deleted artifact or
+// execution, and returns the remaining events together with the artifacts and
+// executions they reference keyed by id.
+func (s *Service) getLiveEventsAndNodes(ctx context.Context, events []*mlpb.Event) ([]*mlpb.Event, map[int64]*mlpb.Artifact, map[int64]*mlpb.Execution, error) {
+	artifacts, err := s.getArtifactsInEvents(ctx, events)
+	if err != nil {
+		return nil, nil, nil, wrapError(err, "failed to get artifacts in events")
+	}
+	executions, err := s.getExecutionsInEvents(ctx, events)
+	if err != nil {
+		return nil, nil, nil, wrapError(err, "failed to get executions in events")
+	}
+
+	var live []*mlpb.Event
 	for _, e := range events {
-		id := e.GetArtifactId()
-		if _, exists := results[id]; exists {
+		if isDeleted(artifacts[e.GetArtifactId()].GetCustomProperties()) ||
+			isDeleted(executions[e.GetExecutionId()].GetCustomProperties()) {
 			continue
 		}
-		artifacts, err := s.store.GetArtifactsByID([]mlmetadata.ArtifactID{mlmetadata.ArtifactID(id)})
-		if err != nil {
-			return nil, err
+		live = append(live, e)
+	}
+	for id, artifact := range artifacts {
+		if isDeleted(artifact.GetCustomProperties()) {
+			delete(artifacts, id)
 		}
-		if len(artifacts) != 1 {
-			return nil, fmt.Errorf("internal error: expecting single new Artifact id, got instead : %v", artifacts)
+	}
+	for id, execution := range executions {
+		if isDeleted(execution.GetCustomProperties()) {
+			delete(executions, id)
+		}
+	}
+	return live, artifacts, executions, nil
+}
+
+// liveEvents drops the events that reference a deleted artifact or execution.
+func (s *Service) liveEvents(ctx context.Context, events []*mlpb.Event) ([]*mlpb.Event, error) {
+	live, _, _, err := s.getLiveEventsAndNodes(ctx, events)
+	return live, err
+}
+
+// getArtifactsInEvents returns the artifacts referenced by events by id, which
+// are read at once.
+func (s *Service) getArtifactsInEvents(ctx context.Context, events []*mlpb.Event) (map[int64]*mlpb.Artifact, error) {
+	results := make(map[int64]*mlpb.Artifact)
+	var aids []mlmd.ArtifactID
+	seen := make(map[int64]bool)
+	for _, e := range events {
+		if id := e.GetArtifactId(); !seen[id] {
+			seen[id] = true
+			aids = append(aids, mlmd.ArtifactID(id))
+		}
+	}
+	if len(aids) == 0 {
+		return results, nil
+	}
+	artifacts, err := s.store.GetArtifactsByID(ctx, aids)
+	if err != nil {
+		return nil, err
+	}
+	for _, artifact := range artifacts {
+		if _, exists := results[artifact.GetId()]; exists {
+			return nil, status.Errorf(codes.Internal, "expecting single Artifact with id %d, got instead : %v", artifact.GetId(), artifacts)
+		}
+		results[artifact.GetId()] = artifact
+	}
+	for _, id := range aids {
+		if _, ok := results[int64(id)]; !ok {
+			return nil, status.Errorf(codes.NotFound, "Artifact %d of event not found", id)
 		}
-		results[id] = artifacts[0]
 	}
 	return results, nil
 }
 
-func (s *Service) getExecutionsInEvents(events []*mlpb.Event) (map[int64]*mlpb.Execution, error) {
+// getExecutionsInEvents returns the executions referenced by events by id,
+// which are read at once.
+func (s *Service) getExecutionsInEvents(ctx context.Context, events []*mlpb.Event) (map[int64]*mlpb.Execution, error) {
 	results := make(map[int64]*mlpb.Execution)
+	var eids []mlmd.ExecutionID
+	seen := make(map[int64]bool)
 	for _, e := range events {
-		id := e.GetExecutionId()
-		if _, exists := results[id]; exists {
-			continue
+		if id := e.GetExecutionId(); !seen[id] {
+			seen[id] = true
+			eids = append(eids, mlmd.ExecutionID(id))
 		}
-		executions, err := s.store.GetExecutionsByID([]mlmetadata.ExecutionID{mlmetadata.ExecutionID(id)})
-		if err != nil {
-			return nil, err
+	}
+	if len(eids) == 0 {
+		return results, nil
+	}
+	executions, err := s.store.GetExecutionsByID(ctx, eids)
+	if err != nil {
+		return nil, err
+	}
+	for _, execution := range executions {
+		if _, exists := results[execution.GetId()]; exists {
+			return nil, status.Errorf(codes.Internal, "expecting single Execution with id %d, got instead : %v", execution.GetId(), executions)
 		}
-		if len(executions) != 1 {
-			return nil, fmt.Errorf("internal error: expecting single new execution id, got instead : %v", executions)
+		results[execution.GetId()] = execution
+	}
+	for _, id := range eids {
+		if _, ok := results[int64(id)]; !ok {
+			return nil, status.Errorf(codes.NotFound, "Execution %d of event not found", id)
 		}
-		results[id] = executions[0]
 	}
 	return results, nil
 }
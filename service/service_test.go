@@ -16,17 +16,29 @@ package service
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
+	"flag"
 	"fmt"
-	"ml_metadata/metadata_store/mlmetadata"
 	mlpb "ml_metadata/proto/metadata_store_go_proto"
+	"net/http"
+	"os"
 	"reflect"
+	"sort"
 	"testing"
+	"time"
 
 	"github.com/golang/protobuf/proto"
 	"github.com/google/go-cmp/cmp"
 	"github.com/google/go-cmp/cmp/cmpopts"
+	"github.com/grpc-ecosystem/grpc-gateway/runtime"
 	"github.com/kubeflow/metadata/api"
+	"github.com/kubeflow/metadata/mlmd"
+	"github.com/kubeflow/metadata/mlmd/sqlstore"
+	"google.golang.org/genproto/protobuf/field_mask"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 )
 
 func TestValidTypeNames(t *testing.T) {
@@ -116,28 +128,51 @@ func TestGetNamespacedName(t *testing.T) {
 		{"kubeflow.org", "types.kubeflow.org/default/kubeflow.org", nil},
 
 		// Error cases.
-		{"", "", errors.New("empty type name: \"\"")},
-		{"kubeflow.org/v1/", "", errors.New("empty type name: \"kubeflow.org/v1/\"")},
+		{"", "", status.Error(codes.InvalidArgument, "empty type name: \"\"")},
+		{"kubeflow.org/v1/", "", status.Error(codes.InvalidArgument, "empty type name: \"kubeflow.org/v1/\"")},
 	}
 
 	for _, test := range tests {
 		name, err := getNamespacedName(test.in)
 
-		if !reflect.DeepEqual(err, test.wantErr) || name != test.wantName {
+		if fmt.Sprint(err) != fmt.Sprint(test.wantErr) || name != test.wantName {
 			t.Errorf("getNamespacedName(%q) = %q, %v\nWant %q, %v",
 				test.in, name, err, test.wantName, test.wantErr)
 		}
 	}
 }
 
-func testMLMDStore(t *testing.T) *mlmetadata.Store {
-	cfg := &mlpb.ConnectionConfig{
-		Config: &mlpb.ConnectionConfig_FakeDatabase{
-			FakeDatabase: &mlpb.FakeDatabaseConfig{},
-		},
+// testStoreBackend is the name of the MetadataStore implementation the tests
+// run against, out of testStoreBackends, which creates those compiled in. MLMD
+// requires cgo, so it is added by mlmd_test.go.
+var (
+	testStoreBackend  string
+	testStoreBackends = map[string]func() (MetadataStore, error){
+		"sqlite": func() (MetadataStore, error) { return sqlstore.NewSQLiteStore(":memory:") },
+	}
+)
+
+// TestMain runs the tests against each MetadataStore implementation, which
+// must behave the same.
+func TestMain(m *testing.M) {
+	flag.Parse()
+	var backends []string
+	for backend := range testStoreBackends {
+		backends = append(backends, backend)
+	}
+	sort.Strings(backends)
+	code := 0
+	for _, testStoreBackend = range backends {
+		if c := m.Run(); c != 0 {
+			fmt.Fprintf(os.Stderr, "Tests failed with the %s store\n", testStoreBackend)
+			code = c
+		}
 	}
+	os.Exit(code)
+}
 
-	store, err := mlmetadata.NewStore(cfg)
+func testMLMDStore(t *testing.T) MetadataStore {
+	store, err := testStoreBackends[testStoreBackend]()
 	if err != nil {
 		t.Fatalf("Failed to create ML Metadata Store: %v", err)
 	}
@@ -301,7 +336,7 @@ func TestGetArtifactType(t *testing.T) {
 		},
 	}
 
-	_, err := store.PutArtifactType(stored, &mlmetadata.PutTypeOptions{AllFieldsMustMatch: true})
+	_, err := store.PutArtifactType(context.Background(), stored, &mlmd.PutTypeOptions{AllFieldsMustMatch: true})
 	if err != nil {
 		t.Fatalf("store.PutArtifactType failure: %v ", err)
 	}
@@ -372,7 +407,7 @@ func TestListArtifactTypes(t *testing.T) {
 		svc := New(store)
 
 		for _, aType := range test.stored {
-			_, err := store.PutArtifactType(aType, &mlmetadata.PutTypeOptions{AllFieldsMustMatch: true})
+			_, err := store.PutArtifactType(ctx, aType, &mlmd.PutTypeOptions{AllFieldsMustMatch: true})
 			if err != nil {
 				t.Fatalf("Failed to create ArtifactType %+v: %v", aType, err)
 			}
@@ -435,7 +470,8 @@ func TestCreateArtifact(t *testing.T) {
 				properties { key: "double_field" value { double_value: 1.1 }}
 				custom_properties { key: "custom_string_field" value { string_value: "custom string value" }}
 				custom_properties { key: "custom_int_field" value { int_value: 200 }}
-				custom_properties { key: "custom_double_field" value { double_value: 2.2 }} }`,
+				custom_properties { key: "custom_double_field" value { double_value: 2.2 }}
+				custom_properties { key: "__kf_workspace" value { string_value: "__kf_default_workspace" }} }`,
 		},
 		// TODO(neuromage): Add more test cases.
 	}
@@ -480,7 +516,7 @@ func TestGetArtifact(t *testing.T) {
 		},
 	}
 
-	typeID, err := store.PutArtifactType(aType, &mlmetadata.PutTypeOptions{AllFieldsMustMatch: true})
+	typeID, err := store.PutArtifactType(context.Background(), aType, &mlmd.PutTypeOptions{AllFieldsMustMatch: true})
 	if err != nil {
 		t.Fatalf("Failed to create ArtifactType %+v: %v", aType, err)
 	}
@@ -522,7 +558,7 @@ func TestGetArtifact(t *testing.T) {
 		}
 		stored.TypeId = proto.Int64(int64(typeID))
 
-		_, err := store.PutArtifacts([]*mlpb.Artifact{stored})
+		_, err := store.PutArtifacts(ctx, []*mlpb.Artifact{stored})
 		if err != nil {
 			t.Errorf("Test case %d\nstore.PutArtifact failure: %v ", i, err)
 		}
@@ -624,6 +660,523 @@ func TestListArtifacts(t *testing.T) {
 	}
 }
 
+func TestListArtifactsWithPagination(t *testing.T) {
+	store := testMLMDStore(t)
+	svc := New(store)
+
+	storeArtifact(
+		t,
+		store,
+		"kubeflow.org/v1/Model",
+		[]*mlpb.Artifact{
+			&mlpb.Artifact{Uri: proto.String("artifact_1")},
+			&mlpb.Artifact{Uri: proto.String("artifact_2")},
+			&mlpb.Artifact{Uri: proto.String("artifact_3")}})
+
+	ctx := context.Background()
+	var uris []string
+	req := &api.ListArtifactsRequest{PageSize: 2}
+	for page := 0; ; page++ {
+		resp, err := svc.ListArtifacts(ctx, req)
+		if err != nil {
+			t.Fatalf("ListArtifacts(%v) = %v\nWant nil error", req, err)
+		}
+		if len(resp.GetArtifacts()) > 2 {
+			t.Fatalf("ListArtifacts(%v) returned %d artifacts\nWant at most 2", req, len(resp.GetArtifacts()))
+		}
+		for _, a := range resp.GetArtifacts() {
+			uris = append(uris, a.GetUri())
+		}
+		if page == 0 {
+			// Artifacts inserted between pages must not invalidate the token.
+			storeArtifact(t, store, "kubeflow.org/v1/Model", []*mlpb.Artifact{&mlpb.Artifact{Uri: proto.String("artifact_4")}})
+		}
+		if resp.GetNextPageToken() == "" {
+			break
+		}
+		req = &api.ListArtifactsRequest{PageSize: 2, PageToken: resp.GetNextPageToken()}
+	}
+
+	want := []string{"artifact_1", "artifact_2", "artifact_3", "artifact_4"}
+	if !cmp.Equal(uris, want) {
+		t.Errorf("Paginated ListArtifacts got URIs %v\nWant %v", uris, want)
+	}
+
+	// Requests without page size get pages of defaultPageSize artifacts.
+	var more []*mlpb.Artifact
+	for i := 0; i < defaultPageSize; i++ {
+		more = append(more, &mlpb.Artifact{Uri: proto.String(fmt.Sprintf("more_%d", i))})
+	}
+	storeArtifact(t, store, "kubeflow.org/v1/Model", more)
+	resp, err := svc.ListArtifacts(ctx, &api.ListArtifactsRequest{})
+	if err != nil || len(resp.GetArtifacts()) != defaultPageSize || resp.GetNextPageToken() == "" {
+		t.Errorf("ListArtifacts without page size = %d artifacts, token %q, %v\nWant %d artifacts and a token", len(resp.GetArtifacts()), resp.GetNextPageToken(), err, defaultPageSize)
+	}
+
+	// Pages of filtered artifacts are filled from as many reads as needed.
+	uris = nil
+	req = &api.ListArtifactsRequest{PageSize: 1, Filter: `uri = "artifact_4" OR uri = "more_50"`}
+	for {
+		resp, err := svc.ListArtifacts(ctx, req)
+		if err != nil {
+			t.Fatalf("ListArtifacts(%v) = %v\nWant nil error", req, err)
+		}
+		for _, a := range resp.GetArtifacts() {
+			uris = append(uris, a.GetUri())
+		}
+		if resp.GetNextPageToken() == "" {
+			break
+		}
+		req.PageToken = resp.GetNextPageToken()
+	}
+	if want := []string{"artifact_4", "more_50"}; !cmp.Equal(uris, want) {
+		t.Errorf("Paginated ListArtifacts with filter got URIs %v\nWant %v", uris, want)
+	}
+
+	// Reads grow when filtering leaves a page short, as stores without paged
+	// queries, like MLMD, read all the records for each.
+	counting := &listCountingStore{MetadataStore: store}
+	resp, err = New(counting).ListArtifacts(ctx, &api.ListArtifactsRequest{PageSize: 1, Filter: `uri = "more_50"`})
+	if err != nil || len(resp.GetArtifacts()) != 1 || counting.lists != 2 {
+		t.Errorf("ListArtifacts with filter = %v, %v after %d reads\nWant 1 artifact after 2 reads", resp, err, counting.lists)
+	}
+
+	for _, req := range []*api.ListArtifactsRequest{
+		{PageToken: "not a token"},
+		{PageSize: -1},
+	} {
+		if _, err := svc.ListArtifacts(ctx, req); err == nil {
+			t.Errorf("ListArtifacts(%v) = nil error\nWant non-nil error", req)
+		}
+	}
+}
+
+// listCountingStore counts the calls to ListArtifacts and GetArtifactsByType.
+type listCountingStore struct {
+	MetadataStore
+	lists  int
+	byType int
+}
+
+func (s *listCountingStore) GetArtifactsByType(ctx context.Context, typeName string) ([]*mlpb.Artifact, error) {
+	s.byType++
+	return s.MetadataStore.GetArtifactsByType(ctx, typeName)
+}
+
+func (s *listCountingStore) ListArtifacts(ctx context.Context, typeName string, opts *mlmd.ListOptions) ([]*mlpb.Artifact, error) {
+	s.lists++
+	return s.MetadataStore.ListArtifacts(ctx, typeName, opts)
+}
+
+func TestListArtifactsWithFilter(t *testing.T) {
+	store := testMLMDStore(t)
+	svc := New(store)
+
+	aType := &mlpb.ArtifactType{
+		Name:       proto.String("kubeflow.org/v1/Model"),
+		Properties: map[string]mlpb.PropertyType{"accuracy": mlpb.PropertyType_DOUBLE},
+	}
+	typeID, err := store.PutArtifactType(context.Background(), aType, &mlmd.PutTypeOptions{AllFieldsMustMatch: true})
+	if err != nil {
+		t.Fatalf("Failed to create ArtifactType %+v: %v", aType, err)
+	}
+	for i, accuracy := range []float64{0.8, 0.92, 0.97} {
+		artifact := &mlpb.Artifact{
+			TypeId:           proto.Int64(int64(typeID)),
+			Uri:              proto.String(fmt.Sprintf("artifact_%d", i+1)),
+			Properties:       map[string]*mlpb.Value{"accuracy": &mlpb.Value{Value: &mlpb.Value_DoubleValue{DoubleValue: accuracy}}},
+			CustomProperties: map[string]*mlpb.Value{"team": &mlpb.Value{Value: &mlpb.Value_StringValue{StringValue: "vision"}}},
+		}
+		if i == 2 {
+			artifact.CustomProperties["team"] = &mlpb.Value{Value: &mlpb.Value_StringValue{StringValue: "nlp"}}
+		}
+		if _, err := store.PutArtifacts(context.Background(), []*mlpb.Artifact{artifact}); err != nil {
+			t.Fatalf("Failed to put Artifact %+v: %v", artifact, err)
+		}
+	}
+
+	tests := []struct {
+		request  *api.ListArtifactsRequest
+		wantURIs []string
+	}{
+		{
+			request:  &api.ListArtifactsRequest{Filter: `properties.accuracy > 0.9`},
+			wantURIs: []string{"artifact_2", "artifact_3"},
+		},
+		{
+			request:  &api.ListArtifactsRequest{Filter: `properties.accuracy > 0.9 AND custom_properties.team = "vision"`},
+			wantURIs: []string{"artifact_2"},
+		},
+		{
+			request:  &api.ListArtifactsRequest{Name: "kubeflow.org/v1/Model", Filter: `uri = "artifact_1" OR custom_properties.team = "nlp"`},
+			wantURIs: []string{"artifact_1", "artifact_3"},
+		},
+		{
+			request:  &api.ListArtifactsRequest{Filter: `properties.accuracy > 0.85`, PageSize: 1},
+			wantURIs: []string{"artifact_2"},
+		},
+		{
+			request:  &api.ListArtifactsRequest{Filter: `properties.accuracy > 1`},
+			wantURIs: nil,
+		},
+	}
+
+	ctx := context.Background()
+	for i, test := range tests {
+		got, err := svc.ListArtifacts(ctx, test.request)
+		if err != nil {
+			t.Errorf("Test case %d\nListArtifacts(%v) = %v\nWant nil error", i, test.request, err)
+			continue
+		}
+		var uris []string
+		for _, a := range got.GetArtifacts() {
+			uris = append(uris, a.GetUri())
+		}
+		if !cmp.Equal(uris, test.wantURIs) {
+			t.Errorf("Test case %d\nListArtifacts(%v) got URIs %v\nWant %v", i, test.request, uris, test.wantURIs)
+		}
+	}
+
+	req := &api.ListArtifactsRequest{Filter: `properties.accuracy >`}
+	if _, err := svc.ListArtifacts(ctx, req); status.Code(err) != codes.InvalidArgument {
+		t.Errorf("ListArtifacts(%v) = %v\nWant InvalidArgument error", req, err)
+	}
+}
+
+func TestUpdateArtifact(t *testing.T) {
+	store := testMLMDStore(t)
+	svc := New(store)
+
+	artifacts := storeArtifact(
+		t,
+		store,
+		"kubeflow.org/v1/Model",
+		[]*mlpb.Artifact{
+			&mlpb.Artifact{
+				Uri: proto.String("gs://bucket/v1"),
+				CustomProperties: map[string]*mlpb.Value{
+					"team":  &mlpb.Value{Value: &mlpb.Value_StringValue{StringValue: "vision"}},
+					"owner": &mlpb.Value{Value: &mlpb.Value_StringValue{StringValue: "alice"}},
+				},
+			}})
+
+	ctx := context.Background()
+	name := fmt.Sprintf("artifact_types/kubeflow.org/v1/Model/artifacts/%d", artifacts[0])
+	update := &mlpb.Artifact{
+		Uri: proto.String("gs://bucket/v2"),
+		CustomProperties: map[string]*mlpb.Value{
+			"team": &mlpb.Value{Value: &mlpb.Value_StringValue{StringValue: "speech"}},
+		},
+	}
+
+	// Only the uri and custom property listed in the mask change; "owner" is
+	// removed since it is listed but absent from the update.
+	resp, err := svc.UpdateArtifact(ctx, &api.UpdateArtifactRequest{
+		Name:       name,
+		Artifact:   update,
+		UpdateMask: &field_mask.FieldMask{Paths: []string{"uri", "custom_properties.owner"}},
+	})
+	if err != nil {
+		t.Fatalf("UpdateArtifact failed: %v", err)
+	}
+	want := &mlpb.Artifact{
+		Id:     proto.Int64(int64(artifacts[0])),
+		TypeId: resp.GetArtifact().TypeId,
+		Uri:    proto.String("gs://bucket/v2"),
+		CustomProperties: map[string]*mlpb.Value{
+			"team": &mlpb.Value{Value: &mlpb.Value_StringValue{StringValue: "vision"}},
+		},
+	}
+	if !proto.Equal(resp.GetArtifact(), want) {
+		t.Errorf("UpdateArtifact got %v\nWant %v", resp.GetArtifact(), want)
+	}
+
+	// Masks in the form produced by the HTTP gateway are accepted as well.
+	resp, err = svc.UpdateArtifact(ctx, &api.UpdateArtifactRequest{
+		Name:       name,
+		Artifact:   update,
+		UpdateMask: &field_mask.FieldMask{Paths: []string{"CustomProperties.Team.StringValue"}},
+	})
+	if err != nil {
+		t.Fatalf("UpdateArtifact failed: %v", err)
+	}
+	if got := resp.GetArtifact().GetCustomProperties()["team"].GetStringValue(); got != "speech" {
+		t.Errorf("UpdateArtifact got custom property team = %q\nWant %q", got, "speech")
+	}
+
+	get, err := svc.GetArtifact(ctx, &api.GetArtifactRequest{Name: name})
+	if err != nil || !proto.Equal(get.GetArtifact(), resp.GetArtifact()) {
+		t.Errorf("GetArtifact after update = %v, %v\nWant %v, nil", get.GetArtifact(), err, resp.GetArtifact())
+	}
+
+	tests := []struct {
+		desc     string
+		artifact *mlpb.Artifact
+		mask     *field_mask.FieldMask
+	}{
+		{"empty mask", update, nil},
+		{"id in mask", update, &field_mask.FieldMask{Paths: []string{"id"}}},
+		{"type_id in mask", update, &field_mask.FieldMask{Paths: []string{"type_id"}}},
+		{"unknown path", update, &field_mask.FieldMask{Paths: []string{"foo"}}},
+		{"nested uri path", update, &field_mask.FieldMask{Paths: []string{"uri.foo"}}},
+		{"changed id", &mlpb.Artifact{Id: proto.Int64(int64(artifacts[0]) + 1)}, &field_mask.FieldMask{Paths: []string{"uri"}}},
+		{"changed type_id", &mlpb.Artifact{TypeId: proto.Int64(-1)}, &field_mask.FieldMask{Paths: []string{"uri"}}},
+		{"reserved custom property", update, &field_mask.FieldMask{Paths: []string{"custom_properties.__kf_deleted"}}},
+	}
+	for _, test := range tests {
+		req := &api.UpdateArtifactRequest{Name: name, Artifact: test.artifact, UpdateMask: test.mask}
+		if _, err := svc.UpdateArtifact(ctx, req); status.Code(err) != codes.InvalidArgument {
+			t.Errorf("Test %q: UpdateArtifact(%v) = %v\nWant InvalidArgument error", test.desc, req, err)
+		}
+	}
+}
+
+func TestArtifactDocument(t *testing.T) {
+	store := testMLMDStore(t)
+	svc := New(store)
+	ctx := context.Background()
+
+	aType := &mlpb.ArtifactType{
+		Name: proto.String("kubeflow.org/v1/Model"),
+		Properties: map[string]mlpb.PropertyType{
+			"name":         mlpb.PropertyType_STRING,
+			"epochs":       mlpb.PropertyType_INT,
+			"accuracy":     mlpb.PropertyType_DOUBLE,
+			"__ALL_META__": mlpb.PropertyType_STRING,
+		},
+	}
+	if _, err := svc.CreateArtifactType(ctx, &api.CreateArtifactTypeRequest{ArtifactType: aType}); err != nil {
+		t.Fatalf("CreateArtifactType failed: %v", err)
+	}
+
+	document := func(artifact *mlpb.Artifact) map[string]interface{} {
+		var doc map[string]interface{}
+		if err := json.Unmarshal([]byte(artifact.GetProperties()["__ALL_META__"].GetStringValue()), &doc); err != nil {
+			t.Fatalf("Artifact %v holds no JSON document: %v", artifact, err)
+		}
+		return doc
+	}
+	allMeta := func(doc string) *mlpb.Artifact {
+		return &mlpb.Artifact{Properties: map[string]*mlpb.Value{
+			"__ALL_META__": &mlpb.Value{Value: &mlpb.Value_StringValue{StringValue: doc}},
+		}}
+	}
+
+	// Simple fields are projected into typed properties and the uri; the id
+	// is the one assigned by the store.
+	created, err := svc.CreateArtifact(ctx, &api.CreateArtifactRequest{
+		Parent:   "artifact_types/kubeflow.org/v1/Model",
+		Artifact: allMeta(`{"id": "999", "name": "mnist", "uri": "gs://bucket/mnist", "epochs": 10, "accuracy": 0.5, "hyperparameters": {"layers": [10, 3]}}`),
+	})
+	if err != nil {
+		t.Fatalf("CreateArtifact failed: %v", err)
+	}
+	artifact := created.GetArtifact()
+	if artifact.GetUri() != "gs://bucket/mnist" ||
+		artifact.GetProperties()["name"].GetStringValue() != "mnist" ||
+		artifact.GetProperties()["epochs"].GetIntValue() != 10 ||
+		artifact.GetProperties()["accuracy"].GetDoubleValue() != 0.5 {
+		t.Errorf("CreateArtifact did not project the document into typed properties: %v", artifact)
+	}
+	want := map[string]interface{}{
+		"id":              fmt.Sprint(artifact.GetId()),
+		"name":            "mnist",
+		"uri":             "gs://bucket/mnist",
+		"epochs":          10.0,
+		"accuracy":        0.5,
+		"hyperparameters": map[string]interface{}{"layers": []interface{}{10.0, 3.0}},
+	}
+	if diff := cmp.Diff(want, document(artifact)); diff != "" {
+		t.Errorf("CreateArtifact returned unexpected document. Diff (-want, +got):\n%s", diff)
+	}
+
+	// Only the fields without a typed property are stored in the document.
+	stored, err := store.GetArtifactsByID(ctx, []mlmd.ArtifactID{mlmd.ArtifactID(artifact.GetId())})
+	if err != nil || len(stored) != 1 {
+		t.Fatalf("GetArtifactsByID = %v, %v", stored, err)
+	}
+	if diff := cmp.Diff(map[string]interface{}{"hyperparameters": want["hyperparameters"]}, document(stored[0])); diff != "" {
+		t.Errorf("Stored unexpected document. Diff (-want, +got):\n%s", diff)
+	}
+
+	// Updating a typed property updates the document.
+	name := fmt.Sprintf("artifact_types/kubeflow.org/v1/Model/artifacts/%d", artifact.GetId())
+	if _, err := svc.UpdateArtifact(ctx, &api.UpdateArtifactRequest{
+		Name: name,
+		Artifact: &mlpb.Artifact{Properties: map[string]*mlpb.Value{
+			"epochs": &mlpb.Value{Value: &mlpb.Value_IntValue{IntValue: 20}},
+		}},
+		UpdateMask: &field_mask.FieldMask{Paths: []string{"properties.epochs"}},
+	}); err != nil {
+		t.Fatalf("UpdateArtifact failed: %v", err)
+	}
+	get, err := svc.GetArtifact(ctx, &api.GetArtifactRequest{Name: name})
+	if err != nil {
+		t.Fatalf("GetArtifact failed: %v", err)
+	}
+	want["epochs"] = 20.0
+	if diff := cmp.Diff(want, document(get.GetArtifact())); diff != "" {
+		t.Errorf("GetArtifact returned unexpected document. Diff (-want, +got):\n%s", diff)
+	}
+
+	// Events and lineage return the whole document as well.
+	if _, err := svc.CreateExecutionType(ctx, &api.CreateExecutionTypeRequest{ExecutionType: &mlpb.ExecutionType{Name: proto.String("kubeflow.org/v1/Train")}}); err != nil {
+		t.Fatalf("CreateExecutionType failed: %v", err)
+	}
+	execution, err := svc.CreateExecution(ctx, &api.CreateExecutionRequest{Parent: "execution_types/kubeflow.org/v1/Train", Execution: &mlpb.Execution{}})
+	if err != nil {
+		t.Fatalf("CreateExecution failed: %v", err)
+	}
+	event := &mlpb.Event{ArtifactId: proto.Int64(artifact.GetId()), ExecutionId: proto.Int64(execution.GetExecution().GetId()), Type: mlpb.Event_OUTPUT.Enum()}
+	if _, err := svc.CreateEvent(ctx, &api.CreateEventRequest{Event: event}); err != nil {
+		t.Fatalf("CreateEvent failed: %v", err)
+	}
+	events, err := svc.ListEvents(ctx, &api.ListEventsRequest{Name: fmt.Sprintf("artifacts/%d", artifact.GetId())})
+	if err != nil {
+		t.Fatalf("ListEvents failed: %v", err)
+	}
+	if diff := cmp.Diff(want, document(events.GetArtifacts()[artifact.GetId()])); diff != "" {
+		t.Errorf("ListEvents returned unexpected document. Diff (-want, +got):\n%s", diff)
+	}
+	lineage, err := svc.GetLineage(ctx, &api.GetLineageRequest{Name: fmt.Sprintf("artifacts/%d", artifact.GetId())})
+	if err != nil {
+		t.Fatalf("GetLineage failed: %v", err)
+	}
+	if diff := cmp.Diff(want, document(lineage.GetArtifacts()[artifact.GetId()])); diff != "" {
+		t.Errorf("GetLineage returned unexpected document. Diff (-want, +got):\n%s", diff)
+	}
+
+	// Updating the document updates the typed properties it sets.
+	updated, err := svc.UpdateArtifact(ctx, &api.UpdateArtifactRequest{
+		Name:       name,
+		Artifact:   allMeta(`{"name": "fashion-mnist", "hyperparameters": {}}`),
+		UpdateMask: &field_mask.FieldMask{Paths: []string{"properties.__ALL_META__"}},
+	})
+	if err != nil {
+		t.Fatalf("UpdateArtifact failed: %v", err)
+	}
+	want["name"] = "fashion-mnist"
+	want["hyperparameters"] = map[string]interface{}{}
+	if diff := cmp.Diff(want, document(updated.GetArtifact())); diff != "" {
+		t.Errorf("UpdateArtifact returned unexpected document. Diff (-want, +got):\n%s", diff)
+	}
+
+	for _, doc := range []string{`{`, `[]`, `null`, `{} {}`} {
+		_, err := svc.CreateArtifact(ctx, &api.CreateArtifactRequest{
+			Parent:   "artifact_types/kubeflow.org/v1/Model",
+			Artifact: allMeta(doc),
+		})
+		if status.Code(err) != codes.InvalidArgument {
+			t.Errorf("CreateArtifact with document %q = %v\nWant InvalidArgument error", doc, err)
+		}
+	}
+}
+
+func TestNestedArtifactDocument(t *testing.T) {
+	store := testMLMDStore(t)
+	svc := New(store)
+	ctx := context.Background()
+
+	aType := &mlpb.ArtifactType{
+		Name: proto.String("kubeflow.org/v1/Model"),
+		Properties: map[string]mlpb.PropertyType{
+			"training_framework.name": mlpb.PropertyType_STRING,
+			"early_stop":              mlpb.PropertyType_INT,
+			"layers":                  mlpb.PropertyType_STRING,
+			"__ALL_META__":            mlpb.PropertyType_STRING,
+		},
+	}
+	if _, err := svc.CreateArtifactType(ctx, &api.CreateArtifactTypeRequest{ArtifactType: aType}); err != nil {
+		t.Fatalf("CreateArtifactType failed: %v", err)
+	}
+
+	document := func(artifact *mlpb.Artifact) map[string]interface{} {
+		var doc map[string]interface{}
+		if err := json.Unmarshal([]byte(artifact.GetProperties()["__ALL_META__"].GetStringValue()), &doc); err != nil {
+			t.Fatalf("Artifact %v holds no JSON document: %v", artifact, err)
+		}
+		return doc
+	}
+	allMeta := func(doc string) *mlpb.Artifact {
+		return &mlpb.Artifact{Properties: map[string]*mlpb.Value{
+			"__ALL_META__": &mlpb.Value{Value: &mlpb.Value_StringValue{StringValue: doc}},
+		}}
+	}
+
+	created, err := svc.CreateArtifact(ctx, &api.CreateArtifactRequest{
+		Parent:   "artifact_types/kubeflow.org/v1/Model",
+		Artifact: allMeta(`{"training_framework": {"name": "tensorflow", "version": "v1.0"}, "early_stop": true, "layers": [10, 3]}`),
+	})
+	if err != nil {
+		t.Fatalf("CreateArtifact failed: %v", err)
+	}
+	artifact := created.GetArtifact()
+	wantProperties := map[string]interface{}{
+		"training_framework.name": "tensorflow",
+		"early_stop":              int64(1),
+		"layers":                  "[10,3]",
+	}
+	gotProperties := make(map[string]interface{})
+	for name, v := range artifact.GetProperties() {
+		switch v.GetValue().(type) {
+		case *mlpb.Value_IntValue:
+			gotProperties[name] = v.GetIntValue()
+		case *mlpb.Value_StringValue:
+			if name != "__ALL_META__" {
+				gotProperties[name] = v.GetStringValue()
+			}
+		}
+	}
+	if diff := cmp.Diff(wantProperties, gotProperties); diff != "" {
+		t.Errorf("CreateArtifact returned unexpected properties. Diff (-want, +got):\n%s", diff)
+	}
+	want := map[string]interface{}{
+		"id":                 fmt.Sprint(artifact.GetId()),
+		"training_framework": map[string]interface{}{"name": "tensorflow", "version": "v1.0"},
+		"early_stop":         true,
+		"layers":             []interface{}{10.0, 3.0},
+	}
+	if diff := cmp.Diff(want, document(artifact)); diff != "" {
+		t.Errorf("CreateArtifact returned unexpected document. Diff (-want, +got):\n%s", diff)
+	}
+
+	// Nested properties can be updated and filtered on by their dotted names.
+	name := fmt.Sprintf("artifact_types/kubeflow.org/v1/Model/artifacts/%d", artifact.GetId())
+	if _, err := svc.UpdateArtifact(ctx, &api.UpdateArtifactRequest{
+		Name: name,
+		Artifact: &mlpb.Artifact{Properties: map[string]*mlpb.Value{
+			"training_framework.name": &mlpb.Value{Value: &mlpb.Value_StringValue{StringValue: "pytorch"}},
+		}},
+		UpdateMask: &field_mask.FieldMask{Paths: []string{"properties.training_framework.name"}},
+	}); err != nil {
+		t.Fatalf("UpdateArtifact failed: %v", err)
+	}
+	list, err := svc.ListArtifacts(ctx, &api.ListArtifactsRequest{Filter: `properties.training_framework.name = "pytorch"`})
+	if err != nil || len(list.GetArtifacts()) != 1 {
+		t.Fatalf("ListArtifacts with a nested property filter = %v, %v\nWant the updated artifact", list, err)
+	}
+	want["training_framework"] = map[string]interface{}{"name": "pytorch", "version": "v1.0"}
+	if diff := cmp.Diff(want, document(list.GetArtifacts()[0])); diff != "" {
+		t.Errorf("ListArtifacts returned unexpected document. Diff (-want, +got):\n%s", diff)
+	}
+
+	// Properties derived from booleans and arrays follow the document.
+	updated, err := svc.UpdateArtifact(ctx, &api.UpdateArtifactRequest{
+		Name:       name,
+		Artifact:   allMeta(`{"early_stop": false, "layers": [5]}`),
+		UpdateMask: &field_mask.FieldMask{Paths: []string{"properties.__ALL_META__"}},
+	})
+	if err != nil {
+		t.Fatalf("UpdateArtifact failed: %v", err)
+	}
+	if got := updated.GetArtifact().GetProperties()["early_stop"].GetIntValue(); got != 0 {
+		t.Errorf("UpdateArtifact got property early_stop = %d\nWant 0", got)
+	}
+	if got := updated.GetArtifact().GetProperties()["layers"].GetStringValue(); got != "[5]" {
+		t.Errorf("UpdateArtifact got property layers = %q\nWant %q", got, "[5]")
+	}
+}
+
 func TestCreateExecutionType(t *testing.T) {
 	store := testMLMDStore(t)
 	svc := New(store)
@@ -793,7 +1346,7 @@ func TestGetExecutionType(t *testing.T) {
 			continue
 		}
 
-		_, err := store.PutExecutionType(stored, &mlmetadata.PutTypeOptions{AllFieldsMustMatch: true})
+		_, err := store.PutExecutionType(ctx, stored, &mlmd.PutTypeOptions{AllFieldsMustMatch: true})
 		if err != nil {
 			t.Errorf("Test case %d\nstore.PutExecutionType failure: %v ", i, err)
 		}
@@ -826,6 +1379,7 @@ func TestListExecutionTypes(t *testing.T) {
 			stored: []*mlpb.ExecutionType{
 				&mlpb.ExecutionType{Name: proto.String("my_namespace1/Exec1")},
 				&mlpb.ExecutionType{Name: proto.String("my_namespace2/Exec2")},
+				&mlpb.ExecutionType{Name: proto.String("__kf_reserved/Exec3")},
 			},
 			wantResponse: &api.ListExecutionTypesResponse{
 				ExecutionTypes: []*mlpb.ExecutionType{
@@ -842,7 +1396,7 @@ func TestListExecutionTypes(t *testing.T) {
 		svc := New(store)
 
 		for _, aType := range test.stored {
-			_, err := store.PutExecutionType(aType, &mlmetadata.PutTypeOptions{AllFieldsMustMatch: true})
+			_, err := store.PutExecutionType(ctx, aType, &mlmd.PutTypeOptions{AllFieldsMustMatch: true})
 			if err != nil {
 				t.Fatalf("Failed to create ExecutionType %+v: %v", aType, err)
 			}
@@ -903,7 +1457,8 @@ func TestCreateExecution(t *testing.T) {
 				properties { key: "double_field" value { double_value: 1.1 }}
 				custom_properties { key: "custom_string_field" value { string_value: "custom string value" }}
 				custom_properties { key: "custom_int_field" value { int_value: 200 }}
-				custom_properties { key: "custom_double_field" value { double_value: 2.2 }} }`,
+				custom_properties { key: "custom_double_field" value { double_value: 2.2 }}
+				custom_properties { key: "__kf_workspace" value { string_value: "__kf_default_workspace" }} }`,
 		},
 		// TODO(neuromage): Add more test cases.
 	}
@@ -948,7 +1503,7 @@ func TestGetExecution(t *testing.T) {
 		},
 	}
 
-	typeID, err := store.PutExecutionType(aType, &mlmetadata.PutTypeOptions{AllFieldsMustMatch: true})
+	typeID, err := store.PutExecutionType(context.Background(), aType, &mlmd.PutTypeOptions{AllFieldsMustMatch: true})
 	if err != nil {
 		t.Fatalf("Failed to create ExecutionType %+v: %v", aType, err)
 	}
@@ -988,7 +1543,7 @@ func TestGetExecution(t *testing.T) {
 		}
 		stored.TypeId = proto.Int64(int64(typeID))
 
-		_, err := store.PutExecutions([]*mlpb.Execution{stored})
+		_, err := store.PutExecutions(ctx, []*mlpb.Execution{stored})
 		if err != nil {
 			t.Errorf("Test case %d\nstore.PutExecution failure: %v ", i, err)
 		}
@@ -1092,6 +1647,122 @@ func TestListExecutions(t *testing.T) {
 	}
 }
 
+func TestListExecutionsWithPagination(t *testing.T) {
+	store := testMLMDStore(t)
+	svc := New(store)
+
+	var executions []*mlpb.Execution
+	for i := 0; i < 5; i++ {
+		executions = append(executions, &mlpb.Execution{Properties: map[string]*mlpb.Value{
+			"name": &mlpb.Value{Value: &mlpb.Value_StringValue{StringValue: fmt.Sprintf("e%d", i)}}}})
+	}
+	storeExecution(t, store, "kubeflow.org/v1/MyExecutionType1", executions)
+
+	tests := []struct {
+		pageSize  int32
+		wantPages [][]string
+	}{
+		{0, [][]string{{"e0", "e1", "e2", "e3", "e4"}}},
+		{2, [][]string{{"e0", "e1"}, {"e2", "e3"}, {"e4"}}},
+		{5, [][]string{{"e0", "e1", "e2", "e3", "e4"}}},
+		{10, [][]string{{"e0", "e1", "e2", "e3", "e4"}}},
+		{maxPageSize + 1, [][]string{{"e0", "e1", "e2", "e3", "e4"}}},
+	}
+
+	ctx := context.Background()
+	for i, test := range tests {
+		var pages [][]string
+		req := &api.ListExecutionsRequest{Name: "kubeflow.org/v1/MyExecutionType1", PageSize: test.pageSize}
+		for {
+			resp, err := svc.ListExecutions(ctx, req)
+			if err != nil {
+				t.Fatalf("Test case %d\nListExecutions(%v) = %v\nWant nil error", i, req, err)
+			}
+			var names []string
+			for _, e := range resp.GetExecutions() {
+				names = append(names, e.GetProperties()["name"].GetStringValue())
+			}
+			pages = append(pages, names)
+			if resp.GetNextPageToken() == "" {
+				break
+			}
+			req.PageToken = resp.GetNextPageToken()
+		}
+
+		if !cmp.Equal(pages, test.wantPages) {
+			t.Errorf("Test case %d\nPaginated ListExecutions got pages %v\nWant %v", i, pages, test.wantPages)
+		}
+	}
+}
+
+func TestUpdateExecution(t *testing.T) {
+	store := testMLMDStore(t)
+	svc := New(store)
+
+	running := mlpb.Execution_RUNNING
+	executions := storeExecution(
+		t,
+		store,
+		"kubeflow.org/v1/MyExecutionType1",
+		[]*mlpb.Execution{
+			&mlpb.Execution{
+				LastKnownState: &running,
+				Properties:     map[string]*mlpb.Value{"name": &mlpb.Value{Value: &mlpb.Value_StringValue{StringValue: "e1"}}},
+			}})
+
+	ctx := context.Background()
+	name := fmt.Sprintf("execution_types/kubeflow.org/v1/MyExecutionType1/executions/%d", executions[0])
+	complete := mlpb.Execution_COMPLETE
+	resp, err := svc.UpdateExecution(ctx, &api.UpdateExecutionRequest{
+		Name:       name,
+		Execution:  &mlpb.Execution{LastKnownState: &complete},
+		UpdateMask: &field_mask.FieldMask{Paths: []string{"last_known_state"}},
+	})
+	if err != nil {
+		t.Fatalf("UpdateExecution failed: %v", err)
+	}
+	want := &mlpb.Execution{
+		Id:             proto.Int64(int64(executions[0])),
+		TypeId:         resp.GetExecution().TypeId,
+		LastKnownState: &complete,
+		Properties:     map[string]*mlpb.Value{"name": &mlpb.Value{Value: &mlpb.Value_StringValue{StringValue: "e1"}}},
+	}
+	if !proto.Equal(resp.GetExecution(), want) {
+		t.Errorf("UpdateExecution got %v\nWant %v", resp.GetExecution(), want)
+	}
+
+	// Property values must still match the execution type.
+	_, err = svc.UpdateExecution(ctx, &api.UpdateExecutionRequest{
+		Name:       name,
+		Execution:  &mlpb.Execution{Properties: map[string]*mlpb.Value{"name": &mlpb.Value{Value: &mlpb.Value_IntValue{IntValue: 1}}}},
+		UpdateMask: &field_mask.FieldMask{Paths: []string{"properties.name"}},
+	})
+	if err == nil {
+		t.Errorf("UpdateExecution with a property of the wrong type = nil error\nWant non-nil error")
+	}
+
+	_, err = svc.UpdateExecution(ctx, &api.UpdateExecutionRequest{
+		Name:       name,
+		Execution:  &mlpb.Execution{LastKnownState: &running},
+		UpdateMask: &field_mask.FieldMask{Paths: []string{"uri"}},
+	})
+	if status.Code(err) != codes.InvalidArgument {
+		t.Errorf("UpdateExecution with mask path uri = %v\nWant InvalidArgument error", err)
+	}
+
+	if _, err := svc.DeleteExecution(ctx, &api.DeleteExecutionRequest{Name: name}); err != nil {
+		t.Fatalf("DeleteExecution(%q) = %v\nWant nil error", name, err)
+	}
+	_, err = svc.UpdateExecution(ctx, &api.UpdateExecutionRequest{
+		Name:       name,
+		Execution:  &mlpb.Execution{LastKnownState: &running},
+		UpdateMask: &field_mask.FieldMask{Paths: []string{"last_known_state"}},
+	})
+	if err == nil {
+		t.Errorf("UpdateExecution of a deleted execution = nil error\nWant non-nil error")
+	}
+}
+
 func TestCreateEventsAndList(t *testing.T) {
 	store := testMLMDStore(t)
 	svc := New(store)
@@ -1155,11 +1826,854 @@ func TestCreateEventsAndList(t *testing.T) {
 
 }
 
-func storeArtifact(t *testing.T, store *mlmetadata.Store, typename string, artifacts []*mlpb.Artifact) []mlmetadata.ArtifactID {
-	aType := &mlpb.ArtifactType{Name: proto.String(typename)}
+func TestGetLineage(t *testing.T) {
+	store := testMLMDStore(t)
+	svc := New(store)
 
-	typeID, err := store.PutArtifactType(aType, &mlmetadata.PutTypeOptions{AllFieldsMustMatch: true})
-	if err != nil {
+	// dataset1, dataset2 -> preprocess -> features -> train -> model -> eval -> metrics
+	executions := storeExecution(
+		t,
+		store,
+		"kubeflow.org/v1/MyExecutionType1",
+		[]*mlpb.Execution{
+			&mlpb.Execution{Properties: map[string]*mlpb.Value{"name": &mlpb.Value{Value: &mlpb.Value_StringValue{StringValue: "preprocess"}}}},
+			&mlpb.Execution{Properties: map[string]*mlpb.Value{"name": &mlpb.Value{Value: &mlpb.Value_StringValue{StringValue: "train"}}}},
+			&mlpb.Execution{Properties: map[string]*mlpb.Value{"name": &mlpb.Value{Value: &mlpb.Value_StringValue{StringValue: "eval"}}}},
+		})
+	preprocess, train, eval := int64(executions[0]), int64(executions[1]), int64(executions[2])
+	artifacts := storeArtifact(
+		t,
+		store,
+		"kubeflow.org/v1/Model",
+		[]*mlpb.Artifact{
+			&mlpb.Artifact{Uri: proto.String("dataset1")},
+			&mlpb.Artifact{Uri: proto.String("dataset2")},
+			&mlpb.Artifact{Uri: proto.String("features")},
+			&mlpb.Artifact{Uri: proto.String("model")},
+			&mlpb.Artifact{Uri: proto.String("metrics")}})
+	dataset1, dataset2, features, model, metrics := int64(artifacts[0]), int64(artifacts[1]), int64(artifacts[2]), int64(artifacts[3]), int64(artifacts[4])
+
+	ctx := context.Background()
+	inputType := mlpb.Event_INPUT
+	outputType := mlpb.Event_OUTPUT
+	for _, e := range []*mlpb.Event{
+		&mlpb.Event{ArtifactId: &dataset1, ExecutionId: &preprocess, Type: &inputType},
+		&mlpb.Event{ArtifactId: &dataset2, ExecutionId: &preprocess, Type: &inputType},
+		&mlpb.Event{ArtifactId: &features, ExecutionId: &preprocess, Type: &outputType},
+		&mlpb.Event{ArtifactId: &features, ExecutionId: &train, Type: &inputType},
+		&mlpb.Event{ArtifactId: &model, ExecutionId: &train, Type: &outputType},
+		&mlpb.Event{ArtifactId: &model, ExecutionId: &eval, Type: &inputType},
+		&mlpb.Event{ArtifactId: &metrics, ExecutionId: &eval, Type: &outputType},
+	} {
+		if _, err := svc.CreateEvent(ctx, &api.CreateEventRequest{Event: e}); err != nil {
+			t.Fatalf("Failed to create event %v, err %v\n", e, err)
+		}
+	}
+
+	modelName := fmt.Sprintf("artifact_types/kubeflow.org/v1/Model/artifacts/%d", model)
+	tests := []struct {
+		req            *api.GetLineageRequest
+		wantEvents     int
+		wantArtifacts  []int64
+		wantExecutions []int64
+	}{
+		{
+			req:            &api.GetLineageRequest{Name: modelName, Direction: api.GetLineageRequest_UPSTREAM},
+			wantEvents:     5,
+			wantArtifacts:  []int64{dataset1, dataset2, features, model},
+			wantExecutions: []int64{preprocess, train},
+		},
+		{
+			req:            &api.GetLineageRequest{Name: modelName, Direction: api.GetLineageRequest_UPSTREAM, MaxDepth: 2},
+			wantEvents:     2,
+			wantArtifacts:  []int64{features, model},
+			wantExecutions: []int64{train},
+		},
+		{
+			req:            &api.GetLineageRequest{Name: fmt.Sprintf("artifacts/%d", model), Direction: api.GetLineageRequest_DOWNSTREAM},
+			wantEvents:     2,
+			wantArtifacts:  []int64{model, metrics},
+			wantExecutions: []int64{eval},
+		},
+		{
+			req:            &api.GetLineageRequest{Name: modelName, MaxDepth: 1},
+			wantEvents:     2,
+			wantArtifacts:  []int64{model},
+			wantExecutions: []int64{train, eval},
+		},
+		{
+			req:            &api.GetLineageRequest{Name: fmt.Sprintf("executions/%d", preprocess), Direction: api.GetLineageRequest_DOWNSTREAM},
+			wantEvents:     5,
+			wantArtifacts:  []int64{features, model, metrics},
+			wantExecutions: []int64{preprocess, train, eval},
+		},
+	}
+
+	ids := func(m interface{}) []int64 {
+		var ids []int64
+		for _, k := range reflect.ValueOf(m).MapKeys() {
+			ids = append(ids, k.Int())
+		}
+		return ids
+	}
+	sortIDs := cmpopts.SortSlices(func(a, b int64) bool { return a < b })
+	for _, test := range tests {
+		resp, err := svc.GetLineage(ctx, test.req)
+		if err != nil {
+			t.Errorf("GetLineage(%v) failed: %v", test.req, err)
+			continue
+		}
+		if len(resp.GetEvents()) != test.wantEvents {
+			t.Errorf("GetLineage(%v) got %d events\nWant %d", test.req, len(resp.GetEvents()), test.wantEvents)
+		}
+		if diff := cmp.Diff(test.wantArtifacts, ids(resp.GetArtifacts()), sortIDs); diff != "" {
+			t.Errorf("GetLineage(%v) got unexpected artifact ids. Diff (-want, +got):\n%s", test.req, diff)
+		}
+		if diff := cmp.Diff(test.wantExecutions, ids(resp.GetExecutions()), sortIDs); diff != "" {
+			t.Errorf("GetLineage(%v) got unexpected execution ids. Diff (-want, +got):\n%s", test.req, diff)
+		}
+	}
+
+	// Lineage walks are bounded even without max_depth.
+	defer func(n int) { maxLineageNodes = n }(maxLineageNodes)
+	maxLineageNodes = 5
+	req := &api.GetLineageRequest{Name: modelName, Direction: api.GetLineageRequest_UPSTREAM}
+	if _, err := svc.GetLineage(ctx, req); status.Code(err) != codes.OutOfRange {
+		t.Errorf("GetLineage(%v) of 6 nodes with at most %d = %v\nWant OutOfRange error", req, maxLineageNodes, err)
+	}
+	maxLineageNodes = 6
+	if _, err := svc.GetLineage(ctx, req); err != nil {
+		t.Errorf("GetLineage(%v) of 6 nodes with at most %d = %v\nWant nil error", req, maxLineageNodes, err)
+	}
+
+	// Deleted nodes cut the lineage.
+	featuresName := fmt.Sprintf("artifact_types/kubeflow.org/v1/Model/artifacts/%d", features)
+	if _, err := svc.DeleteArtifact(ctx, &api.DeleteArtifactRequest{Name: featuresName, Cascade: true}); err != nil {
+		t.Fatalf("DeleteArtifact(%q) failed: %v", featuresName, err)
+	}
+	resp, err := svc.GetLineage(ctx, req)
+	if err != nil || len(resp.GetEvents()) != 1 || len(resp.GetArtifacts()) != 1 || len(resp.GetExecutions()) != 1 {
+		t.Errorf("GetLineage(%v) after deleting %q = %v, %v\nWant 1 event, the model and the train execution", req, featuresName, resp, err)
+	}
+
+	for _, req := range []*api.GetLineageRequest{
+		{Name: "models/1"},
+		{Name: "artifacts/abc"},
+		{Name: modelName, MaxDepth: -1},
+	} {
+		if _, err := svc.GetLineage(ctx, req); status.Code(err) != codes.InvalidArgument {
+			t.Errorf("GetLineage(%v) = %v\nWant InvalidArgument error", req, err)
+		}
+	}
+	if _, err := svc.GetLineage(ctx, &api.GetLineageRequest{Name: featuresName}); err == nil {
+		t.Errorf("GetLineage of deleted artifact %q = nil error\nWant non-nil error", featuresName)
+	}
+}
+
+func TestGetLineageAuthorization(t *testing.T) {
+	svc := New(testMLMDStore(t))
+	svc.SetAuthorizer(testAuthorizer{})
+	ctx := context.Background()
+
+	if _, err := svc.CreateArtifactType(ctx, &api.CreateArtifactTypeRequest{ArtifactType: &mlpb.ArtifactType{Name: proto.String("kubeflow.org/v1/Model")}}); err != nil {
+		t.Fatalf("CreateArtifactType failed: %v", err)
+	}
+	if _, err := svc.CreateExecutionType(ctx, &api.CreateExecutionTypeRequest{ExecutionType: &mlpb.ExecutionType{Name: proto.String("kubeflow.org/v1/Train")}}); err != nil {
+		t.Fatalf("CreateExecutionType failed: %v", err)
+	}
+	for _, ws := range []string{"teamA", "teamB"} {
+		if _, err := svc.CreateWorkspace(ctx, &api.CreateWorkspaceRequest{Workspace: &api.Workspace{Name: ws}}); err != nil {
+			t.Fatalf("CreateWorkspace(%s) failed: %v", ws, err)
+		}
+	}
+
+	// dataset (teamA) -> train (teamB) -> model (teamA)
+	var artifacts []int64
+	for i := 0; i < 2; i++ {
+		resp, err := svc.CreateArtifact(ctx, &api.CreateArtifactRequest{Parent: "artifact_types/kubeflow.org/v1/Model", Artifact: &mlpb.Artifact{}, Workspace: "teamA"})
+		if err != nil {
+			t.Fatalf("CreateArtifact failed: %v", err)
+		}
+		artifacts = append(artifacts, resp.GetArtifact().GetId())
+	}
+	dataset, model := artifacts[0], artifacts[1]
+	resp, err := svc.CreateExecution(ctx, &api.CreateExecutionRequest{Parent: "execution_types/kubeflow.org/v1/Train", Execution: &mlpb.Execution{}, Workspace: "teamB"})
+	if err != nil {
+		t.Fatalf("CreateExecution failed: %v", err)
+	}
+	train := resp.GetExecution().GetId()
+	for _, e := range []*mlpb.Event{
+		{ArtifactId: &dataset, ExecutionId: &train, Type: mlpb.Event_INPUT.Enum()},
+		{ArtifactId: &model, ExecutionId: &train, Type: mlpb.Event_OUTPUT.Enum()},
+	} {
+		if _, err := svc.CreateEvent(ctx, &api.CreateEventRequest{Event: e}); err != nil {
+			t.Fatalf("CreateEvent(%v) failed: %v", e, err)
+		}
+	}
+
+	// The walk stops at nodes the caller may not read, so that it does not
+	// reveal what lies beyond them.
+	reader := withGrants(map[string]Access{
+		"workspace teamA":           ReadAccess,
+		"namespace kubeflow.org/v1": ReadAccess,
+	})
+	req := &api.GetLineageRequest{Name: fmt.Sprintf("artifacts/%d", model), Direction: api.GetLineageRequest_UPSTREAM}
+	lineage, err := svc.GetLineage(reader, req)
+	if err != nil || len(lineage.GetEvents()) != 0 || len(lineage.GetExecutions()) != 0 || len(lineage.GetArtifacts()) != 1 {
+		t.Errorf("GetLineage(%v) = %v, %v\nWant only the model", req, lineage, err)
+	}
+}
+
+func TestDeleteArtifact(t *testing.T) {
+	store := testMLMDStore(t)
+	svc := New(store)
+
+	executions := storeExecution(
+		t,
+		store,
+		"kubeflow.org/v1/MyExecutionType1",
+		[]*mlpb.Execution{
+			&mlpb.Execution{Properties: map[string]*mlpb.Value{"name": &mlpb.Value{Value: &mlpb.Value_StringValue{StringValue: "e1"}}}},
+		})
+	artifacts := storeArtifact(
+		t,
+		store,
+		"kubeflow.org/v1/Model",
+		[]*mlpb.Artifact{
+			&mlpb.Artifact{Uri: proto.String("artifact_1")},
+			&mlpb.Artifact{Uri: proto.String("artifact_2")}})
+
+	ctx := context.Background()
+	inputType := mlpb.Event_INPUT
+	event := &mlpb.Event{
+		ArtifactId:  proto.Int64((int64)(artifacts[0])),
+		ExecutionId: proto.Int64((int64)(executions[0])),
+		Type:        &inputType,
+	}
+	if _, err := svc.CreateEvent(ctx, &api.CreateEventRequest{Event: event}); err != nil {
+		t.Fatalf("Failed to create event %v, err %v\n", event, err)
+	}
+
+	name1 := fmt.Sprintf("artifact_types/kubeflow.org/v1/Model/artifacts/%d", artifacts[0])
+	name2 := fmt.Sprintf("artifact_types/kubeflow.org/v1/Model/artifacts/%d", artifacts[1])
+
+	// An artifact without events can be deleted.
+	if _, err := svc.DeleteArtifact(ctx, &api.DeleteArtifactRequest{Name: name2}); err != nil {
+		t.Fatalf("DeleteArtifact(%q) = %v\nWant nil error", name2, err)
+	}
+	if _, err := svc.GetArtifact(ctx, &api.GetArtifactRequest{Name: name2}); err == nil {
+		t.Errorf("GetArtifact(%q) = nil error after deletion\nWant non-nil error", name2)
+	}
+	if _, err := svc.DeleteArtifact(ctx, &api.DeleteArtifactRequest{Name: name2}); err == nil {
+		t.Errorf("DeleteArtifact(%q) = nil error for a deleted artifact\nWant non-nil error", name2)
+	}
+
+	// An artifact referenced by an event needs cascade.
+	if _, err := svc.DeleteArtifact(ctx, &api.DeleteArtifactRequest{Name: name1}); err == nil {
+		t.Errorf("DeleteArtifact(%q) without cascade = nil error\nWant non-nil error", name1)
+	}
+	if _, err := svc.DeleteArtifact(ctx, &api.DeleteArtifactRequest{Name: name1, Cascade: true}); err != nil {
+		t.Fatalf("DeleteArtifact(%q) with cascade = %v\nWant nil error", name1, err)
+	}
+
+	list, err := svc.ListArtifacts(ctx, &api.ListArtifactsRequest{})
+	if err != nil || len(list.GetArtifacts()) != 0 {
+		t.Errorf("Expect no artifacts after deletion, but got response %v with err %v", list, err)
+	}
+
+	events, err := svc.ListEvents(ctx, &api.ListEventsRequest{Name: fmt.Sprintf("executions/%d", executions[0])})
+	if err != nil || len(events.GetEvents()) != 0 || len(events.GetArtifacts()) != 0 {
+		t.Errorf("Expect no events after cascading deletion, but got response %v with err %v", events, err)
+	}
+
+	if _, err := svc.CreateEvent(ctx, &api.CreateEventRequest{Event: event}); err == nil {
+		t.Errorf("CreateEvent referencing a deleted artifact = nil error\nWant non-nil error")
+	}
+}
+
+// failingGetEventsStore is a MetadataStore whose event lookups always fail.
+type failingGetEventsStore struct {
+	MetadataStore
+}
+
+func (s failingGetEventsStore) GetEventsByArtifactIDs(ctx context.Context, aids []mlmd.ArtifactID) ([]*mlpb.Event, error) {
+	return nil, status.Error(codes.Unavailable, "GetEventsByArtifactIDs failed")
+}
+
+func (s failingGetEventsStore) GetEventsByExecutionIDs(ctx context.Context, eids []mlmd.ExecutionID) ([]*mlpb.Event, error) {
+	return nil, status.Error(codes.Unavailable, "GetEventsByExecutionIDs failed")
+}
+
+// danglingEventsStore is a MetadataStore whose events all are event, which
+// may reference missing records.
+type danglingEventsStore struct {
+	MetadataStore
+	event *mlpb.Event
+}
+
+func (s danglingEventsStore) GetEventsByArtifactIDs(ctx context.Context, aids []mlmd.ArtifactID) ([]*mlpb.Event, error) {
+	return []*mlpb.Event{s.event}, nil
+}
+
+func (s danglingEventsStore) GetEventsByExecutionIDs(ctx context.Context, eids []mlmd.ExecutionID) ([]*mlpb.Event, error) {
+	return []*mlpb.Event{s.event}, nil
+}
+
+func TestListEventsErrors(t *testing.T) {
+	store := testMLMDStore(t)
+	svc := New(store)
+	ctx := context.Background()
+
+	for _, name := range []string{"artifacts/1", "executions/1"} {
+		req := &api.ListEventsRequest{Name: name}
+		if resp, err := svc.ListEvents(ctx, req); err != nil || len(resp.GetEvents()) != 0 {
+			t.Errorf("ListEvents(%v) = %v, %v\nWant no events", req, resp, err)
+		}
+		if _, err := New(failingGetEventsStore{store}).ListEvents(ctx, req); status.Code(err) != codes.Unavailable {
+			t.Errorf("ListEvents(%v) with failing store = %v\nWant Unavailable error", req, err)
+		}
+	}
+	// Events referencing missing records are reported as not found.
+	artifacts := storeArtifact(t, store, "kubeflow.org/v1/Model", []*mlpb.Artifact{&mlpb.Artifact{}})
+	for _, event := range []*mlpb.Event{
+		{ArtifactId: proto.Int64(42), ExecutionId: proto.Int64(42), Type: mlpb.Event_INPUT.Enum()},
+		{ArtifactId: proto.Int64(int64(artifacts[0])), ExecutionId: proto.Int64(42), Type: mlpb.Event_INPUT.Enum()},
+	} {
+		req := &api.ListEventsRequest{Name: "executions/42"}
+		if _, err := New(danglingEventsStore{store, event}).ListEvents(ctx, req); status.Code(err) != codes.NotFound {
+			t.Errorf("ListEvents(%v) of event %v = %v\nWant NotFound error", req, event, err)
+		}
+	}
+
+	for _, name := range []string{"artifacts/a", "executions/"} {
+		req := &api.ListEventsRequest{Name: name}
+		if _, err := svc.ListEvents(ctx, req); status.Code(err) != codes.InvalidArgument {
+			t.Errorf("ListEvents(%v) = %v\nWant InvalidArgument error", req, err)
+		}
+	}
+}
+
+func TestDeleteExecution(t *testing.T) {
+	store := testMLMDStore(t)
+	svc := New(store)
+
+	executions := storeExecution(
+		t,
+		store,
+		"kubeflow.org/v1/MyExecutionType1",
+		[]*mlpb.Execution{
+			&mlpb.Execution{Properties: map[string]*mlpb.Value{"name": &mlpb.Value{Value: &mlpb.Value_StringValue{StringValue: "e1"}}}},
+		})
+	artifacts := storeArtifact(
+		t,
+		store,
+		"kubeflow.org/v1/Model",
+		[]*mlpb.Artifact{
+			&mlpb.Artifact{Uri: proto.String("artifact_1")}})
+
+	ctx := context.Background()
+	outputType := mlpb.Event_OUTPUT
+	event := &mlpb.Event{
+		ArtifactId:  proto.Int64((int64)(artifacts[0])),
+		ExecutionId: proto.Int64((int64)(executions[0])),
+		Type:        &outputType,
+	}
+	if _, err := svc.CreateEvent(ctx, &api.CreateEventRequest{Event: event}); err != nil {
+		t.Fatalf("Failed to create event %v, err %v\n", event, err)
+	}
+
+	name := fmt.Sprintf("execution_types/kubeflow.org/v1/MyExecutionType1/executions/%d", executions[0])
+	if _, err := svc.DeleteExecution(ctx, &api.DeleteExecutionRequest{Name: name}); err == nil {
+		t.Errorf("DeleteExecution(%q) without cascade = nil error\nWant non-nil error", name)
+	}
+	if _, err := svc.DeleteExecution(ctx, &api.DeleteExecutionRequest{Name: name, Cascade: true}); err != nil {
+		t.Fatalf("DeleteExecution(%q) with cascade = %v\nWant nil error", name, err)
+	}
+	if _, err := svc.GetExecution(ctx, &api.GetExecutionRequest{Name: name}); err == nil {
+		t.Errorf("GetExecution(%q) = nil error after deletion\nWant non-nil error", name)
+	}
+
+	list, err := svc.ListExecutions(ctx, &api.ListExecutionsRequest{})
+	if err != nil || len(list.GetExecutions()) != 0 {
+		t.Errorf("Expect no executions after deletion, but got response %v with err %v", list, err)
+	}
+
+	// The artifact is no longer referenced, so it can be deleted without cascade.
+	artifactName := fmt.Sprintf("artifact_types/kubeflow.org/v1/Model/artifacts/%d", artifacts[0])
+	if _, err := svc.DeleteArtifact(ctx, &api.DeleteArtifactRequest{Name: artifactName}); err != nil {
+		t.Errorf("DeleteArtifact(%q) = %v\nWant nil error", artifactName, err)
+	}
+}
+
+func TestDeleteArtifactType(t *testing.T) {
+	store := testMLMDStore(t)
+	svc := New(store)
+
+	storeArtifact(
+		t,
+		store,
+		"kubeflow.org/v1/Model",
+		[]*mlpb.Artifact{
+			&mlpb.Artifact{Uri: proto.String("artifact_1")}})
+	storeArtifact(
+		t,
+		store,
+		"kubeflow.org/v1/Dataset",
+		[]*mlpb.Artifact{})
+
+	ctx := context.Background()
+	if _, err := svc.DeleteArtifactType(ctx, &api.DeleteArtifactTypeRequest{Name: "artifact_types/kubeflow.org/v1/Dataset"}); err != nil {
+		t.Fatalf("DeleteArtifactType of a type without artifacts = %v\nWant nil error", err)
+	}
+	if _, err := svc.DeleteArtifactType(ctx, &api.DeleteArtifactTypeRequest{Name: "artifact_types/kubeflow.org/v1/Model"}); err == nil {
+		t.Errorf("DeleteArtifactType of a type with artifacts = nil error\nWant non-nil error")
+	}
+	if _, err := svc.DeleteArtifactType(ctx, &api.DeleteArtifactTypeRequest{Name: "artifact_types/kubeflow.org/v1/Model", Force: true}); err != nil {
+		t.Fatalf("DeleteArtifactType with force = %v\nWant nil error", err)
+	}
+
+	types, err := svc.ListArtifactTypes(ctx, &api.ListArtifactTypesRequest{})
+	if err != nil || len(types.GetArtifactTypes()) != 0 {
+		t.Errorf("Expect no artifact types after deletion, but got response %v with err %v", types, err)
+	}
+	if _, err := svc.GetArtifactType(ctx, &api.GetArtifactTypeRequest{Name: "artifact_types/kubeflow.org/v1/Model"}); err == nil {
+		t.Errorf("GetArtifactType of a deleted type = nil error\nWant non-nil error")
+	}
+	artifacts, err := svc.ListArtifacts(ctx, &api.ListArtifactsRequest{})
+	if err != nil || len(artifacts.GetArtifacts()) != 0 {
+		t.Errorf("Expect no artifacts after forced type deletion, but got response %v with err %v", artifacts, err)
+	}
+
+	// The sqlite store removes deleted types, so their names can be used
+	// again. MLMD keeps them marked deleted.
+	_, err = svc.CreateArtifactType(ctx, &api.CreateArtifactTypeRequest{
+		ArtifactType: &mlpb.ArtifactType{Name: proto.String("kubeflow.org/v1/Model")},
+	})
+	if testStoreBackend == "sqlite" && err != nil {
+		t.Errorf("CreateArtifactType reusing a deleted type name = %v\nWant nil error", err)
+	}
+	if testStoreBackend == "mlmd" && status.Code(err) != codes.FailedPrecondition {
+		t.Errorf("CreateArtifactType reusing a deleted type name = %v\nWant FailedPrecondition error", err)
+	}
+}
+
+func TestDeleteSchemaType(t *testing.T) {
+	store := testMLMDStore(t)
+	svc := New(store)
+	svc.SetSchemaRegistry(staticSchemaRegistry{{
+		Name: "schemas/kubeflow.org/v1/Model",
+		Id:   "http://github.com/kubeflow/metadata/schema/alpha/artifacts/model.json",
+	}})
+	storeArtifact(t, store, "kubeflow.org/v1/Model", []*mlpb.Artifact{})
+
+	// Schema types can only be deleted from stores that remove them, as
+	// loading the schema would fail on the name of a type marked deleted.
+	ctx := context.Background()
+	_, err := svc.DeleteArtifactType(ctx, &api.DeleteArtifactTypeRequest{Name: "artifact_types/kubeflow.org/v1/Model"})
+	if testStoreBackend == "sqlite" && err != nil {
+		t.Errorf("DeleteArtifactType of a schema type = %v\nWant nil error", err)
+	}
+	if testStoreBackend == "mlmd" && status.Code(err) != codes.FailedPrecondition {
+		t.Errorf("DeleteArtifactType of a schema type = %v\nWant FailedPrecondition error", err)
+	}
+}
+
+func TestDeleteExecutionType(t *testing.T) {
+	store := testMLMDStore(t)
+	svc := New(store)
+
+	storeExecution(
+		t,
+		store,
+		"kubeflow.org/v1/MyExecutionType1",
+		[]*mlpb.Execution{
+			&mlpb.Execution{Properties: map[string]*mlpb.Value{"name": &mlpb.Value{Value: &mlpb.Value_StringValue{StringValue: "e1"}}}},
+		})
+
+	ctx := context.Background()
+	name := "execution_types/kubeflow.org/v1/MyExecutionType1"
+	if _, err := svc.DeleteExecutionType(ctx, &api.DeleteExecutionTypeRequest{Name: name}); err == nil {
+		t.Errorf("DeleteExecutionType of a type with executions = nil error\nWant non-nil error")
+	}
+	if _, err := svc.DeleteExecutionType(ctx, &api.DeleteExecutionTypeRequest{Name: name, Force: true}); err != nil {
+		t.Fatalf("DeleteExecutionType with force = %v\nWant nil error", err)
+	}
+
+	types, err := svc.ListExecutionTypes(ctx, &api.ListExecutionTypesRequest{})
+	if err != nil || len(types.GetExecutionTypes()) != 0 {
+		t.Errorf("Expect no execution types after deletion, but got response %v with err %v", types, err)
+	}
+	executions, err := svc.ListExecutions(ctx, &api.ListExecutionsRequest{})
+	if err != nil || len(executions.GetExecutions()) != 0 {
+		t.Errorf("Expect no executions after forced type deletion, but got response %v with err %v", executions, err)
+	}
+	if _, err := svc.DeleteExecutionType(ctx, &api.DeleteExecutionTypeRequest{Name: name}); err == nil {
+		t.Errorf("DeleteExecutionType of a deleted type = nil error\nWant non-nil error")
+	}
+}
+
+func TestBatchCreate(t *testing.T) {
+	store := testMLMDStore(t)
+	svc := New(store)
+	ctx := context.Background()
+
+	if _, err := svc.CreateArtifactType(ctx, &api.CreateArtifactTypeRequest{ArtifactType: &mlpb.ArtifactType{Name: proto.String("kubeflow.org/v1/Model")}}); err != nil {
+		t.Fatalf("CreateArtifactType failed: %v", err)
+	}
+	if _, err := svc.CreateExecutionType(ctx, &api.CreateExecutionTypeRequest{ExecutionType: &mlpb.ExecutionType{Name: proto.String("kubeflow.org/v1/Train")}}); err != nil {
+		t.Fatalf("CreateExecutionType failed: %v", err)
+	}
+
+	artifactReqs := func(uris ...string) []*api.CreateArtifactRequest {
+		var reqs []*api.CreateArtifactRequest
+		for _, uri := range uris {
+			reqs = append(reqs, &api.CreateArtifactRequest{Parent: "artifact_types/kubeflow.org/v1/Model", Artifact: &mlpb.Artifact{Uri: proto.String(uri)}})
+		}
+		return reqs
+	}
+
+	// A single invalid request fails the whole batch.
+	bad := artifactReqs("a", "b")
+	bad[1].Parent = "artifact_types/kubeflow.org/v1/Missing"
+	if _, err := svc.BatchCreateArtifacts(ctx, &api.BatchCreateArtifactsRequest{Requests: bad}); err == nil {
+		t.Errorf("BatchCreateArtifacts with an invalid request = nil error\nWant non-nil error")
+	}
+	if list, err := svc.ListArtifacts(ctx, &api.ListArtifactsRequest{}); err != nil || len(list.GetArtifacts()) != 0 {
+		t.Errorf("ListArtifacts() after failed batch = %v, %v\nWant no artifacts", list, err)
+	}
+
+	artifacts, err := svc.BatchCreateArtifacts(ctx, &api.BatchCreateArtifactsRequest{Requests: artifactReqs("a", "b", "c")})
+	if err != nil {
+		t.Fatalf("BatchCreateArtifacts failed: %v", err)
+	}
+	var uris []string
+	for _, artifact := range artifacts.GetArtifacts() {
+		if artifact.Id == nil {
+			t.Errorf("BatchCreateArtifacts returned artifact %v without id", artifact)
+		}
+		uris = append(uris, artifact.GetUri())
+	}
+	if diff := cmp.Diff([]string{"a", "b", "c"}, uris); diff != "" {
+		t.Errorf("BatchCreateArtifacts returned unexpected artifacts. Diff (-want, +got):\n%s", diff)
+	}
+
+	executions, err := svc.BatchCreateExecutions(ctx, &api.BatchCreateExecutionsRequest{Requests: []*api.CreateExecutionRequest{
+		{Parent: "execution_types/kubeflow.org/v1/Train", Execution: &mlpb.Execution{}},
+		{Parent: "execution_types/kubeflow.org/v1/Train", Execution: &mlpb.Execution{}},
+	}})
+	if err != nil || len(executions.GetExecutions()) != 2 {
+		t.Fatalf("BatchCreateExecutions() = %v, %v\nWant 2 executions", executions, err)
+	}
+	_, err = svc.BatchCreateExecutions(ctx, &api.BatchCreateExecutionsRequest{Requests: []*api.CreateExecutionRequest{
+		{Parent: "execution_types/kubeflow.org/v1/Train", Execution: &mlpb.Execution{}},
+		{Parent: "execution_types/kubeflow.org/v1/Train", Execution: &mlpb.Execution{Id: proto.Int64(1)}},
+	}})
+	if err == nil {
+		t.Errorf("BatchCreateExecutions with an invalid request = nil error\nWant non-nil error")
+	}
+	if list, err := svc.ListExecutions(ctx, &api.ListExecutionsRequest{}); err != nil || len(list.GetExecutions()) != 2 {
+		t.Errorf("ListExecutions() after failed batch = %v, %v\nWant 2 executions", list, err)
+	}
+
+	inputType := mlpb.Event_INPUT
+	outputType := mlpb.Event_OUTPUT
+	execution := executions.GetExecutions()[0]
+	event := func(artifact *mlpb.Artifact, eventType *mlpb.Event_Type) *mlpb.Event {
+		return &mlpb.Event{ArtifactId: artifact.Id, ExecutionId: execution.Id, Type: eventType}
+	}
+
+	deleted := artifacts.GetArtifacts()[2]
+	deletedName := fmt.Sprintf("artifact_types/kubeflow.org/v1/Model/artifacts/%d", deleted.GetId())
+	if _, err := svc.DeleteArtifact(ctx, &api.DeleteArtifactRequest{Name: deletedName}); err != nil {
+		t.Fatalf("DeleteArtifact(%q) failed: %v", deletedName, err)
+	}
+	_, err = svc.BatchCreateEvents(ctx, &api.BatchCreateEventsRequest{Events: []*mlpb.Event{
+		event(artifacts.GetArtifacts()[0], &inputType),
+		event(deleted, &outputType),
+	}})
+	if err == nil {
+		t.Errorf("BatchCreateEvents referencing a deleted artifact = nil error\nWant non-nil error")
+	}
+
+	_, err = svc.BatchCreateEvents(ctx, &api.BatchCreateEventsRequest{Events: []*mlpb.Event{
+		event(artifacts.GetArtifacts()[0], &inputType),
+		event(artifacts.GetArtifacts()[1], &outputType),
+	}})
+	if err != nil {
+		t.Fatalf("BatchCreateEvents failed: %v", err)
+	}
+	events, err := svc.ListEvents(ctx, &api.ListEventsRequest{Name: fmt.Sprintf("executions/%d", execution.GetId())})
+	if err != nil || len(events.GetEvents()) != 2 {
+		t.Errorf("ListEvents() after BatchCreateEvents = %v, %v\nWant 2 events", events, err)
+	}
+}
+
+// failingEventsStore is a MetadataStore whose PutEvents always fails.
+type failingEventsStore struct {
+	MetadataStore
+}
+
+func (s failingEventsStore) PutEvents(ctx context.Context, events []*mlpb.Event) error {
+	return errors.New("PutEvents failed")
+}
+
+// failingUpdatesStore is a MetadataStore failing to update artifacts or
+// executions, e.g. to reveal the records of RecordExecution.
+type failingUpdatesStore struct {
+	MetadataStore
+	artifacts  bool
+	executions bool
+}
+
+func (s failingUpdatesStore) PutArtifacts(ctx context.Context, artifacts []*mlpb.Artifact) ([]mlmd.ArtifactID, error) {
+	for _, artifact := range artifacts {
+		if s.artifacts && artifact.Id != nil {
+			return nil, errors.New("PutArtifacts failed")
+		}
+	}
+	return s.MetadataStore.PutArtifacts(ctx, artifacts)
+}
+
+func (s failingUpdatesStore) PutExecutions(ctx context.Context, executions []*mlpb.Execution) ([]mlmd.ExecutionID, error) {
+	for _, execution := range executions {
+		if s.executions && execution.Id != nil {
+			return nil, errors.New("PutExecutions failed")
+		}
+	}
+	return s.MetadataStore.PutExecutions(ctx, executions)
+}
+
+func TestRecordExecution(t *testing.T) {
+	store := testMLMDStore(t)
+	svc := New(store)
+	ctx := context.Background()
+
+	if _, err := svc.CreateArtifactType(ctx, &api.CreateArtifactTypeRequest{ArtifactType: &mlpb.ArtifactType{Name: proto.String("kubeflow.org/v1/Model")}}); err != nil {
+		t.Fatalf("CreateArtifactType failed: %v", err)
+	}
+	if _, err := svc.CreateExecutionType(ctx, &api.CreateExecutionTypeRequest{ExecutionType: &mlpb.ExecutionType{Name: proto.String("kubeflow.org/v1/Train")}}); err != nil {
+		t.Fatalf("CreateExecutionType failed: %v", err)
+	}
+	input, err := svc.CreateArtifact(ctx, &api.CreateArtifactRequest{Parent: "artifact_types/kubeflow.org/v1/Model", Artifact: &mlpb.Artifact{Uri: proto.String("input")}})
+	if err != nil {
+		t.Fatalf("CreateArtifact failed: %v", err)
+	}
+	inputName := fmt.Sprintf("artifact_types/kubeflow.org/v1/Model/artifacts/%d", input.GetArtifact().GetId())
+
+	newReq := func() *api.RecordExecutionRequest {
+		return &api.RecordExecutionRequest{
+			Execution:      &api.CreateExecutionRequest{Parent: "execution_types/kubeflow.org/v1/Train", Execution: &mlpb.Execution{}},
+			InputArtifacts: []string{inputName},
+			OutputArtifacts: []*api.CreateArtifactRequest{
+				{Parent: "artifact_types/kubeflow.org/v1/Model", Artifact: &mlpb.Artifact{Uri: proto.String("output1")}},
+				{Parent: "artifact_types/kubeflow.org/v1/Model", Artifact: &mlpb.Artifact{Uri: proto.String("output2")}},
+			},
+		}
+	}
+
+	// Invalid requests and failed writes leave no trace.
+	bad := newReq()
+	bad.OutputArtifacts[1].Parent = "artifact_types/kubeflow.org/v1/Missing"
+	if _, err := svc.RecordExecution(ctx, bad); err == nil {
+		t.Errorf("RecordExecution with an invalid output = nil error\nWant non-nil error")
+	}
+	bad = newReq()
+	bad.InputArtifacts = []string{"artifacts/1000"}
+	if _, err := svc.RecordExecution(ctx, bad); err == nil {
+		t.Errorf("RecordExecution with a missing input = nil error\nWant non-nil error")
+	}
+	if _, err := New(failingEventsStore{store}).RecordExecution(ctx, newReq()); err == nil {
+		t.Errorf("RecordExecution with failing PutEvents = nil error\nWant non-nil error")
+	}
+	if _, err := New(failingUpdatesStore{MetadataStore: store, artifacts: true}).RecordExecution(ctx, newReq()); err == nil {
+		t.Errorf("RecordExecution failing to reveal its outputs = nil error\nWant non-nil error")
+	}
+	if _, err := New(failingUpdatesStore{MetadataStore: store, executions: true}).RecordExecution(ctx, newReq()); err == nil {
+		t.Errorf("RecordExecution failing to reveal its execution = nil error\nWant non-nil error")
+	}
+	if list, err := svc.ListExecutions(ctx, &api.ListExecutionsRequest{}); err != nil || len(list.GetExecutions()) != 0 {
+		t.Errorf("ListExecutions() after failed RecordExecution = %v, %v\nWant no executions", list, err)
+	}
+	if list, err := svc.ListArtifacts(ctx, &api.ListArtifactsRequest{}); err != nil || len(list.GetArtifacts()) != 1 {
+		t.Errorf("ListArtifacts() after failed RecordExecution = %v, %v\nWant only the input artifact", list, err)
+	}
+	if testStoreBackend == "sqlite" {
+		// The failed calls were rolled back rather than hidden.
+		if executions, err := store.GetExecutions(ctx); status.Code(err) != codes.NotFound {
+			t.Errorf("GetExecutions() of the store after failed RecordExecution = %v, %v\nWant NotFound error", executions, err)
+		}
+		if artifacts, err := store.GetArtifacts(ctx); err != nil || len(artifacts) != 1 {
+			t.Errorf("GetArtifacts() of the store after failed RecordExecution = %v, %v\nWant only the input artifact", artifacts, err)
+		}
+	}
+
+	req := newReq()
+	resp, err := svc.RecordExecution(ctx, req)
+	if err != nil {
+		t.Fatalf("RecordExecution failed: %v", err)
+	}
+	if !proto.Equal(req, newReq()) {
+		t.Errorf("RecordExecution changed its request to %v\nWant %v", req, newReq())
+	}
+	if resp.GetExecution().Id == nil || isDeleted(resp.GetExecution().GetCustomProperties()) {
+		t.Errorf("RecordExecution returned execution %v\nWant a live execution with id", resp.GetExecution())
+	}
+	if len(resp.GetOutputArtifacts()) != 2 || resp.GetOutputArtifacts()[1].GetUri() != "output2" || isDeleted(resp.GetOutputArtifacts()[1].GetCustomProperties()) {
+		t.Errorf("RecordExecution returned outputs %v\nWant 2 live outputs in request order", resp.GetOutputArtifacts())
+	}
+
+	events, err := svc.ListEvents(ctx, &api.ListEventsRequest{Name: fmt.Sprintf("executions/%d", resp.GetExecution().GetId())})
+	if err != nil || len(events.GetEvents()) != 3 || len(events.GetArtifacts()) != 3 {
+		t.Errorf("ListEvents() after RecordExecution = %v, %v\nWant 3 events and 3 artifacts", events, err)
+	}
+	var inputs, outputs int
+	for _, e := range resp.GetEvents() {
+		switch e.GetType() {
+		case mlpb.Event_INPUT:
+			inputs++
+		case mlpb.Event_OUTPUT:
+			outputs++
+		}
+	}
+	if inputs != 1 || outputs != 2 {
+		t.Errorf("RecordExecution returned %d INPUT and %d OUTPUT events\nWant 1 and 2", inputs, outputs)
+	}
+}
+
+// failingGetTypeStore is a MetadataStore whose GetArtifactType always fails.
+type failingGetTypeStore struct {
+	MetadataStore
+}
+
+func (s failingGetTypeStore) GetArtifactType(ctx context.Context, name string) (*mlpb.ArtifactType, error) {
+	return nil, status.Error(codes.Unavailable, "GetArtifactType failed")
+}
+
+func TestWorkspaces(t *testing.T) {
+	store := testMLMDStore(t)
+	svc := New(store)
+	ctx := context.Background()
+
+	if _, err := svc.CreateWorkspace(ctx, &api.CreateWorkspaceRequest{Workspace: &api.Workspace{Name: "teamA"}}); err != nil {
+		t.Fatalf("CreateWorkspace(teamA) failed: %v", err)
+	}
+	for _, test := range []struct {
+		name string
+		want codes.Code
+	}{
+		{"teamA", codes.AlreadyExists},
+		{"", codes.InvalidArgument},
+		{"team/A", codes.InvalidArgument},
+		{kfDefaultWorkspace, codes.InvalidArgument},
+	} {
+		if _, err := svc.CreateWorkspace(ctx, &api.CreateWorkspaceRequest{Workspace: &api.Workspace{Name: test.name}}); status.Code(err) != test.want {
+			t.Errorf("CreateWorkspace(%q) = %v\nWant %v error", test.name, err, test.want)
+		}
+	}
+
+	if got, err := svc.GetWorkspace(ctx, &api.GetWorkspaceRequest{Name: "workspaces/teamA"}); err != nil || got.GetWorkspace().GetName() != "teamA" {
+		t.Errorf("GetWorkspace(workspaces/teamA) = %v, %v\nWant teamA, nil", got, err)
+	}
+	if _, err := svc.GetWorkspace(ctx, &api.GetWorkspaceRequest{Name: "workspaces/teamB"}); status.Code(err) != codes.NotFound {
+		t.Errorf("GetWorkspace(workspaces/teamB) = %v\nWant NotFound error", err)
+	}
+	if _, err := New(failingGetTypeStore{store}).GetWorkspace(ctx, &api.GetWorkspaceRequest{Name: "workspaces/teamA"}); status.Code(err) != codes.Unavailable {
+		t.Errorf("GetWorkspace(workspaces/teamA) with failing store = %v\nWant Unavailable error", err)
+	}
+	list, err := svc.ListWorkspaces(ctx, &api.ListWorkspacesRequest{})
+	wantList := &api.ListWorkspacesResponse{Workspaces: []*api.Workspace{{Name: kfDefaultWorkspace}, {Name: "teamA"}}}
+	if err != nil || !proto.Equal(list, wantList) {
+		t.Errorf("ListWorkspaces() = %v, %v\nWant %v, nil", list, err, wantList)
+	}
+
+	// Workspaces are not exposed as artifact types.
+	types, err := svc.ListArtifactTypes(ctx, &api.ListArtifactTypesRequest{})
+	if err != nil || len(types.GetArtifactTypes()) != 0 {
+		t.Errorf("ListArtifactTypes() = %v, %v\nWant no types, nil", types, err)
+	}
+	if _, err := svc.GetArtifactType(ctx, &api.GetArtifactTypeRequest{Name: "artifact_types/__kf_workspaces/teamA"}); err == nil {
+		t.Errorf("GetArtifactType of a workspace = nil error\nWant non-nil error")
+	}
+
+	if _, err := svc.CreateArtifactType(ctx, &api.CreateArtifactTypeRequest{ArtifactType: &mlpb.ArtifactType{Name: proto.String("kubeflow.org/v1/Model")}}); err != nil {
+		t.Fatalf("CreateArtifactType failed: %v", err)
+	}
+	if _, err := svc.CreateExecutionType(ctx, &api.CreateExecutionTypeRequest{ExecutionType: &mlpb.ExecutionType{Name: proto.String("kubeflow.org/v1/Train")}}); err != nil {
+		t.Fatalf("CreateExecutionType failed: %v", err)
+	}
+	for _, ws := range []string{"teamA", "", "teamA"} {
+		req := &api.CreateArtifactRequest{Parent: "artifact_types/kubeflow.org/v1/Model", Artifact: &mlpb.Artifact{Uri: proto.String("gs://" + ws)}, Workspace: ws}
+		if _, err := svc.CreateArtifact(ctx, req); err != nil {
+			t.Fatalf("CreateArtifact(%v) failed: %v", req, err)
+		}
+	}
+	execReq := &api.CreateExecutionRequest{Parent: "execution_types/kubeflow.org/v1/Train", Execution: &mlpb.Execution{}, Workspace: "workspaces/teamA"}
+	if _, err := svc.CreateExecution(ctx, execReq); err != nil {
+		t.Fatalf("CreateExecution(%v) failed: %v", execReq, err)
+	}
+	badReq := &api.CreateArtifactRequest{Parent: "artifact_types/kubeflow.org/v1/Model", Artifact: &mlpb.Artifact{}, Workspace: "teamB"}
+	if _, err := svc.CreateArtifact(ctx, badReq); status.Code(err) != codes.NotFound {
+		t.Errorf("CreateArtifact(%v) = %v\nWant NotFound error", badReq, err)
+	}
+
+	for _, test := range []struct {
+		req  *api.ListArtifactsRequest
+		want int
+	}{
+		{&api.ListArtifactsRequest{}, 3},
+		{&api.ListArtifactsRequest{Workspace: "teamA"}, 2},
+		{&api.ListArtifactsRequest{Workspace: kfDefaultWorkspace}, 1},
+		{&api.ListArtifactsRequest{Filter: `workspace = "teamA"`}, 2},
+		{&api.ListArtifactsRequest{Filter: fmt.Sprintf("workspace = %q", kfDefaultWorkspace)}, 1},
+	} {
+		got, err := svc.ListArtifacts(ctx, test.req)
+		if err != nil || len(got.GetArtifacts()) != test.want {
+			t.Errorf("ListArtifacts(%v) = %v, %v\nWant %d artifacts", test.req, got, err, test.want)
+		}
+	}
+	if _, err := svc.ListArtifacts(ctx, &api.ListArtifactsRequest{Workspace: "teamB"}); status.Code(err) != codes.NotFound {
+		t.Errorf("ListArtifacts in a missing workspace = %v\nWant NotFound error", err)
+	}
+	execs, err := svc.ListExecutions(ctx, &api.ListExecutionsRequest{Workspace: "teamA"})
+	if err != nil || len(execs.GetExecutions()) != 1 {
+		t.Errorf("ListExecutions(teamA) = %v, %v\nWant 1 execution", execs, err)
+	}
+
+	if _, err := svc.DeleteWorkspace(ctx, &api.DeleteWorkspaceRequest{Name: "workspaces/teamA"}); status.Code(err) != codes.FailedPrecondition {
+		t.Errorf("DeleteWorkspace of a non-empty workspace without force = %v\nWant FailedPrecondition error", err)
+	}
+	if _, err := svc.DeleteWorkspace(ctx, &api.DeleteWorkspaceRequest{Name: "workspaces/teamA", Force: true}); err != nil {
+		t.Fatalf("DeleteWorkspace(teamA) with force failed: %v", err)
+	}
+	if _, err := svc.DeleteWorkspace(ctx, &api.DeleteWorkspaceRequest{Name: "workspaces/" + kfDefaultWorkspace, Force: true}); status.Code(err) != codes.FailedPrecondition {
+		t.Errorf("DeleteWorkspace of the default workspace = %v\nWant FailedPrecondition error", err)
+	}
+
+	if _, err := svc.GetWorkspace(ctx, &api.GetWorkspaceRequest{Name: "workspaces/teamA"}); status.Code(err) != codes.NotFound {
+		t.Errorf("GetWorkspace after deletion = %v\nWant NotFound error", err)
+	}
+	if _, err := svc.CreateWorkspace(ctx, &api.CreateWorkspaceRequest{Workspace: &api.Workspace{Name: "teamA"}}); status.Code(err) != codes.FailedPrecondition {
+		t.Errorf("CreateWorkspace reusing a deleted name = %v\nWant FailedPrecondition error", err)
+	}
+	artifacts, err := svc.ListArtifacts(ctx, &api.ListArtifactsRequest{})
+	if err != nil || len(artifacts.GetArtifacts()) != 1 {
+		t.Errorf("ListArtifacts() after deleting teamA = %v, %v\nWant 1 artifact", artifacts, err)
+	}
+	execs, err = svc.ListExecutions(ctx, &api.ListExecutionsRequest{})
+	if err != nil || len(execs.GetExecutions()) != 0 {
+		t.Errorf("ListExecutions() after deleting teamA = %v, %v\nWant no executions", execs, err)
+	}
+	list, err = svc.ListWorkspaces(ctx, &api.ListWorkspacesRequest{})
+	if err != nil || len(list.GetWorkspaces()) != 1 {
+		t.Errorf("ListWorkspaces() after deleting teamA = %v, %v\nWant only the default workspace", list, err)
+	}
+}
+
+func storeArtifact(t *testing.T, store MetadataStore, typename string, artifacts []*mlpb.Artifact) []mlmd.ArtifactID {
+	aType := &mlpb.ArtifactType{Name: proto.String(typename)}
+
+	typeID, err := store.PutArtifactType(context.Background(), aType, &mlmd.PutTypeOptions{AllFieldsMustMatch: true})
+	if err != nil {
 		t.Fatalf("Failed to create ArtifactType %+v: %v", aType, err)
 	}
 
@@ -1167,20 +2681,20 @@ func storeArtifact(t *testing.T, store *mlmetadata.Store, typename string, artif
 		artifact.TypeId = proto.Int64(int64(typeID))
 	}
 
-	resp, err := store.PutArtifacts(artifacts)
+	resp, err := store.PutArtifacts(context.Background(), artifacts)
 	if err != nil {
 		t.Fatalf("Failed to put Artifacts %+v: %v", artifacts, err)
 	}
 	return resp
 }
 
-func storeExecution(t *testing.T, store *mlmetadata.Store, typename string, executions []*mlpb.Execution) []mlmetadata.ExecutionID {
+func storeExecution(t *testing.T, store MetadataStore, typename string, executions []*mlpb.Execution) []mlmd.ExecutionID {
 	aType := &mlpb.ExecutionType{
 		Name:       proto.String(typename),
 		Properties: map[string]mlpb.PropertyType{"name": mlpb.PropertyType_STRING},
 	}
 
-	typeID, err := store.PutExecutionType(aType, &mlmetadata.PutTypeOptions{AllFieldsMustMatch: true})
+	typeID, err := store.PutExecutionType(context.Background(), aType, &mlmd.PutTypeOptions{AllFieldsMustMatch: true})
 	if err != nil {
 		t.Fatalf("Failed to create ExecutionType %+v: %v", aType, err)
 	}
@@ -1189,9 +2703,638 @@ func storeExecution(t *testing.T, store *mlmetadata.Store, typename string, exec
 		execution.TypeId = proto.Int64(int64(typeID))
 	}
 
-	resp, err := store.PutExecutions(executions)
+	resp, err := store.PutExecutions(context.Background(), executions)
 	if err != nil {
 		t.Fatalf("Failed to put Executions %+v: %v", executions, err)
 	}
 	return resp
 }
+
+func TestContainerWorkspaces(t *testing.T) {
+	store := &listCountingStore{MetadataStore: testMLMDStore(t)}
+	svc := New(store)
+	ctx := context.Background()
+	stringValue := func(s string) *mlpb.Value { return &mlpb.Value{Value: &mlpb.Value_StringValue{StringValue: s}} }
+
+	containerType := &mlpb.ArtifactType{
+		Name: proto.String("kubeflow.org/alpha/workspace"),
+		Properties: map[string]mlpb.PropertyType{
+			"name":         mlpb.PropertyType_STRING,
+			"owner":        mlpb.PropertyType_STRING,
+			"__ALL_META__": mlpb.PropertyType_STRING,
+		},
+	}
+	if err := svc.CreateContainerType(ctx, containerType); err != nil {
+		t.Fatalf("CreateContainerType failed: %v", err)
+	}
+
+	for _, test := range []struct {
+		desc string
+		ws   *api.Workspace
+	}{
+		{"unknown type", &api.Workspace{Name: "teamA", Type: "kubeflow.org/alpha/unknown"}},
+		{"properties without type", &api.Workspace{Name: "teamA", Properties: map[string]*mlpb.Value{"owner": stringValue("me")}}},
+		{"undeclared property", &api.Workspace{Name: "teamA", Type: "kubeflow.org/alpha/workspace", Properties: map[string]*mlpb.Value{"size": stringValue("big")}}},
+		{"malformed document", &api.Workspace{Name: "teamA", Type: "kubeflow.org/alpha/workspace", Properties: map[string]*mlpb.Value{"__ALL_META__": stringValue("[]")}}},
+	} {
+		if _, err := svc.CreateWorkspace(ctx, &api.CreateWorkspaceRequest{Workspace: test.ws}); status.Code(err) != codes.InvalidArgument {
+			t.Errorf("CreateWorkspace with %s = %v\nWant InvalidArgument error", test.desc, err)
+		}
+	}
+
+	req := &api.CreateWorkspaceRequest{Workspace: &api.Workspace{
+		Name:       "teamA",
+		Type:       "kubeflow.org/alpha/workspace",
+		Properties: map[string]*mlpb.Value{"__ALL_META__": stringValue(`{"owner":"someone@kubeflow.org","annotations":{"mylabel":"l1"}}`)},
+	}}
+	created, err := svc.CreateWorkspace(ctx, req)
+	if err != nil {
+		t.Fatalf("CreateWorkspace(%v) failed: %v", req, err)
+	}
+	want := &api.Workspace{
+		Name: "teamA",
+		Type: "kubeflow.org/alpha/workspace",
+		Properties: map[string]*mlpb.Value{
+			"name":         stringValue("teamA"),
+			"owner":        stringValue("someone@kubeflow.org"),
+			"__ALL_META__": stringValue(`{"annotations":{"mylabel":"l1"},"id":"teamA","name":"teamA","owner":"someone@kubeflow.org"}`),
+		},
+	}
+	if !proto.Equal(created.GetWorkspace(), want) {
+		t.Errorf("CreateWorkspace(%v) = %v\nWant %v", req, created.GetWorkspace(), want)
+	}
+	if got, err := svc.GetWorkspace(ctx, &api.GetWorkspaceRequest{Name: "workspaces/teamA"}); err != nil || !proto.Equal(got.GetWorkspace(), want) {
+		t.Errorf("GetWorkspace(workspaces/teamA) = %v, %v\nWant %v, nil", got, err, want)
+	}
+	if _, err := svc.CreateWorkspace(ctx, &api.CreateWorkspaceRequest{Workspace: &api.Workspace{Name: "teamB"}}); err != nil {
+		t.Fatalf("CreateWorkspace(teamB) failed: %v", err)
+	}
+	reqC := proto.Clone(req).(*api.CreateWorkspaceRequest)
+	reqC.Workspace.Name = "teamC"
+	createdC, err := svc.CreateWorkspace(ctx, reqC)
+	if err != nil {
+		t.Fatalf("CreateWorkspace(teamC) failed: %v", err)
+	}
+	store.byType = 0
+	list, err := svc.ListWorkspaces(ctx, &api.ListWorkspacesRequest{})
+	wantList := &api.ListWorkspacesResponse{Workspaces: []*api.Workspace{{Name: kfDefaultWorkspace}, want, {Name: "teamB"}, createdC.GetWorkspace()}}
+	if err != nil || !proto.Equal(list, wantList) {
+		t.Errorf("ListWorkspaces() = %v, %v\nWant %v, nil", list, err, wantList)
+	}
+	// The properties of the workspaces of a container type are read once.
+	if store.byType != 1 {
+		t.Errorf("ListWorkspaces() read artifacts by type %d times\nWant 1", store.byType)
+	}
+
+	// Container types and the properties of workspaces are hidden.
+	types, err := svc.ListArtifactTypes(ctx, &api.ListArtifactTypesRequest{})
+	if err != nil || len(types.GetArtifactTypes()) != 0 {
+		t.Errorf("ListArtifactTypes() = %v, %v\nWant no types, nil", types, err)
+	}
+	artifacts, err := svc.ListArtifacts(ctx, &api.ListArtifactsRequest{})
+	if err != nil || len(artifacts.GetArtifacts()) != 0 {
+		t.Errorf("ListArtifacts() = %v, %v\nWant no artifacts, nil", artifacts, err)
+	}
+
+	if _, err := svc.DeleteWorkspace(ctx, &api.DeleteWorkspaceRequest{Name: "workspaces/teamA"}); err != nil {
+		t.Fatalf("DeleteWorkspace(teamA) failed: %v", err)
+	}
+	if _, err := svc.GetWorkspace(ctx, &api.GetWorkspaceRequest{Name: "workspaces/teamA"}); status.Code(err) != codes.NotFound {
+		t.Errorf("GetWorkspace of a deleted workspace = %v\nWant NotFound error", err)
+	}
+	if _, err := svc.CreateWorkspace(ctx, req); status.Code(err) != codes.FailedPrecondition {
+		t.Errorf("CreateWorkspace with the name of a deleted workspace = %v\nWant FailedPrecondition error", err)
+	}
+}
+
+func TestSchemasWithoutRegistry(t *testing.T) {
+	svc := New(testMLMDStore(t))
+	ctx := context.Background()
+
+	if _, err := svc.RegisterSchema(ctx, &api.RegisterSchemaRequest{JsonSchema: `{"$id": "http://example.com/schema.json"}`}); status.Code(err) != codes.Unimplemented {
+		t.Errorf("RegisterSchema without a registry = %v\nWant Unimplemented error", err)
+	}
+	if _, err := svc.ListSchemas(ctx, &api.ListSchemasRequest{}); status.Code(err) != codes.Unimplemented {
+		t.Errorf("ListSchemas without a registry = %v\nWant Unimplemented error", err)
+	}
+	if schemas, err := svc.StoredSchemas(ctx); len(schemas) != 0 || err != nil {
+		t.Errorf("StoredSchemas() = %v, %v\nWant none", schemas, err)
+	}
+}
+
+func stringValidator(v string) *api.PropertyValidator {
+	return &api.PropertyValidator{Type: &api.PropertyValidator_StringType{StringType: &api.StringType{Validator: v}}}
+}
+
+func intValidator(v string) *api.PropertyValidator {
+	return &api.PropertyValidator{Type: &api.PropertyValidator_IntType{IntType: &api.IntType{Validator: v}}}
+}
+
+func doubleValidator(v string) *api.PropertyValidator {
+	return &api.PropertyValidator{Type: &api.PropertyValidator_DoubleType{DoubleType: &api.DoubleType{Validator: v}}}
+}
+
+func TestInvalidPropertyValidators(t *testing.T) {
+	svc := New(testMLMDStore(t))
+	for _, test := range []struct {
+		desc      string
+		validator *api.PropertyValidator
+	}{
+		{"no type", &api.PropertyValidator{}},
+		{"malformed JSON", stringValidator(`{`)},
+		{"unknown keyword", stringValidator(`{"format": "uri"}`)},
+		{"invalid pattern", stringValidator(`{"pattern": "("}`)},
+		{"range of a string", stringValidator(`{"minimum": 1}`)},
+		{"number in string enum", stringValidator(`{"enum": ["a", 1]}`)},
+		{"pattern of a number", doubleValidator(`{"pattern": "a"}`)},
+		{"fraction in integer enum", intValidator(`{"enum": [1.5]}`)},
+	} {
+		err := svc.SetArtifactPropertyValidators("kubeflow.org/v1/thing", map[string]*api.PropertyValidator{"p": test.validator})
+		if status.Code(err) != codes.InvalidArgument {
+			t.Errorf("SetArtifactPropertyValidators with %s = %v\nWant InvalidArgument error", test.desc, err)
+		}
+	}
+}
+
+func TestPropertyValidators(t *testing.T) {
+	svc := New(testMLMDStore(t))
+	ctx := context.Background()
+	stringValue := func(s string) *mlpb.Value { return &mlpb.Value{Value: &mlpb.Value_StringValue{StringValue: s}} }
+	intValue := func(i int64) *mlpb.Value { return &mlpb.Value{Value: &mlpb.Value_IntValue{IntValue: i}} }
+	doubleValue := func(d float64) *mlpb.Value { return &mlpb.Value{Value: &mlpb.Value_DoubleValue{DoubleValue: d}} }
+
+	properties := map[string]mlpb.PropertyType{
+		"stage":  mlpb.PropertyType_STRING,
+		"tag":    mlpb.PropertyType_STRING,
+		"layers": mlpb.PropertyType_INT,
+		"score":  mlpb.PropertyType_DOUBLE,
+		allMeta:  mlpb.PropertyType_STRING,
+	}
+	validators := map[string]*api.PropertyValidator{
+		"stage":  stringValidator(`{"enum": ["training", "validation"]}`),
+		"tag":    stringValidator(`{"pattern": "^v[0-9]+$", "maxLength": 4}`),
+		"layers": intValidator(`{"enum": [1, 2, 3]}`),
+		"score":  doubleValidator(`{"minimum": 0, "maximum": 1, "exclusiveMaximum": true}`),
+	}
+	if _, err := svc.CreateArtifactType(ctx, &api.CreateArtifactTypeRequest{ArtifactType: &mlpb.ArtifactType{Name: proto.String("kubeflow.org/v1/model"), Properties: properties}}); err != nil {
+		t.Fatalf("CreateArtifactType failed: %v", err)
+	}
+	if err := svc.SetArtifactPropertyValidators("kubeflow.org/v1/model", validators); err != nil {
+		t.Fatalf("SetArtifactPropertyValidators failed: %v", err)
+	}
+	if _, err := svc.CreateExecutionType(ctx, &api.CreateExecutionTypeRequest{ExecutionType: &mlpb.ExecutionType{Name: proto.String("kubeflow.org/v1/run"), Properties: properties}}); err != nil {
+		t.Fatalf("CreateExecutionType failed: %v", err)
+	}
+	if err := svc.SetExecutionPropertyValidators("kubeflow.org/v1/run", validators); err != nil {
+		t.Fatalf("SetExecutionPropertyValidators failed: %v", err)
+	}
+
+	aType, err := svc.GetArtifactType(ctx, &api.GetArtifactTypeRequest{Name: "artifact_types/kubeflow.org/v1/model"})
+	if err != nil || !cmp.Equal(aType.GetPropertyValidators(), validators, cmp.Comparer(proto.Equal)) {
+		t.Errorf("GetArtifactType() = %v, %v\nWant property validators %v", aType, err, validators)
+	}
+	eType, err := svc.GetExecutionType(ctx, &api.GetExecutionTypeRequest{Name: "execution_types/kubeflow.org/v1/run"})
+	if err != nil || !cmp.Equal(eType.GetPropertyValidators(), validators, cmp.Comparer(proto.Equal)) {
+		t.Errorf("GetExecutionType() = %v, %v\nWant property validators %v", eType, err, validators)
+	}
+
+	valid := map[string]*mlpb.Value{
+		"stage":  stringValue("training"),
+		"tag":    stringValue("v12"),
+		"layers": intValue(3),
+		"score":  doubleValue(0),
+	}
+	artifact, err := svc.CreateArtifact(ctx, &api.CreateArtifactRequest{
+		Parent:   "artifact_types/kubeflow.org/v1/model",
+		Artifact: &mlpb.Artifact{Properties: valid},
+	})
+	if err != nil {
+		t.Fatalf("CreateArtifact with valid properties failed: %v", err)
+	}
+	execution, err := svc.CreateExecution(ctx, &api.CreateExecutionRequest{
+		Parent:    "execution_types/kubeflow.org/v1/run",
+		Execution: &mlpb.Execution{Properties: valid},
+	})
+	if err != nil {
+		t.Fatalf("CreateExecution with valid properties failed: %v", err)
+	}
+	artifactName := fmt.Sprintf("artifact_types/kubeflow.org/v1/model/artifacts/%d", artifact.GetArtifact().GetId())
+	executionName := fmt.Sprintf("execution_types/kubeflow.org/v1/run/executions/%d", execution.GetExecution().GetId())
+
+	for _, test := range []struct {
+		desc     string
+		property string
+		value    *mlpb.Value
+	}{
+		{"value not in string enum", "stage", stringValue("production")},
+		{"value not matching pattern", "tag", stringValue("latest")},
+		{"too long value", "tag", stringValue("v12345")},
+		{"value not in integer enum", "layers", intValue(4)},
+		{"value below minimum", "score", doubleValue(-0.5)},
+		{"value at exclusive maximum", "score", doubleValue(1)},
+		{"value of __ALL_META__ not in enum", allMeta, stringValue(`{"stage": "production"}`)},
+	} {
+		properties := map[string]*mlpb.Value{test.property: test.value}
+		if _, err := svc.CreateArtifact(ctx, &api.CreateArtifactRequest{
+			Parent:   "artifact_types/kubeflow.org/v1/model",
+			Artifact: &mlpb.Artifact{Properties: properties},
+		}); status.Code(err) != codes.InvalidArgument {
+			t.Errorf("CreateArtifact with %s = %v\nWant InvalidArgument error", test.desc, err)
+		}
+		if _, err := svc.CreateExecution(ctx, &api.CreateExecutionRequest{
+			Parent:    "execution_types/kubeflow.org/v1/run",
+			Execution: &mlpb.Execution{Properties: properties},
+		}); status.Code(err) != codes.InvalidArgument {
+			t.Errorf("CreateExecution with %s = %v\nWant InvalidArgument error", test.desc, err)
+		}
+		if test.property == allMeta {
+			// Stored properties override those in a new __ALL_META__.
+			continue
+		}
+		mask := &field_mask.FieldMask{Paths: []string{"properties." + test.property}}
+		if _, err := svc.UpdateArtifact(ctx, &api.UpdateArtifactRequest{
+			Name:       artifactName,
+			Artifact:   &mlpb.Artifact{Properties: properties},
+			UpdateMask: mask,
+		}); status.Code(err) != codes.InvalidArgument {
+			t.Errorf("UpdateArtifact with %s = %v\nWant InvalidArgument error", test.desc, err)
+		}
+		if _, err := svc.UpdateExecution(ctx, &api.UpdateExecutionRequest{
+			Name:       executionName,
+			Execution:  &mlpb.Execution{Properties: properties},
+			UpdateMask: mask,
+		}); status.Code(err) != codes.InvalidArgument {
+			t.Errorf("UpdateExecution with %s = %v\nWant InvalidArgument error", test.desc, err)
+		}
+	}
+}
+
+// slowStore is a MetadataStore whose GetArtifactTypes blocks until the
+// context of the call is done, like a slow query.
+type slowStore struct {
+	MetadataStore
+}
+
+func (s slowStore) GetArtifactTypes(ctx context.Context) ([]*mlpb.ArtifactType, error) {
+	<-ctx.Done()
+	return nil, ctx.Err()
+}
+
+func TestContextErrors(t *testing.T) {
+	store := testMLMDStore(t)
+	svc := New(store)
+	listTypes := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return svc.ListArtifactTypes(ctx, req.(*api.ListArtifactTypesRequest))
+	}
+	info := &grpc.UnaryServerInfo{FullMethod: "/ml_metadata.MetadataService/ListArtifactTypes"}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	slow := New(slowStore{store})
+	_, err := ErrorInterceptor(ctx, &api.ListArtifactTypesRequest{}, info, func(ctx context.Context, req interface{}) (interface{}, error) {
+		return slow.ListArtifactTypes(ctx, req.(*api.ListArtifactTypesRequest))
+	})
+	if status.Code(err) != codes.DeadlineExceeded {
+		t.Errorf("ListArtifactTypes() past its deadline = %v\nWant code %v", err, codes.DeadlineExceeded)
+	}
+
+	canceled, cancel := context.WithCancel(context.Background())
+	cancel()
+	if _, err := ErrorInterceptor(canceled, &api.ListArtifactTypesRequest{}, info, listTypes); status.Code(err) != codes.Canceled {
+		t.Errorf("ListArtifactTypes() with a canceled context = %v\nWant code %v", err, codes.Canceled)
+	}
+	if _, err := svc.CreateArtifactType(canceled, &api.CreateArtifactTypeRequest{ArtifactType: &mlpb.ArtifactType{Name: proto.String("kubeflow.org/v1/Model")}}); err == nil {
+		t.Errorf("CreateArtifactType with a canceled context = nil error\nWant non-nil error")
+	}
+	if resp, err := ErrorInterceptor(context.Background(), &api.ListArtifactTypesRequest{}, info, listTypes); err != nil || len(resp.(*api.ListArtifactTypesResponse).GetArtifactTypes()) != 0 {
+		t.Errorf("ListArtifactTypes() after a canceled CreateArtifactType = %v, %v\nWant no types", resp, err)
+	}
+
+	late, cancelLate := context.WithCancel(context.Background())
+	defer cancelLate()
+	notFound := func(ctx context.Context, req interface{}) (interface{}, error) {
+		cancelLate()
+		return nil, status.Error(codes.NotFound, "artifact type not found")
+	}
+	if _, err := ErrorInterceptor(late, &api.ListArtifactTypesRequest{}, info, notFound); status.Code(err) != codes.NotFound {
+		t.Errorf("ErrorInterceptor() of a NotFound error returned once the context is canceled = %v\nWant code %v", err, codes.NotFound)
+	}
+	if err := wrapError(context.Canceled, "listing artifact types"); status.Code(err) != codes.Canceled {
+		t.Errorf("wrapError(context.Canceled) = %v\nWant code %v", err, codes.Canceled)
+	}
+}
+
+func TestErrorCodes(t *testing.T) {
+	store := testMLMDStore(t)
+	svc := New(store)
+	ctx := context.Background()
+
+	model := &mlpb.ArtifactType{
+		Name:       proto.String("kubeflow.org/v1/Model"),
+		Properties: map[string]mlpb.PropertyType{"name": mlpb.PropertyType_STRING},
+	}
+	if _, err := svc.CreateArtifactType(ctx, &api.CreateArtifactTypeRequest{ArtifactType: model}); err != nil {
+		t.Fatalf("CreateArtifactType failed: %v", err)
+	}
+	if _, err := svc.CreateArtifact(ctx, &api.CreateArtifactRequest{Parent: "artifact_types/kubeflow.org/v1/Model", Artifact: &mlpb.Artifact{}}); err != nil {
+		t.Fatalf("CreateArtifact failed: %v", err)
+	}
+	if _, err := svc.CreateArtifactType(ctx, &api.CreateArtifactTypeRequest{ArtifactType: &mlpb.ArtifactType{Name: proto.String("kubeflow.org/v1/Dataset")}}); err != nil {
+		t.Fatalf("CreateArtifactType failed: %v", err)
+	}
+	if _, err := svc.DeleteArtifactType(ctx, &api.DeleteArtifactTypeRequest{Name: "artifact_types/kubeflow.org/v1/Dataset"}); err != nil {
+		t.Fatalf("DeleteArtifactType failed: %v", err)
+	}
+
+	// Only MLMD keeps the names of deleted types.
+	reuseCode, reuseHTTP := codes.OK, http.StatusOK
+	if testStoreBackend == "mlmd" {
+		reuseCode, reuseHTTP = codes.FailedPrecondition, http.StatusPreconditionFailed
+	}
+
+	tests := []struct {
+		desc     string
+		call     func() error
+		want     codes.Code
+		wantHTTP int
+	}{
+		{"GetArtifactType of a missing type", func() error {
+			_, err := svc.GetArtifactType(ctx, &api.GetArtifactTypeRequest{Name: "artifact_types/kubeflow.org/v1/Missing"})
+			return err
+		}, codes.NotFound, http.StatusNotFound},
+		{"GetArtifact of a missing artifact", func() error {
+			_, err := svc.GetArtifact(ctx, &api.GetArtifactRequest{Name: "artifact_types/kubeflow.org/v1/Model/artifacts/1000"})
+			return err
+		}, codes.NotFound, http.StatusNotFound},
+		{"CreateArtifact under a missing type", func() error {
+			_, err := svc.CreateArtifact(ctx, &api.CreateArtifactRequest{Parent: "artifact_types/kubeflow.org/v1/Missing", Artifact: &mlpb.Artifact{}})
+			return err
+		}, codes.NotFound, http.StatusNotFound},
+		{"CreateArtifactType without type", func() error {
+			_, err := svc.CreateArtifactType(ctx, &api.CreateArtifactTypeRequest{})
+			return err
+		}, codes.InvalidArgument, http.StatusBadRequest},
+		{"GetArtifact with a malformed name", func() error {
+			_, err := svc.GetArtifact(ctx, &api.GetArtifactRequest{Name: "artifacts/x"})
+			return err
+		}, codes.InvalidArgument, http.StatusBadRequest},
+		{"ListArtifacts with a malformed page token", func() error {
+			_, err := svc.ListArtifacts(ctx, &api.ListArtifactsRequest{PageToken: "x"})
+			return err
+		}, codes.InvalidArgument, http.StatusBadRequest},
+		{"CreateArtifact with an unknown property", func() error {
+			_, err := svc.CreateArtifact(ctx, &api.CreateArtifactRequest{Parent: "artifact_types/kubeflow.org/v1/Model", Artifact: &mlpb.Artifact{
+				Properties: map[string]*mlpb.Value{"size": {Value: &mlpb.Value_IntValue{IntValue: 1}}},
+			}})
+			return err
+		}, codes.InvalidArgument, http.StatusBadRequest},
+		{"CreateArtifactType with other properties", func() error {
+			_, err := svc.CreateArtifactType(ctx, &api.CreateArtifactTypeRequest{ArtifactType: &mlpb.ArtifactType{
+				Name:       proto.String("kubeflow.org/v1/Model"),
+				Properties: map[string]mlpb.PropertyType{"name": mlpb.PropertyType_INT},
+			}})
+			return err
+		}, codes.AlreadyExists, http.StatusConflict},
+		{"DeleteArtifactType of a type with artifacts", func() error {
+			_, err := svc.DeleteArtifactType(ctx, &api.DeleteArtifactTypeRequest{Name: "artifact_types/kubeflow.org/v1/Model"})
+			return err
+		}, codes.FailedPrecondition, http.StatusPreconditionFailed},
+		{"CreateArtifactType reusing a deleted name", func() error {
+			_, err := svc.CreateArtifactType(ctx, &api.CreateArtifactTypeRequest{ArtifactType: &mlpb.ArtifactType{Name: proto.String("kubeflow.org/v1/Dataset")}})
+			return err
+		}, reuseCode, reuseHTTP},
+		{"CreateArtifact with a reserved custom property", func() error {
+			_, err := svc.CreateArtifact(ctx, &api.CreateArtifactRequest{Parent: "artifact_types/kubeflow.org/v1/Model", Artifact: &mlpb.Artifact{
+				CustomProperties: map[string]*mlpb.Value{"__kf_deleted": {Value: &mlpb.Value_IntValue{IntValue: 1}}},
+			}})
+			return err
+		}, codes.InvalidArgument, http.StatusBadRequest},
+		{"error without code", func() error {
+			_, err := ErrorInterceptor(ctx, nil, &grpc.UnaryServerInfo{}, func(context.Context, interface{}) (interface{}, error) {
+				return nil, errors.New("connection refused")
+			})
+			return err
+		}, codes.Internal, http.StatusInternalServerError},
+	}
+	for _, test := range tests {
+		err := test.call()
+		if got := status.Code(err); got != test.want {
+			t.Errorf("%s: error %v has code %v\nWant %v", test.desc, err, got, test.want)
+		}
+		if got := runtime.HTTPStatusFromCode(status.Code(err)); got != test.wantHTTP {
+			t.Errorf("%s: error %v has HTTP status %d\nWant %d", test.desc, err, got, test.wantHTTP)
+		}
+	}
+}
+
+// grantsKey is the context key of the grants of testAuthorizer.
+type grantsKey struct{}
+
+// testAuthorizer allows the workspaces and namespaces of the grants in a
+// context, mapping them to the highest access of the caller. Contexts
+// without grants are allowed everything.
+type testAuthorizer struct{}
+
+func withGrants(grants map[string]Access) context.Context {
+	return context.WithValue(context.Background(), grantsKey{}, grants)
+}
+
+func (testAuthorizer) authorize(ctx context.Context, access Access, name string) error {
+	grants, ok := ctx.Value(grantsKey{}).(map[string]Access)
+	if !ok || grants[name] >= access {
+		return nil
+	}
+	return status.Errorf(codes.PermissionDenied, "no %s access to %q", access, name)
+}
+
+func (a testAuthorizer) AuthorizeWorkspace(ctx context.Context, access Access, workspace string) error {
+	return a.authorize(ctx, access, "workspace "+workspace)
+}
+
+func (a testAuthorizer) AuthorizeNamespace(ctx context.Context, access Access, namespace string) error {
+	return a.authorize(ctx, access, "namespace "+namespace)
+}
+
+// emptySchemaRegistry registers no schema.
+type emptySchemaRegistry struct{}
+
+func (emptySchemaRegistry) RegisterSchema(ctx context.Context, jsonSchema string, save func() error) (*api.Schema, error) {
+	return nil, status.Error(codes.Unimplemented, "no schema registry")
+}
+
+func (emptySchemaRegistry) Schemas() []*api.Schema { return nil }
+
+// staticSchemaRegistry holds schemas registered beforehand.
+type staticSchemaRegistry []*api.Schema
+
+func (staticSchemaRegistry) RegisterSchema(ctx context.Context, jsonSchema string, save func() error) (*api.Schema, error) {
+	return nil, status.Error(codes.Unimplemented, "schemas are static")
+}
+
+func (r staticSchemaRegistry) Schemas() []*api.Schema { return r }
+
+func TestAuthorization(t *testing.T) {
+	svc := New(testMLMDStore(t))
+	svc.SetAuthorizer(testAuthorizer{})
+	svc.SetSchemaRegistry(emptySchemaRegistry{})
+	ctx := context.Background()
+
+	for _, name := range []string{"kubeflow.org/v1/Model", "example.com/Model"} {
+		if _, err := svc.CreateArtifactType(ctx, &api.CreateArtifactTypeRequest{ArtifactType: &mlpb.ArtifactType{Name: proto.String(name)}}); err != nil {
+			t.Fatalf("CreateArtifactType(%s) failed: %v", name, err)
+		}
+	}
+	for _, ws := range []string{"teamA", "teamB"} {
+		if _, err := svc.CreateWorkspace(ctx, &api.CreateWorkspaceRequest{Workspace: &api.Workspace{Name: ws}}); err != nil {
+			t.Fatalf("CreateWorkspace(%s) failed: %v", ws, err)
+		}
+	}
+	var names []string
+	for _, req := range []*api.CreateArtifactRequest{
+		{Parent: "artifact_types/kubeflow.org/v1/Model", Artifact: &mlpb.Artifact{}, Workspace: "teamA"},
+		{Parent: "artifact_types/kubeflow.org/v1/Model", Artifact: &mlpb.Artifact{}, Workspace: "teamB"},
+		{Parent: "artifact_types/example.com/Model", Artifact: &mlpb.Artifact{}, Workspace: "teamA"},
+	} {
+		resp, err := svc.CreateArtifact(ctx, req)
+		if err != nil {
+			t.Fatalf("CreateArtifact(%v) failed: %v", req, err)
+		}
+		names = append(names, fmt.Sprintf("%s/artifacts/%d", req.Parent, resp.GetArtifact().GetId()))
+	}
+
+	reader := withGrants(map[string]Access{
+		"workspace teamA":           ReadAccess,
+		"namespace kubeflow.org/v1": ReadAccess,
+	})
+	writer := withGrants(map[string]Access{
+		"workspace teamA":           WriteAccess,
+		"namespace kubeflow.org/v1": WriteAccess,
+	})
+	tests := []struct {
+		desc string
+		call func() error
+		want codes.Code
+	}{
+		{"GetArtifact readable", func() error {
+			_, err := svc.GetArtifact(reader, &api.GetArtifactRequest{Name: names[0]})
+			return err
+		}, codes.OK},
+		{"GetArtifact of another workspace", func() error {
+			_, err := svc.GetArtifact(reader, &api.GetArtifactRequest{Name: names[1]})
+			return err
+		}, codes.PermissionDenied},
+		{"GetArtifact of another namespace", func() error {
+			_, err := svc.GetArtifact(reader, &api.GetArtifactRequest{Name: names[2]})
+			return err
+		}, codes.PermissionDenied},
+		{"CreateArtifact without write access", func() error {
+			_, err := svc.CreateArtifact(reader, &api.CreateArtifactRequest{Parent: "artifact_types/kubeflow.org/v1/Model", Artifact: &mlpb.Artifact{}, Workspace: "teamA"})
+			return err
+		}, codes.PermissionDenied},
+		{"CreateArtifact with write access", func() error {
+			_, err := svc.CreateArtifact(writer, &api.CreateArtifactRequest{Parent: "artifact_types/kubeflow.org/v1/Model", Artifact: &mlpb.Artifact{}, Workspace: "teamA"})
+			return err
+		}, codes.OK},
+		{"CreateArtifact in another workspace", func() error {
+			_, err := svc.CreateArtifact(writer, &api.CreateArtifactRequest{Parent: "artifact_types/kubeflow.org/v1/Model", Artifact: &mlpb.Artifact{}, Workspace: "teamB"})
+			return err
+		}, codes.PermissionDenied},
+		{"DeleteArtifact without write access", func() error {
+			_, err := svc.DeleteArtifact(reader, &api.DeleteArtifactRequest{Name: names[0]})
+			return err
+		}, codes.PermissionDenied},
+		{"CreateArtifactType without admin access", func() error {
+			_, err := svc.CreateArtifactType(writer, &api.CreateArtifactTypeRequest{ArtifactType: &mlpb.ArtifactType{Name: proto.String("kubeflow.org/v1/Dataset")}})
+			return err
+		}, codes.PermissionDenied},
+		{"DeleteArtifactType without admin access", func() error {
+			_, err := svc.DeleteArtifactType(writer, &api.DeleteArtifactTypeRequest{Name: "artifact_types/kubeflow.org/v1/Model"})
+			return err
+		}, codes.PermissionDenied},
+		{"GetArtifactType of another namespace", func() error {
+			_, err := svc.GetArtifactType(reader, &api.GetArtifactTypeRequest{Name: "artifact_types/example.com/Model"})
+			return err
+		}, codes.PermissionDenied},
+		{"CreateWorkspace without admin access", func() error {
+			_, err := svc.CreateWorkspace(writer, &api.CreateWorkspaceRequest{Workspace: &api.Workspace{Name: "teamC"}})
+			return err
+		}, codes.PermissionDenied},
+		{"GetWorkspace of another workspace", func() error {
+			_, err := svc.GetWorkspace(reader, &api.GetWorkspaceRequest{Name: "workspaces/teamB"})
+			return err
+		}, codes.PermissionDenied},
+		{"ListArtifacts of another workspace", func() error {
+			_, err := svc.ListArtifacts(reader, &api.ListArtifactsRequest{Workspace: "teamB"})
+			return err
+		}, codes.PermissionDenied},
+		{"RegisterSchema without admin access to all namespaces", func() error {
+			_, err := svc.RegisterSchema(writer, &api.RegisterSchemaRequest{JsonSchema: "{}"})
+			return err
+		}, codes.PermissionDenied},
+	}
+	for _, test := range tests {
+		if got := status.Code(test.call()); got != test.want {
+			t.Errorf("%s: got code %v\nWant %v", test.desc, got, test.want)
+		}
+	}
+
+	artifacts, err := svc.ListArtifacts(reader, &api.ListArtifactsRequest{})
+	if err != nil || len(artifacts.GetArtifacts()) != 2 {
+		t.Errorf("ListArtifacts() = %v, %v\nWant the 2 artifacts of teamA and kubeflow.org/v1", artifacts, err)
+	}
+	types, err := svc.ListArtifactTypes(reader, &api.ListArtifactTypesRequest{})
+	if err != nil || len(types.GetArtifactTypes()) != 1 || types.GetArtifactTypes()[0].GetName() != "kubeflow.org/v1/Model" {
+		t.Errorf("ListArtifactTypes() = %v, %v\nWant only kubeflow.org/v1/Model", types, err)
+	}
+	workspaces, err := svc.ListWorkspaces(reader, &api.ListWorkspacesRequest{})
+	if err != nil || len(workspaces.GetWorkspaces()) != 1 || workspaces.GetWorkspaces()[0].GetName() != "teamA" {
+		t.Errorf("ListWorkspaces() = %v, %v\nWant only teamA", workspaces, err)
+	}
+}
+
+func TestStats(t *testing.T) {
+	store := &listCountingStore{MetadataStore: testMLMDStore(t)}
+	svc := New(store)
+	ctx := context.Background()
+
+	if _, err := svc.CreateArtifactType(ctx, &api.CreateArtifactTypeRequest{ArtifactType: &mlpb.ArtifactType{Name: proto.String("kubeflow.org/v1/Model")}}); err != nil {
+		t.Fatalf("CreateArtifactType failed: %v", err)
+	}
+	if _, err := svc.CreateArtifactType(ctx, &api.CreateArtifactTypeRequest{ArtifactType: &mlpb.ArtifactType{Name: proto.String("kubeflow.org/v1/Dataset")}}); err != nil {
+		t.Fatalf("CreateArtifactType failed: %v", err)
+	}
+	if _, err := svc.DeleteArtifactType(ctx, &api.DeleteArtifactTypeRequest{Name: "artifact_types/kubeflow.org/v1/Dataset"}); err != nil {
+		t.Fatalf("DeleteArtifactType failed: %v", err)
+	}
+	if _, err := svc.CreateExecutionType(ctx, &api.CreateExecutionTypeRequest{ExecutionType: &mlpb.ExecutionType{Name: proto.String("kubeflow.org/v1/Train")}}); err != nil {
+		t.Fatalf("CreateExecutionType failed: %v", err)
+	}
+	if _, err := svc.CreateWorkspace(ctx, &api.CreateWorkspaceRequest{Workspace: &api.Workspace{Name: "teamA"}}); err != nil {
+		t.Fatalf("CreateWorkspace failed: %v", err)
+	}
+	var artifactIDs []int64
+	for i := 0; i < 2; i++ {
+		resp, err := svc.CreateArtifact(ctx, &api.CreateArtifactRequest{Parent: "artifact_types/kubeflow.org/v1/Model", Artifact: &mlpb.Artifact{}, Workspace: "teamA"})
+		if err != nil {
+			t.Fatalf("CreateArtifact failed: %v", err)
+		}
+		artifactIDs = append(artifactIDs, resp.GetArtifact().GetId())
+	}
+	if _, err := svc.DeleteArtifact(ctx, &api.DeleteArtifactRequest{Name: fmt.Sprintf("artifact_types/kubeflow.org/v1/Model/artifacts/%d", artifactIDs[0])}); err != nil {
+		t.Fatalf("DeleteArtifact failed: %v", err)
+	}
+	if _, err := svc.CreateExecution(ctx, &api.CreateExecutionRequest{Parent: "execution_types/kubeflow.org/v1/Train", Execution: &mlpb.Execution{}}); err != nil {
+		t.Fatalf("CreateExecution failed: %v", err)
+	}
+
+	store.lists = 0
+	got, err := svc.Stats(ctx)
+	want := &Stats{ArtifactTypes: 1, ExecutionTypes: 1, Artifacts: 1, Executions: 1}
+	if err != nil || !cmp.Equal(got, want) {
+		t.Errorf("Stats() = %+v, %v\nWant %+v, nil", got, err, want)
+	}
+	// Stores without paged lists are read at once.
+	if !store.PagedLists() && store.lists != 0 {
+		t.Errorf("Stats() listed artifacts %d times from a store without paged lists\nWant it to get them at once", store.lists)
+	}
+}
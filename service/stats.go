@@ -0,0 +1,93 @@
+// Copyright 2019 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package service
+
+import (
+	"context"
+	"strings"
+
+	mlpb "ml_metadata/proto/metadata_store_go_proto"
+
+)
+
+// Stats counts the records of the service, as listed by its RPCs.
+type Stats struct {
+	ArtifactTypes  int
+	ExecutionTypes int
+	Artifacts      int
+	Executions     int
+}
+
+// Stats returns the number of types, artifacts and executions, leaving out
+// deleted ones and those the service records for itself, e.g. workspaces.
+// Callers are not authorized: the counts are those of every workspace. Every
+// record is read, so callers should cache the counts, as the metrics do.
+func (s *Service) Stats(ctx context.Context) (*Stats, error) {
+	stats := &Stats{}
+	aTypes, err := s.store.GetArtifactTypes(ctx)
+	if err != nil && !noRecordFound(err) {
+		return nil, err
+	}
+	for _, aType := range aTypes {
+		if !isDeletedType(aType.GetProperties()) && !strings.HasPrefix(aType.GetName(), kfReservedPrefix) {
+			stats.ArtifactTypes++
+		}
+	}
+	eTypes, err := s.store.GetExecutionTypes(ctx)
+	if err != nil && !noRecordFound(err) {
+		return nil, err
+	}
+	for _, eType := range eTypes {
+		if !isDeletedType(eType.GetProperties()) && !strings.HasPrefix(eType.GetName(), kfReservedPrefix) {
+			stats.ExecutionTypes++
+		}
+	}
+
+	if stats.Artifacts, err = s.countArtifacts(ctx); err != nil {
+		return nil, err
+	}
+	if stats.Executions, err = s.countExecutions(ctx); err != nil {
+		return nil, err
+	}
+	return stats, nil
+}
+
+// countArtifacts returns the number of artifacts that are not deleted.
+func (s *Service) countArtifacts(ctx context.Context) (int, error) {
+	count := 0
+	err := s.forEachArtifactPage(ctx, func(artifacts []*mlpb.Artifact) error {
+		for _, artifact := range artifacts {
+			if !isDeleted(artifact.GetCustomProperties()) {
+				count++
+			}
+		}
+		return nil
+	})
+	return count, err
+}
+
+// countExecutions returns the number of executions that are not deleted.
+func (s *Service) countExecutions(ctx context.Context) (int, error) {
+	count := 0
+	err := s.forEachExecutionPage(ctx, func(executions []*mlpb.Execution) error {
+		for _, execution := range executions {
+			if !isDeleted(execution.GetCustomProperties()) {
+				count++
+			}
+		}
+		return nil
+	})
+	return count, err
+}
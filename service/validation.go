@@ -0,0 +1,88 @@
+// Copyright 2019 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package service
+
+import (
+	"fmt"
+	"strings"
+
+	mlpb "ml_metadata/proto/metadata_store_go_proto"
+
+	"github.com/golang/glog"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// ArtifactValidator checks artifacts against the schemas of their types.
+type ArtifactValidator interface {
+	// ValidateArtifact returns the violations of the schema of the
+	// ArtifactType named typeName by artifact. It returns no violations if the
+	// type has no known schema.
+	ValidateArtifact(typeName string, artifact *mlpb.Artifact) ([]string, error)
+}
+
+// ValidationMode controls how artifacts violating their schema are handled.
+type ValidationMode int
+
+const (
+	// LenientValidation logs schema violations and accepts the artifact.
+	LenientValidation ValidationMode = iota
+	// StrictValidation rejects artifacts violating their schema.
+	StrictValidation
+)
+
+// ParseValidationMode returns the ValidationMode named "lenient" or "strict".
+func ParseValidationMode(mode string) (ValidationMode, error) {
+	switch mode {
+	case "lenient":
+		return LenientValidation, nil
+	case "strict":
+		return StrictValidation, nil
+	}
+	return LenientValidation, fmt.Errorf("unknown validation mode %q: must be one of [lenient, strict]", mode)
+}
+
+// SetArtifactValidator sets the validator of the artifacts created through
+// the service. It must be called before the service starts serving.
+func (s *Service) SetArtifactValidator(v ArtifactValidator) {
+	s.artifactValidator = v
+}
+
+// SetValidationMode sets how artifacts violating their schema are handled. It
+// must be called before the service starts serving.
+func (s *Service) SetValidationMode(mode ValidationMode) {
+	s.validationMode = mode
+}
+
+// validateArtifact checks artifact, an instance of the ArtifactType named
+// typeName, with the artifact validator if one is set.
+func (s *Service) validateArtifact(typeName string, artifact *mlpb.Artifact) error {
+	if s.artifactValidator == nil {
+		return nil
+	}
+	violations, err := s.artifactValidator.ValidateArtifact(typeName, artifact)
+	if err != nil {
+		return status.Errorf(codes.Internal, "failed to validate Artifact of type %q: %v", typeName, err)
+	}
+	if len(violations) == 0 {
+		return nil
+	}
+
+	if s.validationMode == StrictValidation {
+		return status.Errorf(codes.InvalidArgument, "Artifact does not match the schema of %q: %s", typeName, strings.Join(violations, "; "))
+	}
+	glog.Warningf("Accepting Artifact that does not match the schema of %q: %s", typeName, strings.Join(violations, "; "))
+	return nil
+}
@@ -0,0 +1,273 @@
+// Copyright 2019 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package tlsconfig builds the TLS configurations of the endpoints of the API
+// server and of the connection of its gateway to its gRPC endpoint, from
+// certificate files that are reloaded when they change.
+package tlsconfig
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/golang/glog"
+)
+
+// Config names the certificate files of the server.
+type Config struct {
+	// CertFile and KeyFile hold the PEM encoded certificate chain and
+	// private key of the server.
+	CertFile string
+	KeyFile  string
+	// ClientCAFile holds the PEM encoded certificates of the authorities
+	// issuing client certificates. It is required unless ClientAuth is
+	// NoClientCert.
+	ClientCAFile string
+	ClientAuth   ClientAuth
+}
+
+// ClientAuth is how the server verifies client certificates.
+type ClientAuth int
+
+const (
+	// NoClientCert does not request client certificates.
+	NoClientCert ClientAuth = iota
+	// VerifyClientCertIfGiven verifies the certificates clients send, but
+	// accepts clients without one.
+	VerifyClientCertIfGiven
+	// RequireAndVerifyClientCert rejects clients without a verified
+	// certificate.
+	RequireAndVerifyClientCert
+)
+
+var clientAuthNames = map[string]ClientAuth{
+	"none":     NoClientCert,
+	"optional": VerifyClientCertIfGiven,
+	"require":  RequireAndVerifyClientCert,
+}
+
+// ParseClientAuth returns the ClientAuth named s: none, optional or require.
+func ParseClientAuth(s string) (ClientAuth, error) {
+	if a, ok := clientAuthNames[s]; ok {
+		return a, nil
+	}
+	return NoClientCert, fmt.Errorf("unknown client authentication %q: please choose from [none, optional, require]", s)
+}
+
+// Reloader holds the certificate and client authorities loaded from the files
+// of a Config. The configurations it returns use the certificates it holds at
+// the time of each handshake, so that reloading them takes effect on new
+// connections without restarting the server.
+type Reloader struct {
+	config Config
+
+	mu         sync.RWMutex
+	cert       *tls.Certificate
+	clientCAs  *x509.CertPool
+	filesState string
+}
+
+// NewReloader returns a Reloader holding the certificates of config.
+func NewReloader(config Config) (*Reloader, error) {
+	if config.CertFile == "" || config.KeyFile == "" {
+		return nil, errors.New("both a certificate and a key file are required")
+	}
+	if config.ClientAuth != NoClientCert && config.ClientCAFile == "" {
+		return nil, errors.New("verifying client certificates requires a client CA file")
+	}
+	if config.ClientAuth == NoClientCert && config.ClientCAFile != "" {
+		return nil, errors.New("a client CA file is only used to verify client certificates")
+	}
+	r := &Reloader{config: config}
+	if err := r.Reload(); err != nil {
+		return nil, err
+	}
+	return r, nil
+}
+
+// Reload reloads the certificates of r from their files. The certificates of r
+// are unchanged if any file fails to load.
+func (r *Reloader) Reload() error {
+	state, err := r.certFilesState()
+	if err != nil {
+		return err
+	}
+	cert, err := tls.LoadX509KeyPair(r.config.CertFile, r.config.KeyFile)
+	if err != nil {
+		return fmt.Errorf("failed to load the certificate %s and key %s: %v", r.config.CertFile, r.config.KeyFile, err)
+	}
+	var clientCAs *x509.CertPool
+	if r.config.ClientCAFile != "" {
+		pem, err := ioutil.ReadFile(r.config.ClientCAFile)
+		if err != nil {
+			return err
+		}
+		clientCAs = x509.NewCertPool()
+		if !clientCAs.AppendCertsFromPEM(pem) {
+			return fmt.Errorf("no certificate found in client CA file %s", r.config.ClientCAFile)
+		}
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.cert = &cert
+	r.clientCAs = clientCAs
+	r.filesState = state
+	return nil
+}
+
+// Watch reloads the certificates of r whenever one of their files is modified
+// since they were loaded, checking every interval until ctx is done. Failed
+// reloads are logged, keeping the previous certificates, and retried once the
+// files change again.
+func (r *Reloader) Watch(ctx context.Context, interval time.Duration) {
+	r.mu.RLock()
+	last := r.filesState
+	r.mu.RUnlock()
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+		state, err := r.certFilesState()
+		if err != nil {
+			glog.Errorf("Failed to read certificate files: %v", err)
+			continue
+		}
+		if state == last {
+			continue
+		}
+		last = state
+
+		if err := r.Reload(); err != nil {
+			glog.Errorf("Failed to reload certificates: %v", err)
+			continue
+		}
+		glog.Infof("Reloaded certificate %s", r.config.CertFile)
+	}
+}
+
+// certFilesState returns the paths, sizes and modification times of the
+// certificate files of r, which change whenever one of the files does.
+func (r *Reloader) certFilesState() (string, error) {
+	var state strings.Builder
+	for _, file := range []string{r.config.CertFile, r.config.KeyFile, r.config.ClientCAFile} {
+		if file == "" {
+			continue
+		}
+		info, err := os.Stat(file)
+		if err != nil {
+			return "", err
+		}
+		fmt.Fprintf(&state, "%s %d %d\n", file, info.Size(), info.ModTime().UnixNano())
+	}
+	return state.String(), nil
+}
+
+func (r *Reloader) certificate() *tls.Certificate {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return r.cert
+}
+
+func (r *Reloader) clientCertPool() *x509.CertPool {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return r.clientCAs
+}
+
+// ServerConfig returns the configuration of the endpoints of the server,
+// presenting the certificate of r and verifying client certificates against
+// the client authorities of r as its Config requires.
+func (r *Reloader) ServerConfig() *tls.Config {
+	c := &tls.Config{
+		MinVersion: tls.VersionTLS12,
+		GetCertificate: func(*tls.ClientHelloInfo) (*tls.Certificate, error) {
+			return r.certificate(), nil
+		},
+	}
+	// Client certificates are verified by verifyClientCert rather than
+	// against ClientCAs, which would not see reloaded authorities.
+	switch r.config.ClientAuth {
+	case VerifyClientCertIfGiven:
+		c.ClientAuth = tls.RequestClientCert
+		c.VerifyPeerCertificate = r.verifyClientCert
+	case RequireAndVerifyClientCert:
+		c.ClientAuth = tls.RequireAnyClientCert
+		c.VerifyPeerCertificate = r.verifyClientCert
+	}
+	return c
+}
+
+// verifyClientCert verifies the certificate chain sent by a client, if any,
+// against the client authorities of r.
+func (r *Reloader) verifyClientCert(rawCerts [][]byte, _ [][]*x509.Certificate) error {
+	if len(rawCerts) == 0 {
+		return nil
+	}
+	certs := make([]*x509.Certificate, len(rawCerts))
+	for i, raw := range rawCerts {
+		cert, err := x509.ParseCertificate(raw)
+		if err != nil {
+			return fmt.Errorf("failed to parse client certificate: %v", err)
+		}
+		certs[i] = cert
+	}
+	opts := x509.VerifyOptions{
+		Roots:         r.clientCertPool(),
+		Intermediates: x509.NewCertPool(),
+		KeyUsages:     []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth},
+	}
+	for _, cert := range certs[1:] {
+		opts.Intermediates.AddCert(cert)
+	}
+	_, err := certs[0].Verify(opts)
+	return err
+}
+
+// GatewayConfig returns the configuration of the connection of the gateway to
+// the gRPC endpoint of the same server. The gateway dials a local address the
+// certificate of the server may not be issued for, so it accepts only the
+// certificate r holds instead of verifying it against an address. It presents
+// that certificate to the gRPC endpoint too, which must therefore be issued
+// for client authentication by a client authority of r when client
+// certificates are verified.
+func (r *Reloader) GatewayConfig() *tls.Config {
+	return &tls.Config{
+		MinVersion: tls.VersionTLS12,
+		// The certificate of the server is checked by VerifyPeerCertificate.
+		InsecureSkipVerify: true,
+		VerifyPeerCertificate: func(rawCerts [][]byte, _ [][]*x509.Certificate) error {
+			if len(rawCerts) == 0 || !bytes.Equal(rawCerts[0], r.certificate().Certificate[0]) {
+				return errors.New("the gRPC endpoint did not present the certificate of the server")
+			}
+			return nil
+		},
+		GetClientCertificate: func(*tls.CertificateRequestInfo) (*tls.Certificate, error) {
+			return r.certificate(), nil
+		},
+	}
+}
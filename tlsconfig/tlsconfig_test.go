@@ -0,0 +1,377 @@
+// Copyright 2019 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tlsconfig
+
+import (
+	"bytes"
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"io/ioutil"
+	"math/big"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+// testCert is a certificate and its key, issued by parent or self-signed.
+type testCert struct {
+	cert *x509.Certificate
+	key  *ecdsa.PrivateKey
+	der  []byte
+}
+
+var serial int64
+
+func newTestCert(t *testing.T, name string, parent *testCert, usages ...x509.ExtKeyUsage) *testCert {
+	t.Helper()
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	serial++
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(serial),
+		Subject:      pkix.Name{CommonName: name},
+		DNSNames:     []string{name},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		KeyUsage:     x509.KeyUsageDigitalSignature,
+		ExtKeyUsage:  usages,
+	}
+	issuer, issuerKey := template, key
+	if parent == nil {
+		template.IsCA = true
+		template.BasicConstraintsValid = true
+		template.KeyUsage |= x509.KeyUsageCertSign
+	} else {
+		issuer, issuerKey = parent.cert, parent.key
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, issuer, &key.PublicKey, issuerKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	cert, err := x509.ParseCertificate(der)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return &testCert{cert: cert, key: key, der: der}
+}
+
+func (c *testCert) certPEM() []byte {
+	return pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: c.der})
+}
+
+func (c *testCert) keyPEM(t *testing.T) []byte {
+	der, err := x509.MarshalECPrivateKey(c.key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: der})
+}
+
+func (c *testCert) tlsCertificate(t *testing.T) *tls.Certificate {
+	cert, err := tls.X509KeyPair(c.certPEM(), c.keyPEM(t))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return &cert
+}
+
+func writeFile(t *testing.T, path string, content []byte) {
+	t.Helper()
+	if err := ioutil.WriteFile(path, content, 0600); err != nil {
+		t.Fatal(err)
+	}
+}
+
+// testFiles writes the certificate and key of server and the certificate of
+// clientCA in dir, returning a Config naming them.
+func testFiles(t *testing.T, dir string, server, clientCA *testCert, clientAuth ClientAuth) Config {
+	config := Config{
+		CertFile:   filepath.Join(dir, "server.crt"),
+		KeyFile:    filepath.Join(dir, "server.key"),
+		ClientAuth: clientAuth,
+	}
+	writeFile(t, config.CertFile, server.certPEM())
+	writeFile(t, config.KeyFile, server.keyPEM(t))
+	if clientCA != nil {
+		config.ClientCAFile = filepath.Join(dir, "client-ca.crt")
+		writeFile(t, config.ClientCAFile, clientCA.certPEM())
+	}
+	return config
+}
+
+// handshake connects a client of clientConfig to a server of serverConfig,
+// returning the certificate presented by the server, or the error of the
+// server if any, else of the client.
+func handshake(t *testing.T, serverConfig, clientConfig *tls.Config) (*x509.Certificate, error) {
+	t.Helper()
+	listener, err := tls.Listen("tcp", "127.0.0.1:0", serverConfig)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer listener.Close()
+	serverErr := make(chan error, 1)
+	go func() {
+		conn, err := listener.Accept()
+		if err != nil {
+			serverErr <- err
+			return
+		}
+		defer conn.Close()
+		if err := conn.(*tls.Conn).Handshake(); err != nil {
+			serverErr <- err
+			return
+		}
+		_, err = conn.Write([]byte("ok"))
+		serverErr <- err
+	}()
+
+	conn, err := tls.Dial("tcp", listener.Addr().String(), clientConfig)
+	if err != nil {
+		if serr := <-serverErr; serr != nil {
+			return nil, serr
+		}
+		return nil, err
+	}
+	defer conn.Close()
+	// The server may only reject the certificate of the client once the
+	// client completed its handshake.
+	_, readErr := ioutil.ReadAll(conn)
+	if err := <-serverErr; err != nil {
+		return nil, err
+	}
+	if readErr != nil {
+		return nil, readErr
+	}
+	return conn.ConnectionState().PeerCertificates[0], nil
+}
+
+func TestParseClientAuth(t *testing.T) {
+	for s, want := range map[string]ClientAuth{
+		"none":     NoClientCert,
+		"optional": VerifyClientCertIfGiven,
+		"require":  RequireAndVerifyClientCert,
+	} {
+		got, err := ParseClientAuth(s)
+		if err != nil || got != want {
+			t.Errorf("ParseClientAuth(%q) = %v, %v; want %v, nil", s, got, err, want)
+		}
+	}
+	if _, err := ParseClientAuth("verify"); err == nil {
+		t.Errorf("ParseClientAuth(%q) succeeded; want error", "verify")
+	}
+}
+
+func TestNewReloader(t *testing.T) {
+	dir, err := ioutil.TempDir("", "tlsconfig")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	ca := newTestCert(t, "ca", nil)
+	server := newTestCert(t, "server.example.com", ca, x509.ExtKeyUsageServerAuth)
+	valid := testFiles(t, dir, server, ca, RequireAndVerifyClientCert)
+	notPEM := filepath.Join(dir, "not-pem")
+	writeFile(t, notPEM, []byte("not a certificate"))
+
+	tests := []struct {
+		name   string
+		config func(c Config) Config
+	}{
+		{"no cert file", func(c Config) Config { c.CertFile = ""; return c }},
+		{"no key file", func(c Config) Config { c.KeyFile = ""; return c }},
+		{"missing cert file", func(c Config) Config { c.CertFile = filepath.Join(dir, "missing"); return c }},
+		{"key of another cert", func(c Config) Config { c.KeyFile = notPEM; return c }},
+		{"client CA without client auth", func(c Config) Config { c.ClientAuth = NoClientCert; return c }},
+		{"client auth without client CA", func(c Config) Config { c.ClientCAFile = ""; return c }},
+		{"client CA file without certificates", func(c Config) Config { c.ClientCAFile = notPEM; return c }},
+	}
+	for _, test := range tests {
+		if _, err := NewReloader(test.config(valid)); err == nil {
+			t.Errorf("NewReloader with %s succeeded; want error", test.name)
+		}
+	}
+	if _, err := NewReloader(valid); err != nil {
+		t.Errorf("NewReloader(%+v) = _, %v; want nil error", valid, err)
+	}
+}
+
+func TestServerConfig(t *testing.T) {
+	dir, err := ioutil.TempDir("", "tlsconfig")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	ca := newTestCert(t, "ca", nil)
+	server := newTestCert(t, "server.example.com", ca, x509.ExtKeyUsageServerAuth)
+	client := newTestCert(t, "client", ca, x509.ExtKeyUsageClientAuth)
+	serverOnly := newTestCert(t, "other-server", ca, x509.ExtKeyUsageServerAuth)
+	untrusted := newTestCert(t, "untrusted", newTestCert(t, "other-ca", nil), x509.ExtKeyUsageClientAuth)
+
+	roots := x509.NewCertPool()
+	roots.AddCert(ca.cert)
+	tests := []struct {
+		clientAuth ClientAuth
+		clientCert *testCert
+		wantErr    bool
+	}{
+		{NoClientCert, nil, false},
+		{NoClientCert, untrusted, false},
+		{VerifyClientCertIfGiven, nil, false},
+		{VerifyClientCertIfGiven, client, false},
+		{VerifyClientCertIfGiven, untrusted, true},
+		{RequireAndVerifyClientCert, nil, true},
+		{RequireAndVerifyClientCert, client, false},
+		{RequireAndVerifyClientCert, untrusted, true},
+		{RequireAndVerifyClientCert, serverOnly, true},
+	}
+	for _, test := range tests {
+		var clientCA *testCert
+		if test.clientAuth != NoClientCert {
+			clientCA = ca
+		}
+		r, err := NewReloader(testFiles(t, dir, server, clientCA, test.clientAuth))
+		if err != nil {
+			t.Fatal(err)
+		}
+		clientConfig := &tls.Config{RootCAs: roots, ServerName: "server.example.com"}
+		name := "no client certificate"
+		if test.clientCert != nil {
+			clientConfig.Certificates = []tls.Certificate{*test.clientCert.tlsCertificate(t)}
+			name = "client certificate " + test.clientCert.cert.Subject.CommonName
+		}
+		_, err = handshake(t, r.ServerConfig(), clientConfig)
+		if gotErr := err != nil; gotErr != test.wantErr {
+			t.Errorf("Handshake with client auth %d and %s = %v; want error: %t", test.clientAuth, name, err, test.wantErr)
+		}
+	}
+}
+
+func TestGatewayConfig(t *testing.T) {
+	dir, err := ioutil.TempDir("", "tlsconfig")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	ca := newTestCert(t, "ca", nil)
+	server := newTestCert(t, "server.example.com", ca, x509.ExtKeyUsageServerAuth, x509.ExtKeyUsageClientAuth)
+	r, err := NewReloader(testFiles(t, dir, server, ca, RequireAndVerifyClientCert))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// The gateway accepts the certificate of the server whatever the address
+	// it dials, and is accepted as a client with it.
+	if _, err := handshake(t, r.ServerConfig(), r.GatewayConfig()); err != nil {
+		t.Errorf("Handshake of the gateway = %v; want nil", err)
+	}
+
+	// It rejects any other certificate, even one from the same authority.
+	other := newTestCert(t, "server.example.com", ca, x509.ExtKeyUsageServerAuth)
+	otherConfig := &tls.Config{Certificates: []tls.Certificate{*other.tlsCertificate(t)}}
+	if _, err := handshake(t, otherConfig, r.GatewayConfig()); err == nil {
+		t.Error("Handshake of the gateway with another server succeeded; want error")
+	}
+}
+
+func TestReload(t *testing.T) {
+	dir, err := ioutil.TempDir("", "tlsconfig")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	ca := newTestCert(t, "ca", nil)
+	oldServer := newTestCert(t, "server.example.com", ca, x509.ExtKeyUsageServerAuth)
+	config := testFiles(t, dir, oldServer, nil, NoClientCert)
+	r, err := NewReloader(config)
+	if err != nil {
+		t.Fatal(err)
+	}
+	roots := x509.NewCertPool()
+	roots.AddCert(ca.cert)
+	clientConfig := &tls.Config{RootCAs: roots, ServerName: "server.example.com"}
+	presented := func() []byte {
+		t.Helper()
+		cert, err := handshake(t, r.ServerConfig(), clientConfig)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return cert.Raw
+	}
+
+	// A certificate without its new key fails to load, keeping the old one.
+	newServer := newTestCert(t, "server.example.com", ca, x509.ExtKeyUsageServerAuth)
+	writeFile(t, config.CertFile, newServer.certPEM())
+	if err := r.Reload(); err == nil {
+		t.Error("Reload of a certificate with the key of another succeeded; want error")
+	}
+	if got := presented(); !bytes.Equal(got, oldServer.der) {
+		t.Error("Server presents a new certificate after a failed reload; want the old one")
+	}
+
+	writeFile(t, config.KeyFile, newServer.keyPEM(t))
+	if err := r.Reload(); err != nil {
+		t.Fatalf("Reload() = %v; want nil", err)
+	}
+	if got := presented(); !bytes.Equal(got, newServer.der) {
+		t.Error("Server presents the old certificate after a reload; want the new one")
+	}
+	if _, err := handshake(t, r.ServerConfig(), r.GatewayConfig()); err != nil {
+		t.Errorf("Handshake of the gateway after a reload = %v; want nil", err)
+	}
+}
+
+func TestWatch(t *testing.T) {
+	dir, err := ioutil.TempDir("", "tlsconfig")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	ca := newTestCert(t, "ca", nil)
+	oldServer := newTestCert(t, "server.example.com", ca, x509.ExtKeyUsageServerAuth)
+	config := testFiles(t, dir, oldServer, nil, NoClientCert)
+	r, err := NewReloader(config)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go r.Watch(ctx, 10*time.Millisecond)
+
+	newServer := newTestCert(t, "server.example.com", ca, x509.ExtKeyUsageServerAuth)
+	// Both files get a new modification time, whatever their sizes.
+	later := time.Now().Add(time.Minute)
+	writeFile(t, config.KeyFile, newServer.keyPEM(t))
+	writeFile(t, config.CertFile, newServer.certPEM())
+	for _, file := range []string{config.CertFile, config.KeyFile} {
+		if err := os.Chtimes(file, later, later); err != nil {
+			t.Fatal(err)
+		}
+	}
+	for deadline := time.Now().Add(5 * time.Second); time.Now().Before(deadline); time.Sleep(10 * time.Millisecond) {
+		if bytes.Equal(r.certificate().Certificate[0], newServer.der) {
+			return
+		}
+	}
+	t.Error("Watch did not reload the modified certificate")
+}